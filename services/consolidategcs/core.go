@@ -0,0 +1,244 @@
+// Copyright 2020 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the 'License');
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an 'AS IS' BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package consolidategcs
+
+import (
+	"context"
+	"fmt"
+	"io/ioutil"
+	"log"
+	"strings"
+
+	"cloud.google.com/go/functions/metadata"
+	"cloud.google.com/go/storage"
+	"google.golang.org/api/iterator"
+
+	"github.com/BrunoReboul/ram/utilities/cai"
+	"github.com/BrunoReboul/ram/utilities/ffo"
+	"github.com/BrunoReboul/ram/utilities/glo"
+	"github.com/BrunoReboul/ram/utilities/gps"
+	"github.com/BrunoReboul/ram/utilities/solution"
+	"github.com/google/uuid"
+)
+
+// Global structure for global variables to optimize the cloud function performances
+type Global struct {
+	assetShortTypeName  string
+	assetType           string
+	bucketHandle        *storage.BucketHandle
+	ctx                 context.Context
+	environment         string
+	instanceName        string
+	logger              *glo.Logger
+	microserviceName    string
+	objectPrefix        string
+	pubSubAttributes    map[string]string
+	PubSubID            string
+	retryTimeOutSeconds int64
+	step                glo.Step
+	stepStack           glo.Steps
+}
+
+// snapshotWriter lazily opens a snapshot object writer on the first line to write
+type snapshotWriter struct {
+	objectName string
+	writer     *storage.Writer
+	count      int64
+}
+
+// Initialize is to be executed in the init() function of the cloud function to optimize the cold start
+func Initialize(ctx context.Context, global *Global) (err error) {
+	log.SetFlags(0)
+	global.ctx = ctx
+
+	var instanceDeployment InstanceDeployment
+	var storageClient *storage.Client
+
+	initID := fmt.Sprintf("%v", uuid.New())
+	err = ffo.ReadUnmarshalYAML(solution.PathToFunctionCode+solution.SettingsFileName, &instanceDeployment)
+	if err != nil {
+		log.Println(glo.Entry{
+			Severity:    "CRITICAL",
+			Message:     "init_failed",
+			Description: fmt.Sprintf("ReadUnmarshalYAML %s %v", solution.SettingsFileName, err),
+			InitID:      initID,
+		})
+		return err
+	}
+
+	global.environment = instanceDeployment.Core.EnvironmentName
+	global.instanceName = instanceDeployment.Core.InstanceName
+	global.microserviceName = instanceDeployment.Core.ServiceName
+
+	global.logger = glo.NewLogger(global.microserviceName, global.instanceName, global.environment, initID)
+	global.logger.SetLogParameters(glo.GetLogParameters(instanceDeployment.Settings.Service.GLO, instanceDeployment.Settings.Instance.GLO))
+	global.logger.Notice("coldstart", "")
+
+	err = glo.InitTracing(ctx,
+		instanceDeployment.Core.SolutionSettings.Hosting.Tracing.Exporter,
+		instanceDeployment.Core.SolutionSettings.Hosting.Tracing.OTLPEndpoint,
+		instanceDeployment.Core.SolutionSettings.Hosting.Tracing.FilePath,
+		global.microserviceName,
+		global.instanceName,
+		global.environment)
+	if err != nil {
+		global.logger.InitFailed(fmt.Sprintf("glo.InitTracing %v", err))
+		return err
+	}
+
+	global.assetType = instanceDeployment.Settings.Instance.CAI.AssetType
+	global.assetShortTypeName = cai.GetAssetShortTypeName(global.assetType)
+	// upload2gcs object names are asset names, listing is narrowed to the asset type prefix
+	global.objectPrefix = cai.GetObjectNamePrefix(global.assetType)
+	global.retryTimeOutSeconds = instanceDeployment.Settings.Service.GCF.RetryTimeOutSeconds
+
+	storageClient, err = storage.NewClient(ctx)
+	if err != nil {
+		global.logger.InitFailed(fmt.Sprintf("storage.NewClient(ctx) %v", err))
+		return err
+	}
+	// bucketHandle must be evaluated after storateClient init
+	global.bucketHandle = storageClient.Bucket(instanceDeployment.Core.SolutionSettings.Hosting.GCS.Buckets.AssetsJSONFile.Name)
+	return nil
+}
+
+// EntryPoint is the function to be executed for each cloud function occurence
+func EntryPoint(ctxEvent context.Context, PubSubMessage gps.PubSubMessage, global *Global) error {
+	// log.Println(string(PubSubMessage.Data))
+	metadata, err := metadata.FromContext(ctxEvent)
+	if err != nil {
+		// Assume an error on the function invoker and try again.
+		global.logger.RedoOnTransient(fmt.Sprintf("pubsub_id no available metadata.FromContext: %v", err))
+		return err
+	}
+	global.stepStack = nil
+	global.PubSubID = metadata.EventID
+	parts := strings.Split(metadata.Resource.Name, "/")
+	global.step = glo.Step{
+		StepID:        fmt.Sprintf("%s/%s", parts[len(parts)-1], global.PubSubID),
+		StepTimestamp: metadata.Timestamp,
+	}
+	global.logger.SetInvocation(global.PubSubID, global.step)
+	global.pubSubAttributes = PubSubMessage.Attributes
+	defer func() {
+		glo.RecordStepSpan(global.ctx, global.microserviceName, global.pubSubAttributes, global.stepStack)
+	}()
+	global.stepStack = append(global.stepStack, global.step)
+	global.logger.SetStepStack(global.stepStack)
+
+	d := global.logger.Start()
+
+	if d.Seconds() > float64(global.retryTimeOutSeconds) {
+		global.logger.NoRetryTooOld()
+		return nil
+	}
+
+	snapshotFolder := fmt.Sprintf("snapshots/%s/dt=%s", global.assetShortTypeName, metadata.Timestamp.UTC().Format("2006-01-02"))
+	// one snapshot per content type
+	snapshots := []struct {
+		contentType string
+		writer      *snapshotWriter
+	}{
+		{contentType: cai.ContentTypeResource, writer: &snapshotWriter{objectName: snapshotFolder + "/resources.ndjson"}},
+		{contentType: cai.ContentTypeIAMPolicy, writer: &snapshotWriter{objectName: snapshotFolder + "/iam_policies.ndjson"}},
+		{contentType: cai.ContentTypeOrgPolicy, writer: &snapshotWriter{objectName: snapshotFolder + "/org_policies.ndjson"}},
+		{contentType: cai.ContentTypeAccessPolicy, writer: &snapshotWriter{objectName: snapshotFolder + "/access_policies.ndjson"}},
+		{contentType: cai.ContentTypeOSInventory, writer: &snapshotWriter{objectName: snapshotFolder + "/os_inventories.ndjson"}},
+		{contentType: cai.ContentTypeRelationship, writer: &snapshotWriter{objectName: snapshotFolder + "/relationships.ndjson"}},
+	}
+
+	var objectCount int64
+	query := &storage.Query{Prefix: global.objectPrefix}
+	it := global.bucketHandle.Objects(global.ctx, query)
+	for {
+		objectAttrs, err := it.Next()
+		if err == iterator.Done {
+			break
+		}
+		if err != nil {
+			global.logger.RedoOnTransient(fmt.Sprintf("global.bucketHandle.Objects it.Next() %v", err))
+			return err
+		}
+		if !isSnapshotCandidate(objectAttrs.Name, objectAttrs.Metadata, global.assetType) {
+			continue
+		}
+		objectCount++
+		line, assetType, err := readCompactJSON(objectAttrs.Name, global)
+		if err != nil {
+			global.logger.RedoOnTransient(fmt.Sprintf("readCompactJSON %s %v", objectAttrs.Name, err))
+			return err
+		}
+		if assetType != global.assetType {
+			continue
+		}
+		contentType := cai.GetObjectNameContentType(objectAttrs.Name)
+		var snapshot *snapshotWriter
+		for _, s := range snapshots {
+			if s.contentType == contentType {
+				snapshot = s.writer
+				break
+			}
+		}
+		if err = snapshot.writeLine(line, global); err != nil {
+			global.logger.RedoOnTransient(fmt.Sprintf("snapshot.writeLine %s %v", snapshot.objectName, err))
+			return err
+		}
+	}
+	var counts string
+	for _, s := range snapshots {
+		snapshot := s.writer
+		counts = fmt.Sprintf("%s %s %d", counts, s.contentType, snapshot.count)
+		if err = snapshot.close(); err != nil {
+			global.logger.RedoOnTransient(fmt.Sprintf("snapshot.close %s %v", snapshot.objectName, err))
+			return err
+		}
+	}
+
+	global.logger.Finish(fmt.Sprintf("finish consolidate %s", snapshotFolder), fmt.Sprintf("objects browsed %d%s", objectCount, counts), "")
+	return nil
+}
+
+// readCompactJSON reads an asset JSON file and returns it as a one line JSON together with its asset type
+func readCompactJSON(objectName string, global *Global) (line []byte, assetType string, err error) {
+	reader, err := global.bucketHandle.Object(objectName).NewReader(global.ctx)
+	if err != nil {
+		return nil, "", fmt.Errorf("NewReader %v", err)
+	}
+	defer reader.Close()
+	content, err := ioutil.ReadAll(reader)
+	if err != nil {
+		return nil, "", fmt.Errorf("ioutil.ReadAll %v", err)
+	}
+	return compactAssetJSON(content)
+}
+
+func (snapshot *snapshotWriter) writeLine(line []byte, global *Global) (err error) {
+	if snapshot.writer == nil {
+		snapshot.writer = global.bucketHandle.Object(snapshot.objectName).NewWriter(global.ctx)
+		snapshot.writer.ContentType = "application/x-ndjson"
+	}
+	if _, err = snapshot.writer.Write(append(line, '\n')); err != nil {
+		return err
+	}
+	snapshot.count++
+	return nil
+}
+
+func (snapshot *snapshotWriter) close() (err error) {
+	if snapshot.writer == nil {
+		return nil
+	}
+	return snapshot.writer.Close()
+}
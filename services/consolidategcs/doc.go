@@ -0,0 +1,78 @@
+// Copyright 2020 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the 'License');
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an 'AS IS' BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+/*
+Package consolidategcs consolidates the assets JSON files of a GCS bucket into daily newline delimited JSON snapshots
+
+One snapshot per asset type and per day, ready to be used as BigQuery external tables or for offline analysis.
+
+Triggered by
+
+Cloud Scheduler Job, through PubSub messages.
+
+Instances
+
+- one per asset type to be consolidated, whatever its content types.
+
+Only the objects under the asset type name prefix are listed, and objects tagged by upload2gcs with another asset type are not read.
+
+Output
+
+Newline delimited JSON files in the assets JSON file bucket:
+
+- snapshots/<assetShortTypeName>/dt=<YYYY-MM-DD>/resources.ndjson
+
+- snapshots/<assetShortTypeName>/dt=<YYYY-MM-DD>/iam_policies.ndjson
+
+- snapshots/<assetShortTypeName>/dt=<YYYY-MM-DD>/org_policies.ndjson, access_policies.ndjson, os_inventories.ndjson, relationships.ndjson for the other content types
+
+Cardinality
+
+One-few, one scheduled pubsub message - one snapshot file written per content type found (with override).
+
+Automatic retrying
+
+Yes.
+
+Implementation example
+
+ package p
+ import (
+     "context"
+
+     "github.com/BrunoReboul/ram/services/consolidategcs"
+     "github.com/BrunoReboul/ram/utilities/ram"
+ )
+ var global consolidategcs.Global
+ var ctx = context.Background()
+
+ // EntryPoint is the function to be executed for each cloud function occurence
+ func EntryPoint(ctxEvent context.Context, PubSubMessage gps.PubSubMessage) error {
+     return consolidategcs.EntryPoint(ctxEvent, PubSubMessage, &global)
+ }
+
+ func init() {
+     consolidategcs.Initialize(ctx, &global)
+ }
+
+Notes
+
+- Reads the current asset JSON files written by upload2gcs, history/ and snapshots/ folders are not consolidated.
+
+- The dt=<YYYY-MM-DD> folder naming enables BigQuery hive partitioning on external tables.
+
+- Objects age is managed by the bucket delete lifecycle rule set from hosting.gcs.buckets.assetsJSONFile.deleteAgeInDays.
+
+*/
+package consolidategcs
@@ -0,0 +1,40 @@
+// Copyright 2020 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the 'License');
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an 'AS IS' BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package consolidategcs
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+)
+
+// compactAssetJSON returns an asset JSON file content as a one line JSON together with its asset type
+// Returns an empty asset type when the content is not an asset
+func compactAssetJSON(content []byte) (line []byte, assetType string, err error) {
+	var asset struct {
+		AssetType string `json:"assetType"`
+	}
+	err = json.Unmarshal(content, &asset)
+	if err != nil {
+		// not an asset JSON file, skip it
+		return nil, "", nil
+	}
+	var buffer bytes.Buffer
+	err = json.Compact(&buffer, content)
+	if err != nil {
+		return nil, "", fmt.Errorf("json.Compact %v", err)
+	}
+	return buffer.Bytes(), asset.AssetType, nil
+}
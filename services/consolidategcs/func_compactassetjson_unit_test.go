@@ -0,0 +1,56 @@
+// Copyright 2020 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the 'License');
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an 'AS IS' BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package consolidategcs
+
+import (
+	"testing"
+)
+
+func TestUnitCompactAssetJSON(t *testing.T) {
+	var testCases = []struct {
+		name          string
+		content       string
+		wantLine      string
+		wantAssetType string
+	}{
+		{
+			name:          "indentedAsset",
+			content:       "{\n    \"name\": \"//compute.googleapis.com/projects/p/zones/z/instances/i\",\n    \"assetType\": \"compute.googleapis.com/Instance\"\n}",
+			wantLine:      `{"name":"//compute.googleapis.com/projects/p/zones/z/instances/i","assetType":"compute.googleapis.com/Instance"}`,
+			wantAssetType: "compute.googleapis.com/Instance",
+		},
+		{
+			name:    "notJSON",
+			content: "not a json",
+		},
+	}
+
+	for _, tc := range testCases {
+		tc := tc // https://github.com/golang/go/wiki/CommonMistakes#using-goroutines-on-loop-iterator-variables
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+			line, assetType, err := compactAssetJSON([]byte(tc.content))
+			if err != nil {
+				t.Fatalf("compactAssetJSON %v", err)
+			}
+			if string(line) != tc.wantLine {
+				t.Errorf("Want '%s' got '%s'", tc.wantLine, string(line))
+			}
+			if assetType != tc.wantAssetType {
+				t.Errorf("Want '%s' got '%s'", tc.wantAssetType, assetType)
+			}
+		})
+	}
+}
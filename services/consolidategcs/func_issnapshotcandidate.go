@@ -0,0 +1,31 @@
+// Copyright 2020 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the 'License');
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an 'AS IS' BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package consolidategcs
+
+import (
+	"strings"
+
+	"github.com/BrunoReboul/ram/utilities/cai"
+)
+
+// isSnapshotCandidate true when an object may hold an asset of the asset type to consolidate
+// Objects tagged with another asset type are skipped without being read, untagged objects written before the tag existed have to be read
+func isSnapshotCandidate(objectName string, objectMetadata map[string]string, assetType string) bool {
+	if !strings.HasSuffix(objectName, ".json") {
+		return false
+	}
+	objectAssetType, ok := objectMetadata[cai.AssetTypeMetadataKey]
+	return !ok || objectAssetType == assetType
+}
@@ -0,0 +1,62 @@
+// Copyright 2020 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the 'License');
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an 'AS IS' BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package consolidategcs
+
+import (
+	"testing"
+)
+
+func TestUnitIsSnapshotCandidate(t *testing.T) {
+	var testCases = []struct {
+		name           string
+		objectName     string
+		objectMetadata map[string]string
+		want           bool
+	}{
+		{
+			name:           "sameAssetType",
+			objectName:     "compute.googleapis.com/projects/p/zones/z/instances/i.json",
+			objectMetadata: map[string]string{"assetType": "compute.googleapis.com/Instance"},
+			want:           true,
+		},
+		{
+			name:           "otherAssetType",
+			objectName:     "compute.googleapis.com/projects/p/global/networks/n.json",
+			objectMetadata: map[string]string{"assetType": "compute.googleapis.com/Network"},
+			want:           false,
+		},
+		{
+			name:       "untagged",
+			objectName: "compute.googleapis.com/projects/p/zones/z/instances/i_iam.json",
+			want:       true,
+		},
+		{
+			name:       "notJSON",
+			objectName: "compute.googleapis.com/projects/p/zones/z/instances/i.txt",
+			want:       false,
+		},
+	}
+
+	for _, tc := range testCases {
+		tc := tc // https://github.com/golang/go/wiki/CommonMistakes#using-goroutines-on-loop-iterator-variables
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+			got := isSnapshotCandidate(tc.objectName, tc.objectMetadata, "compute.googleapis.com/Instance")
+			if got != tc.want {
+				t.Errorf("Want '%v' got '%v'", tc.want, got)
+			}
+		})
+	}
+}
@@ -0,0 +1,34 @@
+// Copyright 2020 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the 'License');
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an 'AS IS' BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package consolidategcs
+
+import (
+	"github.com/BrunoReboul/ram/utilities/sch"
+)
+
+func (instanceDeployment *InstanceDeployment) deploySCHJob() (err error) {
+	jobDeployment := sch.NewJobDeployment()
+	jobDeployment.Core = instanceDeployment.Core
+	jobDeployment.Artifacts = instanceDeployment.Artifacts
+	scheduler := instanceDeployment.Settings.Instance.SCH.Schedulers[instanceDeployment.Core.EnvironmentName]
+	if scheduler.TimeZone != "" {
+		jobDeployment.Settings.TimeZone = scheduler.TimeZone
+	}
+	jobDeployment.Settings.RetryCount = scheduler.RetryCount
+	if scheduler.MaxRetryDuration != "" {
+		jobDeployment.Settings.MaxRetryDuration = scheduler.MaxRetryDuration
+	}
+	return jobDeployment.Deploy()
+}
@@ -48,6 +48,7 @@ import (
 // "cloud.google.com/go/logging" got error json: cannot unmarshal string into Go struct field Entry.Severity of type logging.Severity
 type logEntry struct {
 	InsertID         string    `json:"insertId"`
+	LogName          string    `json:"logName"`
 	Timestamp        time.Time `json:"timestamp"`
 	ReceiveTimestamp time.Time `json:"receiveTimestamp"`
 	Resource         struct {
@@ -59,10 +60,16 @@ type logEntry struct {
 
 // https://developers.google.com/admin-sdk/reports/v1/reference/activity-ref-appendix-a/admin-event-names
 type protoPayload struct {
-	ServiceName  string `json:"serviceName"`
-	MethodName   string `json:"methodName"`
-	ResourceName string `json:"resourceName"`
-	Metadata     struct {
+	ServiceName        string `json:"serviceName"`
+	MethodName         string `json:"methodName"`
+	ResourceName       string `json:"resourceName"`
+	AuthenticationInfo struct {
+		PrincipalEmail string `json:"principalEmail"`
+	} `json:"authenticationInfo"`
+	RequestMetadata struct {
+		CallerIP string `json:"callerIp"`
+	} `json:"requestMetadata"`
+	Metadata struct {
 		Events []event `json:"event"`
 	} `json:"metadata"`
 }
@@ -81,8 +88,17 @@ type groupSettingsParameters []struct {
 	Value string `json:"value"`
 }
 
+// adminEventParameters parameters shared by all admin event types, e.g. USER_EMAIL, SETTING_NAME, NEW_VALUE
+type adminEventParameters []struct {
+	Label string `json:"label"`
+	Name  string `json:"name"`
+	Type  string `json:"type"`
+	Value string `json:"value"`
+}
+
 // Global structure for global variables to optimize the cloud function performances
 type Global struct {
+	auditActorsCollectionID     string
 	cloudresourcemanagerService *cloudresourcemanager.Service
 	collectionID                string
 	ctx                         context.Context
@@ -96,9 +112,12 @@ type Global struct {
 	instanceName                string
 	logEntry                    logEntry
 	logStep                     glo.Step
+	logger                      *glo.Logger
 	microserviceName            string
 	organizationID              string
 	projectID                   string
+	projectNumbers              map[string]int64
+	pubSubAttributes            map[string]string
 	PubSubID                    string
 	pubsubPublisherClient       *pubsub.PublisherClient
 	retriesNumber               time.Duration
@@ -133,20 +152,29 @@ func Initialize(ctx context.Context, global *Global) (err error) {
 	global.instanceName = instanceDeployment.Core.InstanceName
 	global.microserviceName = instanceDeployment.Core.ServiceName
 
-	log.Println(glo.Entry{
-		MicroserviceName: global.microserviceName,
-		InstanceName:     global.instanceName,
-		Environment:      global.environment,
-		Severity:         "NOTICE",
-		Message:          "coldstart",
-		InitID:           initID,
-	})
+	global.logger = glo.NewLogger(global.microserviceName, global.instanceName, global.environment, initID)
+	global.logger.SetLogParameters(glo.GetLogParameters(instanceDeployment.Settings.Service.GLO, instanceDeployment.Settings.Instance.GLO))
+	global.logger.Notice("coldstart", "")
+
+	err = glo.InitTracing(ctx,
+		instanceDeployment.Core.SolutionSettings.Hosting.Tracing.Exporter,
+		instanceDeployment.Core.SolutionSettings.Hosting.Tracing.OTLPEndpoint,
+		instanceDeployment.Core.SolutionSettings.Hosting.Tracing.FilePath,
+		global.microserviceName,
+		global.instanceName,
+		global.environment)
+	if err != nil {
+		global.logger.InitFailed(fmt.Sprintf("glo.InitTracing %v", err))
+		return err
+	}
 
 	gciAdminUserToImpersonate := instanceDeployment.Settings.Instance.GCI.SuperAdminEmail
+	global.auditActorsCollectionID = instanceDeployment.Core.SolutionSettings.Hosting.FireStore.CollectionIDs.AuditActors
 	global.collectionID = instanceDeployment.Core.SolutionSettings.Hosting.FireStore.CollectionIDs.Assets
 	global.GCIGroupMembersTopicName = instanceDeployment.Core.SolutionSettings.Hosting.Pubsub.TopicNames.GCIGroupMembers
 	global.GCIGroupSettingsTopicName = instanceDeployment.Core.SolutionSettings.Hosting.Pubsub.TopicNames.GCIGroupSettings
 	global.projectID = instanceDeployment.Core.SolutionSettings.Hosting.ProjectID
+	global.projectNumbers = make(map[string]int64)
 	global.retriesNumber = instanceDeployment.Settings.Service.RetriesNumber
 	global.retryTimeOutSeconds = instanceDeployment.Settings.Service.GCF.RetryTimeOutSeconds
 	keyJSONFilePath := solution.PathToFunctionCode + instanceDeployment.Settings.Service.KeyJSONFileName
@@ -156,29 +184,13 @@ func Initialize(ctx context.Context, global *Global) (err error) {
 
 	global.firestoreClient, err = firestore.NewClient(global.ctx, global.projectID)
 	if err != nil {
-		log.Println(glo.Entry{
-			MicroserviceName: global.microserviceName,
-			InstanceName:     global.instanceName,
-			Environment:      global.environment,
-			Severity:         "CRITICAL",
-			Message:          "init_failed",
-			Description:      fmt.Sprintf("firestore.NewClient %v", err),
-			InitID:           initID,
-		})
+		global.logger.InitFailed(fmt.Sprintf("firestore.NewClient %v", err))
 		return err
 	}
 
 	serviceAccountKeyNames, err := gfs.ListKeyNames(ctx, global.firestoreClient, instanceDeployment.Core.ServiceName)
 	if err != nil {
-		log.Println(glo.Entry{
-			MicroserviceName: global.microserviceName,
-			InstanceName:     global.instanceName,
-			Environment:      global.environment,
-			Severity:         "CRITICAL",
-			Message:          "init_failed",
-			Description:      fmt.Sprintf("gfs.ListKeyNames %v", err),
-			InitID:           initID,
-		})
+		global.logger.InitFailed(fmt.Sprintf("gfs.ListKeyNames %v", err))
 		return err
 	}
 
@@ -187,74 +199,33 @@ func Initialize(ctx context.Context, global *Global) (err error) {
 		keyJSONFilePath,
 		instanceDeployment.Core.SolutionSettings.Hosting.ProjectID,
 		gciAdminUserToImpersonate,
-		[]string{"https://www.googleapis.com/auth/apps.groups.settings", "https://www.googleapis.com/auth/admin.directory.group.readonly"},
+		[]string{"https://www.googleapis.com/auth/apps.groups.settings",
+			"https://www.googleapis.com/auth/admin.directory.group.readonly",
+			"https://www.googleapis.com/auth/admin.directory.user.readonly"},
 		serviceAccountKeyNames,
-		initID,
-		global.microserviceName,
-		global.instanceName,
-		global.environment); !ok {
+		global.logger); !ok {
 		return fmt.Errorf("aut.GetClientOptionAndCleanKeys")
 	}
 	global.dirAdminService, err = admin.NewService(ctx, clientOption)
 	if err != nil {
-		log.Println(glo.Entry{
-			MicroserviceName: global.microserviceName,
-			InstanceName:     global.instanceName,
-			Environment:      global.environment,
-			Severity:         "CRITICAL",
-			Message:          "init_failed",
-			Description:      fmt.Sprintf("admin.NewService %v", err),
-			InitID:           initID,
-		})
+		global.logger.InitFailed(fmt.Sprintf("admin.NewService %v", err))
 		return err
 	}
 	global.groupsSettingsService, err = groupssettings.NewService(ctx, clientOption)
 	if err != nil {
-		log.Println(glo.Entry{
-			MicroserviceName: global.microserviceName,
-			InstanceName:     global.instanceName,
-			Environment:      global.environment,
-			Severity:         "CRITICAL",
-			Message:          "init_failed",
-			Description:      fmt.Sprintf("groupssettings.NewService %v", err),
-			InitID:           initID,
-		})
+		global.logger.InitFailed(fmt.Sprintf("groupssettings.NewService %v", err))
 	}
 	global.pubsubPublisherClient, err = pubsub.NewPublisherClient(global.ctx)
 	if err != nil {
-		log.Println(glo.Entry{
-			MicroserviceName: global.microserviceName,
-			InstanceName:     global.instanceName,
-			Environment:      global.environment,
-			Severity:         "CRITICAL",
-			Message:          "init_failed",
-			Description:      fmt.Sprintf("global.pubsubPublisherClient %v", err),
-			InitID:           initID,
-		})
+		global.logger.InitFailed(fmt.Sprintf("global.pubsubPublisherClient %v", err))
 	}
 	global.cloudresourcemanagerService, err = cloudresourcemanager.NewService(ctx)
 	if err != nil {
-		log.Println(glo.Entry{
-			MicroserviceName: global.microserviceName,
-			InstanceName:     global.instanceName,
-			Environment:      global.environment,
-			Severity:         "CRITICAL",
-			Message:          "init_failed",
-			Description:      fmt.Sprintf("cloudresourcemanager.NewService %v", err),
-			InitID:           initID,
-		})
+		global.logger.InitFailed(fmt.Sprintf("cloudresourcemanager.NewService %v", err))
 	}
 	err = gps.GetTopicList(global.ctx, global.pubsubPublisherClient, global.projectID, &global.topicList)
 	if err != nil {
-		log.Println(glo.Entry{
-			MicroserviceName: global.microserviceName,
-			InstanceName:     global.instanceName,
-			Environment:      global.environment,
-			Severity:         "CRITICAL",
-			Message:          "init_failed",
-			Description:      fmt.Sprintf("gps.GetTopicList %v", err),
-			InitID:           initID,
-		})
+		global.logger.InitFailed(fmt.Sprintf("gps.GetTopicList %v", err))
 	}
 	return nil
 }
@@ -265,15 +236,7 @@ func EntryPoint(ctxEvent context.Context, PubSubMessage gps.PubSubMessage, globa
 	metadata, err := metadata.FromContext(ctxEvent)
 	if err != nil {
 		// Assume an error on the function invoker and try again.
-		log.Println(glo.Entry{
-			MicroserviceName:   global.microserviceName,
-			InstanceName:       global.instanceName,
-			Environment:        global.environment,
-			Severity:           "CRITICAL",
-			Message:            "redo_on_transient",
-			Description:        fmt.Sprintf("pubsub_id no available metadata.FromContext: %v", err),
-			TriggeringPubsubID: global.PubSubID,
-		})
+		global.logger.RedoOnTransient(fmt.Sprintf("pubsub_id no available metadata.FromContext: %v", err))
 		return err
 	}
 	global.stepStack = nil
@@ -283,52 +246,36 @@ func EntryPoint(ctxEvent context.Context, PubSubMessage gps.PubSubMessage, globa
 		StepID:        fmt.Sprintf("%s/%s", parts[len(parts)-1], global.PubSubID),
 		StepTimestamp: metadata.Timestamp,
 	}
+	global.logger.SetInvocation(global.PubSubID, global.step)
+	global.pubSubAttributes = PubSubMessage.Attributes
+	defer func() {
+		glo.RecordStepSpan(global.ctx, global.microserviceName, global.pubSubAttributes, global.stepStack)
+	}()
 
-	now := time.Now()
-	d := now.Sub(metadata.Timestamp)
-	log.Println(glo.Entry{
-		MicroserviceName:           global.microserviceName,
-		InstanceName:               global.instanceName,
-		Environment:                global.environment,
-		Severity:                   "NOTICE",
-		Message:                    "start",
-		TriggeringPubsubID:         global.PubSubID,
-		TriggeringPubsubAgeSeconds: d.Seconds(),
-		TriggeringPubsubTimestamp:  &metadata.Timestamp,
-		Now:                        &now,
-	})
+	d := global.logger.Start()
 
 	if d.Seconds() > float64(global.retryTimeOutSeconds) {
-		log.Println(glo.Entry{
-			MicroserviceName:           global.microserviceName,
-			InstanceName:               global.instanceName,
-			Environment:                global.environment,
-			Severity:                   "CRITICAL",
-			Message:                    "noretry",
-			Description:                "Pubsub message too old",
-			TriggeringPubsubID:         global.PubSubID,
-			TriggeringPubsubAgeSeconds: d.Seconds(),
-			TriggeringPubsubTimestamp:  &metadata.Timestamp,
-			Now:                        &now,
-		})
+		global.logger.NoRetryTooOld()
 		return nil
 	}
 
 	err = json.Unmarshal(PubSubMessage.Data, &global.logEntry)
 	if err != nil {
-		log.Println(glo.Entry{
-			MicroserviceName:   global.microserviceName,
-			InstanceName:       global.instanceName,
-			Environment:        global.environment,
-			Severity:           "CRITICAL",
-			Message:            "noretry",
-			Description:        fmt.Sprintf("json.Unmarshal logentry %v %v", PubSubMessage.Data, err),
-			TriggeringPubsubID: global.PubSubID,
-		})
+		global.logger.NoRetry(fmt.Sprintf("json.Unmarshal logentry %v %v", PubSubMessage.Data, err))
 		return nil
 	}
 	global.logStep.StepTimestamp = global.logEntry.Timestamp
 
+	// GCP admin activity audit logs are recorded to correlate asset changes with who made them
+	if strings.Contains(global.logEntry.LogName, "cloudaudit.googleapis.com%2Factivity") &&
+		global.logEntry.Resource.Labels["service"] != "admin.googleapis.com" {
+		err = recordAuditActor(global)
+		if err != nil {
+			return fmt.Errorf("pubsub_id %s REDO_ON_TRANSIENT %v", global.PubSubID, err)
+		}
+		return nil
+	}
+
 	switch global.logEntry.Resource.Type {
 	case "audited_resource":
 		switch global.logEntry.Resource.Labels["service"] {
@@ -340,45 +287,89 @@ func EntryPoint(ctxEvent context.Context, PubSubMessage gps.PubSubMessage, globa
 			return nil
 		default:
 			log.Printf("pubsub_id %s NORETRY_ERROR unmanaged global.logEntry.Resource.Labels service  %s", global.PubSubID, global.logEntry.Resource.Labels["service"])
-			now := time.Now()
-			latency := now.Sub(global.step.StepTimestamp)
-			latencyE2E := now.Sub(global.stepStack[0].StepTimestamp)
-			log.Println(glo.Entry{
-				MicroserviceName:     global.microserviceName,
-				InstanceName:         global.instanceName,
-				Environment:          global.environment,
-				Severity:             "NOTICE",
-				Message:              "cancel",
-				Description:          fmt.Sprintf("unmanaged global.logEntry.Resource.Labels service  %s", global.logEntry.Resource.Labels["service"]),
-				Now:                  &now,
-				TriggeringPubsubID:   global.PubSubID,
-				OriginEventTimestamp: &global.stepStack[0].StepTimestamp,
-				LatencySeconds:       latency.Seconds(),
-				LatencyE2ESeconds:    latencyE2E.Seconds(),
-				StepStack:            global.stepStack,
-			})
+			global.logger.Cancel(fmt.Sprintf("unmanaged global.logEntry.Resource.Labels service  %s", global.logEntry.Resource.Labels["service"]))
 			return nil
 		}
 	default:
-		now := time.Now()
-		latency := now.Sub(global.step.StepTimestamp)
-		latencyE2E := now.Sub(global.stepStack[0].StepTimestamp)
-		log.Println(glo.Entry{
-			MicroserviceName:     global.microserviceName,
-			InstanceName:         global.instanceName,
-			Environment:          global.environment,
-			Severity:             "NOTICE",
-			Message:              "cancel",
-			Description:          fmt.Sprintf("unmanaged logEntry.Resource.Type %s", global.logEntry.Resource.Type),
-			Now:                  &now,
-			TriggeringPubsubID:   global.PubSubID,
-			OriginEventTimestamp: &global.stepStack[0].StepTimestamp,
-			LatencySeconds:       latency.Seconds(),
-			LatencyE2ESeconds:    latencyE2E.Seconds(),
-			StepStack:            global.stepStack,
-		})
+		global.logger.Cancel(fmt.Sprintf("unmanaged logEntry.Resource.Type %s", global.logEntry.Resource.Type))
+		return nil
+	}
+}
+
+// recordAuditActor keeps in firestore the last actor who changed an asset
+func recordAuditActor(global *Global) (err error) {
+	var protoPayload protoPayload
+
+	err = json.Unmarshal(global.logEntry.ProtoPayload, &protoPayload)
+	if err != nil {
+		global.logger.NoRetry(fmt.Sprintf("json.Unmarshal protoPaylaod %v %v", global.logEntry.ProtoPayload, err))
+		return nil
+	}
+	global.logStep.StepID = fmt.Sprintf("%s/%s", protoPayload.ResourceName, global.logEntry.InsertID)
+	global.stepStack = append(global.stepStack, global.logStep)
+	global.stepStack = append(global.stepStack, global.step)
+	global.logger.SetStepStack(global.stepStack)
+
+	if protoPayload.ResourceName == "" || protoPayload.AuthenticationInfo.PrincipalEmail == "" {
+		global.logger.Cancel(fmt.Sprintf("no resource name or principal email in audit log %s", global.logEntry.InsertID))
 		return nil
 	}
+
+	assetName, err := cai.GetAssetNameFromAuditLog(protoPayload.ServiceName, protoPayload.ResourceName, func(projectID string) (int64, error) {
+		return getProjectNumber(projectID, global)
+	})
+	if err != nil {
+		return fmt.Errorf("cai.GetAssetNameFromAuditLog %v", err)
+	}
+	actor := cai.Actor{
+		PrincipalEmail: protoPayload.AuthenticationInfo.PrincipalEmail,
+		MethodName:     protoPayload.MethodName,
+		CallerIP:       protoPayload.RequestMetadata.CallerIP,
+		Timestamp:      global.logEntry.Timestamp,
+		LogInsertID:    global.logEntry.InsertID,
+	}
+	documentPath := global.auditActorsCollectionID + "/" + cai.GetActorDocumentID(assetName, cai.GetContentTypeFromAuditLog(protoPayload.ServiceName, protoPayload.MethodName))
+	documentRef := global.firestoreClient.Doc(documentPath)
+	var isStale bool
+	// Log entries may arrive out of order: only the most recent actor is kept
+	err = global.firestoreClient.RunTransaction(global.ctx, func(ctx context.Context, tx *firestore.Transaction) error {
+		isStale = false
+		documentSnap, err := tx.Get(documentRef)
+		if err != nil {
+			if !strings.Contains(strings.ToLower(strings.Replace(err.Error(), " ", "", -1)), "notfound") {
+				return err
+			}
+		} else {
+			var cachedActor cai.Actor
+			if documentSnap.DataTo(&cachedActor) == nil && cachedActor.Timestamp.After(actor.Timestamp) {
+				isStale = true
+				return nil
+			}
+		}
+		return tx.Set(documentRef, actor)
+	})
+	if err != nil {
+		return fmt.Errorf("global.firestoreClient.RunTransaction documentPath %s %v", documentPath, err)
+	}
+	action := "record"
+	if isStale {
+		action = "skip stale"
+	}
+	global.logger.Finish(fmt.Sprintf("finish %s actor %s", action, documentPath), fmt.Sprintf("%s %s %s", actor.PrincipalEmail, actor.MethodName, assetName), "")
+	return nil
+}
+
+// getProjectNumber resolves a project ID to its number, cached for the function instance lifetime as it never changes
+func getProjectNumber(projectID string, global *Global) (projectNumber int64, err error) {
+	if projectNumber, ok := global.projectNumbers[projectID]; ok {
+		return projectNumber, nil
+	}
+	project, err := global.cloudresourcemanagerService.Projects.Get(projectID).Context(global.ctx).Do()
+	if err != nil {
+		return projectNumber, fmt.Errorf("cloudresourcemanagerService.Projects.Get %s %v", projectID, err)
+	}
+	global.projectNumbers[projectID] = project.ProjectNumber
+	return project.ProjectNumber, nil
 }
 
 // https://developers.google.com/admin-sdk/reports/v1/reference/activity-ref-appendix-a/admin-event-names
@@ -387,34 +378,19 @@ func convertAdminActivityEvent(global *Global) (err error) {
 
 	err = json.Unmarshal(global.logEntry.ProtoPayload, &protoPayload)
 	if err != nil {
-		log.Println(glo.Entry{
-			MicroserviceName:   global.microserviceName,
-			InstanceName:       global.instanceName,
-			Environment:        global.environment,
-			Severity:           "CRITICAL",
-			Message:            "noretry",
-			Description:        fmt.Sprintf("json.Unmarshal protoPaylaod %v %v", global.logEntry.ProtoPayload, err),
-			TriggeringPubsubID: global.PubSubID,
-		})
+		global.logger.NoRetry(fmt.Sprintf("json.Unmarshal protoPaylaod %v %v", global.logEntry.ProtoPayload, err))
 		return nil
 	}
 	global.logStep.StepID = fmt.Sprintf("%s/%s", protoPayload.ResourceName, global.logEntry.InsertID)
 	global.stepStack = append(global.stepStack, global.logStep)
 	global.stepStack = append(global.stepStack, global.step)
+	global.logger.SetStepStack(global.stepStack)
 
 	parts := strings.Split(protoPayload.ResourceName, "/")
 	global.organizationID = parts[1]
 	getCustomerID(global)
 	if global.directoryCustomerID == "" {
-		log.Println(glo.Entry{
-			MicroserviceName:   global.microserviceName,
-			InstanceName:       global.instanceName,
-			Environment:        global.environment,
-			Severity:           "CRITICAL",
-			Message:            "noretry",
-			Description:        "cannot get customer ID",
-			TriggeringPubsubID: global.PubSubID,
-		})
+		global.logger.NoRetry("cannot get customer ID")
 		return nil
 	}
 
@@ -422,24 +398,18 @@ func convertAdminActivityEvent(global *Global) (err error) {
 		switch event.EventType {
 		case "GROUP_SETTINGS":
 			return convertGroupSettings(&event, global)
+		case "USER_SETTINGS", "DELEGATED_ADMIN_SETTINGS":
+			// https://developers.google.com/admin-sdk/reports/v1/appendix/activity/admin-user-settings
+			// https://developers.google.com/admin-sdk/reports/v1/appendix/activity/admin-delegated-admin-settings
+			return convertUserSettings(&event, global)
+		case "DOMAIN_SETTINGS":
+			// https://developers.google.com/admin-sdk/reports/v1/appendix/activity/admin-domain-settings
+			return convertDirectorySetting(&event, "domainSettings", global)
+		case "SECURITY_SETTINGS":
+			// https://developers.google.com/admin-sdk/reports/v1/appendix/activity/admin-security-settings
+			return convertDirectorySetting(&event, "securitySettings", global)
 		default:
-			now := time.Now()
-			latency := now.Sub(global.step.StepTimestamp)
-			latencyE2E := now.Sub(global.stepStack[0].StepTimestamp)
-			log.Println(glo.Entry{
-				MicroserviceName:     global.microserviceName,
-				InstanceName:         global.instanceName,
-				Environment:          global.environment,
-				Severity:             "NOTICE",
-				Message:              "cancel",
-				Description:          fmt.Sprintf("unmanaged event.EventType %s", event.EventType),
-				Now:                  &now,
-				TriggeringPubsubID:   global.PubSubID,
-				OriginEventTimestamp: &global.stepStack[0].StepTimestamp,
-				LatencySeconds:       latency.Seconds(),
-				LatencyE2ESeconds:    latencyE2E.Seconds(),
-				StepStack:            global.stepStack,
-			})
+			global.logger.Cancel(fmt.Sprintf("unmanaged event.EventType %s", event.EventType))
 			return nil
 		}
 	}
@@ -457,15 +427,7 @@ func getCustomerID(global *Global) {
 		assetMap := documentSnap.Data()
 		assetMapJSON, err := json.Marshal(assetMap)
 		if err != nil {
-			log.Println(glo.Entry{
-				MicroserviceName:   global.microserviceName,
-				InstanceName:       global.instanceName,
-				Environment:        global.environment,
-				Severity:           "WARNING",
-				Message:            "json.Marshal(assetMap)",
-				Description:        fmt.Sprintf("assetMap %v err %v", assetMap, err),
-				TriggeringPubsubID: global.PubSubID,
-			})
+			global.logger.Warning("json.Marshal(assetMap)", fmt.Sprintf("assetMap %v err %v", assetMap, err))
 			return
 		}
 		// log.Printf("%s", string(assetMapJSON))
@@ -490,27 +452,11 @@ func getCustomerID(global *Global) {
 		}
 	} else {
 		log.Printf("pubsub_id %s WARNING not found in firestore %s", global.PubSubID, documentPath)
-		log.Println(glo.Entry{
-			MicroserviceName:   global.microserviceName,
-			InstanceName:       global.instanceName,
-			Environment:        global.environment,
-			Severity:           "WARNING",
-			Message:            "not found in firestore",
-			Description:        fmt.Sprintf("documentPath %s", documentPath),
-			TriggeringPubsubID: global.PubSubID,
-		})
+		global.logger.Warning("not found in firestore", fmt.Sprintf("documentPath %s", documentPath))
 		//try resourcemamager API
 		resp, err := global.cloudresourcemanagerService.Organizations.Get(global.organizationID).Context(global.ctx).Do()
 		if err != nil {
-			log.Println(glo.Entry{
-				MicroserviceName:   global.microserviceName,
-				InstanceName:       global.instanceName,
-				Environment:        global.environment,
-				Severity:           "WARNING",
-				Message:            "not found with resource manager",
-				Description:        fmt.Sprintf("cloudresourcemanagerService.Organizations.Get orgID %s err %v ", global.organizationID, err),
-				TriggeringPubsubID: global.PubSubID,
-			})
+			global.logger.Warning("not found with resource manager", fmt.Sprintf("cloudresourcemanagerService.Organizations.Get orgID %s err %v ", global.organizationID, err))
 		} else {
 			global.directoryCustomerID = resp.Owner.DirectoryCustomerId
 			return
@@ -523,15 +469,7 @@ func convertGroupSettings(event *event, global *Global) (err error) {
 	var parameters groupSettingsParameters
 	err = json.Unmarshal(event.Parameter, &parameters)
 	if err != nil {
-		log.Println(glo.Entry{
-			MicroserviceName:   global.microserviceName,
-			InstanceName:       global.instanceName,
-			Environment:        global.environment,
-			Severity:           "CRITICAL",
-			Message:            "noretry",
-			Description:        fmt.Sprintf("json.Unmarshal(event.Parameter, &parameters) %v %v", event.Parameter, err),
-			TriggeringPubsubID: global.PubSubID,
-		})
+		global.logger.NoRetry(fmt.Sprintf("json.Unmarshal(event.Parameter, &parameters) %v %v", event.Parameter, err))
 		return nil
 	}
 	var groupEmail string
@@ -543,15 +481,7 @@ func convertGroupSettings(event *event, global *Global) (err error) {
 		}
 	}
 	if groupEmail == "" {
-		log.Println(glo.Entry{
-			MicroserviceName:   global.microserviceName,
-			InstanceName:       global.instanceName,
-			Environment:        global.environment,
-			Severity:           "CRITICAL",
-			Message:            "noretry",
-			Description:        fmt.Sprintf("expected parameter GROUP_EMAIL not found, insertId %s", global.logEntry.InsertID),
-			TriggeringPubsubID: global.PubSubID,
-		})
+		global.logger.NoRetry(fmt.Sprintf("expected parameter GROUP_EMAIL not found, insertId %s", global.logEntry.InsertID))
 		return nil
 	}
 	switch event.EventName {
@@ -591,15 +521,7 @@ func convertGroupSettings(event *event, global *Global) (err error) {
 		}
 		if memberEmail == "" {
 			log.Printf("pubsub_id %s NORETRY_ERROR ADD_GROUP_MEMBER expected parameter USER_EMAIL aka member, not found, insertId %s", global.PubSubID, global.logEntry.InsertID)
-			log.Println(glo.Entry{
-				MicroserviceName:   global.microserviceName,
-				InstanceName:       global.instanceName,
-				Environment:        global.environment,
-				Severity:           "CRITICAL",
-				Message:            "noretry",
-				Description:        fmt.Sprintf("ADD_GROUP_MEMBER expected parameter USER_EMAIL aka member, not found, insertId %s", global.logEntry.InsertID),
-				TriggeringPubsubID: global.PubSubID,
-			})
+			global.logger.NoRetry(fmt.Sprintf("ADD_GROUP_MEMBER expected parameter USER_EMAIL aka member, not found, insertId %s", global.logEntry.InsertID))
 			return nil
 		}
 		return publishGroupMemberDeletion(groupEmail, memberEmail, global)
@@ -607,23 +529,7 @@ func convertGroupSettings(event *event, global *Global) (err error) {
 		// https://developers.google.com/admin-sdk/reports/v1/appendix/activity/admin-group-settings#CHANGE_GROUP_SETTING
 		return publishGroupSettings(groupEmail, global)
 	default:
-		now := time.Now()
-		latency := now.Sub(global.step.StepTimestamp)
-		latencyE2E := now.Sub(global.stepStack[0].StepTimestamp)
-		log.Println(glo.Entry{
-			MicroserviceName:     global.microserviceName,
-			InstanceName:         global.instanceName,
-			Environment:          global.environment,
-			Severity:             "NOTICE",
-			Message:              "cancel",
-			Description:          fmt.Sprintf("unmanaged event.EventName %s", event.EventName),
-			Now:                  &now,
-			TriggeringPubsubID:   global.PubSubID,
-			OriginEventTimestamp: &global.stepStack[0].StepTimestamp,
-			LatencySeconds:       latency.Seconds(),
-			LatencyE2ESeconds:    latencyE2E.Seconds(),
-			StepStack:            global.stepStack,
-		})
+		global.logger.Cancel(fmt.Sprintf("unmanaged event.EventName %s", event.EventName))
 		return nil
 		// https://developers.google.com/admin-sdk/reports/v1/appendix/activity/admin-group-settings#GROUP_LIST_DOWNLOAD
 		// https://developers.google.com/admin-sdk/reports/v1/appendix/activity/admin-group-settings#UPDATE_GROUP_MEMBER_DELIVERY_SETTINGS
@@ -657,7 +563,8 @@ func publishGroupDeletion(groupEmail string, global *Global) (err error) {
 	assets := global.firestoreClient.Collection(global.collectionID)
 	query := assets.Where(
 		"asset.assetType", "==", "www.googleapis.com/admin/directory/groups").Where(
-		"asset.resource.email", "==", strings.ToLower(groupEmail))
+		"asset.resource.email", "==", strings.ToLower(groupEmail)).Where(
+		"deleted", "==", false)
 	var documentSnap *firestore.DocumentSnapshot
 	iter := query.Documents(global.ctx)
 	defer iter.Stop()
@@ -690,15 +597,7 @@ func publishGroupDeletion(groupEmail string, global *Global) (err error) {
 	var i int64
 	for {
 		if i > 0 {
-			log.Println(glo.Entry{
-				MicroserviceName:   global.microserviceName,
-				InstanceName:       global.instanceName,
-				Environment:        global.environment,
-				Severity:           "INFO",
-				Message:            "cleaning cache group orphans",
-				Description:        fmt.Sprintf("iteration %d", i),
-				TriggeringPubsubID: global.PubSubID,
-			})
+			global.logger.Info("cleaning cache group orphans", fmt.Sprintf("iteration %d", i))
 		}
 		i++
 		documentSnap, err = iter.Next()
@@ -742,15 +641,7 @@ func publishGroupDeletion(groupEmail string, global *Global) (err error) {
 		}
 	}
 	if !found {
-		log.Println(glo.Entry{
-			MicroserviceName:   global.microserviceName,
-			InstanceName:       global.instanceName,
-			Environment:        global.environment,
-			Severity:           "WARNING",
-			Message:            "deleted group not found in cache, cannot clean up RAM data",
-			Description:        fmt.Sprintf("groupEmail %s", groupEmail),
-			TriggeringPubsubID: global.PubSubID,
-		})
+		global.logger.Warning("deleted group not found in cache, cannot clean up RAM data", fmt.Sprintf("groupEmail %s", groupEmail))
 	}
 	return nil
 }
@@ -758,19 +649,12 @@ func publishGroupDeletion(groupEmail string, global *Global) (err error) {
 func publishGroup(feedMessage interface{}, isDeleted bool, groupEmail string, assetName string, global *Global) (err error) {
 	feedMessageJSON, err := json.Marshal(feedMessage)
 	if err != nil {
-		log.Println(glo.Entry{
-			MicroserviceName:   global.microserviceName,
-			InstanceName:       global.instanceName,
-			Environment:        global.environment,
-			Severity:           "CRITICAL",
-			Message:            "noretry",
-			Description:        fmt.Sprintf("publishGroup json.Marshal(feedMessage) %v %v", feedMessage, err),
-			TriggeringPubsubID: global.PubSubID,
-		})
+		global.logger.NoRetry(fmt.Sprintf("publishGroup json.Marshal(feedMessage) %v %v", feedMessage, err))
 		return nil
 	}
 	var pubSubMessage pubsubpb.PubsubMessage
 	pubSubMessage.Data = feedMessageJSON
+	pubSubMessage.Attributes = glo.GetTraceAttributes(global.pubSubAttributes, global.stepStack)
 
 	var pubsubMessages []*pubsubpb.PubsubMessage
 	pubsubMessages = append(pubsubMessages, &pubSubMessage)
@@ -778,15 +662,7 @@ func publishGroup(feedMessage interface{}, isDeleted bool, groupEmail string, as
 	var publishRequest pubsubpb.PublishRequest
 	topicShortName := fmt.Sprintf("gci-groups-%s", global.directoryCustomerID)
 	if err = gps.CreateTopic(global.ctx, global.pubsubPublisherClient, &global.topicList, topicShortName, global.projectID); err != nil {
-		log.Println(glo.Entry{
-			MicroserviceName:   global.microserviceName,
-			InstanceName:       global.instanceName,
-			Environment:        global.environment,
-			Severity:           "CRITICAL",
-			Message:            "noretry",
-			Description:        fmt.Sprintf("gps.CreateTopic %s %v", topicShortName, err),
-			TriggeringPubsubID: global.PubSubID,
-		})
+		global.logger.NoRetry(fmt.Sprintf("gps.CreateTopic %s %v", topicShortName, err))
 		return nil
 	}
 	topicName := fmt.Sprintf("projects/%s/topics/%s", global.projectID, topicShortName)
@@ -798,24 +674,7 @@ func publishGroup(feedMessage interface{}, isDeleted bool, groupEmail string, as
 		// log.Printf("publish err no nil %v", err)
 		return fmt.Errorf("%s global.pubsubPublisherClient.Publish: %v", topicShortName, err)
 	}
-	now := time.Now()
-	latency := now.Sub(global.step.StepTimestamp)
-	latencyE2E := now.Sub(global.stepStack[0].StepTimestamp)
-	log.Println(glo.Entry{
-		MicroserviceName:     global.microserviceName,
-		InstanceName:         global.instanceName,
-		Environment:          global.environment,
-		Severity:             "NOTICE",
-		Message:              fmt.Sprintf("finish group %s", groupEmail),
-		Description:          fmt.Sprintf("group published to pubsub %s (isdeleted status=%v) %s topic %s ids %v %s", groupEmail, isDeleted, assetName, topicName, pubsubResponse.MessageIds, string(feedMessageJSON)),
-		Now:                  &now,
-		TriggeringPubsubID:   global.PubSubID,
-		OriginEventTimestamp: &global.stepStack[0].StepTimestamp,
-		LatencySeconds:       latency.Seconds(),
-		LatencyE2ESeconds:    latencyE2E.Seconds(),
-		StepStack:            global.stepStack,
-		AssetInventoryOrigin: "real-time-log-export",
-	})
+	global.logger.Finish(fmt.Sprintf("finish group %s", groupEmail), fmt.Sprintf("group published to pubsub %s (isdeleted status=%v) %s topic %s ids %v %s", groupEmail, isDeleted, assetName, topicName, pubsubResponse.MessageIds, string(feedMessageJSON)), "real-time-log-export")
 	return nil
 }
 
@@ -859,7 +718,8 @@ func publishGroupMemberDeletion(groupEmail string, memberEmail string, global *G
 	query := assets.Where(
 		"asset.assetType", "==", "www.googleapis.com/admin/directory/members").Where(
 		"asset.resource.groupEmail", "==", strings.ToLower(groupEmail)).Where(
-		"asset.resource.memberEmail", "==", strings.ToLower(memberEmail))
+		"asset.resource.memberEmail", "==", strings.ToLower(memberEmail)).Where(
+		"deleted", "==", false)
 	var documentSnap *firestore.DocumentSnapshot
 	iter := query.Documents(global.ctx)
 	defer iter.Stop()
@@ -894,15 +754,7 @@ func publishGroupMemberDeletion(groupEmail string, memberEmail string, global *G
 	var i int64
 	for {
 		if i > 0 {
-			log.Println(glo.Entry{
-				MicroserviceName:   global.microserviceName,
-				InstanceName:       global.instanceName,
-				Environment:        global.environment,
-				Severity:           "INFO",
-				Message:            "cleaning cache groupMember orphans",
-				Description:        fmt.Sprintf("iteration %d", i),
-				TriggeringPubsubID: global.PubSubID,
-			})
+			global.logger.Info("cleaning cache groupMember orphans", fmt.Sprintf("iteration %d", i))
 		}
 		i++
 		documentSnap, err = iter.Next()
@@ -948,15 +800,7 @@ func publishGroupMemberDeletion(groupEmail string, memberEmail string, global *G
 	}
 	if !found {
 		log.Printf("pubsub_id %s NORETRY_ERROR deleted groupMember not found in cache, cannot clean up RAM data member %s group %s", global.PubSubID, memberEmail, groupEmail)
-		log.Println(glo.Entry{
-			MicroserviceName:   global.microserviceName,
-			InstanceName:       global.instanceName,
-			Environment:        global.environment,
-			Severity:           "WARNING",
-			Message:            "deleted groupMember not found in cache, cannot clean up RAM data",
-			Description:        fmt.Sprintf("member %s group %s", memberEmail, groupEmail),
-			TriggeringPubsubID: global.PubSubID,
-		})
+		global.logger.Warning("deleted groupMember not found in cache, cannot clean up RAM data", fmt.Sprintf("member %s group %s", memberEmail, groupEmail))
 	}
 	return nil
 }
@@ -965,19 +809,12 @@ func publishGroupMember(feedMessage interface{}, isDeleted bool, groupEmail stri
 	feedMessageJSON, err := json.Marshal(feedMessage)
 	if err != nil {
 		log.Printf("pubsub_id %s NORETRY_ERROR %s json.Marshal(feedMessage): %v", global.PubSubID, assetName, err)
-		log.Println(glo.Entry{
-			MicroserviceName:   global.microserviceName,
-			InstanceName:       global.instanceName,
-			Environment:        global.environment,
-			Severity:           "CRITICAL",
-			Message:            "noretry",
-			Description:        fmt.Sprintf("publishGroupMember json.Marshal(feedMessage) %v %v", feedMessage, err),
-			TriggeringPubsubID: global.PubSubID,
-		})
+		global.logger.NoRetry(fmt.Sprintf("publishGroupMember json.Marshal(feedMessage) %v %v", feedMessage, err))
 		return nil
 	}
 	var pubSubMessage pubsubpb.PubsubMessage
 	pubSubMessage.Data = feedMessageJSON
+	pubSubMessage.Attributes = glo.GetTraceAttributes(global.pubSubAttributes, global.stepStack)
 
 	var pubsubMessages []*pubsubpb.PubsubMessage
 	pubsubMessages = append(pubsubMessages, &pubSubMessage)
@@ -990,24 +827,7 @@ func publishGroupMember(feedMessage interface{}, isDeleted bool, groupEmail stri
 	if err != nil {
 		return fmt.Errorf("%s global.pubsubPublisherClient.Publish: %v", publishRequest.Topic, err)
 	}
-	now := time.Now()
-	latency := now.Sub(global.step.StepTimestamp)
-	latencyE2E := now.Sub(global.stepStack[0].StepTimestamp)
-	log.Println(glo.Entry{
-		MicroserviceName:     global.microserviceName,
-		InstanceName:         global.instanceName,
-		Environment:          global.environment,
-		Severity:             "NOTICE",
-		Message:              fmt.Sprintf("finish member %s in group %s", memberEmail, groupEmail),
-		Description:          fmt.Sprintf("groupMember published to pubsub %s in group %s (isdeleted status=%v) %s topic %s ids %v %s", memberEmail, groupEmail, isDeleted, assetName, global.GCIGroupMembersTopicName, pubsubResponse.MessageIds, string(feedMessageJSON)),
-		Now:                  &now,
-		TriggeringPubsubID:   global.PubSubID,
-		OriginEventTimestamp: &global.stepStack[0].StepTimestamp,
-		LatencySeconds:       latency.Seconds(),
-		LatencyE2ESeconds:    latencyE2E.Seconds(),
-		StepStack:            global.stepStack,
-		AssetInventoryOrigin: "real-time-log-export",
-	})
+	global.logger.Finish(fmt.Sprintf("finish member %s in group %s", memberEmail, groupEmail), fmt.Sprintf("groupMember published to pubsub %s in group %s (isdeleted status=%v) %s topic %s ids %v %s", memberEmail, groupEmail, isDeleted, assetName, global.GCIGroupMembersTopicName, pubsubResponse.MessageIds, string(feedMessageJSON)), "real-time-log-export")
 	return nil
 }
 
@@ -1039,20 +859,13 @@ func publishGroupSettings(groupEmail string, global *Global) (err error) {
 	feedMessageGroupSettingsJSON, err := json.Marshal(feedMessageGroupSettings)
 	if err != nil {
 		log.Printf("pubsub_id %s NORETRY_ERROR json.Marshal(feedMessageGroupSettings)", global.PubSubID)
-		log.Println(glo.Entry{
-			MicroserviceName:   global.microserviceName,
-			InstanceName:       global.instanceName,
-			Environment:        global.environment,
-			Severity:           "CRITICAL",
-			Message:            "noretry",
-			Description:        fmt.Sprintf("json.Marshal(feedMessageGroupSettings) %v %v", feedMessageGroupSettings, err),
-			TriggeringPubsubID: global.PubSubID,
-		})
+		global.logger.NoRetry(fmt.Sprintf("json.Marshal(feedMessageGroupSettings) %v %v", feedMessageGroupSettings, err))
 		return nil
 	}
 
 	var pubSubMessage pubsubpb.PubsubMessage
 	pubSubMessage.Data = feedMessageGroupSettingsJSON
+	pubSubMessage.Attributes = glo.GetTraceAttributes(global.pubSubAttributes, global.stepStack)
 
 	var pubsubMessages []*pubsubpb.PubsubMessage
 	pubsubMessages = append(pubsubMessages, &pubSubMessage)
@@ -1065,24 +878,7 @@ func publishGroupSettings(groupEmail string, global *Global) (err error) {
 	if err != nil {
 		return fmt.Errorf("%s global.pubsubPublisherClient.Publish: %v", publishRequest.Topic, err)
 	}
-	now := time.Now()
-	latency := now.Sub(global.step.StepTimestamp)
-	latencyE2E := now.Sub(global.stepStack[0].StepTimestamp)
-	log.Println(glo.Entry{
-		MicroserviceName:     global.microserviceName,
-		InstanceName:         global.instanceName,
-		Environment:          global.environment,
-		Severity:             "NOTICE",
-		Message:              fmt.Sprintf("finish groupSetting %s", feedMessageGroupSettings.Asset.Resource.Email),
-		Description:          fmt.Sprintf("groupSettings published to pubsub %s (isdeleted status=%v) %s topic %s ids %v %s", feedMessageGroupSettings.Asset.Resource.Email, feedMessageGroupSettings.Deleted, feedMessageGroupSettings.Asset.Name, global.GCIGroupSettingsTopicName, pubsubResponse.MessageIds, string(feedMessageGroupSettingsJSON)),
-		Now:                  &now,
-		TriggeringPubsubID:   global.PubSubID,
-		OriginEventTimestamp: &global.stepStack[0].StepTimestamp,
-		LatencySeconds:       latency.Seconds(),
-		LatencyE2ESeconds:    latencyE2E.Seconds(),
-		StepStack:            global.stepStack,
-		AssetInventoryOrigin: "real-time-log-export",
-	})
+	global.logger.Finish(fmt.Sprintf("finish groupSetting %s", feedMessageGroupSettings.Asset.Resource.Email), fmt.Sprintf("groupSettings published to pubsub %s (isdeleted status=%v) %s topic %s ids %v %s", feedMessageGroupSettings.Asset.Resource.Email, feedMessageGroupSettings.Deleted, feedMessageGroupSettings.Asset.Name, global.GCIGroupSettingsTopicName, pubsubResponse.MessageIds, string(feedMessageGroupSettingsJSON)), "real-time-log-export")
 
 	return nil
 }
@@ -1096,3 +892,174 @@ func getGroupFromEmail(groupEmail string, global *Global) (group *admin.Group, e
 	}
 	return group, nil
 }
+
+func getParameterValue(parameters adminEventParameters, name string) string {
+	for _, parameter := range parameters {
+		if parameter.Name == name {
+			return parameter.Value
+		}
+	}
+	return ""
+}
+
+func convertUserSettings(event *event, global *Global) (err error) {
+	var parameters adminEventParameters
+	err = json.Unmarshal(event.Parameter, &parameters)
+	if err != nil {
+		global.logger.NoRetry(fmt.Sprintf("json.Unmarshal(event.Parameter, &parameters) %v %v", event.Parameter, err))
+		return nil
+	}
+	userEmail := strings.ToLower(getParameterValue(parameters, "USER_EMAIL"))
+	if event.EventName == "RENAME_USER" && getParameterValue(parameters, "NEW_VALUE") != "" {
+		// https://developers.google.com/admin-sdk/reports/v1/appendix/activity/admin-user-settings#RENAME_USER
+		userEmail = strings.ToLower(getParameterValue(parameters, "NEW_VALUE"))
+	}
+	if userEmail == "" {
+		global.logger.NoRetry(fmt.Sprintf("%s expected parameter USER_EMAIL not found, insertId %s", event.EventName, global.logEntry.InsertID))
+		return nil
+	}
+	switch event.EventName {
+	case "DELETE_USER":
+		// https://developers.google.com/admin-sdk/reports/v1/appendix/activity/admin-user-settings#DELETE_USER
+		return publishUserDeletion(userEmail, global)
+	default:
+		// The user state carries the outcome of the event, e.g. suspended, isAdmin, isDelegatedAdmin, isEnrolledIn2Sv, isEnforcedIn2Sv
+		// https://developers.google.com/admin-sdk/reports/v1/appendix/activity/admin-user-settings#CREATE_USER
+		// https://developers.google.com/admin-sdk/reports/v1/appendix/activity/admin-user-settings#SUSPEND_USER
+		// https://developers.google.com/admin-sdk/reports/v1/appendix/activity/admin-user-settings#GRANT_ADMIN_PRIVILEGE
+		// https://developers.google.com/admin-sdk/reports/v1/appendix/activity/admin-delegated-admin-settings#ASSIGN_ROLE
+		return publishUserCreationOrUpdate(userEmail, global)
+	}
+}
+
+func publishUserCreationOrUpdate(userEmail string, global *Global) (err error) {
+	// userKey: The value can be the user's primary email address, alias email address, or unique user ID.
+	// https://developers.google.com/admin-sdk/directory/v1/reference/users/get
+	user, err := global.dirAdminService.Users.Get(userEmail).Context(global.ctx).Do()
+	if err != nil {
+		return fmt.Errorf("dirAdminService.Users.Get %v", err)
+	}
+	var feedMessage cai.FeedMessageUser
+	feedMessage.Window.StartTime = global.logEntry.Timestamp
+	feedMessage.Origin = "real-time-log-export"
+	feedMessage.Deleted = false
+	feedMessage.Asset.Ancestors = []string{fmt.Sprintf("directories/%s", global.directoryCustomerID)}
+	feedMessage.Asset.AncestryPath = fmt.Sprintf("directories/%s", global.directoryCustomerID)
+	feedMessage.Asset.AssetType = "www.googleapis.com/admin/directory/users"
+	feedMessage.Asset.Name = fmt.Sprintf("//directories/%s/users/%s", global.directoryCustomerID, user.Id)
+	feedMessage.Asset.Resource = user
+	feedMessage.Asset.Resource.Etag = ""
+	feedMessage.StepStack = global.stepStack
+	return publishDirectoryAsset(feedMessage, "users", userEmail, feedMessage.Asset.Name, feedMessage.Deleted, global)
+}
+
+func publishUserDeletion(userEmail string, global *Global) (err error) {
+	assets := global.firestoreClient.Collection(global.collectionID)
+	query := assets.Where(
+		"asset.assetType", "==", "www.googleapis.com/admin/directory/users").Where(
+		"asset.resource.primaryEmail", "==", userEmail).Where(
+		"deleted", "==", false)
+	iter := query.Documents(global.ctx)
+	defer iter.Stop()
+	type cachedFeedMessageUser struct {
+		Asset struct {
+			Name         string   `firestore:"name" json:"name"`
+			AssetType    string   `firestore:"assetType" json:"assetType"`
+			Ancestors    []string `firestore:"ancestors" json:"ancestors"`
+			AncestryPath string   `firestore:"ancestryPath" json:"ancestryPath"`
+			Resource     struct {
+				PrimaryEmail string `firestore:"primaryEmail" json:"primaryEmail"`
+				ID           string `firestore:"id" json:"id"`
+				Kind         string `firestore:"kind" json:"kind"`
+			} `firestore:"resource" json:"resource"`
+		} `firestore:"asset" json:"asset"`
+		Deleted   bool       `firestore:"deleted" json:"deleted"`
+		Origin    string     `firestore:"origin" json:"origin"`
+		Window    cai.Window `firestore:"window" json:"window"`
+		StepStack glo.Steps  `firestore:"-" json:"step_stack,omitempty"`
+	}
+	found := false
+	// multiple documents may be found in case of orphans in cache
+	for {
+		documentSnap, err := iter.Next()
+		if err == iterator.Done {
+			break
+		}
+		if err != nil {
+			return fmt.Errorf("publishUserDeletion iter.Next() %v", err)
+		}
+		var retreivedFeedMessageUser cachedFeedMessageUser
+		err = documentSnap.DataTo(&retreivedFeedMessageUser)
+		if err != nil {
+			return fmt.Errorf("publishUserDeletion documentSnap.DataTo %v", err)
+		}
+		found = true
+		retreivedFeedMessageUser.Window.StartTime = global.logEntry.Timestamp
+		retreivedFeedMessageUser.Origin = "real-time-log-export"
+		retreivedFeedMessageUser.Deleted = true
+		retreivedFeedMessageUser.StepStack = global.stepStack
+		err = publishDirectoryAsset(retreivedFeedMessageUser,
+			"users",
+			retreivedFeedMessageUser.Asset.Resource.PrimaryEmail,
+			retreivedFeedMessageUser.Asset.Name,
+			retreivedFeedMessageUser.Deleted,
+			global)
+		if err != nil {
+			return fmt.Errorf("publishDirectoryAsset(retreivedFeedMessageUser %v", err)
+		}
+	}
+	if !found {
+		global.logger.Warning("deleted user not found in cache, cannot clean up RAM data", fmt.Sprintf("userEmail %s", userEmail))
+	}
+	return nil
+}
+
+// convertDirectorySetting converts a domain or security setting change into the last known value of this setting
+func convertDirectorySetting(event *event, settingsKind string, global *Global) (err error) {
+	var parameters adminEventParameters
+	err = json.Unmarshal(event.Parameter, &parameters)
+	if err != nil {
+		global.logger.NoRetry(fmt.Sprintf("json.Unmarshal(event.Parameter, &parameters) %v %v", event.Parameter, err))
+		return nil
+	}
+	feedMessage, settingKey := cai.NewFeedMessageDirectorySetting(global.directoryCustomerID, settingsKind, event.EventName, func(name string) string {
+		return getParameterValue(parameters, name)
+	})
+	feedMessage.Window.StartTime = global.logEntry.Timestamp
+	feedMessage.Origin = "real-time-log-export"
+	feedMessage.Deleted = false
+	feedMessage.StepStack = global.stepStack
+	return publishDirectoryAsset(feedMessage, settingsKind, settingKey, feedMessage.Asset.Name, feedMessage.Deleted, global)
+}
+
+// publishDirectoryAsset publishes to gci-<kind>-<directoryCustomerID> topic, created when missing
+func publishDirectoryAsset(feedMessage interface{}, kind string, displayKey string, assetName string, isDeleted bool, global *Global) (err error) {
+	feedMessageJSON, err := json.Marshal(feedMessage)
+	if err != nil {
+		global.logger.NoRetry(fmt.Sprintf("publishDirectoryAsset json.Marshal(feedMessage) %v %v", feedMessage, err))
+		return nil
+	}
+	var pubSubMessage pubsubpb.PubsubMessage
+	pubSubMessage.Data = feedMessageJSON
+	pubSubMessage.Attributes = glo.GetTraceAttributes(global.pubSubAttributes, global.stepStack)
+
+	var pubsubMessages []*pubsubpb.PubsubMessage
+	pubsubMessages = append(pubsubMessages, &pubSubMessage)
+
+	var publishRequest pubsubpb.PublishRequest
+	topicShortName := fmt.Sprintf("gci-%s-%s", kind, global.directoryCustomerID)
+	if err = gps.CreateTopic(global.ctx, global.pubsubPublisherClient, &global.topicList, topicShortName, global.projectID); err != nil {
+		global.logger.NoRetry(fmt.Sprintf("gps.CreateTopic %s %v", topicShortName, err))
+		return nil
+	}
+	topicName := fmt.Sprintf("projects/%s/topics/%s", global.projectID, topicShortName)
+	publishRequest.Topic = topicName
+	publishRequest.Messages = pubsubMessages
+
+	pubsubResponse, err := global.pubsubPublisherClient.Publish(global.ctx, &publishRequest)
+	if err != nil {
+		return fmt.Errorf("%s global.pubsubPublisherClient.Publish: %v", topicShortName, err)
+	}
+	global.logger.Finish(fmt.Sprintf("finish %s %s", kind, displayKey), fmt.Sprintf("%s published to pubsub %s (isdeleted status=%v) %s topic %s ids %v %s", kind, displayKey, isDeleted, assetName, topicName, pubsubResponse.MessageIds, string(feedMessageJSON)), "real-time-log-export")
+	return nil
+}
@@ -21,10 +21,18 @@ Some.
 
 At least one to convert gsuite admin logs, related to groups members and groups settings, from GCP Cloud Audit Logs at organization level.
 
+At least one to convert gsuite admin logs related to users, delegated admins, domain settings and security settings.
+
+One per organization to record who changed GCP assets, from GCP SetIamPolicy admin activity audit logs.
+
 Output
 
 Publish pubsub message into several topics, e.g. gci-grouMembers and gci-groupSettings.
 
+Users, domain settings and security settings are published to gci-users-<directoryCustomerID>, gci-domainSettings-<directoryCustomerID> and gci-securitySettings-<directoryCustomerID>, topics created on the fly when missing.
+
+For GCP admin activity audit logs, the actor (principalEmail, methodName, callerIp) is recorded, project IDs being resolved to project numbers to match CAI asset names, in the firestore collection hosting.firestore.collectionIDs.auditActors, one document per asset name and content type, e.g. SetIamPolicy calls being the IAM policy changes, keeping the most recent entry. Consumers of CAI feeds, like monitor and stream2bq, correlate it with the asset change time.
+
 Cardinality
 
 One one: one log message one feed message.
@@ -33,6 +41,12 @@ Notes
 
 - AdminSDK activity logs do not reference the group ID only the email, that is a mutable attribute. A cache in firestore is used to handle this.
 
+- The service account domain wide delegation must include https://www.googleapis.com/auth/admin.directory.user.readonly to get the user state after a user event.
+
+- Domain and security settings have no batch API: their asset holds the last changed value reported in the admin log, listdirectorysettings makes the initial load from the admin activity reports.
+
+- Audit log resource names are mapped to CAI asset names on a best effort basis, e.g. projects are named by number in CAI and by ID in audit logs.
+
 - Each message is a base64-encoded LogEntry object https://cloud.google.com/logging/docs/export/using_exported_logs#pubsub-overview .
 
 */
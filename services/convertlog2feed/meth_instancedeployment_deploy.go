@@ -40,10 +40,28 @@ func (instanceDeployment *InstanceDeployment) Deploy() (err error) {
 		if err = instanceDeployment.deployGRMProjectBindings(); err != nil {
 			return err
 		}
+		// Extended monitoring org
+		if err = instanceDeployment.deployIAMMonitoringOrgRole(); err != nil {
+			return err
+		}
+		if err = instanceDeployment.deployGRMMonitoringOrgBindings(); err != nil {
+			return err
+		}
 		// Core project
 		if err = instanceDeployment.deployGPSTopic(); err != nil {
 			return err
 		}
+	} else {
+		// Check only: prerequisites drift, missing and stale roles
+		if err = instanceDeployment.deployGAEApp(); err != nil {
+			return err
+		}
+		if err = instanceDeployment.deployGRMProjectBindings(); err != nil {
+			return err
+		}
+		if err = instanceDeployment.deployGRMMonitoringOrgBindings(); err != nil {
+			return err
+		}
 	}
 	if err = instanceDeployment.deployGCFFunction(); err != nil {
 		return err
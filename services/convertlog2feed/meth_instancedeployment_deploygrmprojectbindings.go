@@ -25,6 +25,7 @@ func (instanceDeployment *InstanceDeployment) deployGRMProjectBindings() (err er
 	projectBindingsDeployment.Core = instanceDeployment.Core
 	projectBindingsDeployment.Settings.Roles = instanceDeployment.Settings.Service.GCF.ServiceAccountBindings.GRM.Hosting.Project.Roles
 	projectBindingsDeployment.Settings.CustomRoles = instanceDeployment.Settings.Service.GCF.ServiceAccountBindings.GRM.Hosting.Project.CustomRoles
+	projectBindingsDeployment.Settings.Conditions = instanceDeployment.Settings.Service.GCF.ServiceAccountBindings.GRM.Hosting.Project.Conditions
 	projectBindingsDeployment.Artifacts.Member = fmt.Sprintf("serviceAccount:%s@%s.iam.gserviceaccount.com", instanceDeployment.Core.ServiceName, instanceDeployment.Core.SolutionSettings.Hosting.ProjectID)
 	projectBindingsDeployment.Artifacts.ProjectID = projectBindingsDeployment.Core.SolutionSettings.Hosting.ProjectID
 	return projectBindingsDeployment.Deploy()
@@ -20,6 +20,7 @@ import (
 	"github.com/BrunoReboul/ram/utilities/deploy"
 	"github.com/BrunoReboul/ram/utilities/gcb"
 	"github.com/BrunoReboul/ram/utilities/gcf"
+	"github.com/BrunoReboul/ram/utilities/glo"
 	"github.com/BrunoReboul/ram/utilities/gsu"
 	"github.com/BrunoReboul/ram/utilities/iamgt"
 	"google.golang.org/api/iam/v1"
@@ -35,14 +36,16 @@ type InstanceDeployment struct {
 			IAM             iamgt.Parameters
 			GCB             gcb.Parameters
 			GCF             gcf.Parameters
+			GLO             glo.LogParameters
 			KeyJSONFileName string        `yaml:"keyJSONFileName"`
 			RetriesNumber   time.Duration `yaml:"time.Duration"`
 		}
 		Instance struct {
 			GCF gcf.Event
 			GCI struct {
-				SuperAdminEmail string `yaml:"superAdminEmail"`
+				SuperAdminEmail string `yaml:"superAdminEmail" valid:"isEmail"`
 			}
+			GLO glo.LogParameters
 		}
 	}
 }
@@ -58,6 +61,8 @@ func NewInstanceDeployment() *InstanceDeployment {
 		"groupssettings.googleapis.com"}
 	instanceDeployment.Settings.Service.GSU.APIList = append(deploy.GetCommonAPIlist(), instanceDeployment.Settings.Service.GSU.APIList...)
 
+	instanceDeployment.Settings.Service.IAM.RunRoles.MonitoringOrg = []iam.Role{
+		monitoringOrgRunRole()}
 	instanceDeployment.Settings.Service.IAM.RunRoles.Project = []iam.Role{
 		projectRunRole()}
 	instanceDeployment.Settings.Service.IAM.DeployRoles.Project = []iam.Role{
@@ -75,11 +80,13 @@ func NewInstanceDeployment() *InstanceDeployment {
 	instanceDeployment.Settings.Service.GCB.ServiceAccountBindings.IAM.RolesOnServiceAccounts = []string{
 		"roles/iam.serviceAccountUser"}
 
+	instanceDeployment.Settings.Service.GCF.ServiceAccountBindings.GRM.Monitoring.Org.CustomRoles = []string{
+		monitoringOrgRunRole().Title}
 	instanceDeployment.Settings.Service.GCF.ServiceAccountBindings.GRM.Hosting.Project.CustomRoles = []string{
 		projectRunRole().Title}
 	// Data store permissions are not supported in custom roles
 	instanceDeployment.Settings.Service.GCF.ServiceAccountBindings.GRM.Hosting.Project.Roles = []string{
-		"roles/datastore.viewer"}
+		"roles/datastore.user"}
 
 	instanceDeployment.Settings.Service.GCF.AvailableMemoryMb = 128
 	instanceDeployment.Settings.Service.GCF.RetryTimeOutSeconds = 600
@@ -91,6 +98,16 @@ func NewInstanceDeployment() *InstanceDeployment {
 	return &instanceDeployment
 }
 
+// used to resolve project IDs found in audit logs to the project numbers used in CAI asset names
+func monitoringOrgRunRole() (role iam.Role) {
+	role.Title = "ram_convertlog2feed_monitoring_org_run"
+	role.Description = "Real-time Asset Monitor convert log to feed microservice permissions to run on monitoring org"
+	role.Stage = "GA"
+	role.IncludedPermissions = []string{
+		"resourcemanager.projects.get"}
+	return role
+}
+
 func projectRunRole() (role iam.Role) {
 	role.Title = "ram_convertlog2feed_run"
 	role.Description = "Real-time Asset Monitor convert log to feed microservice permissions to run"
@@ -16,6 +16,7 @@ package dumpinventory
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
 	"log"
 	"os"
@@ -25,7 +26,9 @@ import (
 	asset "cloud.google.com/go/asset/apiv1"
 	"cloud.google.com/go/firestore"
 	"cloud.google.com/go/functions/metadata"
+	"cloud.google.com/go/storage"
 	assetpb "google.golang.org/genproto/googleapis/cloud/asset/v1"
+	"google.golang.org/protobuf/types/known/timestamppb"
 
 	"github.com/BrunoReboul/ram/utilities/ffo"
 	"github.com/BrunoReboul/ram/utilities/gfs"
@@ -38,21 +41,34 @@ import (
 // Quota ExportAssets Requests per minute
 const waitSecOnQuotaExceeded = 70
 
+// Used when the instance settings do not specify an export SLA
+const defaultExportSLASeconds = 3600
+
 // Global structure for global variables to optimize the cloud function performances
 type Global struct {
 	assetClient         *asset.Client
 	ctx                 context.Context
 	dumpName            string
 	environment         string
+	exportSLASeconds    int64
 	firestoreClient     *firestore.Client
 	instanceName        string
+	logger              *glo.Logger
 	microserviceName    string
+	pubSubAttributes    map[string]string
 	PubSubID            string
 	projectID           string
 	request             *assetpb.ExportAssetsRequest
 	retryTimeOutSeconds int64
 	step                glo.Step
 	stepStack           glo.Steps
+	storageBucket       *storage.BucketHandle
+}
+
+// ExportRequest optional JSON content of the triggering PubSub message to request a point-in-time export, or to check the running exports
+type ExportRequest struct {
+	ReadTime     time.Time `json:"readTime,omitempty"`
+	CheckExports bool      `json:"checkExports,omitempty"`
 }
 
 // Initialize is to be executed in the init() function of the cloud function to optimize the cold start
@@ -78,83 +94,63 @@ func Initialize(ctx context.Context, global *Global) (err error) {
 	global.instanceName = instanceDeployment.Core.InstanceName
 	global.microserviceName = instanceDeployment.Core.ServiceName
 
-	log.Println(glo.Entry{
-		MicroserviceName: global.microserviceName,
-		InstanceName:     global.instanceName,
-		Environment:      global.environment,
-		Severity:         "NOTICE",
-		Message:          "coldstart",
-		InitID:           initID,
-	})
+	global.logger = glo.NewLogger(global.microserviceName, global.instanceName, global.environment, initID)
+	global.logger.SetLogParameters(glo.GetLogParameters(instanceDeployment.Settings.Service.GLO, instanceDeployment.Settings.Instance.GLO))
+	global.logger.Notice("coldstart", "")
+
+	err = glo.InitTracing(ctx,
+		instanceDeployment.Core.SolutionSettings.Hosting.Tracing.Exporter,
+		instanceDeployment.Core.SolutionSettings.Hosting.Tracing.OTLPEndpoint,
+		instanceDeployment.Core.SolutionSettings.Hosting.Tracing.FilePath,
+		global.microserviceName,
+		global.instanceName,
+		global.environment)
+	if err != nil {
+		global.logger.InitFailed(fmt.Sprintf("glo.InitTracing %v", err))
+		return err
+	}
 
 	global.retryTimeOutSeconds = instanceDeployment.Settings.Service.GCF.RetryTimeOutSeconds
 	global.projectID = instanceDeployment.Core.SolutionSettings.Hosting.ProjectID
 
+	global.exportSLASeconds = instanceDeployment.Settings.Instance.ExportSLASeconds
+	if global.exportSLASeconds == 0 {
+		global.exportSLASeconds = defaultExportSLASeconds
+	}
+
 	global.dumpName = fmt.Sprintf("%s/%s.dump",
 		instanceDeployment.Core.SolutionSettings.Hosting.GCS.Buckets.CAIExport.Name,
 		os.Getenv("K_SERVICE"))
 
-	var gcsDestinationURI assetpb.GcsDestination_Uri
-	gcsDestinationURI.Uri = fmt.Sprintf("gs://%s", global.dumpName)
-
-	var gcsDestination assetpb.GcsDestination
-	gcsDestination.ObjectUri = &gcsDestinationURI
-
-	var outputConfigGCSDestination assetpb.OutputConfig_GcsDestination
-	outputConfigGCSDestination.GcsDestination = &gcsDestination
-
-	var outputConfig assetpb.OutputConfig
-	outputConfig.Destination = &outputConfigGCSDestination
-
 	global.request = &assetpb.ExportAssetsRequest{}
 	switch instanceDeployment.Settings.Instance.CAI.ContentType {
-	case "RESOURCE":
-		global.request.ContentType = assetpb.ContentType_RESOURCE
-	case "IAM_POLICY":
-		global.request.ContentType = assetpb.ContentType_IAM_POLICY
+	case "RESOURCE", "IAM_POLICY", "ORG_POLICY", "ACCESS_POLICY", "OS_INVENTORY", "RELATIONSHIP":
+		global.request.ContentType = assetpb.ContentType(assetpb.ContentType_value[instanceDeployment.Settings.Instance.CAI.ContentType])
 	default:
-		log.Println(glo.Entry{
-			MicroserviceName: global.microserviceName,
-			InstanceName:     global.instanceName,
-			Environment:      global.environment,
-			Severity:         "CRITICAL",
-			Message:          "init_failed",
-			Description:      fmt.Sprintf("unsupported content type: %s", instanceDeployment.Settings.Instance.CAI.ContentType),
-			InitID:           initID,
-		})
+		global.logger.InitFailed(fmt.Sprintf("unsupported content type: %s", instanceDeployment.Settings.Instance.CAI.ContentType))
 		return err
 	}
 
 	global.request.Parent = instanceDeployment.Settings.Instance.CAI.Parent
 	global.request.AssetTypes = instanceDeployment.Settings.Instance.CAI.AssetTypes
-	global.request.OutputConfig = &outputConfig
+	global.request.OutputConfig = getOutputConfig(global.dumpName)
 
 	global.assetClient, err = asset.NewClient(ctx)
 	if err != nil {
-		log.Println(glo.Entry{
-			MicroserviceName: global.microserviceName,
-			InstanceName:     global.instanceName,
-			Environment:      global.environment,
-			Severity:         "CRITICAL",
-			Message:          "init_failed",
-			Description:      fmt.Sprintf("asset.NewClient(ctx) %v", err),
-			InitID:           initID,
-		})
+		global.logger.InitFailed(fmt.Sprintf("asset.NewClient(ctx) %v", err))
 		return err
 	}
 	global.firestoreClient, err = firestore.NewClient(global.ctx, global.projectID)
 	if err != nil {
-		log.Println(glo.Entry{
-			MicroserviceName: global.microserviceName,
-			InstanceName:     global.instanceName,
-			Environment:      global.environment,
-			Severity:         "CRITICAL",
-			Message:          "init_failed",
-			Description:      fmt.Sprintf("firestore.NewClient %v", err),
-			InitID:           initID,
-		})
+		global.logger.InitFailed(fmt.Sprintf("firestore.NewClient %v", err))
+		return err
+	}
+	storageClient, err := storage.NewClient(ctx)
+	if err != nil {
+		global.logger.InitFailed(fmt.Sprintf("storage.NewClient(ctx) %v", err))
 		return err
 	}
+	global.storageBucket = storageClient.Bucket(instanceDeployment.Core.SolutionSettings.Hosting.GCS.Buckets.CAIExport.Name)
 	return nil
 }
 
@@ -164,15 +160,7 @@ func EntryPoint(ctxEvent context.Context, PubSubMessage gps.PubSubMessage, globa
 	metadata, err := metadata.FromContext(ctxEvent)
 	if err != nil {
 		// Assume an error on the function invoker and try again.
-		log.Println(glo.Entry{
-			MicroserviceName:   global.microserviceName,
-			InstanceName:       global.instanceName,
-			Environment:        global.environment,
-			Severity:           "CRITICAL",
-			Message:            "redo_on_transient",
-			Description:        fmt.Sprintf("pubsub_id no available metadata.FromContext: %v", err),
-			TriggeringPubsubID: global.PubSubID,
-		})
+		global.logger.RedoOnTransient(fmt.Sprintf("pubsub_id no available metadata.FromContext: %v", err))
 		return err
 	}
 	global.stepStack = nil
@@ -182,111 +170,243 @@ func EntryPoint(ctxEvent context.Context, PubSubMessage gps.PubSubMessage, globa
 		StepID:        fmt.Sprintf("%s/%s", parts[len(parts)-1], global.PubSubID),
 		StepTimestamp: metadata.Timestamp,
 	}
+	global.logger.SetInvocation(global.PubSubID, global.step)
+	global.pubSubAttributes = PubSubMessage.Attributes
+	defer func() {
+		glo.RecordStepSpan(global.ctx, global.microserviceName, global.pubSubAttributes, global.stepStack)
+	}()
 	global.stepStack = append(global.stepStack, global.step)
+	global.logger.SetStepStack(global.stepStack)
 
-	now := time.Now()
-	d := now.Sub(metadata.Timestamp)
-	log.Println(glo.Entry{
-		MicroserviceName:           global.microserviceName,
-		InstanceName:               global.instanceName,
-		Environment:                global.environment,
-		Severity:                   "NOTICE",
-		Message:                    "start",
-		TriggeringPubsubID:         global.PubSubID,
-		TriggeringPubsubAgeSeconds: d.Seconds(),
-		TriggeringPubsubTimestamp:  &metadata.Timestamp,
-		Now:                        &now,
-	})
+	d := global.logger.Start()
 
 	if d.Seconds() > float64(global.retryTimeOutSeconds) {
-		log.Println(glo.Entry{
-			MicroserviceName:           global.microserviceName,
-			InstanceName:               global.instanceName,
-			Environment:                global.environment,
-			Severity:                   "CRITICAL",
-			Message:                    "noretry",
-			Description:                "Pubsub message too old",
-			TriggeringPubsubID:         global.PubSubID,
-			TriggeringPubsubAgeSeconds: d.Seconds(),
-			TriggeringPubsubTimestamp:  &metadata.Timestamp,
-			Now:                        &now,
-		})
+		global.logger.NoRetryTooOld()
+		return nil
+	}
+
+	var exportRequest ExportRequest
+	// Scheduler messages are plain text, only a JSON message can request a point-in-time export
+	if json.Unmarshal(PubSubMessage.Data, &exportRequest) != nil {
+		exportRequest = ExportRequest{}
+	}
+	if exportRequest.CheckExports {
+		checkRunningExports(global)
 		return nil
 	}
+	request := global.request
+	dumpName := global.dumpName
+	origin := "batch-export"
+	if exportRequest.ReadTime.IsZero() {
+		// The new export overwrites the dump document, a previous operation still running is kept in its own document to be checked
+		if !checkExport(global.dumpName, global) {
+			keepRunningExport(global)
+		}
+	} else {
+		if exportRequest.ReadTime.After(time.Now()) {
+			global.logger.NoRetry(fmt.Sprintf("readTime %v is in the future", exportRequest.ReadTime))
+			return nil
+		}
+		// Own dump name, to not overwrite nor be mistaken for the scheduled export
+		dumpName = fmt.Sprintf("%s.at%s.dump",
+			strings.TrimSuffix(global.dumpName, ".dump"),
+			exportRequest.ReadTime.UTC().Format("20060102T150405Z"))
+		request = &assetpb.ExportAssetsRequest{
+			Parent:       global.request.Parent,
+			AssetTypes:   global.request.AssetTypes,
+			ContentType:  global.request.ContentType,
+			ReadTime:     timestamppb.New(exportRequest.ReadTime),
+			OutputConfig: getOutputConfig(dumpName),
+		}
+		origin = "historical-export"
+	}
 
-	operation, err := global.assetClient.ExportAssets(global.ctx, global.request)
+	exportStartTime := time.Now()
+	operation, err := global.assetClient.ExportAssets(global.ctx, request)
 	if err != nil {
 		if strings.Contains(strings.ToLower(err.Error()), "quota") {
-			log.Println(glo.Entry{
-				MicroserviceName:   global.microserviceName,
-				InstanceName:       global.instanceName,
-				Environment:        global.environment,
-				Severity:           "WARNING",
-				Message:            fmt.Sprintf("waiting_on_quota_exceeded"),
-				Description:        fmt.Sprintf("ExportAssets quota is gone, wait for %d seconds then retry", waitSecOnQuotaExceeded),
-				TriggeringPubsubID: global.PubSubID,
-			})
+			global.logger.Warning(fmt.Sprintf("waiting_on_quota_exceeded"), fmt.Sprintf("ExportAssets quota is gone, wait for %d seconds then retry", waitSecOnQuotaExceeded))
 			time.Sleep(waitSecOnQuotaExceeded * time.Second)
 			return err
 		}
-		log.Println(glo.Entry{
-			MicroserviceName:   global.microserviceName,
-			InstanceName:       global.instanceName,
-			Environment:        global.environment,
-			Severity:           "CRITICAL",
-			Message:            "redo_on_transient",
-			Description:        fmt.Sprintf("global.assetClient.ExportAssets(global.ctx, global.request) %v", err),
-			TriggeringPubsubID: global.PubSubID,
-		})
+		global.logger.RedoOnTransient(fmt.Sprintf("global.assetClient.ExportAssets(global.ctx, request) %v", err))
 		return err
 	}
 	// do NOT wait for response to save function execution time, and avoid function timeout
-	log.Println(glo.Entry{
-		MicroserviceName:   global.microserviceName,
-		InstanceName:       global.instanceName,
-		Environment:        global.environment,
-		Severity:           "INFO",
-		Message:            fmt.Sprintf("gcloud asset operations describe %s", operation.Name()),
-		TriggeringPubsubID: global.PubSubID,
-	})
+	global.logger.Info(fmt.Sprintf("gcloud asset operations describe %s", operation.Name()), "")
+	dumpOperation := gfs.DumpOperation{
+		Name:      operation.Name(),
+		Status:    gfs.DumpOperationRunning,
+		StartTime: exportStartTime,
+		Origin:    origin,
+		ReadTime:  exportRequest.ReadTime,
+	}
 	err = gfs.RecordDump(global.ctx,
-		global.dumpName,
+		dumpName,
 		global.firestoreClient,
 		global.stepStack,
+		&dumpOperation,
 		global.microserviceName,
 		global.instanceName,
 		global.environment,
 		global.PubSubID,
 		5)
 	if err != nil {
-		log.Println(glo.Entry{
-			MicroserviceName:   global.microserviceName,
-			InstanceName:       global.instanceName,
-			Environment:        global.environment,
-			Severity:           "CRITICAL",
-			Message:            "noretry",
-			Description:        fmt.Sprintf("recordDump %v", err),
-			TriggeringPubsubID: global.PubSubID,
-		})
+		global.logger.NoRetry(fmt.Sprintf("recordDump %v", err))
 		return nil
 	}
-	now = time.Now()
-	latency := now.Sub(metadata.Timestamp)
-	latencyE2E := now.Sub(global.stepStack[0].StepTimestamp)
-	log.Println(glo.Entry{
-		MicroserviceName:     global.microserviceName,
-		InstanceName:         global.instanceName,
-		Environment:          global.environment,
-		Severity:             "NOTICE",
-		Message:              fmt.Sprintf("finish export request to %s", global.dumpName),
-		Description:          fmt.Sprintf("operationName %s request %v", operation.Name(), global.request),
-		Now:                  &now,
-		TriggeringPubsubID:   global.PubSubID,
-		OriginEventTimestamp: &metadata.Timestamp,
-		LatencySeconds:       latency.Seconds(),
-		LatencyE2ESeconds:    latencyE2E.Seconds(),
-		StepStack:            global.stepStack,
-		AssetInventoryOrigin: "batch-export",
-	})
+	global.logger.Finish(fmt.Sprintf("finish export request to %s", dumpName), fmt.Sprintf("operationName %s request %v", operation.Name(), request), origin)
 	return nil
 }
+
+func getOutputConfig(dumpName string) *assetpb.OutputConfig {
+	var gcsDestinationURI assetpb.GcsDestination_Uri
+	gcsDestinationURI.Uri = fmt.Sprintf("gs://%s", dumpName)
+
+	var gcsDestination assetpb.GcsDestination
+	gcsDestination.ObjectUri = &gcsDestinationURI
+
+	var outputConfigGCSDestination assetpb.OutputConfig_GcsDestination
+	outputConfigGCSDestination.GcsDestination = &gcsDestination
+
+	var outputConfig assetpb.OutputConfig
+	outputConfig.Destination = &outputConfigGCSDestination
+	return &outputConfig
+}
+
+// checkRunningExports checks the running export operations of the instance, triggered by the check scheduler job
+// Operations not yet done are checked again by the next check, so the invocation is never retried
+func checkRunningExports(global *Global) {
+	dumpNames, err := gfs.ListRunningDumps(global.ctx, global.firestoreClient, global.dumpName)
+	if err != nil {
+		global.logger.Warning("cannot list running exports, left to the next check", fmt.Sprintf("gfs.ListRunningDumps %s %v", global.dumpName, err))
+		return
+	}
+	var doneCount int
+	for _, dumpName := range dumpNames {
+		if checkExport(dumpName, global) {
+			doneCount++
+		}
+	}
+	global.logger.Info(fmt.Sprintf("%d running exports checked, %d done", len(dumpNames), doneCount), "")
+}
+
+// keepRunningExport records the still running operation of the previous export in its own dump document
+func keepRunningExport(global *Global) {
+	dumpOperation, found, err := gfs.GetDumpOperation(global.ctx, global.dumpName, global.firestoreClient, 5)
+	if err != nil {
+		global.logger.Warning("cannot get previous export operation", fmt.Sprintf("gfs.GetDumpOperation %s %v", global.dumpName, err))
+		return
+	}
+	if !found || dumpOperation.Status != gfs.DumpOperationRunning {
+		return
+	}
+	dumpOperation.DumpName = global.dumpName
+	keptDumpName := fmt.Sprintf("%s.started%s.dump",
+		strings.TrimSuffix(global.dumpName, ".dump"),
+		dumpOperation.StartTime.UTC().Format("20060102T150405Z"))
+	err = gfs.RecordDumpOperation(global.ctx,
+		keptDumpName,
+		global.firestoreClient,
+		dumpOperation,
+		global.microserviceName,
+		global.instanceName,
+		global.environment,
+		global.PubSubID,
+		5)
+	if err != nil {
+		global.logger.Warning("cannot keep previous export operation", fmt.Sprintf("recordDumpOperation %s %v", keptDumpName, err))
+		return
+	}
+	global.logger.Warning(fmt.Sprintf("previous export still running, kept in %s", keptDumpName), fmt.Sprintf("operation %s", dumpOperation.Name))
+}
+
+// checkExport polls the export operation recorded for a dump, records its outcome and alerts when it failed or exceeds the SLA
+// done is true when there is nothing left to check
+func checkExport(dumpName string, global *Global) (done bool) {
+	dumpOperation, found, err := gfs.GetDumpOperation(global.ctx, dumpName, global.firestoreClient, 5)
+	if err != nil {
+		global.logger.Warning("cannot get export operation", fmt.Sprintf("gfs.GetDumpOperation %s %v", dumpName, err))
+		return false
+	}
+	if !found || dumpOperation.Name == "" || dumpOperation.Status != gfs.DumpOperationRunning {
+		return true
+	}
+	operation := global.assetClient.ExportAssetsOperation(dumpOperation.Name)
+	_, err = operation.Poll(global.ctx)
+	now := time.Now()
+	if err != nil && !operation.Done() {
+		global.logger.Warning("cannot poll export operation", fmt.Sprintf("operation.Poll %s %v", dumpOperation.Name, err))
+		return false
+	}
+	if !operation.Done() {
+		age := now.Sub(dumpOperation.StartTime)
+		if age.Seconds() > float64(global.exportSLASeconds) {
+			global.logger.Log(glo.Entry{
+				Severity:             "ERROR",
+				Message:              "export_exceeded_sla",
+				Description:          fmt.Sprintf("operation %s still running after %v seconds, SLA is %d seconds", dumpOperation.Name, age.Seconds(), global.exportSLASeconds),
+				Now:                  &now,
+				AssetInventoryOrigin: "batch-export",
+			})
+		}
+		return false
+	}
+	if err != nil {
+		dumpOperation.Status = gfs.DumpOperationFailed
+		dumpOperation.EndTime = now
+		dumpOperation.Error = err.Error()
+		global.logger.Log(glo.Entry{
+			Severity:             "ERROR",
+			Message:              "export_failed",
+			Description:          fmt.Sprintf("operation %s %v", dumpOperation.Name, err),
+			Now:                  &now,
+			AssetInventoryOrigin: "batch-export",
+		})
+	} else {
+		dumpOperation.Status = gfs.DumpOperationSucceeded
+		dumpOperation.EndTime = now
+		// The dump object creation time is the actual end of the export, the poll time being only an upper bound
+		// A kept operation shares its dump object with a newer export, so the object tells nothing about it
+		if dumpOperation.DumpName == "" {
+			dumpObjectName := dumpName[strings.Index(dumpName, "/")+1:]
+			objectAttrs, err := global.storageBucket.Object(dumpObjectName).Attrs(global.ctx)
+			if err != nil {
+				global.logger.Warning("cannot get dump object attributes", fmt.Sprintf("global.storageBucket.Object(dumpObjectName).Attrs %s %v", dumpObjectName, err))
+			} else {
+				dumpOperation.EndTime = objectAttrs.Created
+				dumpOperation.OutputSizeBytes = objectAttrs.Size
+			}
+		}
+		global.logger.Log(glo.Entry{
+			Severity:             "NOTICE",
+			Message:              "export_succeeded",
+			Description:          fmt.Sprintf("operation %s output %s size %d bytes", dumpOperation.Name, dumpName, dumpOperation.OutputSizeBytes),
+			Now:                  &now,
+			AssetInventoryOrigin: "batch-export",
+		})
+	}
+	dumpOperation.DurationSeconds = dumpOperation.EndTime.Sub(dumpOperation.StartTime).Seconds()
+	if dumpOperation.DurationSeconds > float64(global.exportSLASeconds) {
+		global.logger.Log(glo.Entry{
+			Severity:             "ERROR",
+			Message:              "export_exceeded_sla",
+			Description:          fmt.Sprintf("operation %s took %v seconds, SLA is %d seconds", dumpOperation.Name, dumpOperation.DurationSeconds, global.exportSLASeconds),
+			Now:                  &now,
+			AssetInventoryOrigin: "batch-export",
+		})
+	}
+	err = gfs.RecordDumpOperation(global.ctx,
+		dumpName,
+		global.firestoreClient,
+		dumpOperation,
+		global.microserviceName,
+		global.instanceName,
+		global.environment,
+		global.PubSubID,
+		5)
+	if err != nil {
+		global.logger.Warning(fmt.Sprintf("recordDumpOperation %v", err), "")
+	}
+	return true
+}
@@ -19,16 +19,44 @@ Triggered by
 
 Cloud Scheduler Job, through PubSub messages.
 
-Instances
+Instances per monitoring scope: monitoring.organizationIDs, monitoring.folderIDs and monitoring.projectIDs
 
 - one for all IAM bindings policies.
 
 - one per AssetType for resource metadata exports.
 
+- one per other content type listed in monitoring.assetTypes: org policies, access policies, os inventories, relationships.
+
 Output
 
 None, CAI execute exports as an asynchonous task delivered in a Google Cloud Storage bucket.
 
+The export operation name is recorded in the dumps/<dumpName> Firestore document.
+
+Export operation tracking
+
+A second Cloud Scheduler job, <jobName>_check, publishes a {"checkExports": true} message on the same topic, following service setting exportCheckSchedule, every 10 minutes by default.
+This check invocation polls once each running operation of the instance, including point-in-time ones, and is never retried: operations not yet done are left to the next check.
+At each scheduled run, before requesting a new export, the operation recorded by the previous run is also polled.
+When it is still running, it is kept in its own dumps/<dumpName>.started<YYYYMMDDTHHMMSSZ> document, so the new export does not overwrite its record, and later checks still track it.
+Its status, duration and output size are recorded in the dump document.
+Failed exports log export_failed, and exports running longer than instance setting exportSLASeconds log export_exceeded_sla.
+Both feed the ram_export_status log based metric.
+
+Point-in-time export
+
+A PubSub message data {"readTime": "<RFC3339>"} requests the inventory as it was at that time, within the last 35 days CAI keeps.
+Sent by ramcli -export -at <RFC3339>.
+The export is delivered in a separate <dumpName>.at<YYYYMMDDTHHMMSSZ>.dump object and flows downstream with origin historical-export.
+publish2fs and upload2gcs do not overwrite current caches with it, stream2bq records it without changing the last assets views.
+
+Permissions
+
+Granted on each monitoring scope. For folders the custom roles are the ones of the folder organization, as GCP has no folder level custom roles.
+So a folder scope still requires organization level access: resourcemanager.folders.get to resolve the folder organization, and iam.roles.create to create the custom roles in it.
+The deployment stops when the folder organization cannot be resolved.
+Without organization level access, list the projects to monitor in monitoring.projectIDs, their custom roles being project level.
+
 Automatic retrying
 
 Yes.
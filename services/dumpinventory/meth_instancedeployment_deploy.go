@@ -31,6 +31,9 @@ func (instanceDeployment *InstanceDeployment) Deploy() (err error) {
 		if err = instanceDeployment.deployGAEApp(); err != nil {
 			return err
 		}
+		if err = instanceDeployment.deployIAMProjectRoles(); err != nil {
+			return err
+		}
 		if err = instanceDeployment.deployIAMServiceAccount(); err != nil {
 			return err
 		}
@@ -51,6 +54,29 @@ func (instanceDeployment *InstanceDeployment) Deploy() (err error) {
 		if err = instanceDeployment.deploySCHJob(); err != nil {
 			return err
 		}
+		if err = instanceDeployment.deploySCHCheckJob(); err != nil {
+			return err
+		}
+		if err = instanceDeployment.deployGCSBucket(); err != nil {
+			return err
+		}
+	} else {
+		// Check only: prerequisites drift, missing and stale roles
+		if err = instanceDeployment.deployGAEApp(); err != nil {
+			return err
+		}
+		if err = instanceDeployment.deployGRMProjectBindings(); err != nil {
+			return err
+		}
+		if err = instanceDeployment.deployGRMMonitoringOrgBindings(); err != nil {
+			return err
+		}
+		if err = instanceDeployment.deploySCHJob(); err != nil {
+			return err
+		}
+		if err = instanceDeployment.deploySCHCheckJob(); err != nil {
+			return err
+		}
 		if err = instanceDeployment.deployGCSBucket(); err != nil {
 			return err
 		}
@@ -20,17 +20,7 @@ import (
 
 func (instanceDeployment *InstanceDeployment) deployIAMMonitoringOrgRole() (err error) {
 	if len(instanceDeployment.Settings.Service.IAM.RunRoles.MonitoringOrg) > 0 {
-		orgRoleDeployment := iamgt.NewOrgRolesDeployment()
-		orgRoleDeployment.Core = instanceDeployment.Core
-		orgRoleDeployment.Settings.Roles = instanceDeployment.Settings.Service.IAM.RunRoles.MonitoringOrg
-		for _, organizationID := range instanceDeployment.Core.SolutionSettings.Monitoring.OrganizationIDs {
-			orgRoleDeployment.Artifacts.OrganizationID = organizationID
-			err = orgRoleDeployment.Deploy()
-			if err != nil {
-				break
-			}
-		}
-		return err
+		return iamgt.DeployMonitoringScopesRoles(instanceDeployment.Core, instanceDeployment.Settings.Service.IAM.RunRoles.MonitoringOrg)
 	}
 	return nil
 }
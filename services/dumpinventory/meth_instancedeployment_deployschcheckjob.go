@@ -0,0 +1,41 @@
+// Copyright 2020 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the 'License');
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an 'AS IS' BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package dumpinventory
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/BrunoReboul/ram/utilities/sch"
+)
+
+// deploySCHCheckJob deploys the job checking the running export operations, on the same topic as the export job
+func (instanceDeployment *InstanceDeployment) deploySCHCheckJob() (err error) {
+	data, err := json.Marshal(ExportRequest{CheckExports: true})
+	if err != nil {
+		return fmt.Errorf("json.Marshal %v", err)
+	}
+	jobDeployment := sch.NewJobDeployment()
+	jobDeployment.Core = instanceDeployment.Core
+	jobDeployment.Artifacts.JobName = instanceDeployment.Artifacts.JobName + "_check"
+	jobDeployment.Artifacts.TopicName = instanceDeployment.Artifacts.TopicName
+	jobDeployment.Artifacts.Schedule = instanceDeployment.Settings.Service.ExportCheckSchedule
+	jobDeployment.Settings.Data = string(data)
+	scheduler := instanceDeployment.Settings.Instance.SCH.Schedulers[instanceDeployment.Core.EnvironmentName]
+	if scheduler.TimeZone != "" {
+		jobDeployment.Settings.TimeZone = scheduler.TimeZone
+	}
+	return jobDeployment.Deploy()
+}
@@ -22,5 +22,13 @@ func (instanceDeployment *InstanceDeployment) deploySCHJob() (err error) {
 	jobDeployment := sch.NewJobDeployment()
 	jobDeployment.Core = instanceDeployment.Core
 	jobDeployment.Artifacts = instanceDeployment.Artifacts
+	scheduler := instanceDeployment.Settings.Instance.SCH.Schedulers[instanceDeployment.Core.EnvironmentName]
+	if scheduler.TimeZone != "" {
+		jobDeployment.Settings.TimeZone = scheduler.TimeZone
+	}
+	jobDeployment.Settings.RetryCount = scheduler.RetryCount
+	if scheduler.MaxRetryDuration != "" {
+		jobDeployment.Settings.MaxRetryDuration = scheduler.MaxRetryDuration
+	}
 	return jobDeployment.Deploy()
 }
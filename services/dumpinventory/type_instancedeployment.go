@@ -21,6 +21,7 @@ import (
 	"github.com/BrunoReboul/ram/utilities/deploy"
 	"github.com/BrunoReboul/ram/utilities/gcb"
 	"github.com/BrunoReboul/ram/utilities/gcf"
+	"github.com/BrunoReboul/ram/utilities/glo"
 	"github.com/BrunoReboul/ram/utilities/gsu"
 	"github.com/BrunoReboul/ram/utilities/iamgt"
 	"github.com/BrunoReboul/ram/utilities/sch"
@@ -38,14 +39,18 @@ type InstanceDeployment struct {
 	Core     *deploy.Core
 	Settings struct {
 		Service struct {
-			GSU gsu.Parameters
-			IAM iamgt.Parameters
-			GCB gcb.Parameters
-			GCF gcf.Parameters
+			GSU                 gsu.Parameters
+			IAM                 iamgt.Parameters
+			GCB                 gcb.Parameters
+			GCF                 gcf.Parameters
+			GLO                 glo.LogParameters
+			ExportCheckSchedule string `yaml:"exportCheckSchedule" valid:"isCron"`
 		}
 		Instance struct {
-			CAI cai.Parameters
-			SCH sch.Parameters
+			CAI              cai.Parameters
+			SCH              sch.Parameters
+			ExportSLASeconds int64 `yaml:"exportSLASeconds"`
+			GLO              glo.LogParameters
 		}
 	}
 }
@@ -62,6 +67,8 @@ func NewInstanceDeployment() *InstanceDeployment {
 
 	instanceDeployment.Settings.Service.IAM.RunRoles.MonitoringOrg = []iam.Role{
 		monitoringOrgRunRole()}
+	instanceDeployment.Settings.Service.IAM.RunRoles.Project = []iam.Role{
+		projectRunRole()}
 	instanceDeployment.Settings.Service.IAM.DeployRoles.MonitoringOrg = []iam.Role{
 		monitoringOrgDeployExtendedRole()}
 	instanceDeployment.Settings.Service.IAM.DeployRoles.Project = []iam.Role{
@@ -81,6 +88,8 @@ func NewInstanceDeployment() *InstanceDeployment {
 
 	instanceDeployment.Settings.Service.GCF.ServiceAccountBindings.GRM.Monitoring.Org.CustomRoles = []string{
 		monitoringOrgRunRole().Title}
+	instanceDeployment.Settings.Service.GCF.ServiceAccountBindings.GRM.Hosting.Project.CustomRoles = []string{
+		projectRunRole().Title}
 
 	// Data store permissions are not supported in custom roles
 	instanceDeployment.Settings.Service.GCF.ServiceAccountBindings.GRM.Hosting.Project.Roles = []string{
@@ -90,6 +99,8 @@ func NewInstanceDeployment() *InstanceDeployment {
 	instanceDeployment.Settings.Service.GCF.RetryTimeOutSeconds = 600
 	instanceDeployment.Settings.Service.GCF.Timeout = "60s"
 
+	instanceDeployment.Settings.Service.ExportCheckSchedule = "*/10 * * * *"
+
 	return &instanceDeployment
 }
 
@@ -99,7 +110,21 @@ func monitoringOrgRunRole() (role iam.Role) {
 	role.Stage = "GA"
 	role.IncludedPermissions = []string{
 		"cloudasset.assets.exportResource",
-		"cloudasset.assets.exportIamPolicy"}
+		"cloudasset.assets.exportIamPolicy",
+		"cloudasset.assets.exportOrgPolicy",
+		"cloudasset.assets.exportAccessPolicy",
+		"cloudasset.assets.exportAccessLevel",
+		"cloudasset.assets.exportServicePerimeter",
+		"cloudasset.assets.exportOSInventories"}
+	return role
+}
+
+func projectRunRole() (role iam.Role) {
+	role.Title = "ram_dumpinventory_run"
+	role.Description = "Real-time Asset Monitor dump inventory microservice permissions to run"
+	role.Stage = "GA"
+	role.IncludedPermissions = []string{
+		"storage.objects.get"}
 	return role
 }
 
@@ -112,7 +137,11 @@ func monitoringOrgDeployExtendedRole() (role iam.Role) {
 		"iam.roles.get",
 		"iam.roles.update",
 		"resourcemanager.organizations.getIamPolicy",
-		"resourcemanager.organizations.setIamPolicy"}
+		"resourcemanager.organizations.setIamPolicy",
+		"resourcemanager.folders.getIamPolicy",
+		"resourcemanager.folders.setIamPolicy",
+		"resourcemanager.projects.getIamPolicy",
+		"resourcemanager.projects.setIamPolicy"}
 	return role
 }
 
@@ -129,6 +158,7 @@ func projectDeployCoreRole() (role iam.Role) {
 		"storage.buckets.update",
 		"cloudscheduler.jobs.get",
 		"cloudscheduler.jobs.create",
+		"cloudscheduler.jobs.update",
 		"cloudfunctions.functions.sourceCodeSet",
 		"cloudfunctions.functions.get",
 		"cloudfunctions.functions.create",
@@ -48,9 +48,11 @@ type Global struct {
 	firestoreClient       *firestore.Client
 	groupsSettingsService *groupssettings.Service
 	instanceName          string
+	logger                *glo.Logger
 	microserviceName      string
 	outputTopicName       string
 	projectID             string
+	pubSubAttributes      map[string]string
 	PubSubID              string
 	pubsubPublisherClient *pubsub.PublisherClient
 	retryTimeOutSeconds   int64
@@ -83,14 +85,21 @@ func Initialize(ctx context.Context, global *Global) (err error) {
 	global.instanceName = instanceDeployment.Core.InstanceName
 	global.microserviceName = instanceDeployment.Core.ServiceName
 
-	log.Println(glo.Entry{
-		MicroserviceName: global.microserviceName,
-		InstanceName:     global.instanceName,
-		Environment:      global.environment,
-		Severity:         "NOTICE",
-		Message:          "coldstart",
-		InitID:           initID,
-	})
+	global.logger = glo.NewLogger(global.microserviceName, global.instanceName, global.environment, initID)
+	global.logger.SetLogParameters(glo.GetLogParameters(instanceDeployment.Settings.Service.GLO, instanceDeployment.Settings.Instance.GLO))
+	global.logger.Notice("coldstart", "")
+
+	err = glo.InitTracing(ctx,
+		instanceDeployment.Core.SolutionSettings.Hosting.Tracing.Exporter,
+		instanceDeployment.Core.SolutionSettings.Hosting.Tracing.OTLPEndpoint,
+		instanceDeployment.Core.SolutionSettings.Hosting.Tracing.FilePath,
+		global.microserviceName,
+		global.instanceName,
+		global.environment)
+	if err != nil {
+		global.logger.InitFailed(fmt.Sprintf("glo.InitTracing %v", err))
+		return err
+	}
 
 	gciAdminUserToImpersonate := instanceDeployment.Settings.Instance.GCI.SuperAdminEmail
 	global.outputTopicName = instanceDeployment.Core.SolutionSettings.Hosting.Pubsub.TopicNames.GCIGroupSettings
@@ -103,29 +112,13 @@ func Initialize(ctx context.Context, global *Global) (err error) {
 
 	global.firestoreClient, err = firestore.NewClient(global.ctx, global.projectID)
 	if err != nil {
-		log.Println(glo.Entry{
-			MicroserviceName: global.microserviceName,
-			InstanceName:     global.instanceName,
-			Environment:      global.environment,
-			Severity:         "CRITICAL",
-			Message:          "init_failed",
-			Description:      fmt.Sprintf("firestore.NewClient %v", err),
-			InitID:           initID,
-		})
+		global.logger.InitFailed(fmt.Sprintf("firestore.NewClient %v", err))
 		return err
 	}
 
 	serviceAccountKeyNames, err := gfs.ListKeyNames(ctx, global.firestoreClient, instanceDeployment.Core.ServiceName)
 	if err != nil {
-		log.Println(glo.Entry{
-			MicroserviceName: global.microserviceName,
-			InstanceName:     global.instanceName,
-			Environment:      global.environment,
-			Severity:         "CRITICAL",
-			Message:          "init_failed",
-			Description:      fmt.Sprintf("gfs.ListKeyNames %v", err),
-			InitID:           initID,
-		})
+		global.logger.InitFailed(fmt.Sprintf("gfs.ListKeyNames %v", err))
 		return err
 	}
 	if clientOption, ok = aut.GetClientOptionAndCleanKeys(ctx,
@@ -135,36 +128,17 @@ func Initialize(ctx context.Context, global *Global) (err error) {
 		gciAdminUserToImpersonate,
 		[]string{"https://www.googleapis.com/auth/apps.groups.settings"},
 		serviceAccountKeyNames,
-		initID,
-		global.microserviceName,
-		global.instanceName,
-		global.environment); !ok {
+		global.logger); !ok {
 		return fmt.Errorf("aut.GetClientOptionAndCleanKeys")
 	}
 	global.groupsSettingsService, err = groupssettings.NewService(ctx, clientOption)
 	if err != nil {
-		log.Println(glo.Entry{
-			MicroserviceName: global.microserviceName,
-			InstanceName:     global.instanceName,
-			Environment:      global.environment,
-			Severity:         "CRITICAL",
-			Message:          "init_failed",
-			Description:      fmt.Sprintf("groupssettings.NewService %v", err),
-			InitID:           initID,
-		})
+		global.logger.InitFailed(fmt.Sprintf("groupssettings.NewService %v", err))
 		return err
 	}
 	global.pubsubPublisherClient, err = pubsub.NewPublisherClient(global.ctx)
 	if err != nil {
-		log.Println(glo.Entry{
-			MicroserviceName: global.microserviceName,
-			InstanceName:     global.instanceName,
-			Environment:      global.environment,
-			Severity:         "CRITICAL",
-			Message:          "init_failed",
-			Description:      fmt.Sprintf("global.pubsubPublisherClient %v", err),
-			InitID:           initID,
-		})
+		global.logger.InitFailed(fmt.Sprintf("global.pubsubPublisherClient %v", err))
 		return err
 	}
 	return nil
@@ -176,15 +150,7 @@ func EntryPoint(ctxEvent context.Context, PubSubMessage gps.PubSubMessage, globa
 	metadata, err := metadata.FromContext(ctxEvent)
 	if err != nil {
 		// Assume an error on the function invoker and try again.
-		log.Println(glo.Entry{
-			MicroserviceName:   global.microserviceName,
-			InstanceName:       global.instanceName,
-			Environment:        global.environment,
-			Severity:           "CRITICAL",
-			Message:            "redo_on_transient",
-			Description:        fmt.Sprintf("pubsub_id no available metadata.FromContext: %v", err),
-			TriggeringPubsubID: global.PubSubID,
-		})
+		global.logger.RedoOnTransient(fmt.Sprintf("pubsub_id no available metadata.FromContext: %v", err))
 		return err
 	}
 	global.stepStack = nil
@@ -194,34 +160,16 @@ func EntryPoint(ctxEvent context.Context, PubSubMessage gps.PubSubMessage, globa
 		StepID:        fmt.Sprintf("%s/%s", parts[len(parts)-1], global.PubSubID),
 		StepTimestamp: metadata.Timestamp,
 	}
+	global.logger.SetInvocation(global.PubSubID, global.step)
+	global.pubSubAttributes = PubSubMessage.Attributes
+	defer func() {
+		glo.RecordStepSpan(global.ctx, global.microserviceName, global.pubSubAttributes, global.stepStack)
+	}()
 
-	now := time.Now()
-	d := now.Sub(metadata.Timestamp)
-	log.Println(glo.Entry{
-		MicroserviceName:           global.microserviceName,
-		InstanceName:               global.instanceName,
-		Environment:                global.environment,
-		Severity:                   "NOTICE",
-		Message:                    "start",
-		TriggeringPubsubID:         global.PubSubID,
-		TriggeringPubsubAgeSeconds: d.Seconds(),
-		TriggeringPubsubTimestamp:  &metadata.Timestamp,
-		Now:                        &now,
-	})
+	d := global.logger.Start()
 
 	if d.Seconds() > float64(global.retryTimeOutSeconds) {
-		log.Println(glo.Entry{
-			MicroserviceName:           global.microserviceName,
-			InstanceName:               global.instanceName,
-			Environment:                global.environment,
-			Severity:                   "CRITICAL",
-			Message:                    "noretry",
-			Description:                "Pubsub message too old",
-			TriggeringPubsubID:         global.PubSubID,
-			TriggeringPubsubAgeSeconds: d.Seconds(),
-			TriggeringPubsubTimestamp:  &metadata.Timestamp,
-			Now:                        &now,
-		})
+		global.logger.NoRetryTooOld()
 		return nil
 	}
 
@@ -230,15 +178,7 @@ func EntryPoint(ctxEvent context.Context, PubSubMessage gps.PubSubMessage, globa
 	err = json.Unmarshal(PubSubMessage.Data, &feedMessageGroup)
 	if err != nil {
 		log.Printf("pubsub_id %s NORETRY_ERROR json.Unmarshal(pubSubMessage.Data, &feedMessageGroup)", global.PubSubID)
-		log.Println(glo.Entry{
-			MicroserviceName:   global.microserviceName,
-			InstanceName:       global.instanceName,
-			Environment:        global.environment,
-			Severity:           "CRITICAL",
-			Message:            "noretry",
-			Description:        fmt.Sprintf("json.Unmarshal(pubSubMessage.Data, &feedMessageGroup) %v %v", PubSubMessage.Data, err),
-			TriggeringPubsubID: global.PubSubID,
-		})
+		global.logger.NoRetry(fmt.Sprintf("json.Unmarshal(pubSubMessage.Data, &feedMessageGroup) %v %v", PubSubMessage.Data, err))
 		return nil
 	}
 	if feedMessageGroup.StepStack != nil {
@@ -246,6 +186,7 @@ func EntryPoint(ctxEvent context.Context, PubSubMessage gps.PubSubMessage, globa
 	} else {
 		global.stepStack = append(global.stepStack, global.step)
 	}
+	global.logger.SetStepStack(global.stepStack)
 
 	var feedMessageGroupSettings cai.FeedMessageGroupSettings
 	feedMessageGroupSettings.Origin = feedMessageGroup.Origin
@@ -258,27 +199,11 @@ func EntryPoint(ctxEvent context.Context, PubSubMessage gps.PubSubMessage, globa
 		groupSettings, err := global.groupsSettingsService.Groups.Get(feedMessageGroup.Asset.Resource.Email).Do()
 		if err != nil {
 			if strings.Contains(strings.ToLower(err.Error()), "quota") {
-				log.Println(glo.Entry{
-					MicroserviceName:   global.microserviceName,
-					InstanceName:       global.instanceName,
-					Environment:        global.environment,
-					Severity:           "WARNING",
-					Message:            fmt.Sprintf("waiting_on_quota_exceeded"),
-					Description:        fmt.Sprintf("GetGroupSettings quota is gone, wait for %d seconds then retry", waitSecOnQuotaExceeded),
-					TriggeringPubsubID: global.PubSubID,
-				})
+				global.logger.Warning(fmt.Sprintf("waiting_on_quota_exceeded"), fmt.Sprintf("GetGroupSettings quota is gone, wait for %d seconds then retry", waitSecOnQuotaExceeded))
 				time.Sleep(waitSecOnQuotaExceeded * time.Second)
 				return err
 			}
-			log.Println(glo.Entry{
-				MicroserviceName:   global.microserviceName,
-				InstanceName:       global.instanceName,
-				Environment:        global.environment,
-				Severity:           "CRITICAL",
-				Message:            "redo_on_transient",
-				Description:        fmt.Sprintf("groupsSettingsService.Groups.Get %v", err),
-				TriggeringPubsubID: global.PubSubID,
-			})
+			global.logger.RedoOnTransient(fmt.Sprintf("groupsSettingsService.Groups.Get %v", err))
 			return err
 		}
 		feedMessageGroupSettings.Asset.Resource = groupSettings
@@ -288,20 +213,13 @@ func EntryPoint(ctxEvent context.Context, PubSubMessage gps.PubSubMessage, globa
 
 	feedMessageGroupSettingsJSON, err := json.Marshal(feedMessageGroupSettings)
 	if err != nil {
-		log.Println(glo.Entry{
-			MicroserviceName:   global.microserviceName,
-			InstanceName:       global.instanceName,
-			Environment:        global.environment,
-			Severity:           "CRITICAL",
-			Message:            "noretry",
-			Description:        fmt.Sprintf("json.Marshal(feedMessageGroupSettings) %v %v", feedMessageGroupSettings, err),
-			TriggeringPubsubID: global.PubSubID,
-		})
+		global.logger.NoRetry(fmt.Sprintf("json.Marshal(feedMessageGroupSettings) %v %v", feedMessageGroupSettings, err))
 		return nil
 	}
 
 	var pubSubMessage pubsubpb.PubsubMessage
 	pubSubMessage.Data = feedMessageGroupSettingsJSON
+	pubSubMessage.Attributes = glo.GetTraceAttributes(global.pubSubAttributes, global.stepStack)
 
 	var pubsubMessages []*pubsubpb.PubsubMessage
 	pubsubMessages = append(pubsubMessages, &pubSubMessage)
@@ -312,34 +230,9 @@ func EntryPoint(ctxEvent context.Context, PubSubMessage gps.PubSubMessage, globa
 
 	pubsubResponse, err := global.pubsubPublisherClient.Publish(global.ctx, &publishRequest)
 	if err != nil {
-		log.Println(glo.Entry{
-			MicroserviceName:   global.microserviceName,
-			InstanceName:       global.instanceName,
-			Environment:        global.environment,
-			Severity:           "CRITICAL",
-			Message:            "redo_on_transient",
-			Description:        fmt.Sprintf("global.pubsubPublisherClient.Publish %v %v", &publishRequest, err),
-			TriggeringPubsubID: global.PubSubID,
-		})
+		global.logger.RedoOnTransient(fmt.Sprintf("global.pubsubPublisherClient.Publish %v %v", &publishRequest, err))
 		return err
 	}
-	now = time.Now()
-	latency := now.Sub(metadata.Timestamp)
-	latencyE2E := now.Sub(global.stepStack[0].StepTimestamp)
-	log.Println(glo.Entry{
-		MicroserviceName:     global.microserviceName,
-		InstanceName:         global.instanceName,
-		Environment:          global.environment,
-		Severity:             "NOTICE",
-		Message:              fmt.Sprintf("finish %s", feedMessageGroup.Asset.Resource.Email),
-		Description:          fmt.Sprintf("groupSettings published to pubsub %s (isdeleted status=%v) %s topic %s ids %v %s", feedMessageGroup.Asset.Resource.Email, feedMessageGroup.Deleted, feedMessageGroup.Asset.Resource.Id, global.outputTopicName, pubsubResponse.MessageIds, string(feedMessageGroupSettingsJSON)),
-		Now:                  &now,
-		TriggeringPubsubID:   global.PubSubID,
-		OriginEventTimestamp: &metadata.Timestamp,
-		LatencySeconds:       latency.Seconds(),
-		LatencyE2ESeconds:    latencyE2E.Seconds(),
-		StepStack:            global.stepStack,
-		AssetInventoryOrigin: feedMessageGroup.Origin,
-	})
+	global.logger.Finish(fmt.Sprintf("finish %s", feedMessageGroup.Asset.Resource.Email), fmt.Sprintf("groupSettings published to pubsub %s (isdeleted status=%v) %s topic %s ids %v %s", feedMessageGroup.Asset.Resource.Email, feedMessageGroup.Deleted, feedMessageGroup.Asset.Resource.Id, global.outputTopicName, pubsubResponse.MessageIds, string(feedMessageGroupSettingsJSON)), feedMessageGroup.Origin)
 	return nil
 }
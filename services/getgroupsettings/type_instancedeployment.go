@@ -20,6 +20,7 @@ import (
 	"github.com/BrunoReboul/ram/utilities/deploy"
 	"github.com/BrunoReboul/ram/utilities/gcb"
 	"github.com/BrunoReboul/ram/utilities/gcf"
+	"github.com/BrunoReboul/ram/utilities/glo"
 	"github.com/BrunoReboul/ram/utilities/gsu"
 	"github.com/BrunoReboul/ram/utilities/iamgt"
 	"google.golang.org/api/iam/v1"
@@ -35,13 +36,15 @@ type InstanceDeployment struct {
 			IAM             iamgt.Parameters
 			GCB             gcb.Parameters
 			GCF             gcf.Parameters
+			GLO             glo.LogParameters
 			KeyJSONFileName string `yaml:"keyJSONFileName"`
 		}
 		Instance struct {
 			GCF gcf.Event
 			GCI struct {
-				SuperAdminEmail string `yaml:"superAdminEmail"`
+				SuperAdminEmail string `yaml:"superAdminEmail" valid:"isEmail"`
 			}
+			GLO glo.LogParameters
 		}
 	}
 }
@@ -0,0 +1,289 @@
+// Copyright 2020 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the 'License');
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an 'AS IS' BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package listdirectorysettings
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log"
+	"strings"
+	"time"
+
+	"github.com/BrunoReboul/ram/utilities/aut"
+	"github.com/BrunoReboul/ram/utilities/cai"
+	"github.com/BrunoReboul/ram/utilities/ffo"
+	"github.com/BrunoReboul/ram/utilities/gfs"
+	"github.com/BrunoReboul/ram/utilities/glo"
+	"github.com/BrunoReboul/ram/utilities/gps"
+	"github.com/BrunoReboul/ram/utilities/solution"
+	"github.com/google/uuid"
+	"google.golang.org/api/option"
+
+	"cloud.google.com/go/firestore"
+	"cloud.google.com/go/functions/metadata"
+	"cloud.google.com/go/pubsub"
+	reports "google.golang.org/api/admin/reports/v1"
+)
+
+const origin = "batch-listdirectorysettings"
+
+// settingsKinds admin event types replayed from reports, and the asset kind they are converted to, as in convertlog2feed
+var settingsKinds = map[string]string{
+	"DOMAIN_SETTINGS":   "domainSettings",
+	"SECURITY_SETTINGS": "securitySettings",
+}
+
+// Global structure for global variables to optimize the cloud function performances
+type Global struct {
+	ctx                 context.Context
+	directoryCustomerID string
+	firestoreClient     *firestore.Client
+	logger              *glo.Logger
+	lookBackDays        int64
+	maxResultsPerPage   int64 // API Max = 1000
+	microserviceName    string
+	pubSubAttributes    map[string]string
+	pubSubClient        *pubsub.Client
+	PubSubID            string
+	reportsService      *reports.Service
+	retryTimeOutSeconds int64
+	step                glo.Step
+	stepStack           glo.Steps
+	topicNames          map[string]string
+}
+
+// Initialize is to be executed in the init() function of the cloud function to optimize the cold start
+func Initialize(ctx context.Context, global *Global) (err error) {
+	log.SetFlags(0)
+	global.ctx = ctx
+
+	var instanceDeployment InstanceDeployment
+	var clientOption option.ClientOption
+	var ok bool
+
+	initID := fmt.Sprintf("%v", uuid.New())
+	err = ffo.ReadUnmarshalYAML(solution.PathToFunctionCode+solution.SettingsFileName, &instanceDeployment)
+	if err != nil {
+		log.Println(glo.Entry{
+			Severity:    "CRITICAL",
+			Message:     "init_failed",
+			Description: fmt.Sprintf("ReadUnmarshalYAML %s %v", solution.SettingsFileName, err),
+			InitID:      initID,
+		})
+		return err
+	}
+
+	environment := instanceDeployment.Core.EnvironmentName
+	instanceName := instanceDeployment.Core.InstanceName
+	global.microserviceName = instanceDeployment.Core.ServiceName
+
+	global.logger = glo.NewLogger(global.microserviceName, instanceName, environment, initID)
+	global.logger.SetLogParameters(glo.GetLogParameters(instanceDeployment.Settings.Service.GLO, instanceDeployment.Settings.Instance.GLO))
+	global.logger.Notice("coldstart", "")
+
+	err = glo.InitTracing(ctx,
+		instanceDeployment.Core.SolutionSettings.Hosting.Tracing.Exporter,
+		instanceDeployment.Core.SolutionSettings.Hosting.Tracing.OTLPEndpoint,
+		instanceDeployment.Core.SolutionSettings.Hosting.Tracing.FilePath,
+		global.microserviceName,
+		instanceName,
+		environment)
+	if err != nil {
+		global.logger.InitFailed(fmt.Sprintf("glo.InitTracing %v", err))
+		return err
+	}
+
+	gciAdminUserToImpersonate := instanceDeployment.Settings.Instance.GCI.SuperAdminEmail
+	global.directoryCustomerID = instanceDeployment.Settings.Instance.GCI.DirectoryCustomerID
+	global.lookBackDays = instanceDeployment.Settings.Service.LookBackDays
+	global.maxResultsPerPage = instanceDeployment.Settings.Service.MaxResultsPerPage
+	global.retryTimeOutSeconds = instanceDeployment.Settings.Service.GCF.RetryTimeOutSeconds
+	global.topicNames = map[string]string{
+		"domainSettings":   instanceDeployment.Artifacts.DomainSettingsTopicName,
+		"securitySettings": instanceDeployment.Artifacts.SecuritySettingsTopicName,
+	}
+	projectID := instanceDeployment.Core.SolutionSettings.Hosting.ProjectID
+	keyJSONFilePath := solution.PathToFunctionCode + instanceDeployment.Settings.Service.KeyJSONFileName
+	serviceAccountEmail := fmt.Sprintf("%s@%s.iam.gserviceaccount.com",
+		instanceDeployment.Core.ServiceName,
+		instanceDeployment.Core.SolutionSettings.Hosting.ProjectID)
+
+	global.firestoreClient, err = firestore.NewClient(global.ctx, projectID)
+	if err != nil {
+		global.logger.InitFailed(fmt.Sprintf("firestore.NewClient %v", err))
+		return err
+	}
+
+	serviceAccountKeyNames, err := gfs.ListKeyNames(ctx, global.firestoreClient, instanceDeployment.Core.ServiceName)
+	if err != nil {
+		global.logger.InitFailed(fmt.Sprintf("gfs.ListKeyNames %v", err))
+		return err
+	}
+
+	if clientOption, ok = aut.GetClientOptionAndCleanKeys(ctx,
+		serviceAccountEmail,
+		keyJSONFilePath,
+		instanceDeployment.Core.SolutionSettings.Hosting.ProjectID,
+		gciAdminUserToImpersonate,
+		[]string{reports.AdminReportsAuditReadonlyScope},
+		serviceAccountKeyNames,
+		global.logger); !ok {
+		return fmt.Errorf("aut.GetClientOptionAndCleanKeys")
+	}
+	global.reportsService, err = reports.NewService(ctx, clientOption)
+	if err != nil {
+		global.logger.InitFailed(fmt.Sprintf("reports.NewService %v", err))
+		return err
+	}
+	global.pubSubClient, err = pubsub.NewClient(ctx, projectID)
+	if err != nil {
+		global.logger.InitFailed(fmt.Sprintf("pubsub.NewClient %v", err))
+		return err
+	}
+	return nil
+}
+
+// EntryPoint is the function to be executed for each cloud function occurence
+func EntryPoint(ctxEvent context.Context, PubSubMessage gps.PubSubMessage, global *Global) error {
+	metadata, err := metadata.FromContext(ctxEvent)
+	if err != nil {
+		// Assume an error on the function invoker and try again.
+		global.logger.RedoOnTransient(fmt.Sprintf("pubsub_id no available metadata.FromContext: %v", err))
+		return err
+	}
+	global.stepStack = nil
+	global.PubSubID = metadata.EventID
+	parts := strings.Split(metadata.Resource.Name, "/")
+	global.step = glo.Step{
+		StepID:        fmt.Sprintf("%s/%s", parts[len(parts)-1], global.PubSubID),
+		StepTimestamp: metadata.Timestamp,
+	}
+	global.logger.SetInvocation(global.PubSubID, global.step)
+	global.pubSubAttributes = PubSubMessage.Attributes
+	defer func() {
+		glo.RecordStepSpan(global.ctx, global.microserviceName, global.pubSubAttributes, global.stepStack)
+	}()
+
+	d := global.logger.Start()
+
+	if d.Seconds() > float64(global.retryTimeOutSeconds) {
+		global.logger.NoRetryTooOld()
+		return nil
+	}
+	global.stepStack = append(global.stepStack, global.step)
+	global.logger.SetStepStack(global.stepStack)
+
+	feedMessages, err := getLastDirectorySettings(global)
+	if err != nil {
+		global.logger.RedoOnTransient(fmt.Sprintf("getLastDirectorySettings %v", err))
+		return err
+	}
+	if len(feedMessages) == 0 {
+		global.logger.Cancel(fmt.Sprintf("no domain or security settings change found in the last %d days for directory %s", global.lookBackDays, global.directoryCustomerID))
+		return nil
+	}
+	err = publishDirectorySettings(feedMessages, global)
+	if err != nil {
+		global.logger.RedoOnTransient(fmt.Sprintf("publishDirectorySettings %v", err))
+		return err
+	}
+	global.logger.Finish(fmt.Sprintf("finish %d directory settings", len(feedMessages)), fmt.Sprintf("directory %s", global.directoryCustomerID), origin)
+	return nil
+}
+
+// getLastDirectorySettings replays the admin activity reports to get the last known value of each domain and security setting
+// This is the initial load: no Admin SDK API lists these settings, convertlog2feed keeps them up to date in real-time
+func getLastDirectorySettings(global *Global) (feedMessages map[string]cai.FeedMessageDirectorySetting, err error) {
+	feedMessages = make(map[string]cai.FeedMessageDirectorySetting)
+	startTime := time.Now().AddDate(0, 0, -int(global.lookBackDays)).Format(time.RFC3339)
+	err = global.reportsService.Activities.List("all", "admin").
+		CustomerId(global.directoryCustomerID).
+		StartTime(startTime).
+		MaxResults(global.maxResultsPerPage).
+		Pages(global.ctx, func(activities *reports.Activities) error {
+			for _, activity := range activities.Items {
+				if activity.Id == nil {
+					continue
+				}
+				activityTime, err := time.Parse(time.RFC3339Nano, activity.Id.Time)
+				if err != nil {
+					global.logger.Warning("skip activity with unexpected time", fmt.Sprintf("%s %v", activity.Id.Time, err))
+					continue
+				}
+				for _, event := range activity.Events {
+					settingsKind, ok := settingsKinds[event.Type]
+					if !ok {
+						continue
+					}
+					feedMessage, _ := cai.NewFeedMessageDirectorySetting(global.directoryCustomerID, settingsKind, event.Name, func(name string) string {
+						return getParameterValue(event.Parameters, name)
+					})
+					// Reports are listed most recent first, yet do not rely on it
+					if previous, ok := feedMessages[feedMessage.Asset.Name]; ok && !activityTime.After(previous.Window.StartTime) {
+						continue
+					}
+					feedMessage.Window.StartTime = activityTime
+					feedMessage.Origin = origin
+					feedMessage.Deleted = false
+					feedMessage.StepStack = global.stepStack
+					feedMessages[feedMessage.Asset.Name] = feedMessage
+				}
+			}
+			return nil
+		})
+	if err != nil {
+		return feedMessages, fmt.Errorf("reportsService.Activities.List %v", err)
+	}
+	return feedMessages, nil
+}
+
+// publishDirectorySettings publishes each setting to its domainSettings or securitySettings topic, the one convertlog2feed publishes to
+func publishDirectorySettings(feedMessages map[string]cai.FeedMessageDirectorySetting, global *Global) (err error) {
+	attributes := glo.GetTraceAttributes(global.pubSubAttributes, global.stepStack)
+	var publishResults []*pubsub.PublishResult
+	for _, feedMessage := range feedMessages {
+		feedMessageJSON, err := json.Marshal(feedMessage)
+		if err != nil {
+			global.logger.Warning("json.Marshal(feedMessage)", fmt.Sprintf("%s %v", feedMessage.Asset.Name, err))
+			continue
+		}
+		settingsKind := strings.TrimPrefix(feedMessage.Asset.AssetType, "www.googleapis.com/admin/directory/")
+		topic := global.pubSubClient.Topic(global.topicNames[settingsKind])
+		publishResults = append(publishResults, topic.Publish(global.ctx, &pubsub.Message{
+			Data:       feedMessageJSON,
+			Attributes: attributes,
+		}))
+	}
+	var pubSubErrNumber int
+	for _, publishResult := range publishResults {
+		if _, err := publishResult.Get(global.ctx); err != nil {
+			pubSubErrNumber++
+		}
+	}
+	if pubSubErrNumber > 0 {
+		return fmt.Errorf("%d of %d pubsub messages did not publish successfully", pubSubErrNumber, len(publishResults))
+	}
+	return nil
+}
+
+func getParameterValue(parameters []*reports.ActivityEventsParameters, name string) string {
+	for _, parameter := range parameters {
+		if parameter.Name == name {
+			return parameter.Value
+		}
+	}
+	return ""
+}
@@ -0,0 +1,96 @@
+// Copyright 2020 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the 'License');
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an 'AS IS' BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+/*
+Package listdirectorysettings initial load of GCI domain and security settings from the Admin SDK reports API
+
+Triggered by
+
+Cloud Scheduler Job, through PubSub messages.
+
+Instances
+
+few, one per directory customer ID.
+
+Output
+
+PubSub messages to the topics gci-domainSettings-<directoryCustomerID> and gci-securitySettings-<directoryCustomerID> formated like Cloud Asset Inventory feed messages, the ones convertlog2feed publishes to.
+
+Asset types www.googleapis.com/admin/directory/domainSettings and www.googleapis.com/admin/directory/securitySettings, ancestry directories/<directoryCustomerID>.
+
+Cardinality
+
+- one-one: one extraction job, one query paging through the admin activity reports.
+
+- one message per setting, holding the last value changed in the look back period.
+
+Automatic retrying
+
+Yes.
+
+Is recurssive
+
+No.
+
+Domain Wide Delegation
+
+Yes. The service account used to run this cloud function must have domain wide delegation and the following Oauth scopes:
+
+- https://www.googleapis.com/auth/admin.reports.audit.readonly
+
+Key rotation strategy
+
+- A new service account key is created during the cloud function deployment in Cloud Build.
+
+- The json key file is available to the cloud function as a local source file and is not persisted in git.
+
+- The cloud function init function deletes any key but the current one.
+
+- So, how to rotate service accout key? just redeploy the cloud function.
+
+Notes
+
+- There is no Admin SDK API listing domain and security settings: the admin activity DOMAIN_SETTINGS and SECURITY_SETTINGS events are replayed instead.
+
+- Admin activity reports are retained 180 days, service setting lookBackDays. A setting not changed in this period has no asset.
+
+- The window start time is the event time, so a more recent real-time change already cached is not overwritten.
+
+Scheduling
+
+One job per environment from solution settings monitoring.listDirectorySettingsDefaultSchedulers, no listdirectorysettings instance when not set.
+
+Implementation example
+
+ package p
+ import (
+     "context"
+
+     "github.com/BrunoReboul/ram/services/listdirectorysettings"
+     "github.com/BrunoReboul/ram/utilities/ram"
+ )
+ var global listdirectorysettings.Global
+ var ctx = context.Background()
+
+ // EntryPoint is the function to be executed for each cloud function occurence
+ func EntryPoint(ctxEvent context.Context, PubSubMessage gps.PubSubMessage) error {
+     return listdirectorysettings.EntryPoint(ctxEvent, PubSubMessage, &global)
+ }
+
+ func init() {
+     listdirectorysettings.Initialize(ctx, &global)
+ }
+
+*/
+package listdirectorysettings
@@ -47,19 +47,17 @@ const waitSecOnQuotaExceeded = 70
 var ancestors []string
 var ancestryPath string
 var ctx context.Context
-var environment string
 var groupAssetName string
 var groupEmail string
-var instanceName string
 var logEventEveryXPubSubMsg uint64
-var microserviceName string
+var logger *glo.Logger
 var origin string
 var outputTopicName string
 var pubSubClient *pubsub.Client
 var pubSubErrNumber uint64
-var pubSubID string
 var pubSubMsgNumber uint64
 var stepStack glo.Steps
+var traceAttributes map[string]string
 
 // Global structure for global variables to optimize the cloud function performances
 type Global struct {
@@ -71,10 +69,12 @@ type Global struct {
 	instanceName            string
 	logEventEveryXPubSubMsg uint64
 	maxResultsPerPage       int64 // API Max = 200
+	logger                  *glo.Logger
 	microserviceName        string
 	outputTopicName         string
 	projectID               string
 	pubSubClient            *pubsub.Client
+	pubSubAttributes        map[string]string
 	PubSubID                string
 	retryTimeOutSeconds     int64
 	step                    glo.Step
@@ -105,14 +105,21 @@ func Initialize(ctx context.Context, global *Global) (err error) {
 	global.instanceName = instanceDeployment.Core.InstanceName
 	global.microserviceName = instanceDeployment.Core.ServiceName
 
-	log.Println(glo.Entry{
-		MicroserviceName: global.microserviceName,
-		InstanceName:     global.instanceName,
-		Environment:      global.environment,
-		Severity:         "NOTICE",
-		Message:          "coldstart",
-		InitID:           initID,
-	})
+	global.logger = glo.NewLogger(global.microserviceName, global.instanceName, global.environment, initID)
+	global.logger.SetLogParameters(glo.GetLogParameters(instanceDeployment.Settings.Service.GLO, instanceDeployment.Settings.Instance.GLO))
+	global.logger.Notice("coldstart", "")
+
+	err = glo.InitTracing(ctx,
+		instanceDeployment.Core.SolutionSettings.Hosting.Tracing.Exporter,
+		instanceDeployment.Core.SolutionSettings.Hosting.Tracing.OTLPEndpoint,
+		instanceDeployment.Core.SolutionSettings.Hosting.Tracing.FilePath,
+		global.microserviceName,
+		global.instanceName,
+		global.environment)
+	if err != nil {
+		global.logger.InitFailed(fmt.Sprintf("glo.InitTracing %v", err))
+		return err
+	}
 
 	gciAdminUserToImpersonate := instanceDeployment.Settings.Instance.GCI.SuperAdminEmail
 	global.collectionID = instanceDeployment.Core.SolutionSettings.Hosting.FireStore.CollectionIDs.Assets
@@ -129,29 +136,13 @@ func Initialize(ctx context.Context, global *Global) (err error) {
 
 	global.firestoreClient, err = firestore.NewClient(global.ctx, global.projectID)
 	if err != nil {
-		log.Println(glo.Entry{
-			MicroserviceName: global.microserviceName,
-			InstanceName:     global.instanceName,
-			Environment:      global.environment,
-			Severity:         "CRITICAL",
-			Message:          "init_failed",
-			Description:      fmt.Sprintf("firestore.NewClient %v", err),
-			InitID:           initID,
-		})
+		global.logger.InitFailed(fmt.Sprintf("firestore.NewClient %v", err))
 		return err
 	}
 
 	serviceAccountKeyNames, err := gfs.ListKeyNames(ctx, global.firestoreClient, instanceDeployment.Core.ServiceName)
 	if err != nil {
-		log.Println(glo.Entry{
-			MicroserviceName: global.microserviceName,
-			InstanceName:     global.instanceName,
-			Environment:      global.environment,
-			Severity:         "CRITICAL",
-			Message:          "init_failed",
-			Description:      fmt.Sprintf("gfs.ListKeyNames %v", err),
-			InitID:           initID,
-		})
+		global.logger.InitFailed(fmt.Sprintf("gfs.ListKeyNames %v", err))
 		return err
 	}
 
@@ -162,36 +153,17 @@ func Initialize(ctx context.Context, global *Global) (err error) {
 		gciAdminUserToImpersonate,
 		[]string{admin.AdminDirectoryGroupMemberReadonlyScope},
 		serviceAccountKeyNames,
-		initID,
-		global.microserviceName,
-		global.instanceName,
-		global.environment); !ok {
+		global.logger); !ok {
 		return fmt.Errorf("aut.GetClientOptionAndCleanKeys")
 	}
 	global.dirAdminService, err = admin.NewService(ctx, clientOption)
 	if err != nil {
-		log.Println(glo.Entry{
-			MicroserviceName: global.microserviceName,
-			InstanceName:     global.instanceName,
-			Environment:      global.environment,
-			Severity:         "CRITICAL",
-			Message:          "init_failed",
-			Description:      fmt.Sprintf("admin.NewService %v", err),
-			InitID:           initID,
-		})
+		global.logger.InitFailed(fmt.Sprintf("admin.NewService %v", err))
 		return err
 	}
 	global.pubSubClient, err = pubsub.NewClient(ctx, projectID)
 	if err != nil {
-		log.Println(glo.Entry{
-			MicroserviceName: global.microserviceName,
-			InstanceName:     global.instanceName,
-			Environment:      global.environment,
-			Severity:         "CRITICAL",
-			Message:          "init_failed",
-			Description:      fmt.Sprintf("pubsub.NewClient %v", err),
-			InitID:           initID,
-		})
+		global.logger.InitFailed(fmt.Sprintf("pubsub.NewClient %v", err))
 		return err
 	}
 	return nil
@@ -203,15 +175,7 @@ func EntryPoint(ctxEvent context.Context, PubSubMessage gps.PubSubMessage, globa
 	metadata, err := metadata.FromContext(ctxEvent)
 	if err != nil {
 		// Assume an error on the function invoker and try again.
-		log.Println(glo.Entry{
-			MicroserviceName:   global.microserviceName,
-			InstanceName:       global.instanceName,
-			Environment:        global.environment,
-			Severity:           "CRITICAL",
-			Message:            "redo_on_transient",
-			Description:        fmt.Sprintf("pubsub_id no available metadata.FromContext: %v", err),
-			TriggeringPubsubID: global.PubSubID,
-		})
+		global.logger.RedoOnTransient(fmt.Sprintf("pubsub_id no available metadata.FromContext: %v", err))
 		return err
 	}
 	global.stepStack = nil
@@ -221,34 +185,16 @@ func EntryPoint(ctxEvent context.Context, PubSubMessage gps.PubSubMessage, globa
 		StepID:        fmt.Sprintf("%s/%s", parts[len(parts)-1], global.PubSubID),
 		StepTimestamp: metadata.Timestamp,
 	}
+	global.logger.SetInvocation(global.PubSubID, global.step)
+	global.pubSubAttributes = PubSubMessage.Attributes
+	defer func() {
+		glo.RecordStepSpan(global.ctx, global.microserviceName, global.pubSubAttributes, global.stepStack)
+	}()
 
-	now := time.Now()
-	d := now.Sub(metadata.Timestamp)
-	log.Println(glo.Entry{
-		MicroserviceName:           global.microserviceName,
-		InstanceName:               global.instanceName,
-		Environment:                global.environment,
-		Severity:                   "NOTICE",
-		Message:                    "start",
-		TriggeringPubsubID:         global.PubSubID,
-		TriggeringPubsubAgeSeconds: d.Seconds(),
-		TriggeringPubsubTimestamp:  &metadata.Timestamp,
-		Now:                        &now,
-	})
+	d := global.logger.Start()
 
 	if d.Seconds() > float64(global.retryTimeOutSeconds) {
-		log.Println(glo.Entry{
-			MicroserviceName:           global.microserviceName,
-			InstanceName:               global.instanceName,
-			Environment:                global.environment,
-			Severity:                   "CRITICAL",
-			Message:                    "noretry",
-			Description:                "Pubsub message too old",
-			TriggeringPubsubID:         global.PubSubID,
-			TriggeringPubsubAgeSeconds: d.Seconds(),
-			TriggeringPubsubTimestamp:  &metadata.Timestamp,
-			Now:                        &now,
-		})
+		global.logger.NoRetryTooOld()
 		return nil
 	}
 
@@ -257,24 +203,13 @@ func EntryPoint(ctxEvent context.Context, PubSubMessage gps.PubSubMessage, globa
 	logEventEveryXPubSubMsg = global.logEventEveryXPubSubMsg
 	pubSubClient = global.pubSubClient
 	outputTopicName = global.outputTopicName
-	pubSubID = global.PubSubID
-	microserviceName = global.microserviceName
-	instanceName = global.instanceName
-	environment = global.environment
+	logger = global.logger
 
 	var feedMessageGroup cai.FeedMessageGroup
 	err = json.Unmarshal(PubSubMessage.Data, &feedMessageGroup)
 	if err != nil {
 		log.Printf("pubsub_id %s NORETRY_ERROR json.Unmarshal(pubSubMessage.Data, &feedMessageGroup)", global.PubSubID)
-		log.Println(glo.Entry{
-			MicroserviceName:   global.microserviceName,
-			InstanceName:       global.instanceName,
-			Environment:        global.environment,
-			Severity:           "CRITICAL",
-			Message:            "noretry",
-			Description:        fmt.Sprintf("json.Unmarshal(pubSubMessage.Data, &feedMessageGroup) %v %v", PubSubMessage.Data, err),
-			TriggeringPubsubID: global.PubSubID,
-		})
+		global.logger.NoRetry(fmt.Sprintf("json.Unmarshal(pubSubMessage.Data, &feedMessageGroup) %v %v", PubSubMessage.Data, err))
 		return nil
 	}
 	if feedMessageGroup.StepStack != nil {
@@ -282,7 +217,9 @@ func EntryPoint(ctxEvent context.Context, PubSubMessage gps.PubSubMessage, globa
 	} else {
 		global.stepStack = append(global.stepStack, global.step)
 	}
+	global.logger.SetStepStack(global.stepStack)
 	stepStack = global.stepStack
+	traceAttributes = glo.GetTraceAttributes(global.pubSubAttributes, global.stepStack)
 
 	pubSubMsgNumber = 0
 	pubSubErrNumber = 0
@@ -295,15 +232,7 @@ func EntryPoint(ctxEvent context.Context, PubSubMessage gps.PubSubMessage, globa
 		// retreive members from cache
 		err = browseFeedMessageGroupMembersFromCache(global)
 		if err != nil {
-			log.Println(glo.Entry{
-				MicroserviceName:   global.microserviceName,
-				InstanceName:       global.instanceName,
-				Environment:        global.environment,
-				Severity:           "CRITICAL",
-				Message:            "redo_on_transient",
-				Description:        fmt.Sprintf("browseFeedMessageGroupMembersFromCache(global) %v", err),
-				TriggeringPubsubID: global.PubSubID,
-			})
+			global.logger.RedoOnTransient(fmt.Sprintf("browseFeedMessageGroupMembersFromCache(global) %v", err))
 			return err
 		}
 	} else {
@@ -312,67 +241,18 @@ func EntryPoint(ctxEvent context.Context, PubSubMessage gps.PubSubMessage, globa
 		err = global.dirAdminService.Members.List(feedMessageGroup.Asset.Resource.Id).MaxResults(global.maxResultsPerPage).Pages(ctx, browseMembers)
 		if err != nil {
 			if strings.Contains(strings.ToLower(err.Error()), "quota") {
-				log.Println(glo.Entry{
-					MicroserviceName:   global.microserviceName,
-					InstanceName:       global.instanceName,
-					Environment:        global.environment,
-					Severity:           "WARNING",
-					Message:            fmt.Sprintf("waiting_on_quota_exceeded"),
-					Description:        fmt.Sprintf("ListGroupMembers quota is gone, wait for %d seconds then retry", waitSecOnQuotaExceeded),
-					TriggeringPubsubID: global.PubSubID,
-				})
+				global.logger.Warning(fmt.Sprintf("waiting_on_quota_exceeded"), fmt.Sprintf("ListGroupMembers quota is gone, wait for %d seconds then retry", waitSecOnQuotaExceeded))
 				time.Sleep(waitSecOnQuotaExceeded * time.Second)
 				return err
 			}
-			log.Println(glo.Entry{
-				MicroserviceName:   global.microserviceName,
-				InstanceName:       global.instanceName,
-				Environment:        global.environment,
-				Severity:           "CRITICAL",
-				Message:            "redo_on_transient",
-				Description:        fmt.Sprintf("dirAdminService.Members.List %v", err),
-				TriggeringPubsubID: global.PubSubID,
-			})
+			global.logger.RedoOnTransient(fmt.Sprintf("dirAdminService.Members.List %v", err))
 			return err
 		}
 	}
 	if pubSubMsgNumber > 0 {
-		now = time.Now()
-		latency := now.Sub(global.step.StepTimestamp)
-		latencyE2E := now.Sub(global.stepStack[0].StepTimestamp)
-		log.Println(glo.Entry{
-			MicroserviceName:     global.microserviceName,
-			InstanceName:         global.instanceName,
-			Environment:          global.environment,
-			Severity:             "NOTICE",
-			Message:              fmt.Sprintf("finish %s %d members", feedMessageGroup.Asset.Resource.Email, pubSubMsgNumber),
-			Description:          fmt.Sprintf("Group %s %s isDeleted %v Number of members published to pubsub topic %s: %d", feedMessageGroup.Asset.Resource.Email, feedMessageGroup.Asset.Resource.Id, feedMessageGroup.Deleted, outputTopicName, pubSubMsgNumber),
-			Now:                  &now,
-			TriggeringPubsubID:   global.PubSubID,
-			OriginEventTimestamp: &global.stepStack[0].StepTimestamp,
-			LatencySeconds:       latency.Seconds(),
-			LatencyE2ESeconds:    latencyE2E.Seconds(),
-			StepStack:            global.stepStack,
-			AssetInventoryOrigin: feedMessageGroup.Origin,
-		})
+		global.logger.Finish(fmt.Sprintf("finish %s %d members", feedMessageGroup.Asset.Resource.Email, pubSubMsgNumber), fmt.Sprintf("Group %s %s isDeleted %v Number of members published to pubsub topic %s: %d", feedMessageGroup.Asset.Resource.Email, feedMessageGroup.Asset.Resource.Id, feedMessageGroup.Deleted, outputTopicName, pubSubMsgNumber), feedMessageGroup.Origin)
 	} else {
-		now = time.Now()
-		latency := now.Sub(global.step.StepTimestamp)
-		latencyE2E := now.Sub(global.stepStack[0].StepTimestamp)
-		log.Println(glo.Entry{
-			MicroserviceName:     global.microserviceName,
-			InstanceName:         global.instanceName,
-			Environment:          global.environment,
-			Severity:             "NOTICE",
-			Message:              "cancel",
-			Description:          fmt.Sprintf("empty group %s", feedMessageGroup.Asset.Resource.Email),
-			Now:                  &now,
-			TriggeringPubsubID:   global.PubSubID,
-			OriginEventTimestamp: &global.stepStack[0].StepTimestamp,
-			LatencySeconds:       latency.Seconds(),
-			LatencyE2ESeconds:    latencyE2E.Seconds(),
-			StepStack:            global.stepStack,
-		})
+		global.logger.Cancel(fmt.Sprintf("empty group %s", feedMessageGroup.Asset.Resource.Email))
 	}
 	return nil
 }
@@ -385,7 +265,8 @@ func browseFeedMessageGroupMembersFromCache(global *Global) (err error) {
 	assets := global.firestoreClient.Collection(global.collectionID)
 	query := assets.Where(
 		"asset.assetType", "==", "www.googleapis.com/admin/directory/members").Where(
-		"asset.resource.groupEmail", "==", strings.ToLower(groupEmail))
+		"asset.resource.groupEmail", "==", strings.ToLower(groupEmail)).Where(
+		"deleted", "==", false)
 	iter := query.Documents(global.ctx)
 	defer iter.Stop()
 	for {
@@ -394,46 +275,23 @@ func browseFeedMessageGroupMembersFromCache(global *Global) (err error) {
 			break
 		}
 		if err != nil {
-			log.Println(glo.Entry{
-				MicroserviceName:   global.microserviceName,
-				InstanceName:       global.instanceName,
-				Environment:        global.environment,
-				Severity:           "WARNING",
-				Message:            "browseFeedMessageGroupMembersFromCache",
-				Description:        fmt.Sprintf("log and move to next iter.Next() %v", err),
-				TriggeringPubsubID: global.PubSubID,
-			})
+			global.logger.Warning("browseFeedMessageGroupMembersFromCache", fmt.Sprintf("log and move to next iter.Next() %v", err))
 		} else {
 			if documentSnap.Exists() {
 				err = documentSnap.DataTo(&feedMessageMember)
 				if err != nil {
-					log.Println(glo.Entry{
-						MicroserviceName:   global.microserviceName,
-						InstanceName:       global.instanceName,
-						Environment:        global.environment,
-						Severity:           "WARNING",
-						Message:            "browseFeedMessageGroupMembersFromCache",
-						Description:        fmt.Sprintf("log and move to next documentSnap.DataTo %v", err),
-						TriggeringPubsubID: global.PubSubID,
-					})
+					global.logger.Warning("browseFeedMessageGroupMembersFromCache", fmt.Sprintf("log and move to next documentSnap.DataTo %v", err))
 				} else {
 					feedMessageMember.Deleted = true
 					feedMessageMember.Window.StartTime = time.Now()
 					feedMessageMember.Origin = origin
 					feedMessageMemberJSON, err := json.Marshal(feedMessageMember)
 					if err != nil {
-						log.Println(glo.Entry{
-							MicroserviceName:   global.microserviceName,
-							InstanceName:       global.instanceName,
-							Environment:        global.environment,
-							Severity:           "WARNING",
-							Message:            "browseFeedMessageGroupMembersFromCache",
-							Description:        fmt.Sprintf("log and move to next %s json.Marshal(feedMessageMember) %v", feedMessageMember.Asset.Name, err),
-							TriggeringPubsubID: global.PubSubID,
-						})
+						global.logger.Warning("browseFeedMessageGroupMembersFromCache", fmt.Sprintf("log and move to next %s json.Marshal(feedMessageMember) %v", feedMessageMember.Asset.Name, err))
 					} else {
 						pubSubMessage := &pubsub.Message{
-							Data: feedMessageMemberJSON,
+							Data:       feedMessageMemberJSON,
+							Attributes: traceAttributes,
 						}
 						publishResult := topic.Publish(ctx, pubSubMessage)
 						waitgroup.Add(1)
@@ -444,22 +302,11 @@ func browseFeedMessageGroupMembersFromCache(global *Global) (err error) {
 							&pubSubErrNumber,
 							&pubSubMsgNumber,
 							logEventEveryXPubSubMsg,
-							pubSubID,
-							microserviceName,
-							instanceName,
-							environment)
+							logger)
 					}
 				}
 			} else {
-				log.Println(glo.Entry{
-					MicroserviceName:   global.microserviceName,
-					InstanceName:       global.instanceName,
-					Environment:        global.environment,
-					Severity:           "WARNING",
-					Message:            "browseFeedMessageGroupMembersFromCache",
-					Description:        fmt.Sprintf("log and move to next document does not exists %s", documentSnap.Ref.Path),
-					TriggeringPubsubID: global.PubSubID,
-				})
+				global.logger.Warning("browseFeedMessageGroupMembersFromCache", fmt.Sprintf("log and move to next document does not exists %s", documentSnap.Ref.Path))
 			}
 		}
 	}
@@ -491,18 +338,11 @@ func browseMembers(members *admin.Members) error {
 		feedMessageMember.StepStack = stepStack
 		feedMessageMemberJSON, err := json.Marshal(feedMessageMember)
 		if err != nil {
-			log.Println(glo.Entry{
-				MicroserviceName:   microserviceName,
-				InstanceName:       instanceName,
-				Environment:        environment,
-				Severity:           "WARNING",
-				Message:            "browseMembers",
-				Description:        fmt.Sprintf("log and move to next %s json.Marshal(feedMessageMember) %v", member.Email, err),
-				TriggeringPubsubID: pubSubID,
-			})
+			logger.Warning("browseMembers", fmt.Sprintf("log and move to next %s json.Marshal(feedMessageMember) %v", member.Email, err))
 		} else {
 			pubSubMessage := &pubsub.Message{
-				Data: feedMessageMemberJSON,
+				Data:       feedMessageMemberJSON,
+				Attributes: traceAttributes,
 			}
 			publishResult := topic.Publish(ctx, pubSubMessage)
 			waitgroup.Add(1)
@@ -512,10 +352,7 @@ func browseMembers(members *admin.Members) error {
 				&pubSubErrNumber,
 				&pubSubMsgNumber,
 				logEventEveryXPubSubMsg,
-				pubSubID,
-				microserviceName,
-				instanceName,
-				environment)
+				logger)
 		}
 	}
 	waitgroup.Wait()
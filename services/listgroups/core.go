@@ -47,16 +47,14 @@ var ctx context.Context
 var directoryCustomerID string
 var domain string
 var emailPrefix string
-var environment string
-var instanceName string
 var logEventEveryXPubSubMsg uint64
-var microserviceName string
+var logger *glo.Logger
 var outputTopicName string
 var pubSubClient *pubsub.Client
 var pubSubErrNumber uint64
-var pubSubID string
 var pubSubMsgNumber uint64
 var stepStack glo.Steps
+var traceAttributes map[string]string
 
 // Global structure for global variables to optimize the cloud function performances
 type Global struct {
@@ -69,9 +67,11 @@ type Global struct {
 	instanceName            string
 	logEventEveryXPubSubMsg uint64
 	maxResultsPerPage       int64 // API Max = 200
+	logger                  *glo.Logger
 	microserviceName        string
 	outputTopicName         string
 	pubSubClient            *pubsub.Client
+	pubSubAttributes        map[string]string
 	PubSubID                string
 	retryTimeOutSeconds     int64
 	step                    glo.Step
@@ -111,14 +111,21 @@ func Initialize(ctx context.Context, global *Global) (err error) {
 	global.instanceName = instanceDeployment.Core.InstanceName
 	global.microserviceName = instanceDeployment.Core.ServiceName
 
-	log.Println(glo.Entry{
-		MicroserviceName: global.microserviceName,
-		InstanceName:     global.instanceName,
-		Environment:      global.environment,
-		Severity:         "NOTICE",
-		Message:          "coldstart",
-		InitID:           initID,
-	})
+	global.logger = glo.NewLogger(global.microserviceName, global.instanceName, global.environment, initID)
+	global.logger.SetLogParameters(glo.GetLogParameters(instanceDeployment.Settings.Service.GLO, instanceDeployment.Settings.Instance.GLO))
+	global.logger.Notice("coldstart", "")
+
+	err = glo.InitTracing(ctx,
+		instanceDeployment.Core.SolutionSettings.Hosting.Tracing.Exporter,
+		instanceDeployment.Core.SolutionSettings.Hosting.Tracing.OTLPEndpoint,
+		instanceDeployment.Core.SolutionSettings.Hosting.Tracing.FilePath,
+		global.microserviceName,
+		global.instanceName,
+		global.environment)
+	if err != nil {
+		global.logger.InitFailed(fmt.Sprintf("glo.InitTracing %v", err))
+		return err
+	}
 
 	gciAdminUserToImpersonate := instanceDeployment.Settings.Instance.GCI.SuperAdminEmail
 	global.directoryCustomerID = instanceDeployment.Settings.Instance.GCI.DirectoryCustomerID
@@ -135,29 +142,13 @@ func Initialize(ctx context.Context, global *Global) (err error) {
 
 	global.firestoreClient, err = firestore.NewClient(global.ctx, projectID)
 	if err != nil {
-		log.Println(glo.Entry{
-			MicroserviceName: global.microserviceName,
-			InstanceName:     global.instanceName,
-			Environment:      global.environment,
-			Severity:         "CRITICAL",
-			Message:          "init_failed",
-			Description:      fmt.Sprintf("firestore.NewClient %v", err),
-			InitID:           initID,
-		})
+		global.logger.InitFailed(fmt.Sprintf("firestore.NewClient %v", err))
 		return err
 	}
 
 	serviceAccountKeyNames, err := gfs.ListKeyNames(ctx, global.firestoreClient, instanceDeployment.Core.ServiceName)
 	if err != nil {
-		log.Println(glo.Entry{
-			MicroserviceName: global.microserviceName,
-			InstanceName:     global.instanceName,
-			Environment:      global.environment,
-			Severity:         "CRITICAL",
-			Message:          "init_failed",
-			Description:      fmt.Sprintf("gfs.ListKeyNames %v", err),
-			InitID:           initID,
-		})
+		global.logger.InitFailed(fmt.Sprintf("gfs.ListKeyNames %v", err))
 		return err
 	}
 
@@ -168,36 +159,17 @@ func Initialize(ctx context.Context, global *Global) (err error) {
 		gciAdminUserToImpersonate,
 		[]string{admin.AdminDirectoryGroupReadonlyScope, admin.AdminDirectoryDomainReadonlyScope},
 		serviceAccountKeyNames,
-		initID,
-		global.microserviceName,
-		global.instanceName,
-		global.environment); !ok {
+		global.logger); !ok {
 		return fmt.Errorf("aut.GetClientOptionAndCleanKeys")
 	}
 	global.dirAdminService, err = admin.NewService(ctx, clientOption)
 	if err != nil {
-		log.Println(glo.Entry{
-			MicroserviceName: global.microserviceName,
-			InstanceName:     global.instanceName,
-			Environment:      global.environment,
-			Severity:         "CRITICAL",
-			Message:          "init_failed",
-			Description:      fmt.Sprintf("admin.NewService %v", err),
-			InitID:           initID,
-		})
+		global.logger.InitFailed(fmt.Sprintf("admin.NewService %v", err))
 		return err
 	}
 	global.pubSubClient, err = pubsub.NewClient(ctx, projectID)
 	if err != nil {
-		log.Println(glo.Entry{
-			MicroserviceName: global.microserviceName,
-			InstanceName:     global.instanceName,
-			Environment:      global.environment,
-			Severity:         "CRITICAL",
-			Message:          "init_failed",
-			Description:      fmt.Sprintf("pubsub.NewClient %v", err),
-			InitID:           initID,
-		})
+		global.logger.InitFailed(fmt.Sprintf("pubsub.NewClient %v", err))
 		return err
 	}
 	return nil
@@ -209,15 +181,7 @@ func EntryPoint(ctxEvent context.Context, PubSubMessage gps.PubSubMessage, globa
 	metadata, err := metadata.FromContext(ctxEvent)
 	if err != nil {
 		// Assume an error on the function invoker and try again.
-		log.Println(glo.Entry{
-			MicroserviceName:   global.microserviceName,
-			InstanceName:       global.instanceName,
-			Environment:        global.environment,
-			Severity:           "CRITICAL",
-			Message:            "redo_on_transient",
-			Description:        fmt.Sprintf("pubsub_id no available metadata.FromContext: %v", err),
-			TriggeringPubsubID: global.PubSubID,
-		})
+		global.logger.RedoOnTransient(fmt.Sprintf("pubsub_id no available metadata.FromContext: %v", err))
 		return err
 	}
 	global.stepStack = nil
@@ -227,34 +191,16 @@ func EntryPoint(ctxEvent context.Context, PubSubMessage gps.PubSubMessage, globa
 		StepID:        fmt.Sprintf("%s/%s", parts[len(parts)-1], global.PubSubID),
 		StepTimestamp: metadata.Timestamp,
 	}
+	global.logger.SetInvocation(global.PubSubID, global.step)
+	global.pubSubAttributes = PubSubMessage.Attributes
+	defer func() {
+		glo.RecordStepSpan(global.ctx, global.microserviceName, global.pubSubAttributes, global.stepStack)
+	}()
 
-	now := time.Now()
-	d := now.Sub(metadata.Timestamp)
-	log.Println(glo.Entry{
-		MicroserviceName:           global.microserviceName,
-		InstanceName:               global.instanceName,
-		Environment:                global.environment,
-		Severity:                   "NOTICE",
-		Message:                    "start",
-		TriggeringPubsubID:         global.PubSubID,
-		TriggeringPubsubAgeSeconds: d.Seconds(),
-		TriggeringPubsubTimestamp:  &metadata.Timestamp,
-		Now:                        &now,
-	})
+	d := global.logger.Start()
 
 	if d.Seconds() > float64(global.retryTimeOutSeconds) {
-		log.Println(glo.Entry{
-			MicroserviceName:           global.microserviceName,
-			InstanceName:               global.instanceName,
-			Environment:                global.environment,
-			Severity:                   "CRITICAL",
-			Message:                    "noretry",
-			Description:                "Pubsub message too old",
-			TriggeringPubsubID:         global.PubSubID,
-			TriggeringPubsubAgeSeconds: d.Seconds(),
-			TriggeringPubsubTimestamp:  &metadata.Timestamp,
-			Now:                        &now,
-		})
+		global.logger.NoRetryTooOld()
 		return nil
 	}
 
@@ -264,70 +210,27 @@ func EntryPoint(ctxEvent context.Context, PubSubMessage gps.PubSubMessage, globa
 	logEventEveryXPubSubMsg = global.logEventEveryXPubSubMsg
 	pubSubClient = global.pubSubClient
 	outputTopicName = global.outputTopicName
-	pubSubID = global.PubSubID
-	microserviceName = global.microserviceName
-	instanceName = global.instanceName
-	environment = global.environment
+	logger = global.logger
 
 	if strings.HasPrefix(string(PubSubMessage.Data), "cron schedule") {
 		global.stepStack = append(global.stepStack, global.step)
+		global.logger.SetStepStack(global.stepStack)
 
 		err = initiateQueries(global)
 		if err != nil {
-			log.Println(glo.Entry{
-				MicroserviceName:   global.microserviceName,
-				InstanceName:       global.instanceName,
-				Environment:        global.environment,
-				Severity:           "CRITICAL",
-				Message:            "redo_on_transient",
-				Description:        fmt.Sprintf("initiateQueries %v", err),
-				TriggeringPubsubID: global.PubSubID,
-			})
+			global.logger.RedoOnTransient(fmt.Sprintf("initiateQueries %v", err))
 			return err
 		}
-		now := time.Now()
-		latency := now.Sub(metadata.Timestamp)
-		latencyE2E := now.Sub(global.stepStack[0].StepTimestamp)
-		log.Println(glo.Entry{
-			MicroserviceName:     global.microserviceName,
-			InstanceName:         global.instanceName,
-			Environment:          global.environment,
-			Severity:             "NOTICE",
-			Message:              "finish launching sub queries",
-			Description:          "Pubsub messages published to reentrant topic to initiate sub queries",
-			Now:                  &now,
-			TriggeringPubsubID:   global.PubSubID,
-			OriginEventTimestamp: &metadata.Timestamp,
-			LatencySeconds:       latency.Seconds(),
-			LatencyE2ESeconds:    latencyE2E.Seconds(),
-			StepStack:            global.stepStack,
-			AssetInventoryOrigin: "batch-listgroups",
-		})
+		global.logger.Finish("finish launching sub queries", "Pubsub messages published to reentrant topic to initiate sub queries", "batch-listgroups")
 	} else {
 		var settings Settings
 		err = json.Unmarshal(PubSubMessage.Data, &settings)
 		if err != nil {
-			log.Println(glo.Entry{
-				MicroserviceName:   global.microserviceName,
-				InstanceName:       global.instanceName,
-				Environment:        global.environment,
-				Severity:           "CRITICAL",
-				Message:            "noretry",
-				Description:        fmt.Sprintf("json.Unmarshal(PubSubMessage.Data, &settings) %v %v", PubSubMessage.Data, err),
-				TriggeringPubsubID: global.PubSubID,
-			})
+			global.logger.NoRetry(fmt.Sprintf("json.Unmarshal(PubSubMessage.Data, &settings) %v %v", PubSubMessage.Data, err))
 			return nil
 		}
 		if settings.DirectoryCustomerID != directoryCustomerID {
-			log.Println(glo.Entry{
-				MicroserviceName:   global.microserviceName,
-				InstanceName:       global.instanceName,
-				Environment:        global.environment,
-				Severity:           "INFO",
-				Message:            "ignore this trigerring event",
-				Description:        fmt.Sprintf("as directoryCustomerID %s not equal to this instance directoryCustomerID %s", settings.DirectoryCustomerID, directoryCustomerID),
-				TriggeringPubsubID: global.PubSubID,
-			})
+			global.logger.Info("ignore this trigerring event", fmt.Sprintf("as directoryCustomerID %s not equal to this instance directoryCustomerID %s", settings.DirectoryCustomerID, directoryCustomerID))
 		} else {
 			domain = settings.Domain
 			emailPrefix = settings.EmailPrefix
@@ -336,32 +239,18 @@ func EntryPoint(ctxEvent context.Context, PubSubMessage gps.PubSubMessage, globa
 			} else {
 				global.stepStack = append(global.stepStack, global.step)
 			}
+			global.logger.SetStepStack(global.stepStack)
 			stepStack = global.stepStack // as a global variable used in the browse function
+			traceAttributes = glo.GetTraceAttributes(global.pubSubAttributes, global.stepStack)
 
 			err = queryDirectory(settings.Domain, settings.EmailPrefix, global)
 			if err != nil {
 				if strings.Contains(strings.ToLower(err.Error()), "quota") {
-					log.Println(glo.Entry{
-						MicroserviceName:   global.microserviceName,
-						InstanceName:       global.instanceName,
-						Environment:        global.environment,
-						Severity:           "WARNING",
-						Message:            fmt.Sprintf("waiting_on_quota_exceeded"),
-						Description:        fmt.Sprintf("ListGroup quota is gone, wait for %d seconds then retry", waitSecOnQuotaExceeded),
-						TriggeringPubsubID: global.PubSubID,
-					})
+					global.logger.Warning(fmt.Sprintf("waiting_on_quota_exceeded"), fmt.Sprintf("ListGroup quota is gone, wait for %d seconds then retry", waitSecOnQuotaExceeded))
 					time.Sleep(waitSecOnQuotaExceeded * time.Second)
 					return err
 				}
-				log.Println(glo.Entry{
-					MicroserviceName:   global.microserviceName,
-					InstanceName:       global.instanceName,
-					Environment:        global.environment,
-					Severity:           "CRITICAL",
-					Message:            "redo_on_transient",
-					Description:        fmt.Sprintf("queryDirectory %v", err),
-					TriggeringPubsubID: global.PubSubID,
-				})
+				global.logger.RedoOnTransient(fmt.Sprintf("queryDirectory %v", err))
 				return err
 			}
 		}
@@ -374,15 +263,7 @@ func initiateQueries(global *Global) error {
 	alphabetLower := getByteSet('a', 26)
 
 	emailAuthorizedByteSet := append(figures, alphabetLower...)
-	log.Println(glo.Entry{
-		MicroserviceName:   global.microserviceName,
-		InstanceName:       global.instanceName,
-		Environment:        global.environment,
-		Severity:           "INFO",
-		Message:            "initiate multiple queries",
-		Description:        fmt.Sprintf("emailAuthorizedByteSet %s", string(emailAuthorizedByteSet)),
-		TriggeringPubsubID: global.PubSubID,
-	})
+	global.logger.Info("initiate multiple queries", fmt.Sprintf("emailAuthorizedByteSet %s", string(emailAuthorizedByteSet)))
 
 	domains, err := global.dirAdminService.Domains.List(global.directoryCustomerID).Context(global.ctx).Do()
 	if err != nil {
@@ -397,41 +278,18 @@ func initiateQueries(global *Global) error {
 			settings.StepStack = global.stepStack
 			settingsJSON, err := json.Marshal(settings)
 			if err != nil {
-				log.Println(glo.Entry{
-					MicroserviceName:   global.microserviceName,
-					InstanceName:       global.instanceName,
-					Environment:        global.environment,
-					Severity:           "WARNING",
-					Message:            "json.Marshal(settings)",
-					Description:        fmt.Sprintf("settings %v", settings),
-					TriggeringPubsubID: global.PubSubID,
-				})
+				global.logger.Warning("json.Marshal(settings)", fmt.Sprintf("settings %v", settings))
 			} else {
 				pubSubMessage := &pubsub.Message{
-					Data: settingsJSON,
+					Data:       settingsJSON,
+					Attributes: glo.GetTraceAttributes(global.pubSubAttributes, global.stepStack),
 				}
 				topic := global.pubSubClient.Topic(global.inputTopicName)
 				id, err := topic.Publish(global.ctx, pubSubMessage).Get(global.ctx)
 				if err != nil {
-					log.Println(glo.Entry{
-						MicroserviceName:   global.microserviceName,
-						InstanceName:       global.instanceName,
-						Environment:        global.environment,
-						Severity:           "WARNING",
-						Message:            "topic.Publish",
-						Description:        fmt.Sprintf("pubSubMessage %v", pubSubMessage),
-						TriggeringPubsubID: global.PubSubID,
-					})
+					global.logger.Warning("topic.Publish", fmt.Sprintf("pubSubMessage %v", pubSubMessage))
 				} else {
-					log.Println(glo.Entry{
-						MicroserviceName:   global.microserviceName,
-						InstanceName:       global.instanceName,
-						Environment:        global.environment,
-						Severity:           "INFO",
-						Message:            "Pubsub msg published to reentrant topic",
-						Description:        fmt.Sprintf("initiate sub query: domain '%s' emailPrefix '%s' to topic %s msg id: %s", settings.Domain, settings.EmailPrefix, global.inputTopicName, id),
-						TriggeringPubsubID: global.PubSubID,
-					})
+					global.logger.Info("Pubsub msg published to reentrant topic", fmt.Sprintf("initiate sub query: domain '%s' emailPrefix '%s' to topic %s msg id: %s", settings.Domain, settings.EmailPrefix, global.inputTopicName, id))
 				}
 			}
 		}
@@ -440,14 +298,7 @@ func initiateQueries(global *Global) error {
 }
 
 func queryDirectory(domain string, emailPrefix string, global *Global) error {
-	log.Println(glo.Entry{
-		MicroserviceName:   global.microserviceName,
-		InstanceName:       global.instanceName,
-		Environment:        global.environment,
-		Severity:           "INFO",
-		Message:            fmt.Sprintf("settings retrieved, launch query on domain '%s' and email prefix '%s'", domain, emailPrefix),
-		TriggeringPubsubID: global.PubSubID,
-	})
+	global.logger.Info(fmt.Sprintf("settings retrieved, launch query on domain '%s' and email prefix '%s'", domain, emailPrefix), "")
 	pubSubMsgNumber = 0
 	pubSubErrNumber = 0
 	query := fmt.Sprintf("email:%s*", emailPrefix)
@@ -456,75 +307,18 @@ func queryDirectory(domain string, emailPrefix string, global *Global) error {
 	err := global.dirAdminService.Groups.List().Customer(global.directoryCustomerID).Domain(domain).Query(query).MaxResults(global.maxResultsPerPage).OrderBy("email").Pages(global.ctx, browseGroups)
 	if err != nil {
 		if strings.Contains(err.Error(), "Domain not found") {
-			now := time.Now()
-			latency := now.Sub(global.step.StepTimestamp)
-			latencyE2E := now.Sub(global.stepStack[0].StepTimestamp)
-			log.Println(glo.Entry{
-				MicroserviceName:     global.microserviceName,
-				InstanceName:         global.instanceName,
-				Environment:          global.environment,
-				Severity:             "NOTICE",
-				Message:              "cancel",
-				Description:          fmt.Sprintf("domain not found %s query %s customer ID %s", domain, query, global.directoryCustomerID),
-				Now:                  &now,
-				TriggeringPubsubID:   global.PubSubID,
-				OriginEventTimestamp: &global.stepStack[0].StepTimestamp,
-				LatencySeconds:       latency.Seconds(),
-				LatencyE2ESeconds:    latencyE2E.Seconds(),
-				StepStack:            global.stepStack,
-			})
+			global.logger.Cancel(fmt.Sprintf("domain not found %s query %s customer ID %s", domain, query, global.directoryCustomerID))
 		} else {
 			return fmt.Errorf("dirAdminService.Groups.List: %v", err)
 		}
 	}
 	if pubSubMsgNumber > 0 {
-		now := time.Now()
-		latency := now.Sub(global.step.StepTimestamp)
-		latencyE2E := now.Sub(global.stepStack[0].StepTimestamp)
-		log.Println(glo.Entry{
-			MicroserviceName:     global.microserviceName,
-			InstanceName:         global.instanceName,
-			Environment:          global.environment,
-			Severity:             "NOTICE",
-			Message:              fmt.Sprintf("finish %d groups", pubSubMsgNumber),
-			Description:          fmt.Sprintf("directory %s domain '%s' emailPrefix '%s' Number of groups published %d to topic %s", directoryCustomerID, domain, emailPrefix, pubSubMsgNumber, outputTopicName),
-			Now:                  &now,
-			TriggeringPubsubID:   global.PubSubID,
-			OriginEventTimestamp: &global.stepStack[0].StepTimestamp,
-			LatencySeconds:       latency.Seconds(),
-			LatencyE2ESeconds:    latencyE2E.Seconds(),
-			StepStack:            global.stepStack,
-			AssetInventoryOrigin: "batch-listgroups",
-		})
+		global.logger.Finish(fmt.Sprintf("finish %d groups", pubSubMsgNumber), fmt.Sprintf("directory %s domain '%s' emailPrefix '%s' Number of groups published %d to topic %s", directoryCustomerID, domain, emailPrefix, pubSubMsgNumber, outputTopicName), "batch-listgroups")
 	} else {
-		now := time.Now()
-		latency := now.Sub(global.step.StepTimestamp)
-		latencyE2E := now.Sub(global.stepStack[0].StepTimestamp)
-		log.Println(glo.Entry{
-			MicroserviceName:     global.microserviceName,
-			InstanceName:         global.instanceName,
-			Environment:          global.environment,
-			Severity:             "NOTICE",
-			Message:              "cancel",
-			Description:          fmt.Sprintf("no group found for directory %s domain '%s' emailPrefix '%s'", directoryCustomerID, domain, emailPrefix),
-			Now:                  &now,
-			TriggeringPubsubID:   global.PubSubID,
-			OriginEventTimestamp: &global.stepStack[0].StepTimestamp,
-			LatencySeconds:       latency.Seconds(),
-			LatencyE2ESeconds:    latencyE2E.Seconds(),
-			StepStack:            global.stepStack,
-		})
+		global.logger.Cancel(fmt.Sprintf("no group found for directory %s domain '%s' emailPrefix '%s'", directoryCustomerID, domain, emailPrefix))
 	}
 	if pubSubErrNumber > 0 {
-		log.Println(glo.Entry{
-			MicroserviceName:   global.microserviceName,
-			InstanceName:       global.instanceName,
-			Environment:        global.environment,
-			Severity:           "WARNING",
-			Message:            "some pubsub messages did not publish successfully",
-			Description:        fmt.Sprintf("number of droped pussub messages %d", pubSubErrNumber),
-			TriggeringPubsubID: pubSubID,
-		})
+		global.logger.Warning("some pubsub messages did not publish successfully", fmt.Sprintf("number of droped pussub messages %d", pubSubErrNumber))
 	}
 	return nil
 }
@@ -550,18 +344,11 @@ func browseGroups(groups *admin.Groups) error {
 		feedMessage.StepStack = stepStack
 		feedMessageJSON, err := json.Marshal(feedMessage)
 		if err != nil {
-			log.Println(glo.Entry{
-				MicroserviceName:   microserviceName,
-				InstanceName:       instanceName,
-				Environment:        environment,
-				Severity:           "WARNING",
-				Message:            "json.Marshal(settings)",
-				Description:        fmt.Sprintf("feedMessage %v", feedMessage),
-				TriggeringPubsubID: pubSubID,
-			})
+			logger.Warning("json.Marshal(settings)", fmt.Sprintf("feedMessage %v", feedMessage))
 		} else {
 			pubSubMessage := &pubsub.Message{
-				Data: feedMessageJSON,
+				Data:       feedMessageJSON,
+				Attributes: traceAttributes,
 			}
 			publishResult := topic.Publish(ctx, pubSubMessage)
 			waitgroup.Add(1)
@@ -572,10 +359,7 @@ func browseGroups(groups *admin.Groups) error {
 				&pubSubErrNumber,
 				&pubSubMsgNumber,
 				logEventEveryXPubSubMsg,
-				pubSubID,
-				microserviceName,
-				instanceName,
-				environment)
+				logger)
 		}
 	}
 	waitgroup.Wait()
@@ -24,5 +24,13 @@ func (instanceDeployment *InstanceDeployment) deploySCHJob() (err error) {
 	jobDeployment.Artifacts.JobName = instanceDeployment.Artifacts.JobName
 	jobDeployment.Artifacts.Schedule = instanceDeployment.Artifacts.Schedule
 	jobDeployment.Artifacts.TopicName = instanceDeployment.Artifacts.TopicName
+	scheduler := instanceDeployment.Settings.Instance.SCH.Schedulers[instanceDeployment.Core.EnvironmentName]
+	if scheduler.TimeZone != "" {
+		jobDeployment.Settings.TimeZone = scheduler.TimeZone
+	}
+	jobDeployment.Settings.RetryCount = scheduler.RetryCount
+	if scheduler.MaxRetryDuration != "" {
+		jobDeployment.Settings.MaxRetryDuration = scheduler.MaxRetryDuration
+	}
 	return jobDeployment.Deploy()
 }
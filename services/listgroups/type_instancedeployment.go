@@ -20,6 +20,7 @@ import (
 	"github.com/BrunoReboul/ram/utilities/deploy"
 	"github.com/BrunoReboul/ram/utilities/gcb"
 	"github.com/BrunoReboul/ram/utilities/gcf"
+	"github.com/BrunoReboul/ram/utilities/glo"
 	"github.com/BrunoReboul/ram/utilities/gsu"
 	"github.com/BrunoReboul/ram/utilities/iamgt"
 	"github.com/BrunoReboul/ram/utilities/sch"
@@ -42,6 +43,7 @@ type InstanceDeployment struct {
 			IAM                     iamgt.Parameters
 			GCB                     gcb.Parameters
 			GCF                     gcf.Parameters
+			GLO                     glo.LogParameters
 			KeyJSONFileName         string `yaml:"keyJSONFileName"`
 			LogEventEveryXPubSubMsg uint64 `yaml:"logEventEveryXPubSubMsg"`
 			MaxResultsPerPage       int64  `yaml:"maxResultsPerPage"`
@@ -49,9 +51,10 @@ type InstanceDeployment struct {
 		Instance struct {
 			GCI struct {
 				DirectoryCustomerID string `yaml:"directoryCustomerID"`
-				SuperAdminEmail     string `yaml:"superAdminEmail"`
+				SuperAdminEmail     string `yaml:"superAdminEmail" valid:"isEmail"`
 			}
 			SCH sch.Parameters
+			GLO glo.LogParameters
 		}
 	}
 }
@@ -125,6 +128,7 @@ func projectDeployCoreRole() (role iam.Role) {
 		"pubsub.topics.update",
 		"cloudscheduler.jobs.get",
 		"cloudscheduler.jobs.create",
+		"cloudscheduler.jobs.update",
 		"cloudfunctions.functions.sourceCodeSet",
 		"cloudfunctions.functions.get",
 		"cloudfunctions.functions.create",
@@ -0,0 +1,376 @@
+// Copyright 2020 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the 'License');
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an 'AS IS' BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package listusers
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/BrunoReboul/ram/utilities/aut"
+	"github.com/BrunoReboul/ram/utilities/cai"
+	"github.com/BrunoReboul/ram/utilities/ffo"
+	"github.com/BrunoReboul/ram/utilities/gfs"
+	"github.com/BrunoReboul/ram/utilities/glo"
+	"github.com/BrunoReboul/ram/utilities/gps"
+	"github.com/BrunoReboul/ram/utilities/solution"
+	"github.com/google/uuid"
+	"google.golang.org/api/option"
+
+	"cloud.google.com/go/firestore"
+	"cloud.google.com/go/functions/metadata"
+	"cloud.google.com/go/pubsub"
+	admin "google.golang.org/api/admin/directory/v1"
+)
+
+const waitSecOnQuotaExceeded = 70
+
+// Global variable to deal with UsersListCall Pages constraint: no possible to pass variable to the function in pages()
+// https://pkg.go.dev/google.golang.org/api/admin/directory/v1?tab=doc#UsersListCall.Pages
+var ctx context.Context
+var directoryCustomerID string
+var domain string
+var emailPrefix string
+var logEventEveryXPubSubMsg uint64
+var logger *glo.Logger
+var outputTopicName string
+var pubSubClient *pubsub.Client
+var pubSubErrNumber uint64
+var pubSubMsgNumber uint64
+var stepStack glo.Steps
+var traceAttributes map[string]string
+
+// Global structure for global variables to optimize the cloud function performances
+type Global struct {
+	ctx                     context.Context
+	dirAdminService         *admin.Service
+	directoryCustomerID     string
+	environment             string
+	firestoreClient         *firestore.Client
+	inputTopicName          string
+	instanceName            string
+	logEventEveryXPubSubMsg uint64
+	maxResultsPerPage       int64 // API Max = 500
+	logger                  *glo.Logger
+	microserviceName        string
+	outputTopicName         string
+	pubSubClient            *pubsub.Client
+	pubSubAttributes        map[string]string
+	PubSubID                string
+	retryTimeOutSeconds     int64
+	step                    glo.Step
+	stepStack               glo.Steps
+}
+
+// Settings from PubSub triggering event
+type Settings struct {
+	DirectoryCustomerID string    `json:"directoryCustomerID"`
+	Domain              string    `json:"domain"`
+	EmailPrefix         string    `json:"emailPrefix"`
+	StepStack           glo.Steps `json:"step_stack,omitempty"`
+}
+
+// Initialize is to be executed in the init() function of the cloud function to optimize the cold start
+func Initialize(ctx context.Context, global *Global) (err error) {
+	log.SetFlags(0)
+	global.ctx = ctx
+
+	var instanceDeployment InstanceDeployment
+	var clientOption option.ClientOption
+	var ok bool
+
+	initID := fmt.Sprintf("%v", uuid.New())
+	err = ffo.ReadUnmarshalYAML(solution.PathToFunctionCode+solution.SettingsFileName, &instanceDeployment)
+	if err != nil {
+		log.Println(glo.Entry{
+			Severity:    "CRITICAL",
+			Message:     "init_failed",
+			Description: fmt.Sprintf("ReadUnmarshalYAML %s %v", solution.SettingsFileName, err),
+			InitID:      initID,
+		})
+		return err
+	}
+
+	global.environment = instanceDeployment.Core.EnvironmentName
+	global.instanceName = instanceDeployment.Core.InstanceName
+	global.microserviceName = instanceDeployment.Core.ServiceName
+
+	global.logger = glo.NewLogger(global.microserviceName, global.instanceName, global.environment, initID)
+	global.logger.SetLogParameters(glo.GetLogParameters(instanceDeployment.Settings.Service.GLO, instanceDeployment.Settings.Instance.GLO))
+	global.logger.Notice("coldstart", "")
+
+	err = glo.InitTracing(ctx,
+		instanceDeployment.Core.SolutionSettings.Hosting.Tracing.Exporter,
+		instanceDeployment.Core.SolutionSettings.Hosting.Tracing.OTLPEndpoint,
+		instanceDeployment.Core.SolutionSettings.Hosting.Tracing.FilePath,
+		global.microserviceName,
+		global.instanceName,
+		global.environment)
+	if err != nil {
+		global.logger.InitFailed(fmt.Sprintf("glo.InitTracing %v", err))
+		return err
+	}
+
+	gciAdminUserToImpersonate := instanceDeployment.Settings.Instance.GCI.SuperAdminEmail
+	global.directoryCustomerID = instanceDeployment.Settings.Instance.GCI.DirectoryCustomerID
+	global.inputTopicName = instanceDeployment.Artifacts.TopicName
+	global.logEventEveryXPubSubMsg = instanceDeployment.Settings.Service.LogEventEveryXPubSubMsg
+	global.maxResultsPerPage = instanceDeployment.Settings.Service.MaxResultsPerPage
+	global.outputTopicName = instanceDeployment.Artifacts.OutputTopicName
+	global.retryTimeOutSeconds = instanceDeployment.Settings.Service.GCF.RetryTimeOutSeconds
+	projectID := instanceDeployment.Core.SolutionSettings.Hosting.ProjectID
+	keyJSONFilePath := solution.PathToFunctionCode + instanceDeployment.Settings.Service.KeyJSONFileName
+	serviceAccountEmail := fmt.Sprintf("%s@%s.iam.gserviceaccount.com",
+		instanceDeployment.Core.ServiceName,
+		instanceDeployment.Core.SolutionSettings.Hosting.ProjectID)
+
+	global.firestoreClient, err = firestore.NewClient(global.ctx, projectID)
+	if err != nil {
+		global.logger.InitFailed(fmt.Sprintf("firestore.NewClient %v", err))
+		return err
+	}
+
+	serviceAccountKeyNames, err := gfs.ListKeyNames(ctx, global.firestoreClient, instanceDeployment.Core.ServiceName)
+	if err != nil {
+		global.logger.InitFailed(fmt.Sprintf("gfs.ListKeyNames %v", err))
+		return err
+	}
+
+	if clientOption, ok = aut.GetClientOptionAndCleanKeys(ctx,
+		serviceAccountEmail,
+		keyJSONFilePath,
+		instanceDeployment.Core.SolutionSettings.Hosting.ProjectID,
+		gciAdminUserToImpersonate,
+		[]string{admin.AdminDirectoryUserReadonlyScope, admin.AdminDirectoryDomainReadonlyScope},
+		serviceAccountKeyNames,
+		global.logger); !ok {
+		return fmt.Errorf("aut.GetClientOptionAndCleanKeys")
+	}
+	global.dirAdminService, err = admin.NewService(ctx, clientOption)
+	if err != nil {
+		global.logger.InitFailed(fmt.Sprintf("admin.NewService %v", err))
+		return err
+	}
+	global.pubSubClient, err = pubsub.NewClient(ctx, projectID)
+	if err != nil {
+		global.logger.InitFailed(fmt.Sprintf("pubsub.NewClient %v", err))
+		return err
+	}
+	return nil
+}
+
+// EntryPoint is the function to be executed for each cloud function occurence
+func EntryPoint(ctxEvent context.Context, PubSubMessage gps.PubSubMessage, global *Global) error {
+	// log.Println(string(PubSubMessage.Data))
+	metadata, err := metadata.FromContext(ctxEvent)
+	if err != nil {
+		// Assume an error on the function invoker and try again.
+		global.logger.RedoOnTransient(fmt.Sprintf("pubsub_id no available metadata.FromContext: %v", err))
+		return err
+	}
+	global.stepStack = nil
+	global.PubSubID = metadata.EventID
+	parts := strings.Split(metadata.Resource.Name, "/")
+	global.step = glo.Step{
+		StepID:        fmt.Sprintf("%s/%s", parts[len(parts)-1], global.PubSubID),
+		StepTimestamp: metadata.Timestamp,
+	}
+	global.logger.SetInvocation(global.PubSubID, global.step)
+	global.pubSubAttributes = PubSubMessage.Attributes
+	defer func() {
+		glo.RecordStepSpan(global.ctx, global.microserviceName, global.pubSubAttributes, global.stepStack)
+	}()
+
+	d := global.logger.Start()
+
+	if d.Seconds() > float64(global.retryTimeOutSeconds) {
+		global.logger.NoRetryTooOld()
+		return nil
+	}
+
+	// Pass data to global variables to deal with func browseUsers
+	ctx = global.ctx
+	directoryCustomerID = global.directoryCustomerID
+	logEventEveryXPubSubMsg = global.logEventEveryXPubSubMsg
+	pubSubClient = global.pubSubClient
+	outputTopicName = global.outputTopicName
+	logger = global.logger
+
+	if strings.HasPrefix(string(PubSubMessage.Data), "cron schedule") {
+		global.stepStack = append(global.stepStack, global.step)
+		global.logger.SetStepStack(global.stepStack)
+
+		err = initiateQueries(global)
+		if err != nil {
+			global.logger.RedoOnTransient(fmt.Sprintf("initiateQueries %v", err))
+			return err
+		}
+		global.logger.Finish("finish launching sub queries", "Pubsub messages published to reentrant topic to initiate sub queries", "batch-listusers")
+	} else {
+		var settings Settings
+		err = json.Unmarshal(PubSubMessage.Data, &settings)
+		if err != nil {
+			global.logger.NoRetry(fmt.Sprintf("json.Unmarshal(PubSubMessage.Data, &settings) %v %v", PubSubMessage.Data, err))
+			return nil
+		}
+		if settings.DirectoryCustomerID != directoryCustomerID {
+			global.logger.Info("ignore this trigerring event", fmt.Sprintf("as directoryCustomerID %s not equal to this instance directoryCustomerID %s", settings.DirectoryCustomerID, directoryCustomerID))
+		} else {
+			domain = settings.Domain
+			emailPrefix = settings.EmailPrefix
+			if settings.StepStack != nil {
+				global.stepStack = append(settings.StepStack, global.step)
+			} else {
+				global.stepStack = append(global.stepStack, global.step)
+			}
+			global.logger.SetStepStack(global.stepStack)
+			stepStack = global.stepStack // as a global variable used in the browse function
+			traceAttributes = glo.GetTraceAttributes(global.pubSubAttributes, global.stepStack)
+
+			err = queryDirectory(settings.Domain, settings.EmailPrefix, global)
+			if err != nil {
+				if strings.Contains(strings.ToLower(err.Error()), "quota") {
+					global.logger.Warning(fmt.Sprintf("waiting_on_quota_exceeded"), fmt.Sprintf("ListUsers quota is gone, wait for %d seconds then retry", waitSecOnQuotaExceeded))
+					time.Sleep(waitSecOnQuotaExceeded * time.Second)
+					return err
+				}
+				global.logger.RedoOnTransient(fmt.Sprintf("queryDirectory %v", err))
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+func initiateQueries(global *Global) error {
+	figures := getByteSet('0', 10)
+	alphabetLower := getByteSet('a', 26)
+
+	emailAuthorizedByteSet := append(figures, alphabetLower...)
+	global.logger.Info("initiate multiple queries", fmt.Sprintf("emailAuthorizedByteSet %s", string(emailAuthorizedByteSet)))
+
+	domains, err := global.dirAdminService.Domains.List(global.directoryCustomerID).Context(global.ctx).Do()
+	if err != nil {
+		return fmt.Errorf("dirAdminService.Domains.List: %v", err)
+	}
+	for _, domain := range domains.Domains {
+		for _, emailPrefix := range emailAuthorizedByteSet {
+			var settings Settings
+			settings.DirectoryCustomerID = global.directoryCustomerID
+			settings.Domain = domain.DomainName
+			settings.EmailPrefix = string(emailPrefix)
+			settings.StepStack = global.stepStack
+			settingsJSON, err := json.Marshal(settings)
+			if err != nil {
+				global.logger.Warning("json.Marshal(settings)", fmt.Sprintf("settings %v", settings))
+			} else {
+				pubSubMessage := &pubsub.Message{
+					Data:       settingsJSON,
+					Attributes: glo.GetTraceAttributes(global.pubSubAttributes, global.stepStack),
+				}
+				topic := global.pubSubClient.Topic(global.inputTopicName)
+				id, err := topic.Publish(global.ctx, pubSubMessage).Get(global.ctx)
+				if err != nil {
+					global.logger.Warning("topic.Publish", fmt.Sprintf("pubSubMessage %v", pubSubMessage))
+				} else {
+					global.logger.Info("Pubsub msg published to reentrant topic", fmt.Sprintf("initiate sub query: domain '%s' emailPrefix '%s' to topic %s msg id: %s", settings.Domain, settings.EmailPrefix, global.inputTopicName, id))
+				}
+			}
+		}
+	}
+	return nil
+}
+
+func queryDirectory(domain string, emailPrefix string, global *Global) error {
+	global.logger.Info(fmt.Sprintf("settings retrieved, launch query on domain '%s' and email prefix '%s'", domain, emailPrefix), "")
+	pubSubMsgNumber = 0
+	pubSubErrNumber = 0
+	query := fmt.Sprintf("email:%s*", emailPrefix)
+	// log.Printf("query: %s", query)
+	// pages function expect just the name of the callback function. Not an invocation of the function
+	err := global.dirAdminService.Users.List().Customer(global.directoryCustomerID).Domain(domain).Query(query).MaxResults(global.maxResultsPerPage).OrderBy("email").Pages(global.ctx, browseUsers)
+	if err != nil {
+		if strings.Contains(err.Error(), "Domain not found") {
+			global.logger.Cancel(fmt.Sprintf("domain not found %s query %s customer ID %s", domain, query, global.directoryCustomerID))
+		} else {
+			return fmt.Errorf("dirAdminService.Users.List: %v", err)
+		}
+	}
+	if pubSubMsgNumber > 0 {
+		global.logger.Finish(fmt.Sprintf("finish %d users", pubSubMsgNumber), fmt.Sprintf("directory %s domain '%s' emailPrefix '%s' Number of users published %d to topic %s", directoryCustomerID, domain, emailPrefix, pubSubMsgNumber, outputTopicName), "batch-listusers")
+	} else {
+		global.logger.Cancel(fmt.Sprintf("no user found for directory %s domain '%s' emailPrefix '%s'", directoryCustomerID, domain, emailPrefix))
+	}
+	if pubSubErrNumber > 0 {
+		global.logger.Warning("some pubsub messages did not publish successfully", fmt.Sprintf("number of droped pussub messages %d", pubSubErrNumber))
+	}
+	return nil
+}
+
+// browseUsers is executed for each page returning a set of users
+// A non-nil error returned will halt the iteration
+// the only accepted parameter is users: https://pkg.go.dev/google.golang.org/api/admin/directory/v1?tab=doc#UsersListCall.Pages
+// so, it use global variables to this package
+func browseUsers(users *admin.Users) error {
+	var waitgroup sync.WaitGroup
+	topic := pubSubClient.Topic(outputTopicName)
+	for _, user := range users.Users {
+		var feedMessage cai.FeedMessageUser
+		feedMessage.Window.StartTime = time.Now()
+		feedMessage.Origin = "batch-listusers"
+		feedMessage.Deleted = false
+		feedMessage.Asset.Ancestors = []string{fmt.Sprintf("directories/%s", directoryCustomerID)}
+		feedMessage.Asset.AncestryPath = fmt.Sprintf("directories/%s", directoryCustomerID)
+		feedMessage.Asset.AssetType = "www.googleapis.com/admin/directory/users"
+		feedMessage.Asset.Name = fmt.Sprintf("//directories/%s/users/%s", directoryCustomerID, user.Id)
+		feedMessage.Asset.Resource = user
+		feedMessage.Asset.Resource.Etag = ""
+		feedMessage.StepStack = stepStack
+		feedMessageJSON, err := json.Marshal(feedMessage)
+		if err != nil {
+			logger.Warning("json.Marshal(settings)", fmt.Sprintf("feedMessage %v", feedMessage))
+		} else {
+			pubSubMessage := &pubsub.Message{
+				Data:       feedMessageJSON,
+				Attributes: traceAttributes,
+			}
+			publishResult := topic.Publish(ctx, pubSubMessage)
+			waitgroup.Add(1)
+			go gps.GetPublishCallResult(ctx,
+				publishResult,
+				&waitgroup,
+				directoryCustomerID+"/"+user.PrimaryEmail,
+				&pubSubErrNumber,
+				&pubSubMsgNumber,
+				logEventEveryXPubSubMsg,
+				logger)
+		}
+	}
+	waitgroup.Wait()
+	return nil
+}
+
+// getByteSet return a set of lenght contiguous bytes starting at bytes
+func getByteSet(start byte, length int) []byte {
+	byteSet := make([]byte, length)
+	for i := range byteSet {
+		byteSet[i] = start + byte(i)
+	}
+	return byteSet
+}
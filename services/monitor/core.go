@@ -42,6 +42,7 @@ import (
 // Global structure for global variables to optimize the cloud function performances
 type Global struct {
 	assetsCollectionID            string
+	auditActorsCollectionID       string
 	assetsFilePath                string
 	assetsFolderPath              string
 	cloudresourcemanagerService   *cloudresourcemanager.Service
@@ -52,10 +53,12 @@ type Global struct {
 	firestoreClient               *firestore.Client
 	functionName                  string
 	instanceName                  string
+	logger                        *glo.Logger
 	microserviceName              string
 	opaFolderPath                 string
 	ownerLabelKeyName             string
 	projectID                     string
+	pubSubAttributes              map[string]string
 	PubSubID                      string
 	pubsubPublisherClient         *pubsub.PublisherClient
 	ramComplianceStatusTopicName  string
@@ -74,6 +77,7 @@ type feedMessage struct {
 	Window    cai.Window `json:"window"`
 	Deleted   bool       `json:"deleted"`
 	Origin    string     `json:"origin"`
+	Actor     *cai.Actor `json:"actor,omitempty"`
 	StepStack glo.Steps  `json:"step_stack,omitempty"`
 }
 
@@ -93,6 +97,17 @@ type asset struct {
 	IamPolicy               json.RawMessage `json:"iamPolicy"`
 	IamPolicyLegacy         json.RawMessage `json:"iam_policy"`
 	Resource                json.RawMessage `json:"resource"`
+	OrgPolicy               json.RawMessage `json:"orgPolicy,omitempty"`
+	OrgPolicyLegacy         json.RawMessage `json:"org_policy,omitempty"`
+	AccessPolicy            json.RawMessage `json:"accessPolicy,omitempty"`
+	AccessPolicyLegacy      json.RawMessage `json:"access_policy,omitempty"`
+	AccessLevel             json.RawMessage `json:"accessLevel,omitempty"`
+	AccessLevelLegacy       json.RawMessage `json:"access_level,omitempty"`
+	ServicePerimeter        json.RawMessage `json:"servicePerimeter,omitempty"`
+	ServicePerimeterLegacy  json.RawMessage `json:"service_perimeter,omitempty"`
+	OsInventory             json.RawMessage `json:"osInventory,omitempty"`
+	RelatedAssets           json.RawMessage `json:"relatedAssets,omitempty"`
+	RelatedAsset            json.RawMessage `json:"relatedAsset,omitempty"`
 	ProjectID               string          `json:"projectID"`
 }
 
@@ -188,18 +203,26 @@ func Initialize(ctx context.Context, global *Global) (err error) {
 	global.instanceName = instanceDeployment.Core.InstanceName
 	global.microserviceName = instanceDeployment.Core.ServiceName
 
-	log.Println(glo.Entry{
-		MicroserviceName: global.microserviceName,
-		InstanceName:     global.instanceName,
-		Environment:      global.environment,
-		Severity:         "NOTICE",
-		Message:          "coldstart",
-		InitID:           initID,
-	})
+	global.logger = glo.NewLogger(global.microserviceName, global.instanceName, global.environment, initID)
+	global.logger.SetLogParameters(glo.GetLogParameters(instanceDeployment.Settings.Service.GLO, instanceDeployment.Settings.Instance.GLO))
+	global.logger.Notice("coldstart", "")
+
+	err = glo.InitTracing(ctx,
+		instanceDeployment.Core.SolutionSettings.Hosting.Tracing.Exporter,
+		instanceDeployment.Core.SolutionSettings.Hosting.Tracing.OTLPEndpoint,
+		instanceDeployment.Core.SolutionSettings.Hosting.Tracing.FilePath,
+		global.microserviceName,
+		global.instanceName,
+		global.environment)
+	if err != nil {
+		global.logger.InitFailed(fmt.Sprintf("glo.InitTracing %v", err))
+		return err
+	}
 
 	assetsFileName := instanceDeployment.Settings.Service.AssetsFileName
 	assetsFolderName := instanceDeployment.Settings.Service.AssetsFolderName
 	global.assetsCollectionID = instanceDeployment.Core.SolutionSettings.Hosting.FireStore.CollectionIDs.Assets
+	global.auditActorsCollectionID = instanceDeployment.Core.SolutionSettings.Hosting.FireStore.CollectionIDs.AuditActors
 	global.deploymentTime = instanceDeployment.Settings.Instance.DeploymentTime
 	global.functionName = instanceDeployment.Core.InstanceName
 	global.opaFolderPath = instanceDeployment.Settings.Service.OPAFolderPath
@@ -220,54 +243,22 @@ func Initialize(ctx context.Context, global *Global) (err error) {
 	// persist between function invocations.
 	global.cloudresourcemanagerService, err = cloudresourcemanager.NewService(ctx)
 	if err != nil {
-		log.Println(glo.Entry{
-			MicroserviceName: global.microserviceName,
-			InstanceName:     global.instanceName,
-			Environment:      global.environment,
-			Severity:         "CRITICAL",
-			Message:          "init_failed",
-			Description:      fmt.Sprintf("cloudresourcemanager.NewService %v", err),
-			InitID:           initID,
-		})
+		global.logger.InitFailed(fmt.Sprintf("cloudresourcemanager.NewService %v", err))
 		return err
 	}
 	global.cloudresourcemanagerServiceV2, err = cloudresourcemanagerv2.NewService(ctx)
 	if err != nil {
-		log.Println(glo.Entry{
-			MicroserviceName: global.microserviceName,
-			InstanceName:     global.instanceName,
-			Environment:      global.environment,
-			Severity:         "CRITICAL",
-			Message:          "init_failed",
-			Description:      fmt.Sprintf("cloudresourcemanagerv2.NewService %v", err),
-			InitID:           initID,
-		})
+		global.logger.InitFailed(fmt.Sprintf("cloudresourcemanagerv2.NewService %v", err))
 		return err
 	}
 	global.pubsubPublisherClient, err = pubsub.NewPublisherClient(global.ctx)
 	if err != nil {
-		log.Println(glo.Entry{
-			MicroserviceName: global.microserviceName,
-			InstanceName:     global.instanceName,
-			Environment:      global.environment,
-			Severity:         "CRITICAL",
-			Message:          "init_failed",
-			Description:      fmt.Sprintf("pubsub.NewPublisherClient %v", err),
-			InitID:           initID,
-		})
+		global.logger.InitFailed(fmt.Sprintf("pubsub.NewPublisherClient %v", err))
 		return err
 	}
 	global.firestoreClient, err = firestore.NewClient(global.ctx, global.projectID)
 	if err != nil {
-		log.Println(glo.Entry{
-			MicroserviceName: global.microserviceName,
-			InstanceName:     global.instanceName,
-			Environment:      global.environment,
-			Severity:         "CRITICAL",
-			Message:          "init_failed",
-			Description:      fmt.Sprintf("firestore.NewClient %v", err),
-			InitID:           initID,
-		})
+		global.logger.InitFailed(fmt.Sprintf("firestore.NewClient %v", err))
 		return err
 	}
 	return nil
@@ -279,15 +270,7 @@ func EntryPoint(ctxEvent context.Context, PubSubMessage gps.PubSubMessage, globa
 	metadata, err := metadata.FromContext(ctxEvent)
 	if err != nil {
 		// Assume an error on the function invoker and try again.
-		log.Println(glo.Entry{
-			MicroserviceName:   global.microserviceName,
-			InstanceName:       global.instanceName,
-			Environment:        global.environment,
-			Severity:           "CRITICAL",
-			Message:            "redo_on_transient",
-			Description:        fmt.Sprintf("pubsub_id no available metadata.FromContext: %v", err),
-			TriggeringPubsubID: global.PubSubID,
-		})
+		global.logger.RedoOnTransient(fmt.Sprintf("pubsub_id no available metadata.FromContext: %v", err))
 		return err
 	}
 	global.stepStack = nil
@@ -297,47 +280,21 @@ func EntryPoint(ctxEvent context.Context, PubSubMessage gps.PubSubMessage, globa
 		StepID:        fmt.Sprintf("%s/%s", parts[len(parts)-1], global.PubSubID),
 		StepTimestamp: metadata.Timestamp,
 	}
+	global.logger.SetInvocation(global.PubSubID, global.step)
+	global.pubSubAttributes = PubSubMessage.Attributes
+	defer func() {
+		glo.RecordStepSpan(global.ctx, global.microserviceName, global.pubSubAttributes, global.stepStack)
+	}()
 
-	now := time.Now()
-	d := now.Sub(metadata.Timestamp)
-	log.Println(glo.Entry{
-		MicroserviceName:           global.microserviceName,
-		InstanceName:               global.instanceName,
-		Environment:                global.environment,
-		Severity:                   "NOTICE",
-		Message:                    "start",
-		TriggeringPubsubID:         global.PubSubID,
-		TriggeringPubsubAgeSeconds: d.Seconds(),
-		TriggeringPubsubTimestamp:  &metadata.Timestamp,
-		Now:                        &now,
-	})
+	d := global.logger.Start()
 
 	if d.Seconds() > float64(global.retryTimeOutSeconds) {
-		log.Println(glo.Entry{
-			MicroserviceName:           global.microserviceName,
-			InstanceName:               global.instanceName,
-			Environment:                global.environment,
-			Severity:                   "CRITICAL",
-			Message:                    "noretry",
-			Description:                "Pubsub message too old",
-			TriggeringPubsubID:         global.PubSubID,
-			TriggeringPubsubAgeSeconds: d.Seconds(),
-			TriggeringPubsubTimestamp:  &metadata.Timestamp,
-			Now:                        &now,
-		})
+		global.logger.NoRetryTooOld()
 		return nil
 	}
 
 	if strings.Contains(string(PubSubMessage.Data), "You have successfully configured real time feed") {
-		log.Println(glo.Entry{
-			MicroserviceName:   global.microserviceName,
-			InstanceName:       global.instanceName,
-			Environment:        global.environment,
-			Severity:           "NOTICE",
-			Message:            "cancel",
-			Description:        fmt.Sprintf("ignored pubsub message: %s", string(PubSubMessage.Data)),
-			TriggeringPubsubID: global.PubSubID,
-		})
+		global.logger.Cancel(fmt.Sprintf("ignored pubsub message: %s", string(PubSubMessage.Data)))
 		return nil
 	}
 
@@ -346,15 +303,7 @@ func EntryPoint(ctxEvent context.Context, PubSubMessage gps.PubSubMessage, globa
 
 	assetsJSONDocument, feedMessage, err := buildAssetsDocument(PubSubMessage, global)
 	if err != nil {
-		log.Println(glo.Entry{
-			MicroserviceName:   global.microserviceName,
-			InstanceName:       global.instanceName,
-			Environment:        global.environment,
-			Severity:           "CRITICAL",
-			Message:            "noretry",
-			Description:        fmt.Sprintf("buildAssetsDocument(PubSubMessage, global) %v", err),
-			TriggeringPubsubID: global.PubSubID,
-		})
+		global.logger.NoRetry(fmt.Sprintf("buildAssetsDocument(PubSubMessage, global) %v", err))
 		return nil
 	}
 	compliantLog.AssetsJSONDocument = assetsJSONDocument
@@ -374,28 +323,12 @@ func EntryPoint(ctxEvent context.Context, PubSubMessage gps.PubSubMessage, globa
 		complianceStatus.Deleted = false
 		resultSet, feedMessage, err := evalutateConstraints(assetsJSONDocument, feedMessage, global)
 		if err != nil {
-			log.Println(glo.Entry{
-				MicroserviceName:   global.microserviceName,
-				InstanceName:       global.instanceName,
-				Environment:        global.environment,
-				Severity:           "CRITICAL",
-				Message:            "noretry",
-				Description:        fmt.Sprintf("evalutateConstraints(assetsJSONDocument, feedMessage, global) %v", err),
-				TriggeringPubsubID: global.PubSubID,
-			})
+			global.logger.NoRetry(fmt.Sprintf("evalutateConstraints(assetsJSONDocument, feedMessage, global) %v", err))
 			return nil
 		}
 		violations, err := inspectResultSet(resultSet, feedMessage, global)
 		if err != nil {
-			log.Println(glo.Entry{
-				MicroserviceName:   global.microserviceName,
-				InstanceName:       global.instanceName,
-				Environment:        global.environment,
-				Severity:           "CRITICAL",
-				Message:            "noretry",
-				Description:        fmt.Sprintf("inspectResultSet(resultSet, feedMessage, global) %v", err),
-				TriggeringPubsubID: global.PubSubID,
-			})
+			global.logger.NoRetry(fmt.Sprintf("inspectResultSet(resultSet, feedMessage, global) %v", err))
 			return nil
 		}
 		if len(violations) == 0 {
@@ -407,37 +340,13 @@ func EntryPoint(ctxEvent context.Context, PubSubMessage gps.PubSubMessage, globa
 				violation.StepStack = global.stepStack
 				violationJSON, err := json.Marshal(violation)
 				if err != nil {
-					log.Println(glo.Entry{
-						MicroserviceName:   global.microserviceName,
-						InstanceName:       global.instanceName,
-						Environment:        global.environment,
-						Severity:           "CRITICAL",
-						Message:            "noretry",
-						Description:        fmt.Sprintf("json.Marshal(violation) %v", err),
-						TriggeringPubsubID: global.PubSubID,
-					})
+					global.logger.NoRetry(fmt.Sprintf("json.Marshal(violation) %v", err))
 					return nil
 				}
-				log.Println(glo.Entry{
-					MicroserviceName:   global.microserviceName,
-					InstanceName:       global.instanceName,
-					Environment:        global.environment,
-					Severity:           "INFO",
-					Message:            fmt.Sprintf("not_compliant %s violationNum %d", complianceStatus.AssetName, i),
-					Description:        fmt.Sprintf("origin %s timestamp %v violationJSON %s", complianceStatus.AssetInventoryOrigin, complianceStatus.AssetInventoryTimeStamp, string(violationJSON)),
-					TriggeringPubsubID: global.PubSubID,
-				})
+				global.logger.Info(fmt.Sprintf("not_compliant %s violationNum %d", complianceStatus.AssetName, i), fmt.Sprintf("origin %s timestamp %v violationJSON %s", complianceStatus.AssetInventoryOrigin, complianceStatus.AssetInventoryTimeStamp, string(violationJSON)))
 				err = publishPubSubMessage(violationJSON, global.ramViolationTopicName, global)
 				if err != nil {
-					log.Println(glo.Entry{
-						MicroserviceName:   global.microserviceName,
-						InstanceName:       global.instanceName,
-						Environment:        global.environment,
-						Severity:           "CRITICAL",
-						Message:            "redo_on_transient",
-						Description:        err.Error(),
-						TriggeringPubsubID: global.PubSubID,
-					})
+					global.logger.RedoOnTransient(err.Error())
 					return err
 				}
 			}
@@ -445,28 +354,12 @@ func EntryPoint(ctxEvent context.Context, PubSubMessage gps.PubSubMessage, globa
 	}
 	complianceStatusJSON, err := json.Marshal(complianceStatus)
 	if err != nil {
-		log.Println(glo.Entry{
-			MicroserviceName:   global.microserviceName,
-			InstanceName:       global.instanceName,
-			Environment:        global.environment,
-			Severity:           "CRITICAL",
-			Message:            "noretry",
-			Description:        fmt.Sprintf("json.Marshal(complianceStatus) %v", err),
-			TriggeringPubsubID: global.PubSubID,
-		})
+		global.logger.NoRetry(fmt.Sprintf("json.Marshal(complianceStatus) %v", err))
 		return nil
 	}
 	err = publishPubSubMessage(complianceStatusJSON, global.ramComplianceStatusTopicName, global)
 	if err != nil {
-		log.Println(glo.Entry{
-			MicroserviceName:   global.microserviceName,
-			InstanceName:       global.instanceName,
-			Environment:        global.environment,
-			Severity:           "CRITICAL",
-			Message:            "redo_on_transient",
-			Description:        err.Error(),
-			TriggeringPubsubID: global.PubSubID,
-		})
+		global.logger.RedoOnTransient(err.Error())
 		return err
 	}
 	compliantLog.ComplianceStatus = complianceStatus
@@ -474,37 +367,13 @@ func EntryPoint(ctxEvent context.Context, PubSubMessage gps.PubSubMessage, globa
 	if complianceStatus.Compliant == true {
 		CompliantLogJSON, err := json.Marshal(compliantLog)
 		if err != nil {
-			log.Println(glo.Entry{
-				MicroserviceName:   global.microserviceName,
-				InstanceName:       global.instanceName,
-				Environment:        global.environment,
-				Severity:           "CRITICAL",
-				Message:            "noretry",
-				Description:        fmt.Sprintf("json.Marshal(compliantLog) %v", err),
-				TriggeringPubsubID: global.PubSubID,
-			})
+			global.logger.NoRetry(fmt.Sprintf("json.Marshal(compliantLog) %v", err))
 			return nil
 		}
 		if complianceStatus.Deleted == true {
-			log.Println(glo.Entry{
-				MicroserviceName:   global.microserviceName,
-				InstanceName:       global.instanceName,
-				Environment:        global.environment,
-				Severity:           "INFO",
-				Message:            fmt.Sprintf("deleted %s", complianceStatus.AssetName),
-				Description:        fmt.Sprintf("origin %s timestamp %v CompliantLogJSON %s", complianceStatus.AssetInventoryOrigin, complianceStatus.AssetInventoryTimeStamp, string(CompliantLogJSON)),
-				TriggeringPubsubID: global.PubSubID,
-			})
+			global.logger.Info(fmt.Sprintf("deleted %s", complianceStatus.AssetName), fmt.Sprintf("origin %s timestamp %v CompliantLogJSON %s", complianceStatus.AssetInventoryOrigin, complianceStatus.AssetInventoryTimeStamp, string(CompliantLogJSON)))
 		} else {
-			log.Println(glo.Entry{
-				MicroserviceName:   global.microserviceName,
-				InstanceName:       global.instanceName,
-				Environment:        global.environment,
-				Severity:           "INFO",
-				Message:            fmt.Sprintf("compliant %s", complianceStatus.AssetName),
-				Description:        fmt.Sprintf("origin %s timestamp %v CompliantLogJSON %s", complianceStatus.AssetInventoryOrigin, complianceStatus.AssetInventoryTimeStamp, string(CompliantLogJSON)),
-				TriggeringPubsubID: global.PubSubID,
-			})
+			global.logger.Info(fmt.Sprintf("compliant %s", complianceStatus.AssetName), fmt.Sprintf("origin %s timestamp %v CompliantLogJSON %s", complianceStatus.AssetInventoryOrigin, complianceStatus.AssetInventoryTimeStamp, string(CompliantLogJSON)))
 		}
 	}
 	var status string
@@ -513,31 +382,14 @@ func EntryPoint(ctxEvent context.Context, PubSubMessage gps.PubSubMessage, globa
 	} else {
 		status = "not_compliant"
 	}
-	now = time.Now()
-	latency := now.Sub(metadata.Timestamp)
-	latencyE2E := now.Sub(global.stepStack[0].StepTimestamp)
-	log.Println(glo.Entry{
-		MicroserviceName:     global.microserviceName,
-		InstanceName:         global.instanceName,
-		Environment:          global.environment,
-		Severity:             "NOTICE",
-		Message:              fmt.Sprintf("finish %s %s", status, complianceStatus.AssetName),
-		Description:          fmt.Sprintf("number of violations %d", countViolations),
-		Now:                  &now,
-		TriggeringPubsubID:   global.PubSubID,
-		OriginEventTimestamp: &metadata.Timestamp,
-		LatencySeconds:       latency.Seconds(),
-		LatencyE2ESeconds:    latencyE2E.Seconds(),
-		StepStack:            global.stepStack,
-		Compliant:            complianceStatus.Compliant,
-		AssetInventoryOrigin: feedMessage.Origin,
-	})
+	global.logger.FinishCompliance(fmt.Sprintf("finish %s %s", status, complianceStatus.AssetName), fmt.Sprintf("number of violations %d", countViolations), feedMessage.Origin, complianceStatus.Compliant)
 	return nil
 }
 
 func publishPubSubMessage(docJSON []byte, topicName string, global *Global) error {
 	var pubSubMessage pubsubpb.PubsubMessage
 	pubSubMessage.Data = docJSON
+	pubSubMessage.Attributes = glo.GetTraceAttributes(global.pubSubAttributes, global.stepStack)
 
 	var pubsubMessages []*pubsubpb.PubsubMessage
 	pubsubMessages = append(pubsubMessages, &pubSubMessage)
@@ -551,15 +403,7 @@ func publishPubSubMessage(docJSON []byte, topicName string, global *Global) erro
 		return fmt.Errorf("global.pubsubPublisherClient.Publish: %v", err)
 	}
 
-	log.Println(glo.Entry{
-		MicroserviceName:   global.microserviceName,
-		InstanceName:       global.instanceName,
-		Environment:        global.environment,
-		Severity:           "INFO",
-		Message:            fmt.Sprintf("published to topic %s", topicName),
-		Description:        fmt.Sprintf("msg ids %v", pubsubResponse.MessageIds),
-		TriggeringPubsubID: global.PubSubID,
-	})
+	global.logger.Info(fmt.Sprintf("published to topic %s", topicName), fmt.Sprintf("msg ids %v", pubsubResponse.MessageIds))
 	_ = pubsubResponse
 	return nil
 }
@@ -723,11 +567,35 @@ func buildAssetsDocument(pubSubMessage gps.PubSubMessage, global *Global) ([]byt
 		global.stepStack = append(global.stepStack, caiStep)
 		global.stepStack = append(global.stepStack, global.step)
 	}
+	global.logger.SetStepStack(global.stepStack)
 
 	if feedMessage.Origin == "" {
 		feedMessage.Origin = "real-time"
 	}
 
+	// Only real-time changes can be correlated with who made them
+	if feedMessage.Origin == "real-time" && feedMessage.Actor == nil {
+		feedMessage.Actor, err = cai.GetActor(global.ctx,
+			global.firestoreClient,
+			global.auditActorsCollectionID,
+			feedMessage.Asset.Name,
+			cai.AssetContents{
+				Resource:         feedMessage.Asset.Resource,
+				IamPolicy:        feedMessage.Asset.IamPolicy,
+				OrgPolicy:        feedMessage.Asset.OrgPolicy,
+				AccessPolicy:     feedMessage.Asset.AccessPolicy,
+				AccessLevel:      feedMessage.Asset.AccessLevel,
+				ServicePerimeter: feedMessage.Asset.ServicePerimeter,
+				OsInventory:      feedMessage.Asset.OsInventory,
+				RelatedAssets:    feedMessage.Asset.RelatedAssets,
+				RelatedAsset:     feedMessage.Asset.RelatedAsset,
+			}.GetContentType(),
+			feedMessage.Window.StartTime)
+		if err != nil {
+			global.logger.Warning("actor not correlated", fmt.Sprintf("cai.GetActor %v", err))
+		}
+	}
+
 	feedMessage.Asset.AncestryPath = cai.BuildAncestryPath(feedMessage.Asset.Ancestors)
 	feedMessage.Asset.AncestorsDisplayName, feedMessage.Asset.ProjectID = cai.BuildAncestorsDisplayName(global.ctx,
 		feedMessage.Asset.Ancestors,
@@ -741,6 +609,10 @@ func buildAssetsDocument(pubSubMessage gps.PubSubMessage, global *Global) ([]byt
 	feedMessage.Asset.ViolationResolver, _ = cai.GetAssetLabelValue(global.violationResolverLabelKeyName, feedMessage.Asset.Resource)
 	// Duplicate fileds into fieldLegacy for compatibility with existing policy library templates
 	feedMessage.Asset.IamPolicyLegacy = feedMessage.Asset.IamPolicy
+	feedMessage.Asset.OrgPolicyLegacy = feedMessage.Asset.OrgPolicy
+	feedMessage.Asset.AccessPolicyLegacy = feedMessage.Asset.AccessPolicy
+	feedMessage.Asset.AccessLevelLegacy = feedMessage.Asset.AccessLevel
+	feedMessage.Asset.ServicePerimeterLegacy = feedMessage.Asset.ServicePerimeter
 	feedMessage.Asset.AssetTypeLegacy = feedMessage.Asset.AssetType
 	feedMessage.Asset.AncestryPathLegacy = feedMessage.Asset.AncestryPath
 
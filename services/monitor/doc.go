@@ -15,6 +15,8 @@
 /*
 Package monitor check asset compliance
 
+Real-time feed messages are enriched with the actor who made the change (principalEmail, methodName, callerIp), when an admin activity audit log entry recorded by convertlog2feed is correlated with the change time. The actor is carried in violations.
+
 Triggered by
 
 Resource or IAM policies assets feed messages in PubSub topics.
@@ -25,6 +27,10 @@ Instances
 
 - all constraints (yaml settings) related to a REGO rule are evaluated in that REGO rule instance.
 
+- ramcli -newrule <serviceName>_<rule> -asset <assetType> -severity <severity> scaffolds a new instance: instance.yaml with the trigger topic, REGO rule template, constraint and an opa test fixture.
+
+- ramcli -import-policy <policyLibraryPath> creates or updates instances from the GCP policy library, aka Forseti config validator, templates and samples. REGO rules are refreshed on re-import, existing instance settings and constraints are kept. Templates relying on data.inventory, on unsupported lib functions or on several asset types are skipped and listed in the run report.
+
 Output
 
 - PubSub violation topic.
@@ -20,6 +20,7 @@ import (
 	"github.com/BrunoReboul/ram/utilities/deploy"
 	"github.com/BrunoReboul/ram/utilities/gcb"
 	"github.com/BrunoReboul/ram/utilities/gcf"
+	"github.com/BrunoReboul/ram/utilities/glo"
 	"github.com/BrunoReboul/ram/utilities/gsu"
 	"github.com/BrunoReboul/ram/utilities/iamgt"
 	"github.com/BrunoReboul/ram/utilities/solution"
@@ -39,6 +40,7 @@ type InstanceDeployment struct {
 			IAM                   iamgt.Parameters
 			GCB                   gcb.Parameters
 			GCF                   gcf.Parameters
+			GLO                   glo.LogParameters
 			AssetsFileName        string `yaml:"assetsFileName"`
 			AssetsFolderName      string `yaml:"assetsFolderName"`
 			OPAFolderPath         string `yaml:"opaFolderPath"`
@@ -48,6 +50,7 @@ type InstanceDeployment struct {
 		Instance struct {
 			GCF            gcf.Event
 			DeploymentTime time.Time `yaml:"deploymentTime" valid:"-"` // variable of type time.Type MUST discard validater. time.Time is retreived as struct with only unexported field, leading to crash recurusivity of validater
+			GLO            glo.LogParameters
 		}
 	}
 }
@@ -91,6 +94,8 @@ func NewInstanceDeployment() *InstanceDeployment {
 	instanceDeployment.Settings.Service.GCF.AvailableMemoryMb = 128
 	instanceDeployment.Settings.Service.GCF.RetryTimeOutSeconds = 3600
 	instanceDeployment.Settings.Service.GCF.Timeout = "60s"
+	// compliant and violation entries embed the whole assets document
+	instanceDeployment.Settings.Service.GLO.MaxDescriptionLength = 4096
 
 	instanceDeployment.Settings.Service.AssetsFolderName = "/assets"
 	instanceDeployment.Settings.Service.AssetsFileName = "data.json"
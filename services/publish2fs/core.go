@@ -32,20 +32,28 @@ import (
 
 	"cloud.google.com/go/firestore"
 	"cloud.google.com/go/functions/metadata"
+	pubsub "cloud.google.com/go/pubsub/apiv1"
+	pubsubpb "google.golang.org/genproto/googleapis/pubsub/v1"
 )
 
 // Global structure for global variables to optimize the cloud function performances
 type Global struct {
-	collectionID        string
-	ctx                 context.Context
-	environment         string
-	firestoreClient     *firestore.Client
-	instanceName        string
-	microserviceName    string
-	PubSubID            string
-	retryTimeOutSeconds int64
-	step                glo.Step
-	stepStack           glo.Steps
+	assetChangesTopicName string
+	collectionID          string
+	ctx                   context.Context
+	environment           string
+	firestoreClient       *firestore.Client
+	instanceName          string
+	logger                *glo.Logger
+	microserviceName      string
+	projectID             string
+	pubsubPublisherClient *pubsub.PublisherClient
+	pubSubAttributes      map[string]string
+	PubSubID              string
+	retryTimeOutSeconds   int64
+	step                  glo.Step
+	stepStack             glo.Steps
+	tombstoneTTLDays      int64
 }
 
 // feedMessage Cloud Asset Inventory feed message
@@ -55,6 +63,10 @@ type feedMessage struct {
 	Deleted   bool       `json:"deleted" firestore:"deleted"`
 	Origin    string     `json:"origin" firestore:"origin"`
 	StepStack glo.Steps  `json:"step_stack,omitempty" firestore:"step_stack,omitempty"`
+	// ContentType of the cached document, so that the stale check only compares versions of the same content
+	ContentType string `json:"-" firestore:"contentType,omitempty"`
+	// ExpireAt is set on tombstones only, the firestore ttl policy deletes them once expired
+	ExpireAt *time.Time `json:"-" firestore:"expireAt,omitempty"`
 }
 
 // Asset Cloud Asset Metadata
@@ -65,6 +77,14 @@ type asset struct {
 	AncestryPath string                 `json:"ancestryPath" firestore:"ancestryPath"`
 	IamPolicy    map[string]interface{} `json:"iamPolicy" firestore:"iamPolicy,omitempty"`
 	Resource     map[string]interface{} `json:"resource" firestore:"resource"`
+	// org policy is a list of policies, one per constraint
+	OrgPolicy        []interface{}          `json:"orgPolicy,omitempty" firestore:"orgPolicy,omitempty"`
+	AccessPolicy     map[string]interface{} `json:"accessPolicy,omitempty" firestore:"accessPolicy,omitempty"`
+	AccessLevel      map[string]interface{} `json:"accessLevel,omitempty" firestore:"accessLevel,omitempty"`
+	ServicePerimeter map[string]interface{} `json:"servicePerimeter,omitempty" firestore:"servicePerimeter,omitempty"`
+	OsInventory      map[string]interface{} `json:"osInventory,omitempty" firestore:"osInventory,omitempty"`
+	RelatedAssets    map[string]interface{} `json:"relatedAssets,omitempty" firestore:"relatedAssets,omitempty"`
+	RelatedAsset     map[string]interface{} `json:"relatedAsset,omitempty" firestore:"relatedAsset,omitempty"`
 }
 
 // Initialize is to be executed in the init() function of the cloud function to optimize the cold start
@@ -73,7 +93,6 @@ func Initialize(ctx context.Context, global *Global) (err error) {
 	global.ctx = ctx
 
 	var instanceDeployment InstanceDeployment
-	var projectID string
 
 	initID := fmt.Sprintf("%v", uuid.New())
 	err = ffo.ReadUnmarshalYAML(solution.PathToFunctionCode+solution.SettingsFileName, &instanceDeployment)
@@ -91,32 +110,40 @@ func Initialize(ctx context.Context, global *Global) (err error) {
 	global.instanceName = instanceDeployment.Core.InstanceName
 	global.microserviceName = instanceDeployment.Core.ServiceName
 
-	log.Println(glo.Entry{
-		MicroserviceName: global.microserviceName,
-		InstanceName:     global.instanceName,
-		Environment:      global.environment,
-		Severity:         "NOTICE",
-		Message:          "coldstart",
-		InitID:           initID,
-	})
+	global.logger = glo.NewLogger(global.microserviceName, global.instanceName, global.environment, initID)
+	global.logger.SetLogParameters(glo.GetLogParameters(instanceDeployment.Settings.Service.GLO, instanceDeployment.Settings.Instance.GLO))
+	global.logger.Notice("coldstart", "")
+
+	err = glo.InitTracing(ctx,
+		instanceDeployment.Core.SolutionSettings.Hosting.Tracing.Exporter,
+		instanceDeployment.Core.SolutionSettings.Hosting.Tracing.OTLPEndpoint,
+		instanceDeployment.Core.SolutionSettings.Hosting.Tracing.FilePath,
+		global.microserviceName,
+		global.instanceName,
+		global.environment)
+	if err != nil {
+		global.logger.InitFailed(fmt.Sprintf("glo.InitTracing %v", err))
+		return err
+	}
 
+	global.assetChangesTopicName = instanceDeployment.Core.SolutionSettings.Hosting.Pubsub.TopicNames.AssetChanges
 	global.collectionID = instanceDeployment.Core.SolutionSettings.Hosting.FireStore.CollectionIDs.Assets
 	global.retryTimeOutSeconds = instanceDeployment.Settings.Service.GCF.RetryTimeOutSeconds
-	projectID = instanceDeployment.Core.SolutionSettings.Hosting.ProjectID
+	global.tombstoneTTLDays = instanceDeployment.Settings.Service.TombstoneTTLDays
+	global.projectID = instanceDeployment.Core.SolutionSettings.Hosting.ProjectID
 
-	global.firestoreClient, err = firestore.NewClient(ctx, projectID)
+	global.firestoreClient, err = firestore.NewClient(ctx, global.projectID)
 	if err != nil {
-		log.Println(glo.Entry{
-			MicroserviceName: global.microserviceName,
-			InstanceName:     global.instanceName,
-			Environment:      global.environment,
-			Severity:         "CRITICAL",
-			Message:          "init_failed",
-			Description:      fmt.Sprintf("firestore.NewClient %v", err),
-			InitID:           initID,
-		})
+		global.logger.InitFailed(fmt.Sprintf("firestore.NewClient %v", err))
 		return err
 	}
+	if global.assetChangesTopicName != "" {
+		global.pubsubPublisherClient, err = pubsub.NewPublisherClient(ctx)
+		if err != nil {
+			global.logger.InitFailed(fmt.Sprintf("pubsub.NewPublisherClient %v", err))
+			return err
+		}
+	}
 	return nil
 }
 
@@ -126,15 +153,7 @@ func EntryPoint(ctxEvent context.Context, PubSubMessage gps.PubSubMessage, globa
 	metadata, err := metadata.FromContext(ctxEvent)
 	if err != nil {
 		// Assume an error on the function invoker and try again.
-		log.Println(glo.Entry{
-			MicroserviceName:   global.microserviceName,
-			InstanceName:       global.instanceName,
-			Environment:        global.environment,
-			Severity:           "CRITICAL",
-			Message:            "redo_on_transient",
-			Description:        fmt.Sprintf("pubsub_id no available metadata.FromContext: %v", err),
-			TriggeringPubsubID: global.PubSubID,
-		})
+		global.logger.RedoOnTransient(fmt.Sprintf("pubsub_id no available metadata.FromContext: %v", err))
 		return err
 	}
 	global.stepStack = nil
@@ -144,67 +163,38 @@ func EntryPoint(ctxEvent context.Context, PubSubMessage gps.PubSubMessage, globa
 		StepID:        fmt.Sprintf("%s/%s", parts[len(parts)-1], global.PubSubID),
 		StepTimestamp: metadata.Timestamp,
 	}
+	global.logger.SetInvocation(global.PubSubID, global.step)
+	global.pubSubAttributes = PubSubMessage.Attributes
+	defer func() {
+		glo.RecordStepSpan(global.ctx, global.microserviceName, global.pubSubAttributes, global.stepStack)
+	}()
 
-	now := time.Now()
-	d := now.Sub(metadata.Timestamp)
-	log.Println(glo.Entry{
-		MicroserviceName:           global.microserviceName,
-		InstanceName:               global.instanceName,
-		Environment:                global.environment,
-		Severity:                   "NOTICE",
-		Message:                    "start",
-		TriggeringPubsubID:         global.PubSubID,
-		TriggeringPubsubAgeSeconds: d.Seconds(),
-		TriggeringPubsubTimestamp:  &metadata.Timestamp,
-		Now:                        &now,
-	})
+	d := global.logger.Start()
 
 	if d.Seconds() > float64(global.retryTimeOutSeconds) {
-		log.Println(glo.Entry{
-			MicroserviceName:           global.microserviceName,
-			InstanceName:               global.instanceName,
-			Environment:                global.environment,
-			Severity:                   "CRITICAL",
-			Message:                    "noretry",
-			Description:                "Pubsub message too old",
-			TriggeringPubsubID:         global.PubSubID,
-			TriggeringPubsubAgeSeconds: d.Seconds(),
-			TriggeringPubsubTimestamp:  &metadata.Timestamp,
-			Now:                        &now,
-		})
+		global.logger.NoRetryTooOld()
 		return nil
 	}
 
 	if strings.Contains(string(PubSubMessage.Data), "You have successfully configured real time feed") {
-		log.Println(glo.Entry{
-			MicroserviceName:   global.microserviceName,
-			InstanceName:       global.instanceName,
-			Environment:        global.environment,
-			Severity:           "NOTICE",
-			Message:            "cancel",
-			Description:        fmt.Sprintf("ignored pubsub message: %s", string(PubSubMessage.Data)),
-			TriggeringPubsubID: global.PubSubID,
-		})
+		global.logger.Cancel(fmt.Sprintf("ignored pubsub message: %s", string(PubSubMessage.Data)))
 		return nil
 	}
 
 	var feedMessage feedMessage
 	err = json.Unmarshal(PubSubMessage.Data, &feedMessage)
 	if err != nil {
-		log.Println(glo.Entry{
-			MicroserviceName:   global.microserviceName,
-			InstanceName:       global.instanceName,
-			Environment:        global.environment,
-			Severity:           "CRITICAL",
-			Message:            "noretry",
-			Description:        fmt.Sprintf("json.Unmarshal(PubSubMessage.Data, &feedMessage) %v %v", PubSubMessage.Data, err),
-			TriggeringPubsubID: global.PubSubID,
-		})
+		global.logger.NoRetry(fmt.Sprintf("json.Unmarshal(PubSubMessage.Data, &feedMessage) %v %v", PubSubMessage.Data, err))
 		return nil
 	}
 	if feedMessage.Origin == "" {
 		feedMessage.Origin = "real-time"
 	}
+	if feedMessage.Origin == "historical-export" {
+		// Point-in-time exports are for compliance audits, they must not alter the current state cache
+		global.logger.Cancel(fmt.Sprintf("ignored %s asset %s", feedMessage.Origin, feedMessage.Asset.Name))
+		return nil
+	}
 	if feedMessage.StepStack != nil {
 		global.stepStack = append(feedMessage.StepStack, global.step)
 	} else {
@@ -214,72 +204,219 @@ func EntryPoint(ctxEvent context.Context, PubSubMessage gps.PubSubMessage, globa
 		global.stepStack = append(global.stepStack, caiStep)
 		global.stepStack = append(global.stepStack, global.step)
 	}
+	global.logger.SetStepStack(global.stepStack)
 	feedMessage.StepStack = global.stepStack
 
-	documentID := str.RevertSlash(feedMessage.Asset.Name)
+	// iam policy, org policy, access policy, os inventory and relationship feeds share the asset name with resource feeds
+	feedMessage.ContentType = getContentType(feedMessage.Asset)
+	documentID := str.RevertSlash(feedMessage.Asset.Name) + cai.GetContentTypeSuffix(feedMessage.ContentType)
 	documentPath := global.collectionID + "/" + documentID
-	if feedMessage.Deleted == true {
-		_, err = global.firestoreClient.Doc(documentPath).Delete(global.ctx)
+	documentRef := global.firestoreClient.Doc(documentPath)
+	var isStale bool
+	var assetChange *cai.AssetChange
+	// Deletions are recorded as tombstones so that a late or redelivered message cannot resurrect a deleted asset
+	// Tombstones expire once late messages are no longer possible, to bound the collection size
+	if feedMessage.Deleted {
+		expireAt := time.Now().AddDate(0, 0, int(global.tombstoneTTLDays))
+		feedMessage.ExpireAt = &expireAt
+	}
+	err = global.firestoreClient.RunTransaction(global.ctx, func(ctx context.Context, tx *firestore.Transaction) error {
+		isStale = false
+		assetChange = nil
+		documentSnap, err := tx.Get(documentRef)
 		if err != nil {
-			log.Println(glo.Entry{
-				MicroserviceName:   global.microserviceName,
-				InstanceName:       global.instanceName,
-				Environment:        global.environment,
-				Severity:           "CRITICAL",
-				Message:            "redo_on_transient",
-				Description:        fmt.Sprintf("global.firestoreClient.Doc(documentPath).Delete(global.ctx) documentPath %s %v", documentPath, err),
-				TriggeringPubsubID: global.PubSubID,
-			})
-			return err
+			if !strings.Contains(strings.ToLower(strings.Replace(err.Error(), " ", "", -1)), "notfound") {
+				return err
+			}
+		} else {
+			isStale = isStaleFeedMessage(documentSnap, feedMessage.Window.StartTime, feedMessage.ContentType)
+			if isStale {
+				return nil
+			}
 		}
-		now := time.Now()
-		latency := now.Sub(metadata.Timestamp)
-		latencyE2E := now.Sub(global.stepStack[0].StepTimestamp)
-		log.Println(glo.Entry{
-			MicroserviceName:     global.microserviceName,
-			InstanceName:         global.instanceName,
-			Environment:          global.environment,
-			Severity:             "NOTICE",
-			Message:              fmt.Sprintf("finish delete doc %s", documentPath),
-			Now:                  &now,
-			TriggeringPubsubID:   global.PubSubID,
-			OriginEventTimestamp: &metadata.Timestamp,
-			LatencySeconds:       latency.Seconds(),
-			LatencyE2ESeconds:    latencyE2E.Seconds(),
-			StepStack:            global.stepStack,
-			AssetInventoryOrigin: feedMessage.Origin,
-		})
-	} else {
-		_, err = global.firestoreClient.Doc(documentPath).Set(global.ctx, feedMessage)
+		// Computed in the transaction, as the function may be rerun on contention, published once committed
+		if global.assetChangesTopicName != "" {
+			assetChange, err = getAssetChange(documentSnap, feedMessage, global)
+			if err != nil {
+				return err
+			}
+		}
+		return tx.Set(documentRef, feedMessage)
+	})
+	if err != nil {
+		global.logger.RedoOnTransient(fmt.Sprintf("global.firestoreClient.RunTransaction documentPath %s %v", documentPath, err))
+		return err
+	}
+	if assetChange != nil {
+		err = publishAssetChange(assetChange, global)
 		if err != nil {
-			log.Println(glo.Entry{
-				MicroserviceName:   global.microserviceName,
-				InstanceName:       global.instanceName,
-				Environment:        global.environment,
-				Severity:           "CRITICAL",
-				Message:            "redo_on_transient",
-				Description:        fmt.Sprintf("global.firestoreClient.Doc(documentPath).Set(global.ctx, feedMessage) documentPath %s %v", documentPath, err),
-				TriggeringPubsubID: global.PubSubID,
-			})
-			return err
+			// No retry: the cache is already updated so a retry would be skipped as stale
+			global.logger.Error(fmt.Sprintf("publishAssetChange %s", documentPath), err.Error())
 		}
-		now := time.Now()
-		latency := now.Sub(metadata.Timestamp)
-		latencyE2E := now.Sub(global.stepStack[0].StepTimestamp)
-		log.Println(glo.Entry{
-			MicroserviceName:     global.microserviceName,
-			InstanceName:         global.instanceName,
-			Environment:          global.environment,
-			Severity:             "NOTICE",
-			Message:              fmt.Sprintf("finish set doc %s", documentPath),
-			Now:                  &now,
-			TriggeringPubsubID:   global.PubSubID,
-			OriginEventTimestamp: &metadata.Timestamp,
-			LatencySeconds:       latency.Seconds(),
-			LatencyE2ESeconds:    latencyE2E.Seconds(),
-			StepStack:            global.stepStack,
-			AssetInventoryOrigin: feedMessage.Origin,
-		})
 	}
+	var action string
+	switch {
+	case isStale:
+		action = "skip stale"
+	case feedMessage.Deleted:
+		action = "tombstone"
+	default:
+		action = "set"
+	}
+	global.logger.Finish(fmt.Sprintf("finish %s doc %s", action, documentPath), "", feedMessage.Origin)
 	return nil
 }
+
+// isStaleFeedMessage true when the cached document holds the same content type and is as recent or more recent than the incoming feed message
+// e.g. a resource feed message must never be skipped because of a more recent IAM policy feed message on the same asset
+func isStaleFeedMessage(documentSnap *firestore.DocumentSnapshot, startTime time.Time, contentType string) bool {
+	if documentSnap == nil || !documentSnap.Exists() {
+		return false
+	}
+	var stored feedMessage
+	if err := documentSnap.DataTo(&stored); err != nil {
+		return false
+	}
+	if stored.ContentType == "" {
+		// Cached before the content type was recorded
+		stored.ContentType = getContentType(stored.Asset)
+	}
+	if stored.ContentType != contentType {
+		return false
+	}
+	storedStartTimeInterface, err := documentSnap.DataAt("window.startTime")
+	if err != nil {
+		return false
+	}
+	if storedStartTime, ok := storedStartTimeInterface.(time.Time); ok {
+		return !startTime.After(storedStartTime)
+	}
+	return false
+}
+
+// getAssetChange returns what changed between the cached and the incoming version of an asset, nil when nothing changed
+func getAssetChange(documentSnap *firestore.DocumentSnapshot, incoming feedMessage, global *Global) (assetChangePtr *cai.AssetChange, err error) {
+	var previousFeedMessage *feedMessage
+	if documentSnap != nil && documentSnap.Exists() {
+		previousFeedMessage = &feedMessage{}
+		err = documentSnap.DataTo(previousFeedMessage)
+		if err != nil {
+			return nil, fmt.Errorf("documentSnap.DataTo %v", err)
+		}
+	}
+	var assetChange cai.AssetChange
+	assetChange.Name = incoming.Asset.Name
+	assetChange.AssetType = incoming.Asset.AssetType
+	assetChange.Ancestors = incoming.Asset.Ancestors
+	assetChange.Deleted = incoming.Deleted
+	assetChange.Window = incoming.Window
+	assetChange.Origin = incoming.Origin
+	assetChange.StepStack = global.stepStack
+
+	var previousIamPolicy, iamPolicy interface{}
+	var previousContents, contents map[string]interface{}
+	if previousFeedMessage == nil || previousFeedMessage.Deleted {
+		if incoming.Deleted {
+			return nil, nil
+		}
+		assetChange.Created = true
+	} else {
+		assetChange.PreviousStartTime = &previousFeedMessage.Window.StartTime
+		previousContents = getDiffableContents(previousFeedMessage.Asset)
+		previousIamPolicy = previousFeedMessage.Asset.IamPolicy
+	}
+	if !incoming.Deleted {
+		contents = getDiffableContents(incoming.Asset)
+		iamPolicy = incoming.Asset.IamPolicy
+		if !assetChange.Created {
+			for _, path := range diffableContentPaths {
+				assetChange.ChangedPaths = append(assetChange.ChangedPaths,
+					cai.DiffJSON(path, previousContents[path], contents[path])...)
+			}
+		}
+	}
+	assetChange.IAMBindingsAdded, assetChange.IAMBindingsRemoved, err = cai.DiffIAMBindings(previousIamPolicy, iamPolicy)
+	if err != nil {
+		return nil, fmt.Errorf("cai.DiffIAMBindings %v", err)
+	}
+	if !assetChange.Created && !assetChange.Deleted &&
+		len(assetChange.ChangedPaths) == 0 &&
+		len(assetChange.IAMBindingsAdded) == 0 &&
+		len(assetChange.IAMBindingsRemoved) == 0 {
+		return nil, nil
+	}
+
+	return &assetChange, nil
+}
+
+// publishAssetChange publishes an asset change to the asset changes topic
+func publishAssetChange(assetChange *cai.AssetChange, global *Global) (err error) {
+	assetChangeJSON, err := json.Marshal(assetChange)
+	if err != nil {
+		return fmt.Errorf("json.Marshal(assetChange) %v", err)
+	}
+	var pubSubMessage pubsubpb.PubsubMessage
+	pubSubMessage.Data = assetChangeJSON
+	pubSubMessage.Attributes = glo.GetTraceAttributes(global.pubSubAttributes, global.stepStack)
+
+	var publishRequest pubsubpb.PublishRequest
+	publishRequest.Topic = fmt.Sprintf("projects/%s/topics/%s", global.projectID, global.assetChangesTopicName)
+	publishRequest.Messages = []*pubsubpb.PubsubMessage{&pubSubMessage}
+
+	pubsubResponse, err := global.pubsubPublisherClient.Publish(global.ctx, &publishRequest)
+	if err != nil {
+		return fmt.Errorf("global.pubsubPublisherClient.Publish: %v", err)
+	}
+	global.logger.Info(fmt.Sprintf("published to topic %s", global.assetChangesTopicName), fmt.Sprintf("asset change %s msg ids %v", assetChange.Name, pubsubResponse.MessageIds))
+	return nil
+}
+
+// diffableContentPaths asset contents compared path by path, iam policies are compared binding by binding
+var diffableContentPaths = []string{"resource", "orgPolicy", "accessPolicy", "accessLevel", "servicePerimeter", "osInventory", "relatedAssets", "relatedAsset"}
+
+func getDiffableContents(asset asset) map[string]interface{} {
+	contents := make(map[string]interface{})
+	if asset.Resource != nil {
+		contents["resource"] = asset.Resource
+	}
+	if asset.OrgPolicy != nil {
+		contents["orgPolicy"] = asset.OrgPolicy
+	}
+	if asset.AccessPolicy != nil {
+		contents["accessPolicy"] = asset.AccessPolicy
+	}
+	if asset.AccessLevel != nil {
+		contents["accessLevel"] = asset.AccessLevel
+	}
+	if asset.ServicePerimeter != nil {
+		contents["servicePerimeter"] = asset.ServicePerimeter
+	}
+	if asset.OsInventory != nil {
+		contents["osInventory"] = asset.OsInventory
+	}
+	if asset.RelatedAssets != nil {
+		contents["relatedAssets"] = asset.RelatedAssets
+	}
+	if asset.RelatedAsset != nil {
+		contents["relatedAsset"] = asset.RelatedAsset
+	}
+	return contents
+}
+
+// getContentType same precedence as cai.AssetContents.GetContentType, on already unmarshalled contents
+func getContentType(asset asset) string {
+	switch {
+	case asset.IamPolicy != nil:
+		return cai.ContentTypeIAMPolicy
+	case asset.OrgPolicy != nil:
+		return cai.ContentTypeOrgPolicy
+	case asset.AccessPolicy != nil, asset.AccessLevel != nil, asset.ServicePerimeter != nil:
+		return cai.ContentTypeAccessPolicy
+	case asset.OsInventory != nil:
+		return cai.ContentTypeOSInventory
+	case asset.RelatedAssets != nil, asset.RelatedAsset != nil:
+		return cai.ContentTypeRelationship
+	default:
+		return cai.ContentTypeResource
+	}
+}
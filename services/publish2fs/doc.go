@@ -17,6 +17,12 @@ Package publish2fs publish assets resource feeds as FireStore documents
 
 It manages creation, updates and delete.
 
+Writes are performed in a FireStore transaction and applied only when the feed message window start time is newer than the cached one.
+
+IAM policies are cached in documents suffixed with _iam, as they share the asset name with resources. Same for org policies _orgpolicy, access policies _accesspolicy, OS inventories _osinventory and relationships _relationship.
+
+When solution setting hosting.pubsub.topicNames.assetChanges is set, it also compares the incoming asset with the cached version and publishes what changed to this topic: created or deleted flags, changed resource JSON paths with old and new values, IAM role members added and removed. Nothing is published when nothing changed. The change is published once the FireStore transaction is committed.
+
 Triggered by
 
 Resource or IAM policies assets feed messages in PubSub topics.
@@ -27,13 +33,17 @@ Instances
 
 - ussually 3: organizations, folders and projects.
 
+- plus one for IAM policies when assetChanges topic is set.
+
 Output
 
-FireStore documents created, updated, deleted.
+FireStore documents created, updated, or flagged as deleted (tombstones).
+
+Asset change messages in the assetChanges PubSub topic, when set.
 
 Cardinality
 
-One-one, one feed message - one operation performed in FireStore
+One-one, one feed message - one operation performed in FireStore, and zero or one asset change published
 
 Automatic retrying
 
@@ -64,6 +74,12 @@ Notes
 
 - It replaces / by \ in asset names not to confilct with Firestore collection/document structure.
 
+- Deleted assets are kept as tombstones with deleted set to true and the deletion window start time, so that late batch-export or redelivered messages cannot overwrite a more recent state. Tombstones get an expireAt timestamp, tombstoneTTLDays after the deletion, 30 by default, and a Firestore TTL policy on this field, deployed with the instance, deletes them once expired.
+
+- The stale check compares versions of the same content type only, the content type being recorded in each cached document.
+
+- Skipped stale writes are logged as "finish skip stale" and counted by the ram_stale_skipped log based metric.
+
 - Cloud FireStore share the same project's default location than Cloud Storage and App Engine.
 
 - https://cloud.google.com/firestore/docs/locations#default-cloud-location
@@ -41,6 +41,17 @@ func (instanceDeployment *InstanceDeployment) Deploy() (err error) {
 		if err = instanceDeployment.deployGPSTopic(); err != nil {
 			return err
 		}
+	} else {
+		// Check only: prerequisites drift, missing and stale roles
+		if err = instanceDeployment.deployGAEApp(); err != nil {
+			return err
+		}
+		if err = instanceDeployment.deployGRMProjectBindings(); err != nil {
+			return err
+		}
+	}
+	if err = instanceDeployment.deployGFSTTLPolicy(); err != nil {
+		return err
 	}
 	if err = instanceDeployment.deployGCFFunction(); err != nil {
 		return err
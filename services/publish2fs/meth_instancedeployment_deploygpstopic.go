@@ -22,5 +22,17 @@ func (instanceDeployment *InstanceDeployment) deployGPSTopic() (err error) {
 	topicDeployment := gps.NewTopicDeployment()
 	topicDeployment.Core = instanceDeployment.Core
 	topicDeployment.Settings.TopicName = instanceDeployment.Settings.Instance.GCF.TriggerTopic
-	return topicDeployment.Deploy()
+	err = topicDeployment.Deploy()
+	if err != nil {
+		return err
+	}
+
+	if instanceDeployment.Core.SolutionSettings.Hosting.Pubsub.TopicNames.AssetChanges != "" {
+		topicDeployment.Settings.TopicName = instanceDeployment.Core.SolutionSettings.Hosting.Pubsub.TopicNames.AssetChanges
+		err = topicDeployment.Deploy()
+		if err != nil {
+			return err
+		}
+	}
+	return nil
 }
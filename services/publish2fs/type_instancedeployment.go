@@ -20,6 +20,7 @@ import (
 	"github.com/BrunoReboul/ram/utilities/deploy"
 	"github.com/BrunoReboul/ram/utilities/gcb"
 	"github.com/BrunoReboul/ram/utilities/gcf"
+	"github.com/BrunoReboul/ram/utilities/glo"
 	"github.com/BrunoReboul/ram/utilities/gsu"
 	"github.com/BrunoReboul/ram/utilities/iamgt"
 	"google.golang.org/api/iam/v1"
@@ -31,13 +32,16 @@ type InstanceDeployment struct {
 	Core          *deploy.Core
 	Settings      struct {
 		Service struct {
-			GSU gsu.Parameters
-			IAM iamgt.Parameters
-			GCB gcb.Parameters
-			GCF gcf.Parameters
+			GSU              gsu.Parameters
+			IAM              iamgt.Parameters
+			GCB              gcb.Parameters
+			GCF              gcf.Parameters
+			GLO              glo.LogParameters
+			TombstoneTTLDays int64 `yaml:"tombstoneTTLDays" valid:"inRange(1|3650)"`
 		}
 		Instance struct {
 			GCF gcf.Event
+			GLO glo.LogParameters
 		}
 	}
 }
@@ -61,17 +65,24 @@ func NewInstanceDeployment() *InstanceDeployment {
 	instanceDeployment.Settings.Service.GCB.ServiceAccountBindings.GRM.Hosting.Project.CustomRoles = []string{
 		projectDeployCoreRole().Title,
 		iamgt.ProjectDeployExtendedRole().Title}
+	// Firestore ttl policies are field index configurations, not supported in custom roles
+	instanceDeployment.Settings.Service.GCB.ServiceAccountBindings.GRM.Hosting.Project.Roles = []string{
+		"roles/datastore.indexAdmin"}
 	instanceDeployment.Settings.Service.GCB.ServiceAccountBindings.IAM.RolesOnServiceAccounts = []string{
 		"roles/iam.serviceAccountUser"}
 
 	// Data store permissions are not supported in custom roles
 	instanceDeployment.Settings.Service.GCF.ServiceAccountBindings.GRM.Hosting.Project.Roles = []string{
-		"roles/datastore.owner"}
+		"roles/datastore.owner",
+		"roles/pubsub.publisher"}
 
 	instanceDeployment.Settings.Service.GCF.AvailableMemoryMb = 128
 	instanceDeployment.Settings.Service.GCF.RetryTimeOutSeconds = 3600
 	instanceDeployment.Settings.Service.GCF.Timeout = "60s"
 
+	// Far beyond the retry timeout, after which late messages are no longer processed
+	instanceDeployment.Settings.Service.TombstoneTTLDays = 30
+
 	return &instanceDeployment
 }
 
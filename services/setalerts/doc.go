@@ -0,0 +1,40 @@
+// Copyright 2020 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the 'License');
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an 'AS IS' BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+/*
+Package setalerts set cloud monitoring alert policies and their notification channels for RAM
+
+Instances
+
+Multiple: one per alert policy.
+
+Alert policies are defined in instance.yaml with conditions of two kinds:
+
+- threshold: a metric filter, e.g. on the RAM log based metrics ram_execution_status or ram_latency, aggregated and compared to a threshold value.
+
+- burn rate: the burn rate of a service level objective over a lookback period, e.g. the RAM freshness SLOs.
+
+Notification channels are defined in the same instance.yaml, identified by their display name, and shared across alert policies using the same display name.
+
+Output
+
+Cloud Monitoring notification channels and alert policy configured in the stackdriver project.
+
+Check
+
+-check reports the drift of the alert policy and notification channels configuration.
+The values of sensitive notification channel labels, e.g. auth tokens, are obfuscated when read and then reported as a drift.
+
+*/
+package setalerts
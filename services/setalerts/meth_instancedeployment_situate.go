@@ -0,0 +1,35 @@
+// Copyright 2020 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the 'License');
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an 'AS IS' BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package setalerts
+
+import (
+	"github.com/BrunoReboul/ram/utilities/mon"
+	monitoringv3 "google.golang.org/api/monitoring/v3"
+)
+
+// Situate complement settings taking in account the situation for service and instance settings
+func (instanceDeployment *InstanceDeployment) Situate() (err error) {
+	instanceDeployment.Artifacts.AlertPolicy, err = mon.BuildAlertPolicy(instanceDeployment.Settings.Instance.MON,
+		instanceDeployment.Core.SolutionSettings.Hosting.Stackdriver.ProjectID)
+	if err != nil {
+		return err
+	}
+	instanceDeployment.Artifacts.NotificationChannels = []monitoringv3.NotificationChannel{}
+	for _, notificationChannelParameters := range instanceDeployment.Settings.Instance.MON.NotificationChannels {
+		instanceDeployment.Artifacts.NotificationChannels = append(instanceDeployment.Artifacts.NotificationChannels,
+			mon.BuildNotificationChannel(notificationChannelParameters))
+	}
+	return nil
+}
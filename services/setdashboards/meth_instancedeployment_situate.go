@@ -40,9 +40,7 @@ func (instanceDeployment *InstanceDeployment) Situate() (err error) {
 		}
 	}
 	if instanceDeployment.Settings.Instance.MON.SLOFreshnessLayout.SLO != 0 {
-		grouwthFactor := math.Sqrt(2)
-		scale := 0.01
-		thresholdSeconds := scale * math.Pow(grouwthFactor, float64(instanceDeployment.Settings.Instance.MON.SLOFreshnessLayout.CutOffBucketNumber))
+		thresholdSeconds := mon.GetFreshnessThresholdSeconds(instanceDeployment.Settings.Instance.MON.SLOFreshnessLayout.CutOffBucketNumber)
 		var thresholdText string
 		if thresholdSeconds < 60 {
 			thresholdText = fmt.Sprintf("%g seconds", math.Round(thresholdSeconds))
@@ -13,14 +13,18 @@
 // limitations under the License.
 
 /*
-Package setfeeds set Cloud Asset Inventory feeds at organization level
+Package setfeeds set Cloud Asset Inventory feeds at organization, folder or project level
 
-Instances per targeted GCP organization, per environment
+Instances per monitoring scope, per environment. Scopes are monitoring.organizationIDs, monitoring.folderIDs and monitoring.projectIDs, e.g. setfeeds_folder123456_iam_policies
 
 - one feed for all iam policies
 
 - one feed per asset type for resource metadata.
 
+- one feed per other content type when monitoring.assetTypes lists asset types for it: org policies, access policies (access policies, access levels, service perimeters), os inventories, relationships.
+
+Each content type feed delivers to its own topic, hosting.pubsub.topicNames: IAMPolicies, orgPolicies (default cai-org-policies), accessPolicies (default cai-access-policies), osInventories (default cai-os-inventories), relationships (default cai-relationships).
+
 Output
 
 Cloud Asset Inventory feeds set up.
@@ -28,7 +32,9 @@ Cloud Asset Inventory feeds set up.
 
 Notes
 
-- The default quota is 100 feeds at organization level.
+- Folder scopes require organization level access, as their custom roles are created in the folder organization, see dumpinventory. Use project scopes when it is not granted.
+
+- The default quota is 100 feeds at organization level, same at folder and project levels.
 
 - It may be too small to accomodate multiple environments.
 
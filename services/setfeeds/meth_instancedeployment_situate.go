@@ -16,6 +16,7 @@ package setfeeds
 
 import (
 	"fmt"
+	"strings"
 
 	"github.com/BrunoReboul/ram/utilities/cai"
 	assetpb "google.golang.org/genproto/googleapis/cloud/asset/v1"
@@ -35,12 +36,33 @@ func (instanceDeployment *InstanceDeployment) Situate() (err error) {
 			assetShortName)
 		instanceDeployment.Artifacts.TopicName = fmt.Sprintf("cai-rces-%s", assetShortName)
 		return nil
-	case "IAM_POLICY":
-		instanceDeployment.Artifacts.ContentType = assetpb.ContentType_IAM_POLICY
-		instanceDeployment.Artifacts.FeedName = fmt.Sprintf("ram-%s-iam-policies", instanceDeployment.Core.EnvironmentName)
-		instanceDeployment.Artifacts.TopicName = instanceDeployment.Core.SolutionSettings.Hosting.Pubsub.TopicNames.IAMPolicies
+	case "IAM_POLICY", "ORG_POLICY", "ACCESS_POLICY", "OS_INVENTORY", "RELATIONSHIP":
+		// one feed for all asset types, e.g. ram-dev-iam-policies, ram-dev-org-policies, ram-dev-relationships
+		instanceDeployment.Artifacts.ContentType = assetpb.ContentType(assetpb.ContentType_value[instanceDeployment.Settings.Instance.CAI.ContentType])
+		instanceDeployment.Artifacts.FeedName = fmt.Sprintf("ram-%s-%s",
+			instanceDeployment.Core.EnvironmentName,
+			getContentTypeShortName(instanceDeployment.Settings.Instance.CAI.ContentType))
+		instanceDeployment.Artifacts.TopicName = instanceDeployment.Core.SolutionSettings.GetContentTypeTopicName(instanceDeployment.Settings.Instance.CAI.ContentType)
 		return nil
 	default:
 		return fmt.Errorf("Unsupported instanceDeployment.Settings.Instance.CAI.ContentType %s", instanceDeployment.Settings.Instance.CAI.ContentType)
 	}
 }
+
+// getContentTypeShortName returns a plural short name, e.g. IAM_POLICY iam-policies, OS_INVENTORY os-inventories
+func getContentTypeShortName(contentType string) string {
+	switch contentType {
+	case "IAM_POLICY":
+		return "iam-policies"
+	case "ORG_POLICY":
+		return "org-policies"
+	case "ACCESS_POLICY":
+		return "access-policies"
+	case "OS_INVENTORY":
+		return "os-inventories"
+	case "RELATIONSHIP":
+		return "relationships"
+	default:
+		return strings.ToLower(strings.Replace(contentType, "_", "-", -1))
+	}
+}
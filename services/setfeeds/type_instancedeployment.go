@@ -101,6 +101,11 @@ func monitoringOrgDeployCoreRole() (role iam.Role) {
 		"cloudasset.feeds.create",
 		"cloudasset.feeds.update",
 		"cloudasset.assets.exportResource",
-		"cloudasset.assets.exportIamPolicy"}
+		"cloudasset.assets.exportIamPolicy",
+		"cloudasset.assets.exportOrgPolicy",
+		"cloudasset.assets.exportAccessPolicy",
+		"cloudasset.assets.exportAccessLevel",
+		"cloudasset.assets.exportServicePerimeter",
+		"cloudasset.assets.exportOSInventories"}
 	return role
 }
@@ -21,6 +21,8 @@ Multiple.
 
 At least one to export gsuite admin logs, related to groups members and groups settings, from GCP Cloud Audit Logs at organization level.
 
+One per organization to export GCP admin activity audit logs, used to correlate asset changes, resource and IAM policy ones, with who made them.
+
 Output
 
 Logging sink export set up.
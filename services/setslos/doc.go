@@ -0,0 +1,37 @@
+// Copyright 2020 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the 'License');
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an 'AS IS' BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+/*
+Package setslos set cloud monitoring service level objectives for RAM
+
+Instances
+
+Multiple: one per freshness service level objective.
+
+Each instance sets a service monitoring custom service, e.g. one per flow, and a service level objective on this service, e.g. one per origin.
+
+The service level indicator is request based: a distribution cut on the RAM log based metric ram_latency, filtered on the origin, where good events are the ones under the cut off bucket threshold. Instance setting metricID: ram_latency_e2e opts in for the end to end latency.
+
+The error budget and burn rate of these objectives can then be alerted on, see setalerts.
+
+Output
+
+Cloud Monitoring custom service and service level objective configured in the stackdriver project.
+
+Check
+
+-check reports the drift of the custom service and service level objective configuration.
+
+*/
+package setslos
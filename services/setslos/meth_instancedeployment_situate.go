@@ -0,0 +1,29 @@
+// Copyright 2020 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the 'License');
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an 'AS IS' BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package setslos
+
+import (
+	"github.com/BrunoReboul/ram/utilities/mon"
+)
+
+// Situate complement settings taking in account the situation for service and instance settings
+func (instanceDeployment *InstanceDeployment) Situate() (err error) {
+	instanceDeployment.Artifacts.Service.DisplayName = instanceDeployment.Settings.Instance.MON.ServiceDisplayName
+	if instanceDeployment.Artifacts.Service.DisplayName == "" {
+		instanceDeployment.Artifacts.Service.DisplayName = instanceDeployment.Settings.Instance.MON.ServiceID
+	}
+	instanceDeployment.Artifacts.ServiceLevelObjective, err = mon.BuildServiceLevelObjective(instanceDeployment.Settings.Instance.MON)
+	return err
+}
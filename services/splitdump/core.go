@@ -47,9 +47,12 @@ type Global struct {
 	ctx                        context.Context
 	environment                string
 	firestoreClient            *firestore.Client
-	iamTopicName               string
+	contentTypeTopicNames      map[string]string
+	dumpOperation              *gfs.DumpOperation
 	instanceName               string
+	logger                     *glo.Logger
 	microserviceName           string
+	origin                     string
 	projectID                  string
 	PubSubID                   string
 	pubsubPublisherClient      *pubsub.PublisherClient
@@ -63,11 +66,18 @@ type Global struct {
 
 // asset uses the new CAI feed format
 type asset struct {
-	Name      string          `json:"name"`
-	AssetType string          `json:"assetType"`
-	Ancestors []string        `json:"ancestors"`
-	IamPolicy json.RawMessage `json:"iamPolicy"`
-	Resource  json.RawMessage `json:"resource"`
+	Name             string          `json:"name"`
+	AssetType        string          `json:"assetType"`
+	Ancestors        []string        `json:"ancestors"`
+	IamPolicy        json.RawMessage `json:"iamPolicy"`
+	Resource         json.RawMessage `json:"resource"`
+	OrgPolicy        json.RawMessage `json:"orgPolicy,omitempty"`
+	AccessPolicy     json.RawMessage `json:"accessPolicy,omitempty"`
+	AccessLevel      json.RawMessage `json:"accessLevel,omitempty"`
+	ServicePerimeter json.RawMessage `json:"servicePerimeter,omitempty"`
+	OsInventory      json.RawMessage `json:"osInventory,omitempty"`
+	RelatedAssets    json.RawMessage `json:"relatedAssets,omitempty"`
+	RelatedAsset     json.RawMessage `json:"relatedAsset,omitempty"`
 }
 
 // feedMessage Cloud Asset Inventory feed message
@@ -81,11 +91,18 @@ type feedMessage struct {
 // assetLegacy uses the CAI export legacy format, not the new CAI feed format
 // aka asset_type instead of assetType, iam_policy instead of iamPolicy
 type assetLegacy struct {
-	Name      string          `json:"name"`
-	AssetType string          `json:"asset_type"`
-	Ancestors []string        `json:"ancestors"`
-	IamPolicy json.RawMessage `json:"iam_policy"`
-	Resource  json.RawMessage `json:"resource"`
+	Name             string          `json:"name"`
+	AssetType        string          `json:"asset_type"`
+	Ancestors        []string        `json:"ancestors"`
+	IamPolicy        json.RawMessage `json:"iam_policy"`
+	Resource         json.RawMessage `json:"resource"`
+	OrgPolicy        json.RawMessage `json:"org_policy"`
+	AccessPolicy     json.RawMessage `json:"access_policy"`
+	AccessLevel      json.RawMessage `json:"access_level"`
+	ServicePerimeter json.RawMessage `json:"service_perimeter"`
+	OsInventory      json.RawMessage `json:"os_inventory"`
+	RelatedAssets    json.RawMessage `json:"related_assets"`
+	RelatedAsset     json.RawMessage `json:"related_asset"`
 }
 
 // Initialize is to be executed in the init() function of the cloud function to optimize the cold start
@@ -116,16 +133,30 @@ func Initialize(ctx context.Context, global *Global) (err error) {
 	global.instanceName = instanceDeployment.Core.InstanceName
 	global.microserviceName = instanceDeployment.Core.ServiceName
 
-	log.Println(glo.Entry{
-		MicroserviceName: global.microserviceName,
-		InstanceName:     global.instanceName,
-		Environment:      global.environment,
-		Severity:         "NOTICE",
-		Message:          "coldstart",
-		InitID:           initID,
-	})
+	global.logger = glo.NewLogger(global.microserviceName, global.instanceName, global.environment, initID)
+	global.logger.SetLogParameters(glo.GetLogParameters(instanceDeployment.Settings.Service.GLO, instanceDeployment.Settings.Instance.GLO))
+	global.logger.Notice("coldstart", "")
 
-	global.iamTopicName = instanceDeployment.Core.SolutionSettings.Hosting.Pubsub.TopicNames.IAMPolicies
+	err = glo.InitTracing(ctx,
+		instanceDeployment.Core.SolutionSettings.Hosting.Tracing.Exporter,
+		instanceDeployment.Core.SolutionSettings.Hosting.Tracing.OTLPEndpoint,
+		instanceDeployment.Core.SolutionSettings.Hosting.Tracing.FilePath,
+		global.microserviceName,
+		global.instanceName,
+		global.environment)
+	if err != nil {
+		global.logger.InitFailed(fmt.Sprintf("glo.InitTracing %v", err))
+		return err
+	}
+
+	global.contentTypeTopicNames = make(map[string]string)
+	for _, contentType := range []string{cai.ContentTypeIAMPolicy,
+		cai.ContentTypeOrgPolicy,
+		cai.ContentTypeAccessPolicy,
+		cai.ContentTypeOSInventory,
+		cai.ContentTypeRelationship} {
+		global.contentTypeTopicNames[contentType] = instanceDeployment.Core.SolutionSettings.GetContentTypeTopicName(contentType)
+	}
 	global.projectID = instanceDeployment.Core.SolutionSettings.Hosting.ProjectID
 	global.retryTimeOutSeconds = instanceDeployment.Settings.Service.GCF.RetryTimeOutSeconds
 	global.scannerBufferSizeKiloBytes = instanceDeployment.Settings.Instance.ScannerBufferSizeKiloBytes
@@ -133,42 +164,18 @@ func Initialize(ctx context.Context, global *Global) (err error) {
 
 	storageClient, err = storage.NewClient(ctx)
 	if err != nil {
-		log.Println(glo.Entry{
-			MicroserviceName: global.microserviceName,
-			InstanceName:     global.instanceName,
-			Environment:      global.environment,
-			Severity:         "CRITICAL",
-			Message:          "init_failed",
-			Description:      fmt.Sprintf("storage.NewClient(ctx) %v", err),
-			InitID:           initID,
-		})
+		global.logger.InitFailed(fmt.Sprintf("storage.NewClient(ctx) %v", err))
 		return err
 	}
 	global.storageBucket = storageClient.Bucket(instanceDeployment.Core.SolutionSettings.Hosting.GCS.Buckets.CAIExport.Name)
 	global.pubsubPublisherClient, err = pubsub.NewPublisherClient(global.ctx)
 	if err != nil {
-		log.Println(glo.Entry{
-			MicroserviceName: global.microserviceName,
-			InstanceName:     global.instanceName,
-			Environment:      global.environment,
-			Severity:         "CRITICAL",
-			Message:          "init_failed",
-			Description:      fmt.Sprintf("pubsub.NewPublisherClient(global.ctx) %v", err),
-			InitID:           initID,
-		})
+		global.logger.InitFailed(fmt.Sprintf("pubsub.NewPublisherClient(global.ctx) %v", err))
 		return err
 	}
 	global.firestoreClient, err = firestore.NewClient(global.ctx, global.projectID)
 	if err != nil {
-		log.Println(glo.Entry{
-			MicroserviceName: global.microserviceName,
-			InstanceName:     global.instanceName,
-			Environment:      global.environment,
-			Severity:         "CRITICAL",
-			Message:          "init_failed",
-			Description:      fmt.Sprintf("firestore.NewClient %v", err),
-			InitID:           initID,
-		})
+		global.logger.InitFailed(fmt.Sprintf("firestore.NewClient %v", err))
 		return err
 	}
 	return nil
@@ -179,15 +186,7 @@ func EntryPoint(ctxEvent context.Context, gcsEvent gcs.Event, global *Global) er
 	metadata, err := metadata.FromContext(ctxEvent)
 	if err != nil {
 		// Assume an error on the function invoker and try again.
-		log.Println(glo.Entry{
-			MicroserviceName:   global.microserviceName,
-			InstanceName:       global.instanceName,
-			Environment:        global.environment,
-			Severity:           "CRITICAL",
-			Message:            "redo_on_transient",
-			Description:        fmt.Sprintf("pubsub_id no available metadata.FromContext: %v", err),
-			TriggeringPubsubID: global.PubSubID,
-		})
+		global.logger.RedoOnTransient(fmt.Sprintf("pubsub_id no available metadata.FromContext: %v", err))
 		return err
 	}
 	global.stepStack = nil
@@ -197,34 +196,15 @@ func EntryPoint(ctxEvent context.Context, gcsEvent gcs.Event, global *Global) er
 		StepID:        fmt.Sprintf("%s/%s", parts[len(parts)-1], global.PubSubID),
 		StepTimestamp: metadata.Timestamp,
 	}
+	global.logger.SetInvocation(global.PubSubID, global.step)
+	defer func() {
+		glo.RecordStepSpan(global.ctx, global.microserviceName, nil, global.stepStack)
+	}()
 
-	now := time.Now()
-	d := now.Sub(metadata.Timestamp)
-	log.Println(glo.Entry{
-		MicroserviceName:           global.microserviceName,
-		InstanceName:               global.instanceName,
-		Environment:                global.environment,
-		Severity:                   "NOTICE",
-		Message:                    "start",
-		TriggeringPubsubID:         global.PubSubID,
-		TriggeringPubsubAgeSeconds: d.Seconds(),
-		TriggeringPubsubTimestamp:  &metadata.Timestamp,
-		Now:                        &now,
-	})
+	d := global.logger.Start()
 
 	if d.Seconds() > float64(global.retryTimeOutSeconds) {
-		log.Println(glo.Entry{
-			MicroserviceName:           global.microserviceName,
-			InstanceName:               global.instanceName,
-			Environment:                global.environment,
-			Severity:                   "CRITICAL",
-			Message:                    "noretry",
-			Description:                "Pubsub message too old",
-			TriggeringPubsubID:         global.PubSubID,
-			TriggeringPubsubAgeSeconds: d.Seconds(),
-			TriggeringPubsubTimestamp:  &metadata.Timestamp,
-			Now:                        &now,
-		})
+		global.logger.NoRetryTooOld()
 		return nil
 	}
 
@@ -257,77 +237,29 @@ func EntryPoint(ctxEvent context.Context, gcsEvent gcs.Event, global *Global) er
 	// }
 
 	if gcsEvent.ResourceState == "not_exists" {
-		log.Println(glo.Entry{
-			MicroserviceName:   global.microserviceName,
-			InstanceName:       global.instanceName,
-			Environment:        global.environment,
-			Severity:           "NOTICE",
-			Message:            "cancel",
-			Description:        fmt.Sprintf("deleted object %v", gcsEvent.Name),
-			TriggeringPubsubID: global.PubSubID,
-		})
+		global.logger.Cancel(fmt.Sprintf("deleted object %v", gcsEvent.Name))
 		return nil
 	}
 	if gcsEvent.Size == "0" {
-		log.Println(glo.Entry{
-			MicroserviceName:   global.microserviceName,
-			InstanceName:       global.instanceName,
-			Environment:        global.environment,
-			Severity:           "NOTICE",
-			Message:            "cancel",
-			Description:        fmt.Sprintf("empty object %v", gcsEvent.Name),
-			TriggeringPubsubID: global.PubSubID,
-		})
+		global.logger.Cancel(fmt.Sprintf("empty object %v", gcsEvent.Name))
 		return nil
 	}
 	matched, _ := regexp.Match(`dumpinventory.*.dump`, []byte(gcsEvent.Name))
 	if !matched {
-		log.Println(glo.Entry{
-			MicroserviceName:   global.microserviceName,
-			InstanceName:       global.instanceName,
-			Environment:        global.environment,
-			Severity:           "NOTICE",
-			Message:            "cancel",
-			Description:        fmt.Sprintf("not a cai dump %v", gcsEvent.Name),
-			TriggeringPubsubID: global.PubSubID,
-		})
+		global.logger.Cancel(fmt.Sprintf("not a cai dump %v", gcsEvent.Name))
 		return nil
 	}
 	if gcsEvent.Metageneration == "1" {
 		// The metageneration attribute is updated on metadata changes.
 		// The on create value is 1.
-		log.Println(glo.Entry{
-			MicroserviceName:   global.microserviceName,
-			InstanceName:       global.instanceName,
-			Environment:        global.environment,
-			Severity:           "INFO",
-			Message:            fmt.Sprintf("new object tirgger %s", gcsEvent.Name),
-			Description:        fmt.Sprintf("size %s", gcsEvent.Size),
-			TriggeringPubsubID: global.PubSubID,
-		})
+		global.logger.Info(fmt.Sprintf("new object tirgger %s", gcsEvent.Name), fmt.Sprintf("size %s", gcsEvent.Size))
 	} else {
-		log.Println(glo.Entry{
-			MicroserviceName:   global.microserviceName,
-			InstanceName:       global.instanceName,
-			Environment:        global.environment,
-			Severity:           "INFO",
-			Message:            fmt.Sprintf("updated object trigger %s", gcsEvent.Name),
-			Description:        fmt.Sprintf("size %s", gcsEvent.Size),
-			TriggeringPubsubID: global.PubSubID,
-		})
+		global.logger.Info(fmt.Sprintf("updated object trigger %s", gcsEvent.Name), fmt.Sprintf("size %s", gcsEvent.Size))
 	}
 	storageObject := global.storageBucket.Object(gcsEvent.Name)
 	storageObjectReader, err := storageObject.NewReader(global.ctx)
 	if err != nil {
-		log.Println(glo.Entry{
-			MicroserviceName:   global.microserviceName,
-			InstanceName:       global.instanceName,
-			Environment:        global.environment,
-			Severity:           "CRITICAL",
-			Message:            "redo_on_transient",
-			Description:        fmt.Sprintf("storageObject.NewReader(global.ctx) %v", err),
-			TriggeringPubsubID: global.PubSubID,
-		})
+		global.logger.RedoOnTransient(fmt.Sprintf("storageObject.NewReader(global.ctx) %v", err))
 		return err
 	}
 	defer storageObjectReader.Close()
@@ -336,15 +268,7 @@ func EntryPoint(ctxEvent context.Context, gcsEvent gcs.Event, global *Global) er
 	var topicList []string
 	err = gps.GetTopicList(global.ctx, global.pubsubPublisherClient, global.projectID, &topicList)
 	if err != nil {
-		log.Println(glo.Entry{
-			MicroserviceName:   global.microserviceName,
-			InstanceName:       global.instanceName,
-			Environment:        global.environment,
-			Severity:           "CRITICAL",
-			Message:            "redo_on_transient",
-			Description:        fmt.Sprintf("gps.GetTopicList %v", err),
-			TriggeringPubsubID: global.PubSubID,
-		})
+		global.logger.RedoOnTransient(fmt.Sprintf("gps.GetTopicList %v", err))
 		return err
 	}
 
@@ -358,8 +282,24 @@ func EntryPoint(ctxEvent context.Context, gcsEvent gcs.Event, global *Global) er
 	gcsStep.StepID = gcsEvent.ID
 	global.stepStack = append(global.stepStack, gcsStep)
 	global.stepStack = append(global.stepStack, global.step)
+	global.logger.SetStepStack(global.stepStack)
 
 	startTime = gcsEvent.Updated
+	global.origin = "batch-export"
+	global.dumpOperation = nil
+	dumpOperation, found, err := gfs.GetDumpOperation(global.ctx, gcsEvent.Name, global.firestoreClient, 5)
+	if err != nil {
+		global.logger.Warning("cannot get dump operation", fmt.Sprintf("gfs.GetDumpOperation %s %v", gcsEvent.Name, err))
+	}
+	if found {
+		// Child dumps inherit the parent dump operation
+		global.dumpOperation = &dumpOperation
+		// A point-in-time export reflects the inventory at its read time, not at the dump time
+		if dumpOperation.Origin == "historical-export" {
+			global.origin = dumpOperation.Origin
+			startTime = dumpOperation.ReadTime
+		}
+	}
 	dumpLineNumber = 0
 	scanner := bufio.NewScanner(teeStorageObjectReader)
 	scannerBuffer := make([]byte, global.scannerBufferSizeKiloBytes*1024)
@@ -379,56 +319,14 @@ func EntryPoint(ctxEvent context.Context, gcsEvent gcs.Event, global *Global) er
 			childDumpNumber,
 			global)
 		if err != nil {
-			log.Println(glo.Entry{
-				MicroserviceName:   global.microserviceName,
-				InstanceName:       global.instanceName,
-				Environment:        global.environment,
-				Severity:           "CRITICAL",
-				Message:            "noretry",
-				Description:        fmt.Sprintf("splitToChildDumps %v", err),
-				TriggeringPubsubID: global.PubSubID,
-			})
+			global.logger.NoRetry(fmt.Sprintf("splitToChildDumps %v", err))
 			return nil
 		}
 		childDumpNumber++
-		now := time.Now()
-		latency := now.Sub(global.step.StepTimestamp)
-		latencyE2E := now.Sub(global.stepStack[0].StepTimestamp)
-		log.Println(glo.Entry{
-			MicroserviceName:     global.microserviceName,
-			InstanceName:         global.instanceName,
-			Environment:          global.environment,
-			Severity:             "NOTICE",
-			Message:              fmt.Sprintf("finish split to %d childDumps %s", childDumpNumber, gcsEvent.Name),
-			Description:          fmt.Sprintf("dumpLineNumber %d gcsEvent.Generation %s duration %v", dumpLineNumber, gcsEvent.Generation, duration),
-			Now:                  &now,
-			TriggeringPubsubID:   global.PubSubID,
-			OriginEventTimestamp: &global.stepStack[0].StepTimestamp,
-			LatencySeconds:       latency.Seconds(),
-			LatencyE2ESeconds:    latencyE2E.Seconds(),
-			StepStack:            global.stepStack,
-			AssetInventoryOrigin: "batch-export",
-		})
+		global.logger.Finish(fmt.Sprintf("finish split to %d childDumps %s", childDumpNumber, gcsEvent.Name), fmt.Sprintf("dumpLineNumber %d gcsEvent.Generation %s duration %v", dumpLineNumber, gcsEvent.Generation, duration), global.origin)
 	} else {
 		dumpLineNumber, duration = splitToLines(buffer, global, &pubSubMsgNumber, &topicList, startTime)
-		now := time.Now()
-		latency := now.Sub(global.step.StepTimestamp)
-		latencyE2E := now.Sub(global.stepStack[0].StepTimestamp)
-		log.Println(glo.Entry{
-			MicroserviceName:     global.microserviceName,
-			InstanceName:         global.instanceName,
-			Environment:          global.environment,
-			Severity:             "NOTICE",
-			Message:              fmt.Sprintf("finish split to %d lines %s", dumpLineNumber, gcsEvent.Name),
-			Description:          fmt.Sprintf("pubSubMsgNumber %d gcsEvent.Generation %v duration %v", pubSubMsgNumber, gcsEvent.Generation, duration),
-			Now:                  &now,
-			TriggeringPubsubID:   global.PubSubID,
-			OriginEventTimestamp: &global.stepStack[0].StepTimestamp,
-			LatencySeconds:       latency.Seconds(),
-			LatencyE2ESeconds:    latencyE2E.Seconds(),
-			StepStack:            global.stepStack,
-			AssetInventoryOrigin: "batch-export",
-		})
+		global.logger.Finish(fmt.Sprintf("finish split to %d lines %s", dumpLineNumber, gcsEvent.Name), fmt.Sprintf("pubSubMsgNumber %d gcsEvent.Generation %v duration %v", pubSubMsgNumber, gcsEvent.Generation, duration), global.origin)
 	}
 	return nil
 }
@@ -465,15 +363,7 @@ func splitToChildDumps(buffer bytes.Buffer, parentDumpName string, parentGenerat
 			for i = 0; i < 10; i++ {
 				_, err = fmt.Fprint(storageObjectWriter, childDumpContent)
 				if err != nil {
-					log.Println(glo.Entry{
-						MicroserviceName:   global.microserviceName,
-						InstanceName:       global.instanceName,
-						Environment:        global.environment,
-						Severity:           "WARNING",
-						Message:            "fmt.Fprint(storageObjectWriter, childDumpContent)",
-						Description:        fmt.Sprintf("iteration %d err %v", i, err),
-						TriggeringPubsubID: global.PubSubID,
-					})
+					global.logger.Warning("fmt.Fprint(storageObjectWriter, childDumpContent)", fmt.Sprintf("iteration %d err %v", i, err))
 					time.Sleep(i * 100 * time.Millisecond)
 				} else {
 					done = true
@@ -488,15 +378,7 @@ func splitToChildDumps(buffer bytes.Buffer, parentDumpName string, parentGenerat
 			for i = 0; i < 10; i++ {
 				err = storageObjectWriter.Close()
 				if err != nil {
-					log.Println(glo.Entry{
-						MicroserviceName:   global.microserviceName,
-						InstanceName:       global.instanceName,
-						Environment:        global.environment,
-						Severity:           "WARNING",
-						Message:            fmt.Sprintf("storageObjectWriter.Close() %s", childDumpName),
-						Description:        fmt.Sprintf("iteration %d dumpLineNumber %d childDumpLineNumber %d err %v", i, dumpLineNumber, childDumpLineNumber, err),
-						TriggeringPubsubID: global.PubSubID,
-					})
+					global.logger.Warning(fmt.Sprintf("storageObjectWriter.Close() %s", childDumpName), fmt.Sprintf("iteration %d dumpLineNumber %d childDumpLineNumber %d err %v", i, dumpLineNumber, childDumpLineNumber, err))
 					time.Sleep(i * 100 * time.Millisecond)
 				} else {
 					done = true
@@ -510,20 +392,14 @@ func splitToChildDumps(buffer bytes.Buffer, parentDumpName string, parentGenerat
 				childDumpName,
 				global.firestoreClient,
 				global.stepStack,
+				global.dumpOperation,
 				global.microserviceName,
 				global.instanceName,
 				global.environment,
 				global.PubSubID,
 				5)
 			if err != nil {
-				log.Println(glo.Entry{
-					MicroserviceName:   global.microserviceName,
-					InstanceName:       global.instanceName,
-					Environment:        global.environment,
-					Severity:           "WARNING",
-					Message:            fmt.Sprintf("recordDump %v", err),
-					TriggeringPubsubID: global.PubSubID,
-				})
+				global.logger.Warning(fmt.Sprintf("recordDump %v", err), "")
 			}
 
 			childDumpNumber++
@@ -540,15 +416,7 @@ func splitToChildDumps(buffer bytes.Buffer, parentDumpName string, parentGenerat
 	for i = 0; i < 10; i++ {
 		_, err = fmt.Fprint(storageObjectWriter, childDumpContent)
 		if err != nil {
-			log.Println(glo.Entry{
-				MicroserviceName:   global.microserviceName,
-				InstanceName:       global.instanceName,
-				Environment:        global.environment,
-				Severity:           "WARNING",
-				Message:            "fmt.Fprint(storageObjectWriter, childDumpContent)",
-				Description:        fmt.Sprintf("iteration %d err %v", i, err),
-				TriggeringPubsubID: global.PubSubID,
-			})
+			global.logger.Warning("fmt.Fprint(storageObjectWriter, childDumpContent)", fmt.Sprintf("iteration %d err %v", i, err))
 			time.Sleep(i * 100 * time.Millisecond)
 		} else {
 			done = true
@@ -563,15 +431,7 @@ func splitToChildDumps(buffer bytes.Buffer, parentDumpName string, parentGenerat
 	for i = 0; i < 10; i++ {
 		err = storageObjectWriter.Close()
 		if err != nil {
-			log.Println(glo.Entry{
-				MicroserviceName:   global.microserviceName,
-				InstanceName:       global.instanceName,
-				Environment:        global.environment,
-				Severity:           "WARNING",
-				Message:            fmt.Sprintf("storageObjectWriter.Close() %s", childDumpName),
-				Description:        fmt.Sprintf("iteration %d dumpLineNumber %d childDumpLineNumber %d err %v", i, dumpLineNumber, childDumpLineNumber, err),
-				TriggeringPubsubID: global.PubSubID,
-			})
+			global.logger.Warning(fmt.Sprintf("storageObjectWriter.Close() %s", childDumpName), fmt.Sprintf("iteration %d dumpLineNumber %d childDumpLineNumber %d err %v", i, dumpLineNumber, childDumpLineNumber, err))
 			time.Sleep(i * 100 * time.Millisecond)
 		} else {
 			done = true
@@ -585,20 +445,14 @@ func splitToChildDumps(buffer bytes.Buffer, parentDumpName string, parentGenerat
 		childDumpName,
 		global.firestoreClient,
 		global.stepStack,
+		global.dumpOperation,
 		global.microserviceName,
 		global.instanceName,
 		global.environment,
 		global.PubSubID,
 		5)
 	if err != nil {
-		log.Println(glo.Entry{
-			MicroserviceName:   global.microserviceName,
-			InstanceName:       global.instanceName,
-			Environment:        global.environment,
-			Severity:           "WARNING",
-			Message:            fmt.Sprintf("recordDump %v", err),
-			TriggeringPubsubID: global.PubSubID,
-		})
+		global.logger.Warning(fmt.Sprintf("recordDump %v", err), "")
 	}
 
 	duration = time.Since(start)
@@ -627,60 +481,40 @@ func processDumpLine(dumpline string, global *Global, pointerTopubSubMsgNumber *
 	var topicName string
 	err := json.Unmarshal([]byte(dumpline), &assetLegacy)
 	if err != nil {
-		log.Println(glo.Entry{
-			MicroserviceName:   global.microserviceName,
-			InstanceName:       global.instanceName,
-			Environment:        global.environment,
-			Severity:           "WARNING",
-			Message:            "json.Unmarshal([]byte(dumpline), &assetLegacy)",
-			Description:        fmt.Sprintf("err %v dumpline %s", err, dumpline),
-			TriggeringPubsubID: global.PubSubID,
-		})
+		global.logger.Warning("json.Unmarshal([]byte(dumpline), &assetLegacy)", fmt.Sprintf("err %v dumpline %s", err, dumpline))
 	} else {
 		asset := transposeAsset(assetLegacy)
-		if asset.IamPolicy == nil && asset.Resource == nil {
-			log.Println(glo.Entry{
-				MicroserviceName:   global.microserviceName,
-				InstanceName:       global.instanceName,
-				Environment:        global.environment,
-				Severity:           "WARNING",
-				Message:            "ignored dump line: no IamPolicy object nor Resource object",
-				Description:        fmt.Sprintf("dumpline %s", dumpline),
-				TriggeringPubsubID: global.PubSubID,
-			})
+		contentType := cai.AssetContents{
+			Resource:         asset.Resource,
+			IamPolicy:        asset.IamPolicy,
+			OrgPolicy:        asset.OrgPolicy,
+			AccessPolicy:     asset.AccessPolicy,
+			AccessLevel:      asset.AccessLevel,
+			ServicePerimeter: asset.ServicePerimeter,
+			OsInventory:      asset.OsInventory,
+			RelatedAssets:    asset.RelatedAssets,
+			RelatedAsset:     asset.RelatedAsset,
+		}.GetContentType()
+		if contentType == "" {
+			global.logger.Warning("ignored dump line: no content object, e.g. Resource, IamPolicy, OrgPolicy", fmt.Sprintf("dumpline %s", dumpline))
 		} else {
-			if asset.IamPolicy != nil {
-				topicName = global.iamTopicName
-			} else {
+			if contentType == cai.ContentTypeResource {
 				topicName = "cai-rces-" + cai.GetAssetShortTypeName(asset.AssetType)
+			} else {
+				topicName = global.contentTypeTopicNames[contentType]
 			}
 			// log.Println("topicName", topicName)
 			if err = gps.CreateTopic(global.ctx, global.pubsubPublisherClient, topicListPointer, topicName, global.projectID); err != nil {
-				log.Println(glo.Entry{
-					MicroserviceName:   global.microserviceName,
-					InstanceName:       global.instanceName,
-					Environment:        global.environment,
-					Severity:           "WARNING",
-					Message:            fmt.Sprintf("ignored dump line: no topic to publish %s", topicName),
-					Description:        fmt.Sprintf("err %v dumpline %s", err, dumpline),
-					TriggeringPubsubID: global.PubSubID,
-				})
+				global.logger.Warning(fmt.Sprintf("ignored dump line: no topic to publish %s", topicName), fmt.Sprintf("err %v dumpline %s", err, dumpline))
 			} else {
 				feedMessageJSON, err := json.Marshal(getFeedMessage(asset, startTime, global))
 				if err != nil {
-					log.Println(glo.Entry{
-						MicroserviceName:   global.microserviceName,
-						InstanceName:       global.instanceName,
-						Environment:        global.environment,
-						Severity:           "CRITICAL",
-						Message:            "noretry",
-						Description:        fmt.Sprintf("json.Marshal(getFeedMessage(asset, startTime)) %v", err),
-						TriggeringPubsubID: global.PubSubID,
-					})
+					global.logger.NoRetry(fmt.Sprintf("json.Marshal(getFeedMessage(asset, startTime)) %v", err))
 					return err
 				}
 				var pubSubMessage pubsubpb.PubsubMessage
 				pubSubMessage.Data = feedMessageJSON
+				pubSubMessage.Attributes = glo.GetTraceAttributes(nil, global.stepStack)
 
 				var pubsubMessages []*pubsubpb.PubsubMessage
 				pubsubMessages = append(pubsubMessages, &pubSubMessage)
@@ -691,14 +525,7 @@ func processDumpLine(dumpline string, global *Global, pointerTopubSubMsgNumber *
 
 				pubsubResponse, err := global.pubsubPublisherClient.Publish(global.ctx, &publishRequest)
 				if err != nil {
-					log.Println(glo.Entry{
-						MicroserviceName:   global.microserviceName,
-						InstanceName:       global.instanceName,
-						Environment:        global.environment,
-						Severity:           "WARNING",
-						Message:            fmt.Sprintf("dump line not publihed to pubsub topic %v", err),
-						TriggeringPubsubID: global.PubSubID,
-					})
+					global.logger.Warning(fmt.Sprintf("dump line not publihed to pubsub topic %v", err), "")
 				}
 				// log.Println(glo.Entry{
 				// 	MicroserviceName:   global.microserviceName,
@@ -720,7 +547,7 @@ func processDumpLine(dumpline string, global *Global, pointerTopubSubMsgNumber *
 func getFeedMessage(asset asset, startTime time.Time, global *Global) feedMessage {
 	var feedMessage feedMessage
 	feedMessage.Asset = asset
-	feedMessage.Origin = "batch-export"
+	feedMessage.Origin = global.origin
 	feedMessage.Window.StartTime = startTime
 	feedMessage.StepStack = global.stepStack
 	return feedMessage
@@ -732,6 +559,13 @@ func transposeAsset(assetLegacy assetLegacy) asset {
 	asset.AssetType = assetLegacy.AssetType
 	asset.IamPolicy = assetLegacy.IamPolicy
 	asset.Resource = assetLegacy.Resource
+	asset.OrgPolicy = assetLegacy.OrgPolicy
+	asset.AccessPolicy = assetLegacy.AccessPolicy
+	asset.AccessLevel = assetLegacy.AccessLevel
+	asset.ServicePerimeter = assetLegacy.ServicePerimeter
+	asset.OsInventory = assetLegacy.OsInventory
+	asset.RelatedAssets = assetLegacy.RelatedAssets
+	asset.RelatedAsset = assetLegacy.RelatedAsset
 	asset.Ancestors = assetLegacy.Ancestors
 	return asset
 }
@@ -745,49 +579,20 @@ func getDumpStepStack(objectName string, global *Global, retriesNumber time.Dura
 		documentSnap, err = global.firestoreClient.Doc(documentPath).Get(global.ctx)
 		if err != nil {
 			if strings.Contains(strings.ToLower(strings.Replace(err.Error(), " ", "", -1)), "notfound") {
-				log.Println(glo.Entry{
-					MicroserviceName:   global.microserviceName,
-					InstanceName:       global.instanceName,
-					Environment:        global.environment,
-					Severity:           "WARNING",
-					Message:            "recordDump dump document does not exist",
-					Description:        fmt.Sprintf("global.firestoreClient.Doc(documentPath).Get %s %v", documentPath, err),
-					TriggeringPubsubID: global.PubSubID,
-				})
+				global.logger.Warning("recordDump dump document does not exist", fmt.Sprintf("global.firestoreClient.Doc(documentPath).Get %s %v", documentPath, err))
 				return nil
 			}
-			log.Println(glo.Entry{
-				MicroserviceName:   global.microserviceName,
-				InstanceName:       global.instanceName,
-				Environment:        global.environment,
-				Severity:           "WARNING",
-				Message:            fmt.Sprintf("iteration %d global.firestoreClient.Doc(documentPath).Get %s %v", i, documentPath, err),
-				TriggeringPubsubID: global.PubSubID,
-			})
+			global.logger.Warning(fmt.Sprintf("iteration %d global.firestoreClient.Doc(documentPath).Get %s %v", i, documentPath, err), "")
 			time.Sleep(i * 100 * time.Millisecond)
 		} else {
 			rawStepStackInterface, err := documentSnap.DataAt("stepStack")
 			if err != nil {
-				log.Println(glo.Entry{
-					MicroserviceName:   global.microserviceName,
-					InstanceName:       global.instanceName,
-					Environment:        global.environment,
-					Severity:           "WARNING",
-					Message:            fmt.Sprintf("iteration %d stepStack, err := documentSnap.DataAt %s %v", i, documentPath, err),
-					TriggeringPubsubID: global.PubSubID,
-				})
+				global.logger.Warning(fmt.Sprintf("iteration %d stepStack, err := documentSnap.DataAt %s %v", i, documentPath, err), "")
 				time.Sleep(i * 100 * time.Millisecond)
 			} else {
 				rawStepStack, ok := rawStepStackInterface.([]interface{})
 				if !ok {
-					log.Println(glo.Entry{
-						MicroserviceName:   global.microserviceName,
-						InstanceName:       global.instanceName,
-						Environment:        global.environment,
-						Severity:           "WARNING",
-						Message:            fmt.Sprintf("rawStepStackInterface unexected type is %T", rawStepStackInterface),
-						TriggeringPubsubID: global.PubSubID,
-					})
+					global.logger.Warning(fmt.Sprintf("rawStepStackInterface unexected type is %T", rawStepStackInterface), "")
 					return nil
 				}
 				stepStack = nil
@@ -795,55 +600,26 @@ func getDumpStepStack(objectName string, global *Global, retriesNumber time.Dura
 				for _, rawStepInterface := range rawStepStack {
 					rawStep, ok := rawStepInterface.(map[string]interface{})
 					if !ok {
-						log.Println(glo.Entry{
-							MicroserviceName:   global.microserviceName,
-							InstanceName:       global.instanceName,
-							Environment:        global.environment,
-							Severity:           "WARNING",
-							Message:            fmt.Sprintf("rawStepInterface unexected type is %T", rawStepInterface),
-							TriggeringPubsubID: global.PubSubID,
-						})
+						global.logger.Warning(fmt.Sprintf("rawStepInterface unexected type is %T", rawStepInterface), "")
 						return nil
 					}
 					var stepIDInterface interface{} = rawStep["StepID"]
 					stepID, ok := stepIDInterface.(string)
 					if !ok {
-						log.Println(glo.Entry{
-							MicroserviceName:   global.microserviceName,
-							InstanceName:       global.instanceName,
-							Environment:        global.environment,
-							Severity:           "WARNING",
-							Message:            fmt.Sprintf("stepIDInterface unexected type is %T", stepIDInterface),
-							TriggeringPubsubID: global.PubSubID,
-						})
+						global.logger.Warning(fmt.Sprintf("stepIDInterface unexected type is %T", stepIDInterface), "")
 						return nil
 					}
 					var stepTimestampInterface interface{} = rawStep["StepTimestamp"]
 					stepTimestamp, ok := stepTimestampInterface.(time.Time)
 					if !ok {
-						log.Println(glo.Entry{
-							MicroserviceName:   global.microserviceName,
-							InstanceName:       global.instanceName,
-							Environment:        global.environment,
-							Severity:           "WARNING",
-							Message:            fmt.Sprintf("stepTimestampInterface unexected type is %T", stepTimestampInterface),
-							TriggeringPubsubID: global.PubSubID,
-						})
+						global.logger.Warning(fmt.Sprintf("stepTimestampInterface unexected type is %T", stepTimestampInterface), "")
 						return nil
 					}
 					step.StepID = stepID
 					step.StepTimestamp = stepTimestamp
 					stepStack = append(stepStack, step)
 				}
-				log.Println(glo.Entry{
-					MicroserviceName:   global.microserviceName,
-					InstanceName:       global.instanceName,
-					Environment:        global.environment,
-					Severity:           "INFO",
-					Message:            fmt.Sprintf("dump stepStack retrieved %s", documentPath),
-					Description:        fmt.Sprintf("stepStack %v", stepStack),
-					TriggeringPubsubID: global.PubSubID,
-				})
+				global.logger.Info(fmt.Sprintf("dump stepStack retrieved %s", documentPath), fmt.Sprintf("stepStack %v", stepStack))
 				return stepStack
 			}
 		}
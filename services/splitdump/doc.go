@@ -31,6 +31,8 @@ Output
 
 - Delivered in the same topics as used per CAI real-time.
 
+- Routed per content type: one cai-rces-<assetType> topic per asset type for resources, one topic for all asset types for iam policies, org policies, access policies, os inventories and relationships.
+
 - Tags to differentiate them from CAI real time feeds.
 
 - Create missing topics en the fly (best effort) in case it does not already exist for real-time.
@@ -25,5 +25,10 @@ func (instanceDeployment *InstanceDeployment) deployGCSBucket() (err error) {
 	if bucketDeployment.Settings.DeleteAgeInDays == 0 {
 		bucketDeployment.Settings.DeleteAgeInDays = bucketDeployment.Core.SolutionSettings.Hosting.GCS.Buckets.CAIExport.DeleteAgeInDays
 	}
+	if bucketDeployment.Core.SolutionSettings.Hosting.GCS.Buckets.CAIExport.StorageClass != "" {
+		bucketDeployment.Settings.StorageClass = bucketDeployment.Core.SolutionSettings.Hosting.GCS.Buckets.CAIExport.StorageClass
+	}
+	bucketDeployment.Settings.VersioningEnabled = bucketDeployment.Core.SolutionSettings.Hosting.GCS.Buckets.CAIExport.VersioningEnabled
+	bucketDeployment.Settings.RetentionPeriodInDays = bucketDeployment.Core.SolutionSettings.Hosting.GCS.Buckets.CAIExport.RetentionPeriodInDays
 	return bucketDeployment.Deploy()
 }
@@ -41,8 +41,10 @@ import (
 
 // Global structure for global variables to optimize the cloud function performances
 type Global struct {
+	assetHashesCollectionID       string
 	assetInventoryOrigin          string
 	assetsCollectionID            string
+	auditActorsCollectionID       string
 	cloudresourcemanagerService   *cloudresourcemanager.Service
 	cloudresourcemanagerServiceV2 *cloudresourcemanagerv2.Service // v2 is needed for folders
 	ctx                           context.Context
@@ -50,8 +52,11 @@ type Global struct {
 	firestoreClient               *firestore.Client
 	inserter                      *bigquery.Inserter
 	instanceName                  string
+	intervalDays                  int64
+	logger                        *glo.Logger
 	microserviceName              string
 	ownerLabelKeyName             string
+	pubSubAttributes              map[string]string
 	PubSubID                      string
 	retryTimeOutSeconds           int64
 	step                          glo.Step
@@ -145,6 +150,7 @@ type feedMessage struct {
 	Asset     asset      `json:"asset"`
 	Window    cai.Window `json:"window"`
 	Origin    string     `json:"origin"`
+	Actor     *cai.Actor `json:"actor,omitempty"`
 	StepStack glo.Steps  `json:"step_stack,omitempty"`
 }
 
@@ -153,6 +159,7 @@ type feedMessageBQ struct {
 	Asset  assetBQ    `json:"asset"`
 	Window cai.Window `json:"window"`
 	Origin string     `json:"origin"`
+	Actor  *cai.Actor `json:"actor"`
 }
 
 // asset Cloud Asset Metadata
@@ -167,6 +174,13 @@ type asset struct {
 	AssetType               string          `json:"assetType"`
 	IamPolicy               json.RawMessage `json:"iamPolicy"`
 	Resource                json.RawMessage `json:"resource"`
+	OrgPolicy               json.RawMessage `json:"orgPolicy"`
+	AccessPolicy            json.RawMessage `json:"accessPolicy"`
+	AccessLevel             json.RawMessage `json:"accessLevel"`
+	ServicePerimeter        json.RawMessage `json:"servicePerimeter"`
+	OsInventory             json.RawMessage `json:"osInventory"`
+	RelatedAssets           json.RawMessage `json:"relatedAssets"`
+	RelatedAsset            json.RawMessage `json:"relatedAsset"`
 }
 
 // assetBQ format to persist asset in BQ violations table
@@ -181,6 +195,13 @@ type assetBQ struct {
 	AssetType               string `json:"assetType"`
 	IamPolicy               string `json:"iamPolicy"`
 	Resource                string `json:"resource"`
+	OrgPolicy               string `json:"orgPolicy"`
+	AccessPolicy            string `json:"accessPolicy"`
+	AccessLevel             string `json:"accessLevel"`
+	ServicePerimeter        string `json:"servicePerimeter"`
+	OsInventory             string `json:"osInventory"`
+	RelatedAssets           string `json:"relatedAssets"`
+	RelatedAsset            string `json:"relatedAsset"`
 }
 
 // assetFeedMessageBQ Cloud Asset Inventory feed message for asset table
@@ -193,17 +214,25 @@ type assetFeedMessageBQ struct {
 
 // assetAssetBQ format to persist asset in BQ assets table
 type assetAssetBQ struct {
-	Name                    string    `json:"name"`
-	Owner                   string    `json:"owner"`
-	ViolationResolver       string    `json:"violationResolver"`
-	AncestryPathDisplayName string    `json:"ancestryPathDisplayName"`
-	AncestryPath            string    `json:"ancestryPath"`
-	AncestorsDisplayName    []string  `json:"ancestorsDisplayName"`
-	Ancestors               []string  `json:"ancestors"`
-	AssetType               string    `json:"assetType"`
-	Deleted                 bool      `json:"deleted"`
-	Timestamp               time.Time `json:"timestamp"`
-	ProjectID               string    `json:"projectID"`
+	Name                    string     `json:"name"`
+	Owner                   string     `json:"owner"`
+	ViolationResolver       string     `json:"violationResolver"`
+	AncestryPathDisplayName string     `json:"ancestryPathDisplayName"`
+	AncestryPath            string     `json:"ancestryPath"`
+	AncestorsDisplayName    []string   `json:"ancestorsDisplayName"`
+	Ancestors               []string   `json:"ancestors"`
+	AssetType               string     `json:"assetType"`
+	Deleted                 bool       `json:"deleted"`
+	Timestamp               time.Time  `json:"timestamp"`
+	ProjectID               string     `json:"projectID"`
+	Actor                   *cai.Actor `json:"actor"`
+	Origin                  string     `json:"origin"`
+}
+
+// assetHash last content hash streamed for an asset, persisted in firestore
+type assetHash struct {
+	ContentHash string    `firestore:"contentHash"`
+	WrittenAt   time.Time `firestore:"writtenAt"`
 }
 
 // Initialize is to be executed in the init() function of the cloud function to optimize the cold start
@@ -231,18 +260,28 @@ func Initialize(ctx context.Context, global *Global) (err error) {
 	global.instanceName = instanceDeployment.Core.InstanceName
 	global.microserviceName = instanceDeployment.Core.ServiceName
 
-	log.Println(glo.Entry{
-		MicroserviceName: global.microserviceName,
-		InstanceName:     global.instanceName,
-		Environment:      global.environment,
-		Severity:         "NOTICE",
-		Message:          "coldstart",
-		InitID:           initID,
-	})
+	global.logger = glo.NewLogger(global.microserviceName, global.instanceName, global.environment, initID)
+	global.logger.SetLogParameters(glo.GetLogParameters(instanceDeployment.Settings.Service.GLO, instanceDeployment.Settings.Instance.GLO))
+	global.logger.Notice("coldstart", "")
+
+	err = glo.InitTracing(ctx,
+		instanceDeployment.Core.SolutionSettings.Hosting.Tracing.Exporter,
+		instanceDeployment.Core.SolutionSettings.Hosting.Tracing.OTLPEndpoint,
+		instanceDeployment.Core.SolutionSettings.Hosting.Tracing.FilePath,
+		global.microserviceName,
+		global.instanceName,
+		global.environment)
+	if err != nil {
+		global.logger.InitFailed(fmt.Sprintf("glo.InitTracing %v", err))
+		return err
+	}
 
 	datasetName := instanceDeployment.Core.SolutionSettings.Hosting.Bigquery.Dataset.Name
+	global.assetHashesCollectionID = instanceDeployment.Core.SolutionSettings.Hosting.FireStore.CollectionIDs.AssetHashes
 	global.assetsCollectionID = instanceDeployment.Core.SolutionSettings.Hosting.FireStore.CollectionIDs.Assets
+	global.auditActorsCollectionID = instanceDeployment.Core.SolutionSettings.Hosting.FireStore.CollectionIDs.AuditActors
 	global.ownerLabelKeyName = instanceDeployment.Core.SolutionSettings.Monitoring.LabelKeyNames.Owner
+	global.intervalDays = instanceDeployment.Core.SolutionSettings.Hosting.Bigquery.Views.IntervalDays
 	global.retryTimeOutSeconds = instanceDeployment.Settings.Service.GCF.RetryTimeOutSeconds
 	global.tableName = instanceDeployment.Settings.Instance.Bigquery.TableName
 	global.violationResolverLabelKeyName = instanceDeployment.Core.SolutionSettings.Monitoring.LabelKeyNames.ViolationResolver
@@ -250,84 +289,36 @@ func Initialize(ctx context.Context, global *Global) (err error) {
 
 	bigQueryClient, err = bigquery.NewClient(global.ctx, projectID)
 	if err != nil {
-		log.Println(glo.Entry{
-			MicroserviceName: global.microserviceName,
-			InstanceName:     global.instanceName,
-			Environment:      global.environment,
-			Severity:         "CRITICAL",
-			Message:          "init_failed",
-			Description:      fmt.Sprintf("bigquery.NewClient %v", err),
-			InitID:           initID,
-		})
+		global.logger.InitFailed(fmt.Sprintf("bigquery.NewClient %v", err))
 		return err
 	}
 	dataset := bigQueryClient.Dataset(datasetName)
 	_, err = dataset.Metadata(ctx)
 	if err != nil {
-		log.Println(glo.Entry{
-			MicroserviceName: global.microserviceName,
-			InstanceName:     global.instanceName,
-			Environment:      global.environment,
-			Severity:         "CRITICAL",
-			Message:          "init_failed",
-			Description:      fmt.Sprintf("dataset.Metadata %v", err),
-			InitID:           initID,
-		})
+		global.logger.InitFailed(fmt.Sprintf("dataset.Metadata %v", err))
 		return err
 	}
 	table = dataset.Table(global.tableName)
 	_, err = table.Metadata(ctx)
 	if err != nil {
-		log.Println(glo.Entry{
-			MicroserviceName: global.microserviceName,
-			InstanceName:     global.instanceName,
-			Environment:      global.environment,
-			Severity:         "CRITICAL",
-			Message:          "init_failed",
-			Description:      fmt.Sprintf("missing table %s %v", global.tableName, err),
-			InitID:           initID,
-		})
+		global.logger.InitFailed(fmt.Sprintf("missing table %s %v", global.tableName, err))
 		return err
 	}
 	global.inserter = table.Inserter()
 	if global.tableName == "assets" {
 		global.cloudresourcemanagerService, err = cloudresourcemanager.NewService(global.ctx)
 		if err != nil {
-			log.Println(glo.Entry{
-				MicroserviceName: global.microserviceName,
-				InstanceName:     global.instanceName,
-				Environment:      global.environment,
-				Severity:         "CRITICAL",
-				Message:          "init_failed",
-				Description:      fmt.Sprintf("cloudresourcemanager.NewService %v", err),
-				InitID:           initID,
-			})
+			global.logger.InitFailed(fmt.Sprintf("cloudresourcemanager.NewService %v", err))
 			return err
 		}
 		global.cloudresourcemanagerServiceV2, err = cloudresourcemanagerv2.NewService(global.ctx)
 		if err != nil {
-			log.Println(glo.Entry{
-				MicroserviceName: global.microserviceName,
-				InstanceName:     global.instanceName,
-				Environment:      global.environment,
-				Severity:         "CRITICAL",
-				Message:          "init_failed",
-				Description:      fmt.Sprintf("cloudresourcemanagerv2.NewService %v", err),
-				InitID:           initID,
-			})
+			global.logger.InitFailed(fmt.Sprintf("cloudresourcemanagerv2.NewService %v", err))
 			return err
 		}
 		global.firestoreClient, err = firestore.NewClient(global.ctx, projectID)
 		if err != nil {
-			log.Println(glo.Entry{
-				MicroserviceName: global.microserviceName,
-				InstanceName:     global.instanceName,
-				Environment:      global.environment,
-				Severity:         "CRITICAL",
-				Message:          "init_failed",
-				Description:      fmt.Sprintf("firestore.NewClient %v", err),
-				InitID:           initID,
-			})
+			global.logger.InitFailed(fmt.Sprintf("firestore.NewClient %v", err))
 			return err
 		}
 	}
@@ -340,15 +331,7 @@ func EntryPoint(ctxEvent context.Context, PubSubMessage gps.PubSubMessage, globa
 	metadata, err := metadata.FromContext(ctxEvent)
 	if err != nil {
 		// Assume an error on the function invoker and try again.
-		log.Println(glo.Entry{
-			MicroserviceName:   global.microserviceName,
-			InstanceName:       global.instanceName,
-			Environment:        global.environment,
-			Severity:           "CRITICAL",
-			Message:            "redo_on_transient",
-			Description:        fmt.Sprintf("pubsub_id no available metadata.FromContext: %v", err),
-			TriggeringPubsubID: global.PubSubID,
-		})
+		global.logger.RedoOnTransient(fmt.Sprintf("pubsub_id no available metadata.FromContext: %v", err))
 		return err
 	}
 	global.stepStack = nil
@@ -358,88 +341,42 @@ func EntryPoint(ctxEvent context.Context, PubSubMessage gps.PubSubMessage, globa
 		StepID:        fmt.Sprintf("%s/%s", parts[len(parts)-1], global.PubSubID),
 		StepTimestamp: metadata.Timestamp,
 	}
+	global.logger.SetInvocation(global.PubSubID, global.step)
+	global.pubSubAttributes = PubSubMessage.Attributes
+	defer func() {
+		glo.RecordStepSpan(global.ctx, global.microserviceName, global.pubSubAttributes, global.stepStack)
+	}()
 
-	now := time.Now()
-	d := now.Sub(metadata.Timestamp)
-	log.Println(glo.Entry{
-		MicroserviceName:           global.microserviceName,
-		InstanceName:               global.instanceName,
-		Environment:                global.environment,
-		Severity:                   "NOTICE",
-		Message:                    "start",
-		TriggeringPubsubID:         global.PubSubID,
-		TriggeringPubsubAgeSeconds: d.Seconds(),
-		TriggeringPubsubTimestamp:  &metadata.Timestamp,
-		Now:                        &now,
-	})
+	d := global.logger.Start()
 
 	if d.Seconds() > float64(global.retryTimeOutSeconds) {
-		log.Println(glo.Entry{
-			MicroserviceName:           global.microserviceName,
-			InstanceName:               global.instanceName,
-			Environment:                global.environment,
-			Severity:                   "CRITICAL",
-			Message:                    "noretry",
-			Description:                "Pubsub message too old",
-			TriggeringPubsubID:         global.PubSubID,
-			TriggeringPubsubAgeSeconds: d.Seconds(),
-			TriggeringPubsubTimestamp:  &metadata.Timestamp,
-			Now:                        &now,
-		})
+		global.logger.NoRetryTooOld()
 		return nil
 	}
 
 	if strings.Contains(string(PubSubMessage.Data), "You have successfully configured real time feed") {
-		log.Println(glo.Entry{
-			MicroserviceName:   global.microserviceName,
-			InstanceName:       global.instanceName,
-			Environment:        global.environment,
-			Severity:           "NOTICE",
-			Message:            "cancel",
-			Description:        fmt.Sprintf("ignored pubsub message: %s", string(PubSubMessage.Data)),
-			TriggeringPubsubID: global.PubSubID,
-		})
+		global.logger.Cancel(fmt.Sprintf("ignored pubsub message: %s", string(PubSubMessage.Data)))
 		return nil
 	}
 	var insertID string
+	var skipped bool
 	switch global.tableName {
 	case "complianceStatus":
 		insertID, err = persistComplianceStatus(PubSubMessage.Data, global)
 	case "violations":
 		insertID, err = persistViolation(PubSubMessage.Data, global)
 	case "assets":
-		insertID, err = persistAsset(PubSubMessage.Data, global)
+		insertID, skipped, err = persistAsset(PubSubMessage.Data, global)
 	}
 	if err != nil {
-		log.Println(glo.Entry{
-			MicroserviceName:   global.microserviceName,
-			InstanceName:       global.instanceName,
-			Environment:        global.environment,
-			Severity:           "CRITICAL",
-			Message:            "redo_on_transient",
-			Description:        err.Error(),
-			TriggeringPubsubID: global.PubSubID,
-		})
+		global.logger.RedoOnTransient(err.Error())
 		return err
 	}
+	if skipped {
+		return nil
+	}
 	if insertID != "" {
-		now := time.Now()
-		latency := now.Sub(metadata.Timestamp)
-		latencyE2E := now.Sub(global.stepStack[0].StepTimestamp)
-		log.Println(glo.Entry{
-			MicroserviceName:     global.microserviceName,
-			InstanceName:         global.instanceName,
-			Environment:          global.environment,
-			Severity:             "NOTICE",
-			Message:              fmt.Sprintf("finish %s", insertID),
-			Now:                  &now,
-			TriggeringPubsubID:   global.PubSubID,
-			OriginEventTimestamp: &global.stepStack[0].StepTimestamp,
-			LatencySeconds:       latency.Seconds(),
-			LatencyE2ESeconds:    latencyE2E.Seconds(),
-			StepStack:            global.stepStack,
-			AssetInventoryOrigin: global.assetInventoryOrigin,
-		})
+		global.logger.Finish(fmt.Sprintf("finish %s", insertID), "", global.assetInventoryOrigin)
 		// Description:          fmt.Sprintf("insert %s ok %s", global.tableName, insertID),
 	}
 	// log.Printf("pubsub_id %s exit nil", global.PubSubID)
@@ -450,15 +387,7 @@ func persistComplianceStatus(pubSubJSONDoc []byte, global *Global) (insertID str
 	var complianceStatus monitor.ComplianceStatus
 	err = json.Unmarshal(pubSubJSONDoc, &complianceStatus)
 	if err != nil {
-		log.Println(glo.Entry{
-			MicroserviceName:   global.microserviceName,
-			InstanceName:       global.instanceName,
-			Environment:        global.environment,
-			Severity:           "CRITICAL",
-			Message:            "noretry",
-			Description:        fmt.Sprintf("json.Unmarshal(pubSubJSONDoc, &complianceStatus) %v", err),
-			TriggeringPubsubID: global.PubSubID,
-		})
+		global.logger.NoRetry(fmt.Sprintf("json.Unmarshal(pubSubJSONDoc, &complianceStatus) %v", err))
 		return "", nil
 	}
 	if complianceStatus.StepStack != nil {
@@ -466,6 +395,7 @@ func persistComplianceStatus(pubSubJSONDoc []byte, global *Global) (insertID str
 	} else {
 		global.stepStack = append(global.stepStack, global.step)
 	}
+	global.logger.SetStepStack(global.stepStack)
 	global.assetInventoryOrigin = complianceStatus.AssetInventoryOrigin
 
 	insertID = str.Hash(fmt.Sprintf("%s%v%s%v",
@@ -487,15 +417,7 @@ func persistViolation(pubSubJSONDoc []byte, global *Global) (insertID string, er
 	var violationBQ violationBQ
 	err = json.Unmarshal(pubSubJSONDoc, &violation)
 	if err != nil {
-		log.Println(glo.Entry{
-			MicroserviceName:   global.microserviceName,
-			InstanceName:       global.instanceName,
-			Environment:        global.environment,
-			Severity:           "CRITICAL",
-			Message:            "noretry",
-			Description:        fmt.Sprintf("json.Unmarshal(pubSubJSONDoc, &violation) %v", err),
-			TriggeringPubsubID: global.PubSubID,
-		})
+		global.logger.NoRetry(fmt.Sprintf("json.Unmarshal(pubSubJSONDoc, &violation) %v", err))
 		return "", nil
 	}
 	if violation.StepStack != nil {
@@ -503,6 +425,7 @@ func persistViolation(pubSubJSONDoc []byte, global *Global) (insertID string, er
 	} else {
 		global.stepStack = append(global.stepStack, global.step)
 	}
+	global.logger.SetStepStack(global.stepStack)
 	global.assetInventoryOrigin = violation.FeedMessage.Origin
 
 	violationBQ.NonCompliance.Message = violation.NonCompliance.Message
@@ -516,6 +439,7 @@ func persistViolation(pubSubJSONDoc []byte, global *Global) (insertID string, er
 	violationBQ.ConstraintConfig.Spec.Parameters = string(violation.ConstraintConfig.Spec.Parameters)
 	violationBQ.FeedMessage.Window = violation.FeedMessage.Window
 	violationBQ.FeedMessage.Origin = violation.FeedMessage.Origin
+	violationBQ.FeedMessage.Actor = violation.FeedMessage.Actor
 	violationBQ.FeedMessage.Asset.Name = violation.FeedMessage.Asset.Name
 	violationBQ.FeedMessage.Asset.Owner = violation.FeedMessage.Asset.Owner
 	violationBQ.FeedMessage.Asset.ViolationResolver = violation.FeedMessage.Asset.ViolationResolver
@@ -526,6 +450,13 @@ func persistViolation(pubSubJSONDoc []byte, global *Global) (insertID string, er
 	violationBQ.FeedMessage.Asset.AncestryPathDisplayName = violation.FeedMessage.Asset.AncestryPathDisplayName
 	violationBQ.FeedMessage.Asset.IamPolicy = string(violationBQ.FeedMessage.Asset.IamPolicy)
 	violationBQ.FeedMessage.Asset.Resource = string(violation.FeedMessage.Asset.Resource)
+	violationBQ.FeedMessage.Asset.OrgPolicy = string(violation.FeedMessage.Asset.OrgPolicy)
+	violationBQ.FeedMessage.Asset.AccessPolicy = string(violation.FeedMessage.Asset.AccessPolicy)
+	violationBQ.FeedMessage.Asset.AccessLevel = string(violation.FeedMessage.Asset.AccessLevel)
+	violationBQ.FeedMessage.Asset.ServicePerimeter = string(violation.FeedMessage.Asset.ServicePerimeter)
+	violationBQ.FeedMessage.Asset.OsInventory = string(violation.FeedMessage.Asset.OsInventory)
+	violationBQ.FeedMessage.Asset.RelatedAssets = string(violation.FeedMessage.Asset.RelatedAssets)
+	violationBQ.FeedMessage.Asset.RelatedAsset = string(violation.FeedMessage.Asset.RelatedAsset)
 	violationBQ.RegoModules = string(violation.RegoModules)
 
 	insertID = str.Hash(fmt.Sprintf("%s%v%s%v%s",
@@ -543,46 +474,22 @@ func persistViolation(pubSubJSONDoc []byte, global *Global) (insertID string, er
 	return insertID, nil
 }
 
-func persistAsset(pubSubJSONDoc []byte, global *Global) (insertID string, err error) {
+func persistAsset(pubSubJSONDoc []byte, global *Global) (insertID string, skipped bool, err error) {
 	var feedMessage feedMessage
 	err = json.Unmarshal(pubSubJSONDoc, &feedMessage)
 	if err != nil {
-		log.Println(glo.Entry{
-			MicroserviceName:   global.microserviceName,
-			InstanceName:       global.instanceName,
-			Environment:        global.environment,
-			Severity:           "CRITICAL",
-			Message:            "noretry",
-			Description:        fmt.Sprintf("json.Unmarshal(pubSubJSONDoc, &feedMessage) %v", err),
-			TriggeringPubsubID: global.PubSubID,
-		})
-		return "", nil
+		global.logger.NoRetry(fmt.Sprintf("json.Unmarshal(pubSubJSONDoc, &feedMessage) %v", err))
+		return "", false, nil
 	}
 	var assetFeedMessageBQ assetFeedMessageBQ
 	err = json.Unmarshal(pubSubJSONDoc, &assetFeedMessageBQ)
 	if err != nil {
-		log.Println(glo.Entry{
-			MicroserviceName:   global.microserviceName,
-			InstanceName:       global.instanceName,
-			Environment:        global.environment,
-			Severity:           "CRITICAL",
-			Message:            "noretry",
-			Description:        fmt.Sprintf("json.Unmarshal(pubSubJSONDoc, &assetFeedMessageBQ) %v", err),
-			TriggeringPubsubID: global.PubSubID,
-		})
-		return "", nil
+		global.logger.NoRetry(fmt.Sprintf("json.Unmarshal(pubSubJSONDoc, &assetFeedMessageBQ) %v", err))
+		return "", false, nil
 	}
 	if assetFeedMessageBQ.Asset.Name == "" {
-		log.Println(glo.Entry{
-			MicroserviceName:   global.microserviceName,
-			InstanceName:       global.instanceName,
-			Environment:        global.environment,
-			Severity:           "CRITICAL",
-			Message:            "noretry",
-			Description:        "assetFeedMessageBQ.Asset.Name is empty",
-			TriggeringPubsubID: global.PubSubID,
-		})
-		return "", nil
+		global.logger.NoRetry("assetFeedMessageBQ.Asset.Name is empty")
+		return "", false, nil
 	}
 	if feedMessage.StepStack != nil {
 		global.stepStack = append(feedMessage.StepStack, global.step)
@@ -593,6 +500,7 @@ func persistAsset(pubSubJSONDoc []byte, global *Global) (insertID string, err er
 		global.stepStack = append(global.stepStack, caiStep)
 		global.stepStack = append(global.stepStack, global.step)
 	}
+	global.logger.SetStepStack(global.stepStack)
 
 	assetFeedMessageBQ.Asset.Timestamp = feedMessage.Window.StartTime
 	assetFeedMessageBQ.Asset.Deleted = assetFeedMessageBQ.Deleted
@@ -608,6 +516,55 @@ func persistAsset(pubSubJSONDoc []byte, global *Global) (insertID string, err er
 	assetFeedMessageBQ.Asset.ViolationResolver, _ = cai.GetAssetLabelValue(global.violationResolverLabelKeyName, feedMessage.Asset.Resource)
 
 	global.assetInventoryOrigin = assetFeedMessageBQ.Origin
+	assetFeedMessageBQ.Asset.Origin = assetFeedMessageBQ.Origin
+
+	assetContents := cai.AssetContents{
+		Resource:         feedMessage.Asset.Resource,
+		IamPolicy:        feedMessage.Asset.IamPolicy,
+		OrgPolicy:        feedMessage.Asset.OrgPolicy,
+		AccessPolicy:     feedMessage.Asset.AccessPolicy,
+		AccessLevel:      feedMessage.Asset.AccessLevel,
+		ServicePerimeter: feedMessage.Asset.ServicePerimeter,
+		OsInventory:      feedMessage.Asset.OsInventory,
+		RelatedAssets:    feedMessage.Asset.RelatedAssets,
+		RelatedAsset:     feedMessage.Asset.RelatedAsset,
+	}
+
+	// Only real-time changes can be correlated with who made them
+	assetFeedMessageBQ.Asset.Actor = feedMessage.Actor
+	if (feedMessage.Origin == "" || feedMessage.Origin == "real-time") && feedMessage.Actor == nil {
+		assetFeedMessageBQ.Asset.Actor, err = cai.GetActor(global.ctx,
+			global.firestoreClient,
+			global.auditActorsCollectionID,
+			feedMessage.Asset.Name,
+			assetContents.GetContentType(),
+			feedMessage.Window.StartTime)
+		if err != nil {
+			global.logger.Warning("actor not correlated", fmt.Sprintf("cai.GetActor %v", err))
+		}
+	}
+
+	hashDocumentID := str.RevertSlash(feedMessage.Asset.Name) + cai.GetContentTypeSuffix(assetContents.GetContentType())
+	hashDocumentRef := global.firestoreClient.Collection(global.assetHashesCollectionID).Doc(hashDocumentID)
+	var contentHash string
+	// Point-in-time exports neither skip on nor update the content hash of the current asset state
+	isHistorical := feedMessage.Origin == "historical-export"
+	if !assetFeedMessageBQ.Deleted && !isHistorical {
+		contentHash, err = cai.HashAssetContent(assetContents, assetFeedMessageBQ.Asset.Ancestors)
+		if err != nil {
+			global.logger.NoRetry(fmt.Sprintf("cai.HashAssetContent %v", err))
+			return "", false, nil
+		}
+		isUnchanged, err := isUnchangedAsset(hashDocumentRef, contentHash, global)
+		if err != nil {
+			return "", false, fmt.Errorf("isUnchangedAsset %s %v", hashDocumentID, err)
+		}
+		if isUnchanged {
+			// still a finish, the asset is up to date in BigQuery so that freshness metrics still work
+			global.logger.Finish(fmt.Sprintf("finish skip unchanged %s", hashDocumentID), "", global.assetInventoryOrigin)
+			return "", true, nil
+		}
+	}
 
 	insertID = str.Hash(fmt.Sprintf("%s%v",
 		assetFeedMessageBQ.Asset.Name,
@@ -617,7 +574,52 @@ func persistAsset(pubSubJSONDoc []byte, global *Global) (insertID string, err er
 	}
 
 	if err := global.inserter.Put(global.ctx, savers); err != nil {
-		return "", fmt.Errorf("inserter.Put %v", err)
+		return "", false, fmt.Errorf("inserter.Put %v", err)
 	}
-	return insertID, nil
+
+	if isHistorical {
+		return insertID, false, nil
+	}
+	if assetFeedMessageBQ.Deleted {
+		_, err = hashDocumentRef.Delete(global.ctx)
+		if err != nil {
+			return "", false, fmt.Errorf("hashDocumentRef.Delete %s %v", hashDocumentID, err)
+		}
+	} else {
+		_, err = hashDocumentRef.Set(global.ctx, assetHash{
+			ContentHash: contentHash,
+			WrittenAt:   time.Now(),
+		})
+		if err != nil {
+			return "", false, fmt.Errorf("hashDocumentRef.Set %s %v", hashDocumentID, err)
+		}
+	}
+	return insertID, false, nil
+}
+
+// isUnchangedAsset true when the last streamed row has the same content hash and is young enough
+// Rows older than half the views interval are streamed again so that the asset stays in the last assets view
+func isUnchangedAsset(hashDocumentRef *firestore.DocumentRef, contentHash string, global *Global) (bool, error) {
+	documentSnap, err := hashDocumentRef.Get(global.ctx)
+	if err != nil {
+		if strings.Contains(strings.ToLower(err.Error()), "notfound") {
+			return false, nil
+		}
+		return false, fmt.Errorf("hashDocumentRef.Get %v", err)
+	}
+	var lastAssetHash assetHash
+	err = documentSnap.DataTo(&lastAssetHash)
+	if err != nil {
+		return false, fmt.Errorf("documentSnap.DataTo %v", err)
+	}
+	if lastAssetHash.ContentHash != contentHash {
+		return false, nil
+	}
+	if global.intervalDays > 0 {
+		maxAge := time.Duration(global.intervalDays) * 24 * time.Hour / 2
+		if time.Since(lastAssetHash.WrittenAt) > maxAge {
+			return false, nil
+		}
+	}
+	return true, nil
 }
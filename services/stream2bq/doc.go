@@ -17,6 +17,10 @@ Package stream2bq streams PubSub message into BigQuery tables
 
 It can stream into 3 RAM tables: 1) assets 2) compliance states 3) violations.
 
+Unchanged assets are skipped: the hash of the asset contents and ancestors is stored in the firestore collection hosting.firestore.collectionIDs.assetHashes. Skips are logged as "finish skip unchanged" with their latencies, so that freshness metrics still work. An unchanged asset is still streamed once older than half of hosting.bigquery.views.intervalDays, so that it stays in the last assets view.
+
+Real-time asset rows, and violations, include the actor who made the change when correlated with an admin activity audit log entry recorded by convertlog2feed.
+
 Triggered by
 
 Messages in related PubSub topics.
@@ -35,7 +39,7 @@ Streaming into BigQuery tables.
 
 Cardinality
 
-One-one, one pubsub message - one stream inserted in BigQuery.
+One-one, one pubsub message - one stream inserted in BigQuery, or none when the asset is unchanged.
 
 Automatic retrying
 
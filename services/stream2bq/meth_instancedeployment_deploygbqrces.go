@@ -31,6 +31,8 @@ func (instanceDeployment *InstanceDeployment) deployGBQRces() (err error) {
 		intervalDays = 365
 	}
 	log.Printf("gbq views intervalDays %d", intervalDays)
+	resourceReport := instanceDeployment.Core.StartResourceReport("gbq table and views", fmt.Sprintf("%s.%s", datasetName, tableName))
+	defer resourceReport.End(&err)
 
 	switch tableName {
 	case "complianceStatus":
@@ -21,17 +21,9 @@ import (
 )
 
 func (instanceDeployment *InstanceDeployment) deployGRMMonitoringOrgBindings() (err error) {
-	orgBindingsDeployment := grm.NewOrgBindingsDeployment()
-	orgBindingsDeployment.Core = instanceDeployment.Core
-	orgBindingsDeployment.Settings.Roles = instanceDeployment.Settings.Service.GCF.ServiceAccountBindings.GRM.Monitoring.Org.Roles
-	orgBindingsDeployment.Settings.CustomRoles = instanceDeployment.Settings.Service.GCF.ServiceAccountBindings.GRM.Monitoring.Org.CustomRoles
-	for _, organizationID := range orgBindingsDeployment.Core.SolutionSettings.Monitoring.OrganizationIDs {
-		orgBindingsDeployment.Artifacts.OrganizationID = organizationID
-		orgBindingsDeployment.Artifacts.Member = fmt.Sprintf("serviceAccount:%s@%s.iam.gserviceaccount.com", instanceDeployment.Core.ServiceName, instanceDeployment.Core.SolutionSettings.Hosting.ProjectID)
-		err = orgBindingsDeployment.Deploy()
-		if err != nil {
-			break
-		}
-	}
-	return err
+	return grm.DeployMonitoringScopesBindings(instanceDeployment.Core,
+		fmt.Sprintf("serviceAccount:%s@%s.iam.gserviceaccount.com", instanceDeployment.Core.ServiceName, instanceDeployment.Core.SolutionSettings.Hosting.ProjectID),
+		instanceDeployment.Settings.Service.GCF.ServiceAccountBindings.GRM.Monitoring.Org.Roles,
+		instanceDeployment.Settings.Service.GCF.ServiceAccountBindings.GRM.Monitoring.Org.CustomRoles,
+		instanceDeployment.Settings.Service.GCF.ServiceAccountBindings.GRM.Monitoring.Org.Conditions)
 }
@@ -20,6 +20,7 @@ import (
 	"github.com/BrunoReboul/ram/utilities/deploy"
 	"github.com/BrunoReboul/ram/utilities/gcb"
 	"github.com/BrunoReboul/ram/utilities/gcf"
+	"github.com/BrunoReboul/ram/utilities/glo"
 	"github.com/BrunoReboul/ram/utilities/gsu"
 	"github.com/BrunoReboul/ram/utilities/iamgt"
 	"google.golang.org/api/iam/v1"
@@ -35,12 +36,14 @@ type InstanceDeployment struct {
 			IAM iamgt.Parameters
 			GCB gcb.Parameters
 			GCF gcf.Parameters
+			GLO glo.LogParameters
 		}
 		Instance struct {
 			GCF      gcf.Event
 			Bigquery struct {
 				TableName string `yaml:"tableName"`
 			}
+			GLO glo.LogParameters
 		}
 	}
 }
@@ -77,7 +80,7 @@ func NewInstanceDeployment() *InstanceDeployment {
 		projectRunRole().Title}
 	// Data store permissions are not supported in custom roles
 	instanceDeployment.Settings.Service.GCF.ServiceAccountBindings.GRM.Hosting.Project.Roles = []string{
-		"roles/datastore.viewer"}
+		"roles/datastore.user"}
 
 	instanceDeployment.Settings.Service.GCF.AvailableMemoryMb = 128
 	instanceDeployment.Settings.Service.GCF.RetryTimeOutSeconds = 3600
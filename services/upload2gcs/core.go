@@ -36,6 +36,9 @@ import (
 	cloudresourcemanagerv2 "google.golang.org/api/cloudresourcemanager/v2"
 )
 
+// contentHashMetadataKey custom object metadata used to detect unchanged assets
+const contentHashMetadataKey = "contentHash"
+
 // Global structure for global variables to optimize the cloud function performances
 type Global struct {
 	assetsCollectionID            string
@@ -44,11 +47,15 @@ type Global struct {
 	cloudresourcemanagerService   *cloudresourcemanager.Service
 	cloudresourcemanagerServiceV2 *cloudresourcemanagerv2.Service // v2 is needed for folders
 	ctx                           context.Context
+	deleteAgeInDays               int64
 	environment                   string
 	firestoreClient               *firestore.Client
 	instanceName                  string
+	keepHistory                   bool
+	logger                        *glo.Logger
 	microserviceName              string
 	ownerLabelKeyName             string
+	pubSubAttributes              map[string]string
 	PubSubID                      string
 	retryTimeOutSeconds           int64
 	step                          glo.Step
@@ -81,6 +88,13 @@ type asset struct {
 	Resource                json.RawMessage        `json:"resource"`
 	IamPolicy               map[string]interface{} `json:"iamPolicy"`
 	IamPolicyLegacy         map[string]interface{} `json:"iam_policy"`
+	OrgPolicy               json.RawMessage        `json:"orgPolicy,omitempty"`
+	AccessPolicy            json.RawMessage        `json:"accessPolicy,omitempty"`
+	AccessLevel             json.RawMessage        `json:"accessLevel,omitempty"`
+	ServicePerimeter        json.RawMessage        `json:"servicePerimeter,omitempty"`
+	OsInventory             json.RawMessage        `json:"osInventory,omitempty"`
+	RelatedAssets           json.RawMessage        `json:"relatedAssets,omitempty"`
+	RelatedAsset            json.RawMessage        `json:"relatedAsset,omitempty"`
 	ProjectID               string                 `json:"projectID"`
 }
 
@@ -108,16 +122,25 @@ func Initialize(ctx context.Context, global *Global) (err error) {
 	global.instanceName = instanceDeployment.Core.InstanceName
 	global.microserviceName = instanceDeployment.Core.ServiceName
 
-	log.Println(glo.Entry{
-		MicroserviceName: global.microserviceName,
-		InstanceName:     global.instanceName,
-		Environment:      global.environment,
-		Severity:         "NOTICE",
-		Message:          "coldstart",
-		InitID:           initID,
-	})
+	global.logger = glo.NewLogger(global.microserviceName, global.instanceName, global.environment, initID)
+	global.logger.SetLogParameters(glo.GetLogParameters(instanceDeployment.Settings.Service.GLO, instanceDeployment.Settings.Instance.GLO))
+	global.logger.Notice("coldstart", "")
+
+	err = glo.InitTracing(ctx,
+		instanceDeployment.Core.SolutionSettings.Hosting.Tracing.Exporter,
+		instanceDeployment.Core.SolutionSettings.Hosting.Tracing.OTLPEndpoint,
+		instanceDeployment.Core.SolutionSettings.Hosting.Tracing.FilePath,
+		global.microserviceName,
+		global.instanceName,
+		global.environment)
+	if err != nil {
+		global.logger.InitFailed(fmt.Sprintf("glo.InitTracing %v", err))
+		return err
+	}
 
 	global.assetsCollectionID = instanceDeployment.Core.SolutionSettings.Hosting.FireStore.CollectionIDs.Assets
+	global.deleteAgeInDays = instanceDeployment.Core.SolutionSettings.Hosting.GCS.Buckets.AssetsJSONFile.DeleteAgeInDays
+	global.keepHistory = instanceDeployment.Core.SolutionSettings.Hosting.GCS.Buckets.AssetsJSONFile.KeepHistory
 	global.ownerLabelKeyName = instanceDeployment.Core.SolutionSettings.Monitoring.LabelKeyNames.Owner
 	global.retryTimeOutSeconds = instanceDeployment.Settings.Service.GCF.RetryTimeOutSeconds
 	global.violationResolverLabelKeyName = instanceDeployment.Core.SolutionSettings.Monitoring.LabelKeyNames.ViolationResolver
@@ -125,15 +148,7 @@ func Initialize(ctx context.Context, global *Global) (err error) {
 
 	storageClient, err = storage.NewClient(ctx)
 	if err != nil {
-		log.Println(glo.Entry{
-			MicroserviceName: global.microserviceName,
-			InstanceName:     global.instanceName,
-			Environment:      global.environment,
-			Severity:         "CRITICAL",
-			Message:          "init_failed",
-			Description:      fmt.Sprintf("storage.NewClient(ctx) %v", err),
-			InitID:           initID,
-		})
+		global.logger.InitFailed(fmt.Sprintf("storage.NewClient(ctx) %v", err))
 		return err
 	}
 	// bucketHandle must be evaluated after storateClient init
@@ -141,41 +156,17 @@ func Initialize(ctx context.Context, global *Global) (err error) {
 
 	global.cloudresourcemanagerService, err = cloudresourcemanager.NewService(ctx)
 	if err != nil {
-		log.Println(glo.Entry{
-			MicroserviceName: global.microserviceName,
-			InstanceName:     global.instanceName,
-			Environment:      global.environment,
-			Severity:         "CRITICAL",
-			Message:          "init_failed",
-			Description:      fmt.Sprintf("cloudresourcemanager.NewService(ctx) %v", err),
-			InitID:           initID,
-		})
+		global.logger.InitFailed(fmt.Sprintf("cloudresourcemanager.NewService(ctx) %v", err))
 		return err
 	}
 	global.cloudresourcemanagerServiceV2, err = cloudresourcemanagerv2.NewService(ctx)
 	if err != nil {
-		log.Println(glo.Entry{
-			MicroserviceName: global.microserviceName,
-			InstanceName:     global.instanceName,
-			Environment:      global.environment,
-			Severity:         "CRITICAL",
-			Message:          "init_failed",
-			Description:      fmt.Sprintf("cloudresourcemanagerv2.NewService(ctx) %v", err),
-			InitID:           initID,
-		})
+		global.logger.InitFailed(fmt.Sprintf("cloudresourcemanagerv2.NewService(ctx) %v", err))
 		return err
 	}
 	global.firestoreClient, err = firestore.NewClient(ctx, projectID)
 	if err != nil {
-		log.Println(glo.Entry{
-			MicroserviceName: global.microserviceName,
-			InstanceName:     global.instanceName,
-			Environment:      global.environment,
-			Severity:         "CRITICAL",
-			Message:          "init_failed",
-			Description:      fmt.Sprintf("firestore.NewClient(ctx, projectID) %v", err),
-			InitID:           initID,
-		})
+		global.logger.InitFailed(fmt.Sprintf("firestore.NewClient(ctx, projectID) %v", err))
 		return err
 	}
 	return nil
@@ -187,15 +178,7 @@ func EntryPoint(ctxEvent context.Context, PubSubMessage gps.PubSubMessage, globa
 	metadata, err := metadata.FromContext(ctxEvent)
 	if err != nil {
 		// Assume an error on the function invoker and try again.
-		log.Println(glo.Entry{
-			MicroserviceName:   global.microserviceName,
-			InstanceName:       global.instanceName,
-			Environment:        global.environment,
-			Severity:           "CRITICAL",
-			Message:            "redo_on_transient",
-			Description:        fmt.Sprintf("pubsub_id no available metadata.FromContext: %v", err),
-			TriggeringPubsubID: global.PubSubID,
-		})
+		global.logger.RedoOnTransient(fmt.Sprintf("pubsub_id no available metadata.FromContext: %v", err))
 		return err
 	}
 	global.stepStack = nil
@@ -205,47 +188,21 @@ func EntryPoint(ctxEvent context.Context, PubSubMessage gps.PubSubMessage, globa
 		StepID:        fmt.Sprintf("%s/%s", parts[len(parts)-1], global.PubSubID),
 		StepTimestamp: metadata.Timestamp,
 	}
+	global.logger.SetInvocation(global.PubSubID, global.step)
+	global.pubSubAttributes = PubSubMessage.Attributes
+	defer func() {
+		glo.RecordStepSpan(global.ctx, global.microserviceName, global.pubSubAttributes, global.stepStack)
+	}()
 
-	now := time.Now()
-	d := now.Sub(metadata.Timestamp)
-	log.Println(glo.Entry{
-		MicroserviceName:           global.microserviceName,
-		InstanceName:               global.instanceName,
-		Environment:                global.environment,
-		Severity:                   "NOTICE",
-		Message:                    "start",
-		TriggeringPubsubID:         global.PubSubID,
-		TriggeringPubsubAgeSeconds: d.Seconds(),
-		TriggeringPubsubTimestamp:  &metadata.Timestamp,
-		Now:                        &now,
-	})
+	d := global.logger.Start()
 
 	if d.Seconds() > float64(global.retryTimeOutSeconds) {
-		log.Println(glo.Entry{
-			MicroserviceName:           global.microserviceName,
-			InstanceName:               global.instanceName,
-			Environment:                global.environment,
-			Severity:                   "CRITICAL",
-			Message:                    "noretry",
-			Description:                "Pubsub message too old",
-			TriggeringPubsubID:         global.PubSubID,
-			TriggeringPubsubAgeSeconds: d.Seconds(),
-			TriggeringPubsubTimestamp:  &metadata.Timestamp,
-			Now:                        &now,
-		})
+		global.logger.NoRetryTooOld()
 		return nil
 	}
 
 	if strings.Contains(string(PubSubMessage.Data), "You have successfully configured real time feed") {
-		log.Println(glo.Entry{
-			MicroserviceName:   global.microserviceName,
-			InstanceName:       global.instanceName,
-			Environment:        global.environment,
-			Severity:           "NOTICE",
-			Message:            "cancel",
-			Description:        fmt.Sprintf("ignored pubsub message: %s", string(PubSubMessage.Data)),
-			TriggeringPubsubID: global.PubSubID,
-		})
+		global.logger.Cancel(fmt.Sprintf("ignored pubsub message: %s", string(PubSubMessage.Data)))
 		return nil
 	}
 
@@ -256,20 +213,17 @@ func EntryPoint(ctxEvent context.Context, PubSubMessage gps.PubSubMessage, globa
 	var feedMessage feedMessage
 	err = json.Unmarshal(PubSubMessage.Data, &feedMessage)
 	if err != nil {
-		log.Println(glo.Entry{
-			MicroserviceName:   global.microserviceName,
-			InstanceName:       global.instanceName,
-			Environment:        global.environment,
-			Severity:           "CRITICAL",
-			Message:            "noretry",
-			Description:        fmt.Sprintf("json.Unmarshal(PubSubMessage.Data, &feedMessage) %v %v", PubSubMessage.Data, err),
-			TriggeringPubsubID: global.PubSubID,
-		})
+		global.logger.NoRetry(fmt.Sprintf("json.Unmarshal(PubSubMessage.Data, &feedMessage) %v %v", PubSubMessage.Data, err))
 		return nil
 	}
 	if feedMessage.Origin == "" {
 		feedMessage.Origin = "real-time"
 	}
+	if feedMessage.Origin == "historical-export" {
+		// Point-in-time exports are for compliance audits, they must not alter the current assets bucket
+		global.logger.Cancel(fmt.Sprintf("ignored %s asset %s", feedMessage.Origin, feedMessage.Asset.Name))
+		return nil
+	}
 	if feedMessage.StepStack != nil {
 		global.stepStack = append(feedMessage.StepStack, global.step)
 	} else {
@@ -279,6 +233,7 @@ func EntryPoint(ctxEvent context.Context, PubSubMessage gps.PubSubMessage, globa
 		global.stepStack = append(global.stepStack, caiStep)
 		global.stepStack = append(global.stepStack, global.step)
 	}
+	global.logger.SetStepStack(global.stepStack)
 	feedMessage.StepStack = global.stepStack
 
 	feedMessage.Asset.Origin = feedMessage.Origin
@@ -300,131 +255,166 @@ func EntryPoint(ctxEvent context.Context, PubSubMessage gps.PubSubMessage, globa
 
 	feedMessageJSON, err := json.Marshal(feedMessage)
 	if err != nil {
-		log.Println(glo.Entry{
-			MicroserviceName:   global.microserviceName,
-			InstanceName:       global.instanceName,
-			Environment:        global.environment,
-			Severity:           "CRITICAL",
-			Message:            "noretry",
-			Description:        fmt.Sprintf("json.Marshal(feedMessage) %v", err),
-			TriggeringPubsubID: global.PubSubID,
-		})
+		global.logger.NoRetry(fmt.Sprintf("json.Marshal(feedMessage) %v", err))
 		return nil
 	}
 	// log.Printf("%s", string(feedMessageJSON))
 	_ = feedMessageJSON
 
-	var objectNameSuffix string
+	// contents of a same asset name are stored in distinct objects, e.g. <name>.json, <name>_iam.json, <name>_orgpolicy.json
+	contentType := cai.ContentTypeIAMPolicy
 	if feedMessage.Asset.IamPolicy == nil {
-		objectNameSuffix = ".json"
-	} else {
-		objectNameSuffix = "_iam.json"
+		contentType = getAssetContents(feedMessage).GetContentType()
 	}
+	objectNameSuffix := cai.GetContentTypeSuffix(contentType) + ".json"
 
 	objectName := strings.Replace(feedMessage.Asset.Name, "/", "", 2) + objectNameSuffix
 	// log.Println("objectName", objectName)
 	storageObject := global.bucketHandle.Object(objectName)
 
+	var contentHash string
+	if !feedMessage.Deleted {
+		contentHash, err = hashFeedMessageContent(feedMessage)
+		if err != nil {
+			global.logger.NoRetry(fmt.Sprintf("hashFeedMessageContent %s %v", objectName, err))
+			return nil
+		}
+		isUnchanged, err := isUnchangedObject(storageObject, contentHash, feedMessage.Asset.AssetType, global)
+		if err != nil {
+			global.logger.RedoOnTransient(fmt.Sprintf("isUnchangedObject %s %v", objectName, err))
+			return err
+		}
+		if isUnchanged {
+			// still a finish, the object is up to date so that freshness metrics still work
+			global.logger.Finish(fmt.Sprintf("finish skip unchanged obj %s", objectName), "", feedMessage.Origin)
+			return nil
+		}
+	}
+
+	if global.keepHistory {
+		err = writeHistoryVersion(feedMessage, objectName, objectNameSuffix, global)
+		if err != nil {
+			global.logger.RedoOnTransient(fmt.Sprintf("writeHistoryVersion %s %v", objectName, err))
+			return err
+		}
+	}
+
 	if feedMessage.Deleted == true {
 		err = storageObject.Delete(global.ctx)
 		if err != nil {
 			if strings.Contains(err.Error(), "object doesn't exist") {
-				log.Println(glo.Entry{
-					MicroserviceName:   global.microserviceName,
-					InstanceName:       global.instanceName,
-					Environment:        global.environment,
-					Severity:           "WARNING",
-					Message:            fmt.Sprintf("object doesn't exist, cannot delete %s", objectName),
-					TriggeringPubsubID: global.PubSubID,
-				})
+				global.logger.Warning(fmt.Sprintf("object doesn't exist, cannot delete %s", objectName), "")
 				return nil
 			}
-			log.Println(glo.Entry{
-				MicroserviceName:   global.microserviceName,
-				InstanceName:       global.instanceName,
-				Environment:        global.environment,
-				Severity:           "CRITICAL",
-				Message:            "redo_on_transient",
-				Description:        fmt.Sprintf("storageObject.Delete(global.ctx) %s %v", objectName, err),
-				TriggeringPubsubID: global.PubSubID,
-			})
+			global.logger.RedoOnTransient(fmt.Sprintf("storageObject.Delete(global.ctx) %s %v", objectName, err))
 			return err
 		}
-		now := time.Now()
-		latency := now.Sub(metadata.Timestamp)
-		latencyE2E := now.Sub(global.stepStack[0].StepTimestamp)
-		log.Println(glo.Entry{
-			MicroserviceName:     global.microserviceName,
-			InstanceName:         global.instanceName,
-			Environment:          global.environment,
-			Severity:             "NOTICE",
-			Message:              fmt.Sprintf("finish delete obj %s", objectName),
-			Now:                  &now,
-			TriggeringPubsubID:   global.PubSubID,
-			OriginEventTimestamp: &metadata.Timestamp,
-			LatencySeconds:       latency.Seconds(),
-			LatencyE2ESeconds:    latencyE2E.Seconds(),
-			StepStack:            global.stepStack,
-			AssetInventoryOrigin: feedMessage.Origin,
-		})
+		global.logger.Finish(fmt.Sprintf("finish delete obj %s", objectName), "", feedMessage.Origin)
 	} else {
 		content, err := json.MarshalIndent(feedMessage.Asset, "", "    ")
 		if err != nil {
-			log.Println(glo.Entry{
-				MicroserviceName:   global.microserviceName,
-				InstanceName:       global.instanceName,
-				Environment:        global.environment,
-				Severity:           "CRITICAL",
-				Message:            "noretry",
-				Description:        fmt.Sprintf("json.MarshalIndent(feedMessage.Asset %v", err),
-				TriggeringPubsubID: global.PubSubID,
-			})
+			global.logger.NoRetry(fmt.Sprintf("json.MarshalIndent(feedMessage.Asset %v", err))
 			return nil
 		}
 		storageObjectWriter := storageObject.NewWriter(global.ctx)
+		storageObjectWriter.Metadata = map[string]string{
+			contentHashMetadataKey:   contentHash,
+			cai.AssetTypeMetadataKey: feedMessage.Asset.AssetType}
 		_, err = fmt.Fprint(storageObjectWriter, string(content))
 		if err != nil {
-			log.Println(glo.Entry{
-				MicroserviceName:   global.microserviceName,
-				InstanceName:       global.instanceName,
-				Environment:        global.environment,
-				Severity:           "CRITICAL",
-				Message:            "redo_on_transient",
-				Description:        fmt.Sprintf("fmt.Fprint(storageObjectWriter, string(content)) %s %v", objectName, err),
-				TriggeringPubsubID: global.PubSubID,
-			})
+			global.logger.RedoOnTransient(fmt.Sprintf("fmt.Fprint(storageObjectWriter, string(content)) %s %v", objectName, err))
 			return err
 		}
 		err = storageObjectWriter.Close()
 		if err != nil {
-			log.Println(glo.Entry{
-				MicroserviceName:   global.microserviceName,
-				InstanceName:       global.instanceName,
-				Environment:        global.environment,
-				Severity:           "CRITICAL",
-				Message:            "redo_on_transient",
-				Description:        fmt.Sprintf("storageObjectWriter.Close() %s %v", objectName, err),
-				TriggeringPubsubID: global.PubSubID,
-			})
+			global.logger.RedoOnTransient(fmt.Sprintf("storageObjectWriter.Close() %s %v", objectName, err))
 			return err
 		}
-		now := time.Now()
-		latency := now.Sub(metadata.Timestamp)
-		latencyE2E := now.Sub(global.stepStack[0].StepTimestamp)
-		log.Println(glo.Entry{
-			MicroserviceName:     global.microserviceName,
-			InstanceName:         global.instanceName,
-			Environment:          global.environment,
-			Severity:             "NOTICE",
-			Message:              fmt.Sprintf("finish write obj %s", objectName),
-			Now:                  &now,
-			TriggeringPubsubID:   global.PubSubID,
-			OriginEventTimestamp: &metadata.Timestamp,
-			LatencySeconds:       latency.Seconds(),
-			LatencyE2ESeconds:    latencyE2E.Seconds(),
-			StepStack:            global.stepStack,
-			AssetInventoryOrigin: feedMessage.Origin,
-		})
+		global.logger.Finish(fmt.Sprintf("finish write obj %s", objectName), "", feedMessage.Origin)
 	}
 	return nil
 }
+
+// writeHistoryVersion writes an immutable dated version of the feed message, including deletions
+// history/<objectName without suffix><suffix without .json>/<window start time>.json
+func writeHistoryVersion(feedMessage feedMessage, objectName string, objectNameSuffix string, global *Global) (err error) {
+	historyObjectName := fmt.Sprintf("history/%s%s/%s.json",
+		strings.TrimSuffix(objectName, objectNameSuffix),
+		strings.TrimSuffix(objectNameSuffix, ".json"),
+		feedMessage.Window.StartTime.UTC().Format(time.RFC3339Nano))
+	content, err := json.MarshalIndent(feedMessage, "", "    ")
+	if err != nil {
+		return fmt.Errorf("json.MarshalIndent(feedMessage) %v", err)
+	}
+	// Immutable: a redelivered message must not rewrite an existing version
+	storageObjectWriter := global.bucketHandle.Object(historyObjectName).If(storage.Conditions{DoesNotExist: true}).NewWriter(global.ctx)
+	_, err = fmt.Fprint(storageObjectWriter, string(content))
+	if err != nil {
+		return fmt.Errorf("fmt.Fprint(storageObjectWriter, string(content)) %s %v", historyObjectName, err)
+	}
+	err = storageObjectWriter.Close()
+	if err != nil {
+		if strings.Contains(strings.ToLower(err.Error()), "conditionnotmet") {
+			global.logger.Info(fmt.Sprintf("history version already exists %s", historyObjectName), "")
+			return nil
+		}
+		return fmt.Errorf("storageObjectWriter.Close() %s %v", historyObjectName, err)
+	}
+	return nil
+}
+
+// hashFeedMessageContent hashes the asset contents as delivered by Cloud Asset Inventory
+func hashFeedMessageContent(feedMessage feedMessage) (hash string, err error) {
+	contents := getAssetContents(feedMessage)
+	if feedMessage.Asset.IamPolicy != nil {
+		contents.IamPolicy, err = json.Marshal(feedMessage.Asset.IamPolicy)
+		if err != nil {
+			return "", fmt.Errorf("json.Marshal(feedMessage.Asset.IamPolicy) %v", err)
+		}
+	}
+	return cai.HashAssetContent(contents, feedMessage.Asset.Ancestors)
+}
+
+// getAssetContents returns the asset contents but the iam policy, as it is not kept as raw JSON
+func getAssetContents(feedMessage feedMessage) cai.AssetContents {
+	return cai.AssetContents{
+		Resource:         feedMessage.Asset.Resource,
+		OrgPolicy:        feedMessage.Asset.OrgPolicy,
+		AccessPolicy:     feedMessage.Asset.AccessPolicy,
+		AccessLevel:      feedMessage.Asset.AccessLevel,
+		ServicePerimeter: feedMessage.Asset.ServicePerimeter,
+		OsInventory:      feedMessage.Asset.OsInventory,
+		RelatedAssets:    feedMessage.Asset.RelatedAssets,
+		RelatedAsset:     feedMessage.Asset.RelatedAsset,
+	}
+}
+
+// isUnchangedObject true when the existing object has the same content hash and is young enough
+// Objects older than half the bucket delete age are rewritten so that the lifecycle rule never deletes a live asset
+// When unchanged, the asset type tag is set on objects written before it existed
+func isUnchangedObject(storageObject *storage.ObjectHandle, contentHash string, assetType string, global *Global) (bool, error) {
+	objectAttrs, err := storageObject.Attrs(global.ctx)
+	if err != nil {
+		if err == storage.ErrObjectNotExist {
+			return false, nil
+		}
+		return false, fmt.Errorf("storageObject.Attrs %v", err)
+	}
+	if objectAttrs.Metadata[contentHashMetadataKey] != contentHash {
+		return false, nil
+	}
+	if global.deleteAgeInDays > 0 {
+		maxAge := time.Duration(global.deleteAgeInDays) * 24 * time.Hour / 2
+		if time.Since(objectAttrs.Created) > maxAge {
+			return false, nil
+		}
+	}
+	if objectAttrs.Metadata[cai.AssetTypeMetadataKey] != assetType {
+		objectAttrs.Metadata[cai.AssetTypeMetadataKey] = assetType
+		_, err = storageObject.Update(global.ctx, storage.ObjectAttrsToUpdate{Metadata: objectAttrs.Metadata})
+		if err != nil {
+			return false, fmt.Errorf("storageObject.Update %v", err)
+		}
+	}
+	return true, nil
+}
@@ -17,6 +17,10 @@ Package upload2gcs stores feeds as JSON files in a GCS bucket
 
 Manage file creation (with override) and deletion.
 
+Unchanged assets are skipped: the hash of the asset contents, e.g. resource, iam policy, org policy, and of its ancestors is stored in the object metadata contentHash and compared before writing. Skips are logged as "finish skip unchanged obj" with their latencies, so that freshness metrics still work. An unchanged object is still rewritten once older than half of hosting.gcs.buckets.assetsJSONFile.deleteAgeInDays, so that the bucket lifecycle rule never deletes a live asset.
+
+When solution setting hosting.gcs.buckets.assetsJSONFile.keepHistory is true, it also writes immutable dated versions history/<asset>/<timestamp>.json, deletions included.
+
 Triggered by
 
 Messages in related PubSub topics.
@@ -25,13 +29,15 @@ Instances
 
 - one per AssetType for resource metadata exports.
 
+- one per content type topic for iam policies, org policies, access policies, os inventories and relationships.
+
 Output
 
-JSON files into a GCS bucket.
+JSON files into a GCS bucket, named after the asset name with a suffix per content type: none for resources, _iam, _orgpolicy, _accesspolicy, _osinventory, _relationship.
 
 Cardinality
 
-one-one, one pubsub message - one file created (with override) or deleted.
+one-one, one pubsub message - one file created (with override), skipped when unchanged, or deleted, plus one history version when keepHistory is set and the file is not skipped.
 
 Automatic retrying
 
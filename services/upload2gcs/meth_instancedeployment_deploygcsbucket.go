@@ -25,5 +25,10 @@ func (instanceDeployment *InstanceDeployment) deployGCSBucket() (err error) {
 	if bucketDeployment.Settings.DeleteAgeInDays == 0 {
 		bucketDeployment.Settings.DeleteAgeInDays = bucketDeployment.Core.SolutionSettings.Hosting.GCS.Buckets.AssetsJSONFile.DeleteAgeInDays
 	}
+	if bucketDeployment.Core.SolutionSettings.Hosting.GCS.Buckets.AssetsJSONFile.StorageClass != "" {
+		bucketDeployment.Settings.StorageClass = bucketDeployment.Core.SolutionSettings.Hosting.GCS.Buckets.AssetsJSONFile.StorageClass
+	}
+	bucketDeployment.Settings.VersioningEnabled = bucketDeployment.Core.SolutionSettings.Hosting.GCS.Buckets.AssetsJSONFile.VersioningEnabled
+	bucketDeployment.Settings.RetentionPeriodInDays = bucketDeployment.Core.SolutionSettings.Hosting.GCS.Buckets.AssetsJSONFile.RetentionPeriodInDays
 	return bucketDeployment.Deploy()
 }
@@ -20,6 +20,7 @@ import (
 	"github.com/BrunoReboul/ram/utilities/deploy"
 	"github.com/BrunoReboul/ram/utilities/gcb"
 	"github.com/BrunoReboul/ram/utilities/gcf"
+	"github.com/BrunoReboul/ram/utilities/glo"
 	"github.com/BrunoReboul/ram/utilities/gsu"
 	"github.com/BrunoReboul/ram/utilities/iamgt"
 	"google.golang.org/api/iam/v1"
@@ -35,9 +36,11 @@ type InstanceDeployment struct {
 			IAM iamgt.Parameters
 			GCB gcb.Parameters
 			GCF gcf.Parameters
+			GLO glo.LogParameters
 		}
 		Instance struct {
 			GCF gcf.Event
+			GLO glo.LogParameters
 		}
 	}
 }
@@ -91,7 +94,9 @@ func projectRunRole() (role iam.Role) {
 	role.IncludedPermissions = []string{
 		"storage.buckets.get",
 		"storage.objects.create",
-		"storage.objects.delete"}
+		"storage.objects.delete",
+		"storage.objects.get",
+		"storage.objects.update"}
 	return role
 }
 
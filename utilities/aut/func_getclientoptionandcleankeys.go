@@ -17,6 +17,7 @@ package aut
 import (
 	"context"
 
+	"github.com/BrunoReboul/ram/utilities/glo"
 	"golang.org/x/oauth2/jwt"
 	"google.golang.org/api/option"
 )
@@ -29,10 +30,7 @@ func GetClientOptionAndCleanKeys(ctx context.Context,
 	gciAdminUserToImpersonate string,
 	scopes []string,
 	serviceAccountKeyNames []string,
-	initID string,
-	microserviceName string,
-	instanceName string,
-	environment string) (
+	logger *glo.Logger) (
 	option.ClientOption, bool) {
 	var clientOption option.ClientOption
 	var jwtConfig *jwt.Config
@@ -44,10 +42,7 @@ func GetClientOptionAndCleanKeys(ctx context.Context,
 		gciAdminUserToImpersonate,
 		scopes,
 		serviceAccountKeyNames,
-		initID,
-		microserviceName,
-		instanceName,
-		environment)
+		logger)
 	if err != nil {
 		return clientOption, false
 	}
@@ -18,6 +18,7 @@ import (
 	"context"
 	"encoding/base64"
 
+	"github.com/BrunoReboul/ram/utilities/glo"
 	"golang.org/x/oauth2/jwt"
 )
 
@@ -29,10 +30,7 @@ func getJWTConfigAndCleanKeys(ctx context.Context,
 	gciAdminUserToImpersonate string,
 	scopes []string,
 	serviceAccountKeyNames []string,
-	initID string,
-	microserviceName string,
-	instanceName string,
-	environment string) (
+	logger *glo.Logger) (
 	jwtConfig *jwt.Config,
 	err error) {
 	keyRestAPIFormat, err := getKeyJSONdataAndCleanKeys(ctx,
@@ -40,10 +38,7 @@ func getJWTConfigAndCleanKeys(ctx context.Context,
 		keyJSONFilePath,
 		projectID,
 		serviceAccountKeyNames,
-		initID,
-		microserviceName,
-		instanceName,
-		environment)
+		logger)
 	if err != nil {
 		return jwtConfig, err
 	}
@@ -65,7 +60,7 @@ func getJWTConfigAndCleanKeys(ctx context.Context,
 
 	// using Json Web joken a the method with cerdentials does not yet implement the subject impersonification
 	// https://github.com/googleapis/google-api-java-client/issues/1007
-	jwtConfig, err = getJWTConfigAndImpersonate(keyJSONdata, gciAdminUserToImpersonate, scopes)
+	jwtConfig, err = getJWTConfigAndImpersonate(keyJSONdata, gciAdminUserToImpersonate, scopes, logger)
 	if err != nil {
 		return jwtConfig, err
 	}
@@ -15,21 +15,22 @@
 package aut
 
 import (
-	"log"
+	"fmt"
 
+	"github.com/BrunoReboul/ram/utilities/glo"
 	"golang.org/x/oauth2/google"
 	"golang.org/x/oauth2/jwt"
 )
 
 // getJWTConfigAndImpersonate build JWT with impersonification
-func getJWTConfigAndImpersonate(keyJSONdata []byte, gciAdminUserToImpersonate string, scopes []string) (jwtConfig *jwt.Config, err error) {
+func getJWTConfigAndImpersonate(keyJSONdata []byte, gciAdminUserToImpersonate string, scopes []string, logger *glo.Logger) (jwtConfig *jwt.Config, err error) {
 	// using Json Web joken a the method with cerdentials does not yet implement the subject impersonification
 	// https://github.com/googleapis/google-api-java-client/issues/1007
 
 	// scope constants: https://godoc.org/google.golang.org/api/admin/directory/v1#pkg-constants
 	jwtConfig, err = google.JWTConfigFromJSON(keyJSONdata, scopes...)
 	if err != nil {
-		log.Printf("google.JWTConfigFromJSON: %v", err)
+		logger.InitFailed(fmt.Sprintf("google.JWTConfigFromJSON %v", err))
 		return jwtConfig, err
 	}
 	jwtConfig.Subject = gciAdminUserToImpersonate
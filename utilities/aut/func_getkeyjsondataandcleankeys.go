@@ -19,7 +19,6 @@ import (
 	"encoding/json"
 	"fmt"
 	"io/ioutil"
-	"log"
 
 	"github.com/BrunoReboul/ram/utilities/glo"
 	"github.com/BrunoReboul/ram/utilities/str"
@@ -32,10 +31,7 @@ func getKeyJSONdataAndCleanKeys(ctx context.Context,
 	keyJSONFilePath string,
 	projectID string,
 	serviceAccountKeyNames []string,
-	initID string,
-	microserviceName string,
-	instanceName string,
-	environment string) (
+	logger *glo.Logger) (
 	keyRestAPIFormat keyRestAPIFormat,
 	err error) {
 	var keyJSONdata []byte
@@ -44,55 +40,23 @@ func getKeyJSONdataAndCleanKeys(ctx context.Context,
 
 	iamService, err = iam.NewService(ctx)
 	if err != nil {
-		log.Println(glo.Entry{
-			MicroserviceName: microserviceName,
-			InstanceName:     instanceName,
-			Environment:      environment,
-			Severity:         "CRITICAL",
-			Message:          "init_failed",
-			Description:      fmt.Sprintf("iam.NewService %v", err),
-			InitID:           initID,
-		})
+		logger.InitFailed(fmt.Sprintf("iam.NewService %v", err))
 		return keyRestAPIFormat, err
 	}
 	resource := "projects/-/serviceAccounts/" + serviceAccountEmail
 	listServiceAccountKeyResponse, err := iamService.Projects.ServiceAccounts.Keys.List(resource).Do()
 	if err != nil {
-		log.Println(glo.Entry{
-			MicroserviceName: microserviceName,
-			InstanceName:     instanceName,
-			Environment:      environment,
-			Severity:         "CRITICAL",
-			Message:          "init_failed",
-			Description:      fmt.Sprintf("iamService.Projects.ServiceAccounts.Keys.List %v", err),
-			InitID:           initID,
-		})
+		logger.InitFailed(fmt.Sprintf("iamService.Projects.ServiceAccounts.Keys.List %v", err))
 		return keyRestAPIFormat, err
 	}
 	keyJSONdata, err = ioutil.ReadFile(keyJSONFilePath)
 	if err != nil {
-		log.Println(glo.Entry{
-			MicroserviceName: microserviceName,
-			InstanceName:     instanceName,
-			Environment:      environment,
-			Severity:         "CRITICAL",
-			Message:          "init_failed",
-			Description:      fmt.Sprintf("ioutil.ReadFile(keyJSONFilePath) %v", err),
-			InitID:           initID,
-		})
+		logger.InitFailed(fmt.Sprintf("ioutil.ReadFile(keyJSONFilePath) %v", err))
 		return keyRestAPIFormat, err
 	}
 	err = json.Unmarshal(keyJSONdata, &keyRestAPIFormat)
 	if err != nil {
-		log.Println(glo.Entry{
-			MicroserviceName: microserviceName,
-			InstanceName:     instanceName,
-			Environment:      environment,
-			Severity:         "CRITICAL",
-			Message:          "init_failed",
-			Description:      fmt.Sprintf("json.Unmarshal(keyJSONdata, &keyRestAPIFormat) %v", err),
-			InitID:           initID,
-		})
+		logger.InitFailed(fmt.Sprintf("json.Unmarshal(keyJSONdata, &keyRestAPIFormat) %v", err))
 		return keyRestAPIFormat, err
 	}
 	currentKeyName = keyRestAPIFormat.Name
@@ -100,58 +64,18 @@ func getKeyJSONdataAndCleanKeys(ctx context.Context,
 	// Clean keys
 	for _, serviceAccountKey := range listServiceAccountKeyResponse.Keys {
 		if serviceAccountKey.Name == currentKeyName {
-			log.Println(glo.Entry{
-				MicroserviceName: microserviceName,
-				InstanceName:     instanceName,
-				Environment:      environment,
-				Severity:         "INFO",
-				Message:          "init_keep_current_key",
-				Description:      fmt.Sprintf("ValidAfterTime %s named %s", serviceAccountKey.ValidAfterTime, serviceAccountKey.Name),
-				InitID:           initID,
-			})
+			logger.Info("init_keep_current_key", fmt.Sprintf("ValidAfterTime %s named %s", serviceAccountKey.ValidAfterTime, serviceAccountKey.Name))
 		} else {
 			if str.Find(serviceAccountKeyNames, serviceAccountKey.Name) {
-				log.Println(glo.Entry{
-					MicroserviceName: microserviceName,
-					InstanceName:     instanceName,
-					Environment:      environment,
-					Severity:         "INFO",
-					Message:          "init_keep_recorded_key",
-					Description:      fmt.Sprintf("ValidAfterTime %s named %s", serviceAccountKey.ValidAfterTime, serviceAccountKey.Name),
-					InitID:           initID,
-				})
+				logger.Info("init_keep_recorded_key", fmt.Sprintf("ValidAfterTime %s named %s", serviceAccountKey.ValidAfterTime, serviceAccountKey.Name))
 			} else {
 				if serviceAccountKey.KeyType == "SYSTEM_MANAGED" {
-					log.Println(glo.Entry{
-						MicroserviceName: microserviceName,
-						InstanceName:     instanceName,
-						Environment:      environment,
-						Severity:         "INFO",
-						Message:          "init_ignore_system_managed_key",
-						Description:      fmt.Sprintf("named %s", serviceAccountKey.Name),
-						InitID:           initID,
-					})
+					logger.Info("init_ignore_system_managed_key", fmt.Sprintf("named %s", serviceAccountKey.Name))
 				} else {
-					log.Println(glo.Entry{
-						MicroserviceName: microserviceName,
-						InstanceName:     instanceName,
-						Environment:      environment,
-						Severity:         "INFO",
-						Message:          "init_delete_key",
-						Description:      fmt.Sprintf("ValidAfterTime %s named %s", serviceAccountKey.ValidAfterTime, serviceAccountKey.Name),
-						InitID:           initID,
-					})
+					logger.Info("init_delete_key", fmt.Sprintf("ValidAfterTime %s named %s", serviceAccountKey.ValidAfterTime, serviceAccountKey.Name))
 					_, err = iamService.Projects.ServiceAccounts.Keys.Delete(serviceAccountKey.Name).Do()
 					if err != nil {
-						log.Println(glo.Entry{
-							MicroserviceName: microserviceName,
-							InstanceName:     instanceName,
-							Environment:      environment,
-							Severity:         "CRITICAL",
-							Message:          "init_cannot_delete_key",
-							Description:      fmt.Sprintf("iamService.Projects.ServiceAccounts.Keys.Delete: %v", err),
-							InitID:           initID,
-						})
+						logger.Critical("init_cannot_delete_key", fmt.Sprintf("iamService.Projects.ServiceAccounts.Keys.Delete: %v", err))
 						return keyRestAPIFormat, err
 					}
 				}
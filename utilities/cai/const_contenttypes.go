@@ -0,0 +1,26 @@
+// Copyright 2020 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the 'License');
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an 'AS IS' BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cai
+
+// Cloud Asset Inventory content types
+// https://cloud.google.com/asset-inventory/docs/reference/rest/v1/feeds#contenttype
+const (
+	ContentTypeResource     = "RESOURCE"
+	ContentTypeIAMPolicy    = "IAM_POLICY"
+	ContentTypeOrgPolicy    = "ORG_POLICY"
+	ContentTypeAccessPolicy = "ACCESS_POLICY"
+	ContentTypeOSInventory  = "OS_INVENTORY"
+	ContentTypeRelationship = "RELATIONSHIP"
+)
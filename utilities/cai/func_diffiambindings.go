@@ -0,0 +1,89 @@
+// Copyright 2020 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the 'License');
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an 'AS IS' BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cai
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+)
+
+// DiffIAMBindings lists the role members added and removed between two IAM policies
+// Bindings are keyed by role and condition expression, as a policy may bind the same role under several conditions
+func DiffIAMBindings(oldIamPolicy interface{}, newIamPolicy interface{}) (added []IAMBindingMember, removed []IAMBindingMember, err error) {
+	oldMembers, err := getIAMBindingMembers(oldIamPolicy)
+	if err != nil {
+		return nil, nil, fmt.Errorf("old iamPolicy %v", err)
+	}
+	newMembers, err := getIAMBindingMembers(newIamPolicy)
+	if err != nil {
+		return nil, nil, fmt.Errorf("new iamPolicy %v", err)
+	}
+	for bindingMember := range newMembers {
+		if !oldMembers[bindingMember] {
+			added = append(added, bindingMember)
+		}
+	}
+	for bindingMember := range oldMembers {
+		if !newMembers[bindingMember] {
+			removed = append(removed, bindingMember)
+		}
+	}
+	sortIAMBindingMembers(added)
+	sortIAMBindingMembers(removed)
+	return added, removed, nil
+}
+
+func getIAMBindingMembers(iamPolicy interface{}) (map[IAMBindingMember]bool, error) {
+	bindingMembers := make(map[IAMBindingMember]bool)
+	if iamPolicy == nil {
+		return bindingMembers, nil
+	}
+	iamPolicyJSON, err := json.Marshal(iamPolicy)
+	if err != nil {
+		return nil, err
+	}
+	var policy struct {
+		Bindings []struct {
+			Role      string   `json:"role"`
+			Members   []string `json:"members"`
+			Condition struct {
+				Expression string `json:"expression"`
+			} `json:"condition"`
+		} `json:"bindings"`
+	}
+	err = json.Unmarshal(iamPolicyJSON, &policy)
+	if err != nil {
+		return nil, err
+	}
+	for _, binding := range policy.Bindings {
+		for _, member := range binding.Members {
+			bindingMembers[IAMBindingMember{Role: binding.Role, Condition: binding.Condition.Expression, Member: member}] = true
+		}
+	}
+	return bindingMembers, nil
+}
+
+func sortIAMBindingMembers(bindingMembers []IAMBindingMember) {
+	sort.Slice(bindingMembers, func(i, j int) bool {
+		if bindingMembers[i].Role != bindingMembers[j].Role {
+			return bindingMembers[i].Role < bindingMembers[j].Role
+		}
+		if bindingMembers[i].Condition != bindingMembers[j].Condition {
+			return bindingMembers[i].Condition < bindingMembers[j].Condition
+		}
+		return bindingMembers[i].Member < bindingMembers[j].Member
+	})
+}
@@ -0,0 +1,111 @@
+// Copyright 2020 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the 'License');
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an 'AS IS' BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cai
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+func TestUnitDiffIAMBindings(t *testing.T) {
+	var testCases = []struct {
+		name        string
+		oldJSON     string
+		newJSON     string
+		wantAdded   []IAMBindingMember
+		wantRemoved []IAMBindingMember
+	}{
+		{
+			name:    "noOldPolicy",
+			newJSON: `{"bindings": [{"role": "roles/owner", "members": ["user:a@example.com"]}]}`,
+			wantAdded: []IAMBindingMember{
+				{Role: "roles/owner", Member: "user:a@example.com"}},
+		},
+		{
+			name:    "addedOwner",
+			oldJSON: `{"bindings": [{"role": "roles/viewer", "members": ["user:a@example.com"]}]}`,
+			newJSON: `{"bindings": [{"role": "roles/viewer", "members": ["user:a@example.com"]}, {"role": "roles/owner", "members": ["user:b@example.com", "user:a@example.com"]}]}`,
+			wantAdded: []IAMBindingMember{
+				{Role: "roles/owner", Member: "user:a@example.com"},
+				{Role: "roles/owner", Member: "user:b@example.com"}},
+		},
+		{
+			name:    "removedMember",
+			oldJSON: `{"bindings": [{"role": "roles/viewer", "members": ["user:a@example.com", "group:g@example.com"]}]}`,
+			newJSON: `{"bindings": [{"role": "roles/viewer", "members": ["user:a@example.com"]}]}`,
+			wantRemoved: []IAMBindingMember{
+				{Role: "roles/viewer", Member: "group:g@example.com"}},
+		},
+		{
+			name:    "conditionChanged",
+			oldJSON: `{"bindings": [{"role": "roles/viewer", "members": ["user:a@example.com"], "condition": {"title": "t", "expression": "request.time < timestamp(\"2021-01-01T00:00:00Z\")"}}]}`,
+			newJSON: `{"bindings": [{"role": "roles/viewer", "members": ["user:a@example.com"], "condition": {"title": "t", "expression": "request.time < timestamp(\"2022-01-01T00:00:00Z\")"}}]}`,
+			wantAdded: []IAMBindingMember{
+				{Role: "roles/viewer", Condition: `request.time < timestamp("2022-01-01T00:00:00Z")`, Member: "user:a@example.com"}},
+			wantRemoved: []IAMBindingMember{
+				{Role: "roles/viewer", Condition: `request.time < timestamp("2021-01-01T00:00:00Z")`, Member: "user:a@example.com"}},
+		},
+		{
+			name:    "sameRoleUnconditionalAndConditional",
+			oldJSON: `{"bindings": [{"role": "roles/viewer", "members": ["user:a@example.com"]}, {"role": "roles/viewer", "members": ["user:a@example.com"], "condition": {"expression": "resource.name.startsWith(\"projects/p\")"}}]}`,
+			newJSON: `{"bindings": [{"role": "roles/viewer", "members": ["user:a@example.com"], "condition": {"expression": "resource.name.startsWith(\"projects/p\")"}}]}`,
+			wantRemoved: []IAMBindingMember{
+				{Role: "roles/viewer", Member: "user:a@example.com"}},
+		},
+		{
+			name:    "noChange",
+			oldJSON: `{"etag": "abc", "bindings": [{"role": "roles/viewer", "members": ["user:a@example.com"]}]}`,
+			newJSON: `{"etag": "def", "bindings": [{"members": ["user:a@example.com"], "role": "roles/viewer"}]}`,
+		},
+	}
+
+	for _, tc := range testCases {
+		tc := tc // https://github.com/golang/go/wiki/CommonMistakes#using-goroutines-on-loop-iterator-variables
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+			var oldIamPolicy, newIamPolicy interface{}
+			if tc.oldJSON != "" {
+				err := json.Unmarshal([]byte(tc.oldJSON), &oldIamPolicy)
+				if err != nil {
+					t.Fatalf("json.Unmarshal oldJSON %v", err)
+				}
+			}
+			err := json.Unmarshal([]byte(tc.newJSON), &newIamPolicy)
+			if err != nil {
+				t.Fatalf("json.Unmarshal newJSON %v", err)
+			}
+			added, removed, err := DiffIAMBindings(oldIamPolicy, newIamPolicy)
+			if err != nil {
+				t.Fatalf("Want no error and got %v", err)
+			}
+			if len(added) != len(tc.wantAdded) {
+				t.Fatalf("Want %d added got %d %v", len(tc.wantAdded), len(added), added)
+			}
+			for i := range added {
+				if added[i] != tc.wantAdded[i] {
+					t.Errorf("Want added %v got %v", tc.wantAdded[i], added[i])
+				}
+			}
+			if len(removed) != len(tc.wantRemoved) {
+				t.Fatalf("Want %d removed got %d %v", len(tc.wantRemoved), len(removed), removed)
+			}
+			for i := range removed {
+				if removed[i] != tc.wantRemoved[i] {
+					t.Errorf("Want removed %v got %v", tc.wantRemoved[i], removed[i])
+				}
+			}
+		})
+	}
+}
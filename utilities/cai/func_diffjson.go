@@ -0,0 +1,56 @@
+// Copyright 2020 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the 'License');
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an 'AS IS' BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cai
+
+import (
+	"reflect"
+	"sort"
+)
+
+// DiffJSON lists the JSON paths which values differ between two unmarshalled JSON documents
+// Objects are walked recursively, other values including arrays are compared as a whole
+// Paths are dot separated and sorted, a missing value is reported as nil
+func DiffJSON(path string, oldValue interface{}, newValue interface{}) (pathChanges []PathChange) {
+	oldMap, oldIsMap := oldValue.(map[string]interface{})
+	newMap, newIsMap := newValue.(map[string]interface{})
+	if oldIsMap && newIsMap {
+		var keys []string
+		for key := range oldMap {
+			keys = append(keys, key)
+		}
+		for key := range newMap {
+			if _, ok := oldMap[key]; !ok {
+				keys = append(keys, key)
+			}
+		}
+		sort.Strings(keys)
+		for _, key := range keys {
+			childPath := key
+			if path != "" {
+				childPath = path + "." + key
+			}
+			pathChanges = append(pathChanges, DiffJSON(childPath, oldMap[key], newMap[key])...)
+		}
+		return pathChanges
+	}
+	if !reflect.DeepEqual(oldValue, newValue) {
+		pathChanges = append(pathChanges, PathChange{
+			Path:     path,
+			OldValue: oldValue,
+			NewValue: newValue,
+		})
+	}
+	return pathChanges
+}
@@ -0,0 +1,79 @@
+// Copyright 2020 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the 'License');
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an 'AS IS' BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cai
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+func TestUnitDiffJSON(t *testing.T) {
+	var testCases = []struct {
+		name      string
+		oldJSON   string
+		newJSON   string
+		wantPaths []string
+	}{
+		{
+			name:      "noChange",
+			oldJSON:   `{"data": {"name": "a", "labels": {"owner": "cpasmoi"}}}`,
+			newJSON:   `{"data": {"labels": {"owner": "cpasmoi"}, "name": "a"}}`,
+			wantPaths: []string{},
+		},
+		{
+			name:      "changedLabel",
+			oldJSON:   `{"data": {"name": "a", "labels": {"owner": "cpasmoi"}}}`,
+			newJSON:   `{"data": {"name": "a", "labels": {"owner": "ohnonono"}}}`,
+			wantPaths: []string{"data.labels.owner"},
+		},
+		{
+			name:      "addedAndRemovedKeys",
+			oldJSON:   `{"data": {"name": "a", "description": "blabla"}}`,
+			newJSON:   `{"data": {"name": "a", "labels": {"owner": "cpasmoi"}}}`,
+			wantPaths: []string{"data.description", "data.labels"},
+		},
+		{
+			name:      "arrayAsAWhole",
+			oldJSON:   `{"data": {"zones": ["a", "b"]}}`,
+			newJSON:   `{"data": {"zones": ["a", "c"]}}`,
+			wantPaths: []string{"data.zones"},
+		},
+	}
+
+	for _, tc := range testCases {
+		tc := tc // https://github.com/golang/go/wiki/CommonMistakes#using-goroutines-on-loop-iterator-variables
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+			var oldValue, newValue interface{}
+			err := json.Unmarshal([]byte(tc.oldJSON), &oldValue)
+			if err != nil {
+				t.Fatalf("json.Unmarshal oldJSON %v", err)
+			}
+			err = json.Unmarshal([]byte(tc.newJSON), &newValue)
+			if err != nil {
+				t.Fatalf("json.Unmarshal newJSON %v", err)
+			}
+			pathChanges := DiffJSON("", oldValue, newValue)
+			if len(pathChanges) != len(tc.wantPaths) {
+				t.Fatalf("Want %d path changes got %d %v", len(tc.wantPaths), len(pathChanges), pathChanges)
+			}
+			for i, pathChange := range pathChanges {
+				if pathChange.Path != tc.wantPaths[i] {
+					t.Errorf("Want path %s got %s", tc.wantPaths[i], pathChange.Path)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,51 @@
+// Copyright 2020 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the 'License');
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an 'AS IS' BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cai
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"cloud.google.com/go/firestore"
+)
+
+// GetActor retrieve who changed a content type of an asset from the audit log actors cached in firestore
+// Returns nil when no actor is correlated with the change time
+func GetActor(ctx context.Context,
+	firestoreClient *firestore.Client,
+	collectionID string,
+	assetName string,
+	contentType string,
+	changeTime time.Time) (*Actor, error) {
+	documentPath := collectionID + "/" + GetActorDocumentID(assetName, contentType)
+	documentSnap, err := firestoreClient.Doc(documentPath).Get(ctx)
+	if err != nil {
+		if strings.Contains(strings.ToLower(err.Error()), "notfound") {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("firestoreClient.Doc(documentPath).Get %s %v", documentPath, err)
+	}
+	var actor Actor
+	err = documentSnap.DataTo(&actor)
+	if err != nil {
+		return nil, fmt.Errorf("documentSnap.DataTo %s %v", documentPath, err)
+	}
+	if !IsCorrelatedActor(actor, changeTime) {
+		return nil, nil
+	}
+	return &actor, nil
+}
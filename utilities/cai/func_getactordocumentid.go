@@ -0,0 +1,23 @@
+// Copyright 2020 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the 'License');
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an 'AS IS' BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cai
+
+import "github.com/BrunoReboul/ram/utilities/str"
+
+// GetActorDocumentID returns the firestore document ID of the actor who last changed a content type of an asset
+// Resource and IAM policy changes of a same asset are made by different calls, so they have distinct actors
+func GetActorDocumentID(assetName, contentType string) string {
+	return str.RevertSlash(assetName) + GetContentTypeSuffix(contentType)
+}
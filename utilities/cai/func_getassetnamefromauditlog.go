@@ -0,0 +1,47 @@
+// Copyright 2020 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the 'License');
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an 'AS IS' BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cai
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// GetAssetNameFromAuditLog build the Cloud Asset Inventory asset name from audit log service name and resource name
+// Resource manager audit logs name projects by ID while CAI name them by number: getProjectNumber resolves it
+func GetAssetNameFromAuditLog(serviceName string, resourceName string, getProjectNumber func(projectID string) (projectNumber int64, err error)) (assetName string, err error) {
+	if strings.HasPrefix(resourceName, "//") {
+		return resourceName, nil
+	}
+	resourceName = strings.TrimPrefix(resourceName, "/")
+	switch serviceName {
+	case "cloudresourcemanager.googleapis.com":
+		parts := strings.Split(resourceName, "/")
+		if len(parts) == 2 && parts[0] == "projects" {
+			if _, err := strconv.ParseInt(parts[1], 10, 64); err != nil {
+				projectNumber, err := getProjectNumber(parts[1])
+				if err != nil {
+					return "", fmt.Errorf("getProjectNumber %s %v", parts[1], err)
+				}
+				resourceName = fmt.Sprintf("projects/%d", projectNumber)
+			}
+		}
+	// Cloud storage audit logs name buckets projects/_/buckets/<bucket> while CAI name them //storage.googleapis.com/<bucket>
+	case "storage.googleapis.com":
+		resourceName = strings.TrimPrefix(resourceName, "projects/_/buckets/")
+	}
+	return fmt.Sprintf("//%s/%s", serviceName, resourceName), nil
+}
@@ -0,0 +1,113 @@
+// Copyright 2020 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the 'License');
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an 'AS IS' BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cai
+
+import (
+	"fmt"
+	"testing"
+)
+
+func TestUnitGetAssetNameFromAuditLog(t *testing.T) {
+	projectNumbers := map[string]int64{"myproject": 123456789012}
+	getProjectNumber := func(projectID string) (projectNumber int64, err error) {
+		projectNumber, ok := projectNumbers[projectID]
+		if !ok {
+			return 0, fmt.Errorf("project %s not found", projectID)
+		}
+		return projectNumber, nil
+	}
+	var testCases = []struct {
+		name          string
+		serviceName   string
+		resourceName  string
+		wantAssetName string
+		wantErr       bool
+	}{
+		{
+			name:          "projectID",
+			serviceName:   "cloudresourcemanager.googleapis.com",
+			resourceName:  "projects/myproject",
+			wantAssetName: "//cloudresourcemanager.googleapis.com/projects/123456789012",
+		},
+		{
+			name:          "projectNumber",
+			serviceName:   "cloudresourcemanager.googleapis.com",
+			resourceName:  "projects/123456789012",
+			wantAssetName: "//cloudresourcemanager.googleapis.com/projects/123456789012",
+		},
+		{
+			name:         "projectNotFound",
+			serviceName:  "cloudresourcemanager.googleapis.com",
+			resourceName: "projects/unknownproject",
+			wantErr:      true,
+		},
+		{
+			name:          "folder",
+			serviceName:   "cloudresourcemanager.googleapis.com",
+			resourceName:  "folders/345678901234",
+			wantAssetName: "//cloudresourcemanager.googleapis.com/folders/345678901234",
+		},
+		{
+			name:          "organization",
+			serviceName:   "cloudresourcemanager.googleapis.com",
+			resourceName:  "organizations/567890123456",
+			wantAssetName: "//cloudresourcemanager.googleapis.com/organizations/567890123456",
+		},
+		{
+			name:          "computeInstance",
+			serviceName:   "compute.googleapis.com",
+			resourceName:  "projects/myproject/zones/europe-west1-b/instances/myinstance",
+			wantAssetName: "//compute.googleapis.com/projects/myproject/zones/europe-west1-b/instances/myinstance",
+		},
+		{
+			name:          "storageBucket",
+			serviceName:   "storage.googleapis.com",
+			resourceName:  "projects/_/buckets/mybucket",
+			wantAssetName: "//storage.googleapis.com/mybucket",
+		},
+		{
+			name:          "alreadyFullName",
+			serviceName:   "bigquery.googleapis.com",
+			resourceName:  "//bigquery.googleapis.com/projects/myproject/datasets/mydataset",
+			wantAssetName: "//bigquery.googleapis.com/projects/myproject/datasets/mydataset",
+		},
+		{
+			name:          "leadingSlash",
+			serviceName:   "pubsub.googleapis.com",
+			resourceName:  "/projects/myproject/topics/mytopic",
+			wantAssetName: "//pubsub.googleapis.com/projects/myproject/topics/mytopic",
+		},
+	}
+
+	for _, tc := range testCases {
+		tc := tc // https://github.com/golang/go/wiki/CommonMistakes#using-goroutines-on-loop-iterator-variables
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+			got, err := GetAssetNameFromAuditLog(tc.serviceName, tc.resourceName, getProjectNumber)
+			if tc.wantErr {
+				if err == nil {
+					t.Errorf("Want an error got nil")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Want no error got %v", err)
+			}
+			if got != tc.wantAssetName {
+				t.Errorf("Want %s got %s", tc.wantAssetName, got)
+			}
+		})
+	}
+}
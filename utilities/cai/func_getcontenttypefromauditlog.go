@@ -0,0 +1,36 @@
+// Copyright 2020 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the 'License');
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an 'AS IS' BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cai
+
+import "strings"
+
+// GetContentTypeFromAuditLog returns the CAI content type changed by an admin activity audit log entry
+// Method names are compared case insensitive, as both SetIamPolicy and setIamPolicy are used, cloud storage using setIamPermissions
+func GetContentTypeFromAuditLog(serviceName, methodName string) string {
+	methodName = strings.ToLower(methodName)
+	switch {
+	case strings.Contains(methodName, "setiampolicy"),
+		strings.Contains(methodName, "setiampermissions"):
+		return ContentTypeIAMPolicy
+	case serviceName == "orgpolicy.googleapis.com",
+		strings.Contains(methodName, "setorgpolicy"),
+		strings.Contains(methodName, "clearorgpolicy"):
+		return ContentTypeOrgPolicy
+	case serviceName == "accesscontextmanager.googleapis.com":
+		return ContentTypeAccessPolicy
+	default:
+		return ContentTypeResource
+	}
+}
@@ -0,0 +1,80 @@
+// Copyright 2020 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the 'License');
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an 'AS IS' BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cai
+
+import "testing"
+
+func TestUnitGetContentTypeFromAuditLog(t *testing.T) {
+	var testCases = []struct {
+		name        string
+		serviceName string
+		methodName  string
+		want        string
+	}{
+		{
+			name:        "projectSetIamPolicy",
+			serviceName: "cloudresourcemanager.googleapis.com",
+			methodName:  "SetIamPolicy",
+			want:        ContentTypeIAMPolicy,
+		},
+		{
+			name:        "bucketSetIamPolicy",
+			serviceName: "storage.googleapis.com",
+			methodName:  "storage.setIamPermissions",
+			want:        ContentTypeIAMPolicy,
+		},
+		{
+			name:        "instanceSetIamPolicy",
+			serviceName: "compute.googleapis.com",
+			methodName:  "v1.compute.instances.setIamPolicy",
+			want:        ContentTypeIAMPolicy,
+		},
+		{
+			name:        "setOrgPolicy",
+			serviceName: "cloudresourcemanager.googleapis.com",
+			methodName:  "SetOrgPolicy",
+			want:        ContentTypeOrgPolicy,
+		},
+		{
+			name:        "orgPolicyAPI",
+			serviceName: "orgpolicy.googleapis.com",
+			methodName:  "google.cloud.orgpolicy.v2.OrgPolicy.UpdatePolicy",
+			want:        ContentTypeOrgPolicy,
+		},
+		{
+			name:        "servicePerimeter",
+			serviceName: "accesscontextmanager.googleapis.com",
+			methodName:  "google.identity.accesscontextmanager.v1.AccessContextManager.UpdateServicePerimeter",
+			want:        ContentTypeAccessPolicy,
+		},
+		{
+			name:        "instanceInsert",
+			serviceName: "compute.googleapis.com",
+			methodName:  "v1.compute.instances.insert",
+			want:        ContentTypeResource,
+		},
+	}
+
+	for _, tc := range testCases {
+		tc := tc // https://github.com/golang/go/wiki/CommonMistakes#using-goroutines-on-loop-iterator-variables
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+			got := GetContentTypeFromAuditLog(tc.serviceName, tc.methodName)
+			if got != tc.want {
+				t.Errorf("Want %s got %s", tc.want, got)
+			}
+		})
+	}
+}
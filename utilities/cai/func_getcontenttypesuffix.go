@@ -0,0 +1,34 @@
+// Copyright 2020 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the 'License');
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an 'AS IS' BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cai
+
+// GetContentTypeSuffix returns the suffix to distinguish the contents of a same asset name, e.g. in cache document IDs and object names
+// RESOURCE has no suffix
+func GetContentTypeSuffix(contentType string) string {
+	switch contentType {
+	case ContentTypeIAMPolicy:
+		return "_iam"
+	case ContentTypeOrgPolicy:
+		return "_orgpolicy"
+	case ContentTypeAccessPolicy:
+		return "_accesspolicy"
+	case ContentTypeOSInventory:
+		return "_osinventory"
+	case ContentTypeRelationship:
+		return "_relationship"
+	default:
+		return ""
+	}
+}
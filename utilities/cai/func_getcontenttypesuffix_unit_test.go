@@ -0,0 +1,45 @@
+// Copyright 2020 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the 'License');
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an 'AS IS' BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cai
+
+import (
+	"testing"
+)
+
+func TestUnitGetContentTypeSuffix(t *testing.T) {
+	var testCases = []struct {
+		contentType string
+		want        string
+	}{
+		{contentType: ContentTypeResource, want: ""},
+		{contentType: "", want: ""},
+		{contentType: ContentTypeIAMPolicy, want: "_iam"},
+		{contentType: ContentTypeOrgPolicy, want: "_orgpolicy"},
+		{contentType: ContentTypeAccessPolicy, want: "_accesspolicy"},
+		{contentType: ContentTypeOSInventory, want: "_osinventory"},
+		{contentType: ContentTypeRelationship, want: "_relationship"},
+	}
+
+	for _, tc := range testCases {
+		tc := tc // https://github.com/golang/go/wiki/CommonMistakes#using-goroutines-on-loop-iterator-variables
+		t.Run(tc.contentType, func(t *testing.T) {
+			t.Parallel()
+			got := GetContentTypeSuffix(tc.contentType)
+			if got != tc.want {
+				t.Errorf("Want '%s' got '%s'", tc.want, got)
+			}
+		})
+	}
+}
@@ -0,0 +1,35 @@
+// Copyright 2020 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the 'License');
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an 'AS IS' BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cai
+
+import (
+	"strings"
+)
+
+// GetObjectNameContentType returns the content type of an asset JSON file from its object name suffix, e.g. _iam.json
+func GetObjectNameContentType(objectName string) string {
+	for _, contentType := range []string{
+		ContentTypeIAMPolicy,
+		ContentTypeOrgPolicy,
+		ContentTypeAccessPolicy,
+		ContentTypeOSInventory,
+		ContentTypeRelationship,
+	} {
+		if strings.HasSuffix(objectName, GetContentTypeSuffix(contentType)+".json") {
+			return contentType
+		}
+	}
+	return ContentTypeResource
+}
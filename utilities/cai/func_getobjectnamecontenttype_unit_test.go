@@ -0,0 +1,45 @@
+// Copyright 2020 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the 'License');
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an 'AS IS' BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cai
+
+import (
+	"testing"
+)
+
+func TestUnitGetObjectNameContentType(t *testing.T) {
+	var testCases = []struct {
+		objectName string
+		want       string
+	}{
+		{objectName: "compute.googleapis.com/projects/p/zones/z/instances/i.json", want: ContentTypeResource},
+		{objectName: "cloudresourcemanager.googleapis.com/projects/123_iam.json", want: ContentTypeIAMPolicy},
+		{objectName: "cloudresourcemanager.googleapis.com/projects/123_orgpolicy.json", want: ContentTypeOrgPolicy},
+		{objectName: "cloudresourcemanager.googleapis.com/organizations/1_accesspolicy.json", want: ContentTypeAccessPolicy},
+		{objectName: "compute.googleapis.com/projects/p/zones/z/instances/i_osinventory.json", want: ContentTypeOSInventory},
+		{objectName: "compute.googleapis.com/projects/p/zones/z/instances/i_relationship.json", want: ContentTypeRelationship},
+		{objectName: "compute.googleapis.com/projects/p/global/networks/my_iam_network.json", want: ContentTypeResource},
+	}
+
+	for _, tc := range testCases {
+		tc := tc // https://github.com/golang/go/wiki/CommonMistakes#using-goroutines-on-loop-iterator-variables
+		t.Run(tc.objectName, func(t *testing.T) {
+			t.Parallel()
+			got := GetObjectNameContentType(tc.objectName)
+			if got != tc.want {
+				t.Errorf("Want '%s' got '%s'", tc.want, got)
+			}
+		})
+	}
+}
@@ -0,0 +1,43 @@
+// Copyright 2020 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the 'License');
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an 'AS IS' BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cai
+
+import (
+	"strings"
+)
+
+// GetObjectNamePrefix returns the object name prefix under which the JSON files of an asset type are stored
+// Object names are asset names without the leading //, the prefix is the asset name API service,
+// narrowed to the collection when it comes first, e.g. cloudresourcemanager.googleapis.com/folders/
+func GetObjectNamePrefix(assetType string) string {
+	switch assetType {
+	case "cloudresourcemanager.googleapis.com/Organization":
+		return "cloudresourcemanager.googleapis.com/organizations/"
+	case "cloudresourcemanager.googleapis.com/Folder":
+		return "cloudresourcemanager.googleapis.com/folders/"
+	case "cloudresourcemanager.googleapis.com/Project":
+		return "cloudresourcemanager.googleapis.com/projects/"
+	}
+	serviceName := strings.Split(assetType, "/")[0]
+	switch {
+	case strings.HasSuffix(serviceName, "k8s.io"):
+		// kubernetes objects are named after their GKE cluster
+		return "container.googleapis.com/"
+	case serviceName == "www.googleapis.com" || serviceName == "groupssettings.googleapis.com":
+		// Workspace assets are named after their directory
+		return "directories/"
+	}
+	return serviceName + "/"
+}
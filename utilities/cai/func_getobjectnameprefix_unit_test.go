@@ -0,0 +1,46 @@
+// Copyright 2020 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the 'License');
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an 'AS IS' BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cai
+
+import (
+	"testing"
+)
+
+func TestUnitGetObjectNamePrefix(t *testing.T) {
+	var testCases = []struct {
+		assetType string
+		want      string
+	}{
+		{assetType: "compute.googleapis.com/Instance", want: "compute.googleapis.com/"},
+		{assetType: "cloudresourcemanager.googleapis.com/Organization", want: "cloudresourcemanager.googleapis.com/organizations/"},
+		{assetType: "cloudresourcemanager.googleapis.com/Folder", want: "cloudresourcemanager.googleapis.com/folders/"},
+		{assetType: "cloudresourcemanager.googleapis.com/Project", want: "cloudresourcemanager.googleapis.com/projects/"},
+		{assetType: "k8s.io/Pod", want: "container.googleapis.com/"},
+		{assetType: "rbac.authorization.k8s.io/Role", want: "container.googleapis.com/"},
+		{assetType: "www.googleapis.com/admin/directory/groups", want: "directories/"},
+		{assetType: "groupssettings.googleapis.com/groupSettings", want: "directories/"},
+	}
+
+	for _, tc := range testCases {
+		tc := tc // https://github.com/golang/go/wiki/CommonMistakes#using-goroutines-on-loop-iterator-variables
+		t.Run(tc.assetType, func(t *testing.T) {
+			t.Parallel()
+			got := GetObjectNamePrefix(tc.assetType)
+			if got != tc.want {
+				t.Errorf("Want '%s' got '%s'", tc.want, got)
+			}
+		})
+	}
+}
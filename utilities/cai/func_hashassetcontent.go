@@ -0,0 +1,68 @@
+// Copyright 2020 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the 'License');
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an 'AS IS' BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cai
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/BrunoReboul/ram/utilities/str"
+)
+
+// HashAssetContent returns a hash of an asset contents and ancestors independent of JSON key order and white spaces
+// Ancestors are hashed so that a move, e.g. of a project to another folder, changes the hash of all its child assets
+func HashAssetContent(contents AssetContents, ancestors []string) (string, error) {
+	var content struct {
+		Ancestors        []string    `json:"ancestors,omitempty"`
+		Resource         interface{} `json:"resource"`
+		IamPolicy        interface{} `json:"iamPolicy"`
+		OrgPolicy        interface{} `json:"orgPolicy,omitempty"`
+		AccessPolicy     interface{} `json:"accessPolicy,omitempty"`
+		AccessLevel      interface{} `json:"accessLevel,omitempty"`
+		ServicePerimeter interface{} `json:"servicePerimeter,omitempty"`
+		OsInventory      interface{} `json:"osInventory,omitempty"`
+		RelatedAssets    interface{} `json:"relatedAssets,omitempty"`
+		RelatedAsset     interface{} `json:"relatedAsset,omitempty"`
+	}
+	for _, part := range []struct {
+		name    string
+		rawJSON json.RawMessage
+		value   *interface{}
+	}{
+		{name: "resource", rawJSON: contents.Resource, value: &content.Resource},
+		{name: "iamPolicy", rawJSON: contents.IamPolicy, value: &content.IamPolicy},
+		{name: "orgPolicy", rawJSON: contents.OrgPolicy, value: &content.OrgPolicy},
+		{name: "accessPolicy", rawJSON: contents.AccessPolicy, value: &content.AccessPolicy},
+		{name: "accessLevel", rawJSON: contents.AccessLevel, value: &content.AccessLevel},
+		{name: "servicePerimeter", rawJSON: contents.ServicePerimeter, value: &content.ServicePerimeter},
+		{name: "osInventory", rawJSON: contents.OsInventory, value: &content.OsInventory},
+		{name: "relatedAssets", rawJSON: contents.RelatedAssets, value: &content.RelatedAssets},
+		{name: "relatedAsset", rawJSON: contents.RelatedAsset, value: &content.RelatedAsset},
+	} {
+		if len(part.rawJSON) > 0 {
+			err := json.Unmarshal(part.rawJSON, part.value)
+			if err != nil {
+				return "", fmt.Errorf("json.Unmarshal %s %v", part.name, err)
+			}
+		}
+	}
+	content.Ancestors = ancestors
+	// json.Marshal sorts map keys, so the result is canonical
+	canonicalJSON, err := json.Marshal(content)
+	if err != nil {
+		return "", fmt.Errorf("json.Marshal %v", err)
+	}
+	return str.Hash(string(canonicalJSON)), nil
+}
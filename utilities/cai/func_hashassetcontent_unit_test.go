@@ -0,0 +1,124 @@
+// Copyright 2020 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the 'License');
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an 'AS IS' BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cai
+
+import (
+	"testing"
+)
+
+func TestUnitHashAssetContent(t *testing.T) {
+	var testCases = []struct {
+		name               string
+		resourceJSON       string
+		iamPolicyJSON      string
+		otherResourceJSON  string
+		otherIamPolicyJSON string
+		orgPolicyJSON      string
+		otherOrgPolicyJSON string
+		ancestors          []string
+		otherAncestors     []string
+		wantSameHash       bool
+		wantError          bool
+	}{
+		{
+			name:              "jsonError",
+			resourceJSON:      `{"data":`,
+			otherResourceJSON: `{}`,
+			wantError:         true,
+		},
+		{
+			name:              "keyOrderAndWhiteSpaces",
+			resourceJSON:      `{"data": {"name": "a", "labels": {"owner": "cpasmoi", "env": "dev"}}}`,
+			otherResourceJSON: `{"data":{"labels":{"env":"dev","owner":"cpasmoi"},"name":"a"}}`,
+			wantSameHash:      true,
+		},
+		{
+			name:               "iamPolicyOnly",
+			iamPolicyJSON:      `{"bindings": [{"role": "roles/viewer", "members": ["user:a@example.com"]}]}`,
+			otherIamPolicyJSON: `{"bindings":[{"members":["user:a@example.com"],"role":"roles/viewer"}]}`,
+			wantSameHash:       true,
+		},
+		{
+			name:              "differentLabelValue",
+			resourceJSON:      `{"data": {"labels": {"owner": "cpasmoi"}}}`,
+			otherResourceJSON: `{"data": {"labels": {"owner": "ohnonono"}}}`,
+			wantSameHash:      false,
+		},
+		{
+			name:               "resourceVersusIamPolicy",
+			resourceJSON:       `{"data": {}}`,
+			otherIamPolicyJSON: `{"data": {}}`,
+			wantSameHash:       false,
+		},
+		{
+			name:               "orgPolicyKeyOrder",
+			orgPolicyJSON:      `[{"constraint": "constraints/compute.vmExternalIpAccess", "listPolicy": {"allValues": "DENY"}}]`,
+			otherOrgPolicyJSON: `[{"listPolicy":{"allValues":"DENY"},"constraint":"constraints/compute.vmExternalIpAccess"}]`,
+			wantSameHash:       true,
+		},
+		{
+			name:               "differentOrgPolicy",
+			orgPolicyJSON:      `[{"constraint": "constraints/compute.vmExternalIpAccess", "listPolicy": {"allValues": "DENY"}}]`,
+			otherOrgPolicyJSON: `[{"constraint": "constraints/compute.vmExternalIpAccess", "listPolicy": {"allValues": "ALLOW"}}]`,
+			wantSameHash:       false,
+		},
+		{
+			name:              "sameAncestors",
+			resourceJSON:      `{"data": {"name": "a"}}`,
+			otherResourceJSON: `{"data": {"name": "a"}}`,
+			ancestors:         []string{"projects/123", "folders/456", "organizations/789"},
+			otherAncestors:    []string{"projects/123", "folders/456", "organizations/789"},
+			wantSameHash:      true,
+		},
+		{
+			name:              "projectMovedToAnotherFolder",
+			resourceJSON:      `{"data": {"name": "a"}}`,
+			otherResourceJSON: `{"data": {"name": "a"}}`,
+			ancestors:         []string{"projects/123", "folders/456", "organizations/789"},
+			otherAncestors:    []string{"projects/123", "folders/654", "organizations/789"},
+			wantSameHash:      false,
+		},
+	}
+
+	for _, tc := range testCases {
+		tc := tc // https://github.com/golang/go/wiki/CommonMistakes#using-goroutines-on-loop-iterator-variables
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+			hash, err := HashAssetContent(AssetContents{
+				Resource:  []byte(tc.resourceJSON),
+				IamPolicy: []byte(tc.iamPolicyJSON),
+				OrgPolicy: []byte(tc.orgPolicyJSON)}, tc.ancestors)
+			if err != nil {
+				if !tc.wantError {
+					t.Errorf("Want no error and got %v", err)
+				}
+				return
+			}
+			if tc.wantError {
+				t.Errorf("Want an error and got no error")
+			}
+			otherHash, err := HashAssetContent(AssetContents{
+				Resource:  []byte(tc.otherResourceJSON),
+				IamPolicy: []byte(tc.otherIamPolicyJSON),
+				OrgPolicy: []byte(tc.otherOrgPolicyJSON)}, tc.otherAncestors)
+			if err != nil {
+				t.Errorf("Want no error and got %v", err)
+			}
+			if tc.wantSameHash != (hash == otherHash) {
+				t.Errorf("Want same hash %v got hash '%s' and other hash '%s'", tc.wantSameHash, hash, otherHash)
+			}
+		})
+	}
+}
@@ -0,0 +1,29 @@
+// Copyright 2020 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the 'License');
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an 'AS IS' BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cai
+
+import "time"
+
+// IsCorrelatedActor true when the actor audit log entry is close enough to the asset change time
+func IsCorrelatedActor(actor Actor, changeTime time.Time) bool {
+	if actor.Timestamp.IsZero() || changeTime.IsZero() {
+		return false
+	}
+	gap := changeTime.Sub(actor.Timestamp)
+	if gap < 0 {
+		gap = -gap
+	}
+	return gap <= ActorCorrelationTolerance
+}
@@ -0,0 +1,71 @@
+// Copyright 2020 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the 'License');
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an 'AS IS' BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cai
+
+import (
+	"testing"
+	"time"
+)
+
+func TestUnitIsCorrelatedActor(t *testing.T) {
+	changeTime := time.Date(2020, 6, 1, 10, 0, 0, 0, time.UTC)
+	var testCases = []struct {
+		name           string
+		actorTimestamp time.Time
+		changeTime     time.Time
+		want           bool
+	}{
+		{
+			name:           "sameTime",
+			actorTimestamp: changeTime,
+			changeTime:     changeTime,
+			want:           true,
+		},
+		{
+			name:           "logBeforeChange",
+			actorTimestamp: changeTime.Add(-2 * time.Minute),
+			changeTime:     changeTime,
+			want:           true,
+		},
+		{
+			name:           "logAfterChange",
+			actorTimestamp: changeTime.Add(30 * time.Second),
+			changeTime:     changeTime,
+			want:           true,
+		},
+		{
+			name:           "tooOld",
+			actorTimestamp: changeTime.Add(-1 * time.Hour),
+			changeTime:     changeTime,
+			want:           false,
+		},
+		{
+			name:       "noActorTimestamp",
+			changeTime: changeTime,
+			want:       false,
+		},
+	}
+
+	for _, tc := range testCases {
+		tc := tc // https://github.com/golang/go/wiki/CommonMistakes#using-goroutines-on-loop-iterator-variables
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+			got := IsCorrelatedActor(Actor{Timestamp: tc.actorTimestamp}, tc.changeTime)
+			if got != tc.want {
+				t.Errorf("Want %v got %v", tc.want, got)
+			}
+		})
+	}
+}
@@ -0,0 +1,52 @@
+// Copyright 2020 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the 'License');
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an 'AS IS' BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cai
+
+import (
+	"fmt"
+	"strings"
+)
+
+// NewFeedMessageDirectorySetting builds the asset of a Workspace domain or security setting from an admin event
+// settingsKind is domainSettings or securitySettings, window, origin and step stack are left to the caller
+func NewFeedMessageDirectorySetting(directoryCustomerID string, settingsKind string, eventName string, getParameterValue func(name string) string) (feedMessage FeedMessageDirectorySetting, settingKey string) {
+	feedMessage.Asset.Resource = DirectorySetting{
+		EventName:       eventName,
+		SettingName:     getParameterValue("SETTING_NAME"),
+		OldValue:        getParameterValue("OLD_VALUE"),
+		NewValue:        getParameterValue("NEW_VALUE"),
+		DomainName:      getParameterValue("DOMAIN_NAME"),
+		OrgUnitName:     getParameterValue("ORG_UNIT_NAME"),
+		ApplicationName: getParameterValue("APPLICATION_NAME"),
+	}
+	// Some events have no SETTING_NAME parameter, the event name is then the setting
+	settingName := feedMessage.Asset.Resource.SettingName
+	if settingName == "" {
+		settingName = eventName
+	}
+	var settingKeyParts []string
+	for _, part := range []string{feedMessage.Asset.Resource.ApplicationName, settingName, feedMessage.Asset.Resource.OrgUnitName} {
+		if part != "" {
+			settingKeyParts = append(settingKeyParts, strings.NewReplacer(" ", "_", "/", "_").Replace(part))
+		}
+	}
+	settingKey = strings.Join(settingKeyParts, "_")
+
+	feedMessage.Asset.Ancestors = []string{fmt.Sprintf("directories/%s", directoryCustomerID)}
+	feedMessage.Asset.AncestryPath = fmt.Sprintf("directories/%s", directoryCustomerID)
+	feedMessage.Asset.AssetType = fmt.Sprintf("www.googleapis.com/admin/directory/%s", settingsKind)
+	feedMessage.Asset.Name = fmt.Sprintf("//directories/%s/%s/%s", directoryCustomerID, settingsKind, settingKey)
+	return feedMessage, settingKey
+}
@@ -0,0 +1,79 @@
+// Copyright 2020 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the 'License');
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an 'AS IS' BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cai
+
+import (
+	"testing"
+)
+
+func TestUnitNewFeedMessageDirectorySetting(t *testing.T) {
+	var testCases = []struct {
+		name          string
+		settingsKind  string
+		eventName     string
+		parameters    map[string]string
+		wantKey       string
+		wantAssetName string
+		wantAssetType string
+	}{
+		{
+			name:         "settingNameWithOrgUnit",
+			settingsKind: "securitySettings",
+			eventName:    "CHANGE_APPLICATION_SETTING",
+			parameters: map[string]string{
+				"APPLICATION_NAME": "Security",
+				"SETTING_NAME":     "Allowed 2SV methods",
+				"ORG_UNIT_NAME":    "acme/it",
+				"NEW_VALUE":        "ONLY_SECURITY_KEY",
+			},
+			wantKey:       "Security_Allowed_2SV_methods_acme_it",
+			wantAssetName: "//directories/C0123abc/securitySettings/Security_Allowed_2SV_methods_acme_it",
+			wantAssetType: "www.googleapis.com/admin/directory/securitySettings",
+		},
+		{
+			name:         "noSettingName",
+			settingsKind: "domainSettings",
+			eventName:    "ADD_TRUSTED_DOMAINS",
+			parameters: map[string]string{
+				"DOMAIN_NAME": "example.com",
+			},
+			wantKey:       "ADD_TRUSTED_DOMAINS",
+			wantAssetName: "//directories/C0123abc/domainSettings/ADD_TRUSTED_DOMAINS",
+			wantAssetType: "www.googleapis.com/admin/directory/domainSettings",
+		},
+	}
+
+	for _, tc := range testCases {
+		tc := tc // https://github.com/golang/go/wiki/CommonMistakes#using-goroutines-on-loop-iterator-variables
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+			feedMessage, settingKey := NewFeedMessageDirectorySetting("C0123abc", tc.settingsKind, tc.eventName, func(name string) string {
+				return tc.parameters[name]
+			})
+			if settingKey != tc.wantKey {
+				t.Errorf("Want key '%s' got '%s'", tc.wantKey, settingKey)
+			}
+			if feedMessage.Asset.Name != tc.wantAssetName {
+				t.Errorf("Want asset name '%s' got '%s'", tc.wantAssetName, feedMessage.Asset.Name)
+			}
+			if feedMessage.Asset.AssetType != tc.wantAssetType {
+				t.Errorf("Want asset type '%s' got '%s'", tc.wantAssetType, feedMessage.Asset.AssetType)
+			}
+			if feedMessage.Asset.Resource.EventName != tc.eventName {
+				t.Errorf("Want event name '%s' got '%s'", tc.eventName, feedMessage.Asset.Resource.EventName)
+			}
+		})
+	}
+}
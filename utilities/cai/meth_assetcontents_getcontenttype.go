@@ -0,0 +1,42 @@
+// Copyright 2020 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the 'License');
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an 'AS IS' BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cai
+
+import "encoding/json"
+
+// GetContentType returns the content type of the asset, or an empty string when the asset has no content
+// An asset has only one content type, iam policy first as resource may also be set by some exports
+func (contents AssetContents) GetContentType() string {
+	switch {
+	case isSet(contents.IamPolicy):
+		return ContentTypeIAMPolicy
+	case isSet(contents.OrgPolicy):
+		return ContentTypeOrgPolicy
+	case isSet(contents.AccessPolicy), isSet(contents.AccessLevel), isSet(contents.ServicePerimeter):
+		return ContentTypeAccessPolicy
+	case isSet(contents.OsInventory):
+		return ContentTypeOSInventory
+	case isSet(contents.RelatedAssets), isSet(contents.RelatedAsset):
+		return ContentTypeRelationship
+	case isSet(contents.Resource):
+		return ContentTypeResource
+	default:
+		return ""
+	}
+}
+
+func isSet(rawJSON json.RawMessage) bool {
+	return len(rawJSON) > 0 && string(rawJSON) != "null"
+}
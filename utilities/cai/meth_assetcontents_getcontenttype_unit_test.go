@@ -0,0 +1,79 @@
+// Copyright 2020 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the 'License');
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an 'AS IS' BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cai
+
+import (
+	"testing"
+)
+
+func TestUnitGetContentType(t *testing.T) {
+	var testCases = []struct {
+		name     string
+		contents AssetContents
+		want     string
+	}{
+		{
+			name:     "empty",
+			contents: AssetContents{},
+			want:     "",
+		},
+		{
+			name:     "nullResource",
+			contents: AssetContents{Resource: []byte("null")},
+			want:     "",
+		},
+		{
+			name:     "resource",
+			contents: AssetContents{Resource: []byte(`{"data":{}}`)},
+			want:     ContentTypeResource,
+		},
+		{
+			name:     "iamPolicyOverResource",
+			contents: AssetContents{Resource: []byte(`{"data":{}}`), IamPolicy: []byte(`{"bindings":[]}`)},
+			want:     ContentTypeIAMPolicy,
+		},
+		{
+			name:     "orgPolicy",
+			contents: AssetContents{OrgPolicy: []byte(`[{"constraint":"constraints/compute.vmExternalIpAccess"}]`)},
+			want:     ContentTypeOrgPolicy,
+		},
+		{
+			name:     "servicePerimeter",
+			contents: AssetContents{ServicePerimeter: []byte(`{"name":"accessPolicies/1/servicePerimeters/p"}`)},
+			want:     ContentTypeAccessPolicy,
+		},
+		{
+			name:     "osInventory",
+			contents: AssetContents{OsInventory: []byte(`{"osInfo":{}}`)},
+			want:     ContentTypeOSInventory,
+		},
+		{
+			name:     "relatedAsset",
+			contents: AssetContents{RelatedAsset: []byte(`{"asset":"//compute.googleapis.com/projects/p/zones/z/disks/d"}`)},
+			want:     ContentTypeRelationship,
+		},
+	}
+
+	for _, tc := range testCases {
+		tc := tc // https://github.com/golang/go/wiki/CommonMistakes#using-goroutines-on-loop-iterator-variables
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+			got := tc.contents.GetContentType()
+			if got != tc.want {
+				t.Errorf("Want '%s' got '%s'", tc.want, got)
+			}
+		})
+	}
+}
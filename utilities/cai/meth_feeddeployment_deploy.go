@@ -20,14 +20,17 @@ import (
 	"reflect"
 	"strings"
 
+	"github.com/BrunoReboul/ram/utilities/deploy"
 	assetpb "google.golang.org/genproto/googleapis/cloud/asset/v1"
 )
 
-// Deploy get-create resource feeds, get-create-update the iam policies feed
+// Deploy get-create resource feeds, get-create-update the iam policies, org policies, access policies, os inventories and relationships feeds
 func (feedDeployment *FeedDeployment) Deploy() (err error) {
 	log.Printf("%s cai cloud asset inventory feed", feedDeployment.Core.InstanceName)
 	feedDeployment.Artifacts.FeedFullName = fmt.Sprintf("%s/feeds/%s",
 		feedDeployment.Settings.Instance.CAI.Parent, feedDeployment.Artifacts.FeedName)
+	resourceReport := feedDeployment.Core.StartResourceReport("cai feed", feedDeployment.Artifacts.FeedFullName)
+	defer resourceReport.End(&err)
 	var getFeedRequest assetpb.GetFeedRequest
 	getFeedRequest.Name = feedDeployment.Artifacts.FeedFullName
 	feedFound := true
@@ -46,11 +49,13 @@ func (feedDeployment *FeedDeployment) Deploy() (err error) {
 		}
 		var s string
 		if feedDeployment.Artifacts.ContentType != feed.ContentType {
+			resourceReport.Drift = append(resourceReport.Drift, "contentType")
 			s = fmt.Sprintf("%scontentType\nwant %s\nhave %s\n", s,
 				feedDeployment.Artifacts.ContentType,
 				feed.ContentType)
 		}
 		if !reflect.DeepEqual(feedDeployment.Settings.Instance.CAI.AssetTypes, feed.AssetTypes) {
+			resourceReport.Drift = append(resourceReport.Drift, "assetTypes")
 			s = fmt.Sprintf("%sassetTypes\nwant %s\nhave %s\n", s,
 				feedDeployment.Settings.Instance.CAI.AssetTypes[:],
 				feed.AssetTypes[:])
@@ -60,6 +65,7 @@ func (feedDeployment *FeedDeployment) Deploy() (err error) {
 			feedDeployment.Artifacts.TopicName)
 		d := feed.FeedOutputConfig.GetPubsubDestination()
 		if d.Topic != wantedTopic {
+			resourceReport.Drift = append(resourceReport.Drift, "pubSubTopic")
 			s = fmt.Sprintf("%spubSubTopic\nwant %s\nhave %s\n", s,
 				wantedTopic,
 				d.Topic)
@@ -74,12 +80,21 @@ func (feedDeployment *FeedDeployment) Deploy() (err error) {
 		case assetpb.ContentType_RESOURCE:
 			log.Printf("%s cai feed found. will NOT be updated as type is RESOURCE %s", feedDeployment.Core.InstanceName, feed.Name)
 			return nil
-		case assetpb.ContentType_IAM_POLICY:
+		case assetpb.ContentType_IAM_POLICY,
+			assetpb.ContentType_ORG_POLICY,
+			assetpb.ContentType_ACCESS_POLICY,
+			assetpb.ContentType_OS_INVENTORY,
+			assetpb.ContentType_RELATIONSHIP:
+			if !reflect.DeepEqual(feedDeployment.Settings.Instance.CAI.AssetTypes, feed.AssetTypes) {
+				resourceReport.Action = deploy.ActionUpdated
+				resourceReport.Drift = append(resourceReport.Drift, "assetTypes")
+			}
 			return feedDeployment.updateFeed(feed)
 		default:
 			return fmt.Errorf("Feed found of unmanged ContentType %v", feed.ContentType)
 		}
 	} else {
+		resourceReport.Action = deploy.ActionCreated
 		return feedDeployment.createFeed()
 	}
 }
@@ -119,7 +134,7 @@ func (feedDeployment *FeedDeployment) createFeed() (err error) {
 }
 
 func (feedDeployment *FeedDeployment) updateFeed(existingFeed *assetpb.Feed) (err error) {
-	log.Printf("%s cai feed found, starting update as type is IAM_POLICY %s", feedDeployment.Core.InstanceName, existingFeed.Name)
+	log.Printf("%s cai feed found, starting update as type is %v %s", feedDeployment.Core.InstanceName, existingFeed.ContentType, existingFeed.Name)
 	existingFeed.AssetTypes = feedDeployment.Settings.Instance.CAI.AssetTypes
 
 	var updateFeedRequest assetpb.UpdateFeedRequest
@@ -0,0 +1,29 @@
+// Copyright 2020 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the 'License');
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an 'AS IS' BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cai
+
+import "time"
+
+// ActorCorrelationTolerance maximum gap between an audit log entry and an asset change to be correlated
+const ActorCorrelationTolerance = 5 * time.Minute
+
+// Actor who made a change, from the admin activity audit log entry correlated with an asset change
+type Actor struct {
+	PrincipalEmail string    `json:"principalEmail" firestore:"principalEmail"`
+	MethodName     string    `json:"methodName" firestore:"methodName"`
+	CallerIP       string    `json:"callerIp" firestore:"callerIp"`
+	Timestamp      time.Time `json:"timestamp" firestore:"timestamp"`
+	LogInsertID    string    `json:"logInsertId" firestore:"logInsertId"`
+}
@@ -53,6 +53,37 @@ type assetMember struct {
 	Resource     member          `json:"resource"`
 }
 
+// assetUser CAI like format
+type assetUser struct {
+	Name         string          `json:"name"`
+	AssetType    string          `json:"assetType"`
+	Ancestors    []string        `json:"ancestors"`
+	AncestryPath string          `json:"ancestryPath"`
+	IamPolicy    json.RawMessage `json:"iamPolicy"`
+	Resource     *admin.User     `json:"resource"`
+}
+
+// assetDirectorySetting CAI like format
+type assetDirectorySetting struct {
+	Name         string           `json:"name"`
+	AssetType    string           `json:"assetType"`
+	Ancestors    []string         `json:"ancestors"`
+	AncestryPath string           `json:"ancestryPath"`
+	IamPolicy    json.RawMessage  `json:"iamPolicy"`
+	Resource     DirectorySetting `json:"resource"`
+}
+
+// DirectorySetting last known value of a Workspace domain or security setting, from admin activity logs
+type DirectorySetting struct {
+	EventName       string `json:"eventName"`
+	SettingName     string `json:"settingName"`
+	OldValue        string `json:"oldValue"`
+	NewValue        string `json:"newValue"`
+	DomainName      string `json:"domainName"`
+	OrgUnitName     string `json:"orgUnitName"`
+	ApplicationName string `json:"applicationName"`
+}
+
 // member is sligthly different from admim.Member to have both group email and member email
 type member struct {
 	MemberEmail string `json:"memberEmail"`
@@ -90,6 +121,24 @@ type FeedMessageMember struct {
 	StepStack glo.Steps   `json:"step_stack,omitempty"`
 }
 
+// FeedMessageUser CAI like format
+type FeedMessageUser struct {
+	Asset     assetUser `json:"asset"`
+	Window    Window    `json:"window"`
+	Deleted   bool      `json:"deleted"`
+	Origin    string    `json:"origin"`
+	StepStack glo.Steps `json:"step_stack,omitempty"`
+}
+
+// FeedMessageDirectorySetting CAI like format
+type FeedMessageDirectorySetting struct {
+	Asset     assetDirectorySetting `json:"asset"`
+	Window    Window                `json:"window"`
+	Deleted   bool                  `json:"deleted"`
+	Origin    string                `json:"origin"`
+	StepStack glo.Steps             `json:"step_stack,omitempty"`
+}
+
 // Window Cloud Asset Inventory feed message time window
 type Window struct {
 	StartTime time.Time `json:"startTime" firestore:"startTime"`
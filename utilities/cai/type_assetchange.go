@@ -0,0 +1,51 @@
+// Copyright 2020 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the 'License');
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an 'AS IS' BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cai
+
+import (
+	"time"
+
+	"github.com/BrunoReboul/ram/utilities/glo"
+)
+
+// AssetChange what changed between the cached version and the incoming version of an asset
+type AssetChange struct {
+	Name               string             `json:"name"`
+	AssetType          string             `json:"assetType"`
+	Ancestors          []string           `json:"ancestors"`
+	Created            bool               `json:"created"`
+	Deleted            bool               `json:"deleted"`
+	PreviousStartTime  *time.Time         `json:"previousStartTime,omitempty"`
+	Window             Window             `json:"window"`
+	Origin             string             `json:"origin"`
+	ChangedPaths       []PathChange       `json:"changedPaths"`
+	IAMBindingsAdded   []IAMBindingMember `json:"iamBindingsAdded"`
+	IAMBindingsRemoved []IAMBindingMember `json:"iamBindingsRemoved"`
+	StepStack          glo.Steps          `json:"step_stack,omitempty"`
+}
+
+// PathChange a JSON path which value changed
+type PathChange struct {
+	Path     string      `json:"path"`
+	OldValue interface{} `json:"oldValue"`
+	NewValue interface{} `json:"newValue"`
+}
+
+// IAMBindingMember one member granted one role, optionally under a condition
+type IAMBindingMember struct {
+	Role      string `json:"role"`
+	Condition string `json:"condition,omitempty"`
+	Member    string `json:"member"`
+}
@@ -0,0 +1,32 @@
+// Copyright 2020 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the 'License');
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an 'AS IS' BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cai
+
+import "encoding/json"
+
+// AssetContents the content of an asset as raw JSON, one field per content type
+// ACCESS_POLICY content is either an accessPolicy, an accessLevel or a servicePerimeter
+// RELATIONSHIP content is relatedAssets, or relatedAsset in the newer feed format
+type AssetContents struct {
+	Resource         json.RawMessage `json:"resource,omitempty"`
+	IamPolicy        json.RawMessage `json:"iamPolicy,omitempty"`
+	OrgPolicy        json.RawMessage `json:"orgPolicy,omitempty"`
+	AccessPolicy     json.RawMessage `json:"accessPolicy,omitempty"`
+	AccessLevel      json.RawMessage `json:"accessLevel,omitempty"`
+	ServicePerimeter json.RawMessage `json:"servicePerimeter,omitempty"`
+	OsInventory      json.RawMessage `json:"osInventory,omitempty"`
+	RelatedAssets    json.RawMessage `json:"relatedAssets,omitempty"`
+	RelatedAsset     json.RawMessage `json:"relatedAsset,omitempty"`
+}
@@ -0,0 +1,29 @@
+// Copyright 2020 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the 'License');
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an 'AS IS' BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package deploy
+
+// Actions taken on a resource, as recorded in the run report
+const (
+	// ActionUnchanged the resource was found as expected
+	ActionUnchanged = "unchanged"
+	// ActionCreated the resource was not found and has been created
+	ActionCreated = "created"
+	// ActionUpdated the resource drifted from settings and has been updated
+	ActionUpdated = "updated"
+	// ActionSkipped the resource could not be read or written, e.g. missing permission, and a warning has been logged
+	ActionSkipped = "skipped"
+	// ActionFailed the resource deployment returned an error
+	ActionFailed = "failed"
+)
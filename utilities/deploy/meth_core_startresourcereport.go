@@ -0,0 +1,31 @@
+// Copyright 2020 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the 'License');
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an 'AS IS' BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package deploy
+
+import "time"
+
+// StartResourceReport starts the report of one resource deployment for the current instance
+// Default action is unchanged, the deployer sets it when it creates, updates or skips the resource
+func (core *Core) StartResourceReport(kind, name string) *ResourceReport {
+	return &ResourceReport{
+		Kind:         kind,
+		Name:         name,
+		Action:       ActionUnchanged,
+		StartTime:    time.Now(),
+		report:       core.Report,
+		serviceName:  core.ServiceName,
+		instanceName: core.InstanceName,
+	}
+}
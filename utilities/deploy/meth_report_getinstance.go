@@ -0,0 +1,31 @@
+// Copyright 2020 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the 'License');
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an 'AS IS' BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package deploy
+
+// getInstance returns the instance report, creating it when not yet found. The caller holds the mutex
+func (report *Report) getInstance(serviceName, instanceName string) *InstanceReport {
+	for _, instanceReport := range report.Instances {
+		if instanceReport.ServiceName == serviceName && instanceReport.InstanceName == instanceName {
+			return instanceReport
+		}
+	}
+	instanceReport := &InstanceReport{
+		ServiceName:  serviceName,
+		InstanceName: instanceName,
+		Resources:    make([]*ResourceReport, 0),
+	}
+	report.Instances = append(report.Instances, instanceReport)
+	return instanceReport
+}
@@ -0,0 +1,113 @@
+// Copyright 2020 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the 'License');
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an 'AS IS' BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package deploy
+
+import (
+	"encoding/xml"
+	"fmt"
+	"strings"
+)
+
+type junitTestSuites struct {
+	XMLName  xml.Name         `xml:"testsuites"`
+	Name     string           `xml:"name,attr"`
+	Tests    int              `xml:"tests,attr"`
+	Failures int              `xml:"failures,attr"`
+	Time     string           `xml:"time,attr"`
+	Suites   []junitTestSuite `xml:"testsuite"`
+}
+
+type junitTestSuite struct {
+	Name      string          `xml:"name,attr"`
+	Tests     int             `xml:"tests,attr"`
+	Failures  int             `xml:"failures,attr"`
+	Time      string          `xml:"time,attr"`
+	TestCases []junitTestCase `xml:"testcase"`
+}
+
+type junitTestCase struct {
+	ClassName string        `xml:"classname,attr"`
+	Name      string        `xml:"name,attr"`
+	Time      string        `xml:"time,attr"`
+	Failure   *junitFailure `xml:"failure,omitempty"`
+	SystemOut string        `xml:"system-out,omitempty"`
+}
+
+type junitFailure struct {
+	Message string `xml:"message,attr"`
+	Text    string `xml:",chardata"`
+}
+
+// MarshalJUnit renders the report as JUnit XML: one test suite per microservice, one test case per instance, resources actions as system-out
+func (report *Report) MarshalJUnit() ([]byte, error) {
+	report.mutex.Lock()
+	defer report.mutex.Unlock()
+	testSuites := junitTestSuites{
+		Name: fmt.Sprintf("ramcli %s %s", report.Command, report.EnvironmentName),
+		Time: fmt.Sprintf("%.3f", report.DurationSeconds),
+	}
+	suiteIndexes := make(map[string]int)
+	suiteDurations := make(map[string]float64)
+	for _, instanceReport := range report.Instances {
+		// Resources deployed outside of a microservice, e.g. initial setup, are grouped in a ramcli suite
+		suiteName := instanceReport.ServiceName
+		if suiteName == "" {
+			suiteName = "ramcli"
+		}
+		i, ok := suiteIndexes[suiteName]
+		if !ok {
+			testSuites.Suites = append(testSuites.Suites, junitTestSuite{Name: suiteName})
+			i = len(testSuites.Suites) - 1
+			suiteIndexes[suiteName] = i
+		}
+		testCase := junitTestCase{
+			ClassName: suiteName,
+			Name:      instanceReport.InstanceName,
+			Time:      fmt.Sprintf("%.3f", instanceReport.DurationSeconds),
+		}
+		var lines []string
+		for _, resourceReport := range instanceReport.Resources {
+			line := fmt.Sprintf("%s %s %s %.3fs", resourceReport.Kind, resourceReport.Name, resourceReport.Action, resourceReport.DurationSeconds)
+			if len(resourceReport.Drift) > 0 {
+				line = fmt.Sprintf("%s drift: %s", line, strings.Join(resourceReport.Drift, ", "))
+			}
+			if resourceReport.Error != "" {
+				line = fmt.Sprintf("%s error: %s", line, resourceReport.Error)
+			}
+			lines = append(lines, line)
+		}
+		testCase.SystemOut = strings.Join(lines, "\n")
+		if instanceReport.Error != "" {
+			testCase.Failure = &junitFailure{
+				Message: "deployment failed",
+				Text:    instanceReport.Error,
+			}
+			testSuites.Suites[i].Failures++
+			testSuites.Failures++
+		}
+		testSuites.Suites[i].Tests++
+		testSuites.Suites[i].TestCases = append(testSuites.Suites[i].TestCases, testCase)
+		testSuites.Tests++
+		suiteDurations[suiteName] += instanceReport.DurationSeconds
+	}
+	for i := range testSuites.Suites {
+		testSuites.Suites[i].Time = fmt.Sprintf("%.3f", suiteDurations[testSuites.Suites[i].Name])
+	}
+	b, err := xml.MarshalIndent(testSuites, "", "  ")
+	if err != nil {
+		return nil, err
+	}
+	return append([]byte(xml.Header), b...), nil
+}
@@ -0,0 +1,107 @@
+// Copyright 2020 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the 'License');
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an 'AS IS' BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package deploy
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestUnitReportMarshalJUnit(t *testing.T) {
+	var testCases = []struct {
+		name          string
+		instanceError string
+		resourceError string
+		drift         []string
+		wantStrings   []string
+	}{
+		{
+			name: "success",
+			wantStrings: []string{
+				`<testsuites name="ramcli deploy dev" tests="1" failures="0"`,
+				`<testsuite name="monitor" tests="1" failures="0"`,
+				`<testcase classname="monitor" name="monitor_gcp_bq_dataset_location"`,
+				`gps topic ram-violation unchanged`,
+			},
+		},
+		{
+			name:  "drift",
+			drift: []string{"labels"},
+			wantStrings: []string{
+				`failures="0"`,
+				`gps topic ram-violation updated`,
+				`drift: labels`,
+			},
+		},
+		{
+			name:          "failure",
+			instanceError: "monitor_gcp_bq_dataset_location topic error",
+			resourceError: "topic error",
+			wantStrings: []string{
+				`<testsuites name="ramcli deploy dev" tests="1" failures="1"`,
+				`<failure message="deployment failed">monitor_gcp_bq_dataset_location topic error</failure>`,
+				`gps topic ram-violation failed`,
+				`error: topic error`,
+			},
+		},
+	}
+	for _, tc := range testCases {
+		tc := tc // https://github.com/golang/go/wiki/CommonMistakes#using-goroutines-on-loop-iterator-variables
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+			var core Core
+			core.ServiceName = "monitor"
+			core.InstanceName = "monitor_gcp_bq_dataset_location"
+			core.Report = NewReport("deploy", "dev", "v1.0.0")
+			start := time.Now()
+
+			var err error
+			if tc.resourceError != "" {
+				err = fmt.Errorf("%s", tc.resourceError)
+			}
+			resourceReport := core.StartResourceReport("gps topic", "ram-violation")
+			if len(tc.drift) > 0 {
+				resourceReport.Action = ActionUpdated
+				resourceReport.Drift = tc.drift
+			}
+			resourceReport.End(&err)
+
+			var instanceErr error
+			if tc.instanceError != "" {
+				instanceErr = fmt.Errorf("%s", tc.instanceError)
+			}
+			core.Report.RecordInstance(core.ServiceName, core.InstanceName, start, instanceErr)
+			core.Report.End(instanceErr)
+
+			if len(core.Report.Instances) != 1 {
+				t.Fatalf("Want 1 instance got %d", len(core.Report.Instances))
+			}
+			if len(core.Report.Instances[0].Resources) != 1 {
+				t.Fatalf("Want 1 resource got %d", len(core.Report.Instances[0].Resources))
+			}
+			b, err := core.Report.MarshalJUnit()
+			if err != nil {
+				t.Fatalf("MarshalJUnit %v", err)
+			}
+			for _, wantString := range tc.wantStrings {
+				if !strings.Contains(string(b), wantString) {
+					t.Errorf("Want '%s' got '%s'", wantString, string(b))
+				}
+			}
+		})
+	}
+}
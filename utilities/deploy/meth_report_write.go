@@ -0,0 +1,46 @@
+// Copyright 2020 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the 'License');
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an 'AS IS' BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package deploy
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+)
+
+// Write writes the report as JSON, and as JUnit XML when junitFilePath is not empty
+func (report *Report) Write(jsonFilePath, junitFilePath string) (err error) {
+	if jsonFilePath != "" {
+		report.mutex.Lock()
+		b, err := json.MarshalIndent(report, "", "  ")
+		report.mutex.Unlock()
+		if err != nil {
+			return fmt.Errorf("json.MarshalIndent %v", err)
+		}
+		if err = ioutil.WriteFile(jsonFilePath, b, 0644); err != nil {
+			return fmt.Errorf("ioutil.WriteFile %v", err)
+		}
+	}
+	if junitFilePath != "" {
+		b, err := report.MarshalJUnit()
+		if err != nil {
+			return fmt.Errorf("report.MarshalJUnit %v", err)
+		}
+		if err = ioutil.WriteFile(junitFilePath, b, 0644); err != nil {
+			return fmt.Errorf("ioutil.WriteFile %v", err)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,33 @@
+// Copyright 2020 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the 'License');
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an 'AS IS' BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package deploy
+
+import "time"
+
+// End records the resource report in the run report, to be deferred with the address of the deployer named error
+func (resourceReport *ResourceReport) End(err *error) {
+	resourceReport.DurationSeconds = time.Since(resourceReport.StartTime).Seconds()
+	if err != nil && *err != nil {
+		resourceReport.Action = ActionFailed
+		resourceReport.Error = (*err).Error()
+	}
+	if resourceReport.report == nil {
+		return
+	}
+	resourceReport.report.mutex.Lock()
+	defer resourceReport.report.mutex.Unlock()
+	instanceReport := resourceReport.report.getInstance(resourceReport.serviceName, resourceReport.instanceName)
+	instanceReport.Resources = append(instanceReport.Resources, resourceReport)
+}
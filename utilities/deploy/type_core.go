@@ -16,6 +16,7 @@ package deploy
 
 import (
 	"context"
+	"time"
 
 	"google.golang.org/api/appengine/v1"
 	"google.golang.org/api/cloudbilling/v1"
@@ -24,6 +25,7 @@ import (
 	asset "cloud.google.com/go/asset/apiv1"
 	"cloud.google.com/go/bigquery"
 	"cloud.google.com/go/firestore"
+	firestoreadmin "cloud.google.com/go/firestore/apiv1/admin"
 	pubsub "cloud.google.com/go/pubsub/apiv1"
 	scheduler "cloud.google.com/go/scheduler/apiv1"
 	"cloud.google.com/go/storage"
@@ -35,6 +37,7 @@ import (
 	"google.golang.org/api/iam/v1"
 	"google.golang.org/api/logging/v2"
 	"google.golang.org/api/monitoring/v1"
+	monitoringv3 "google.golang.org/api/monitoring/v3"
 	"google.golang.org/api/serviceusage/v1"
 )
 
@@ -52,25 +55,32 @@ type Core struct {
 	GoVersion                   string
 	RamcliServiceAccount        string
 	Dump                        bool
-	InstanceFolderRelativePaths []string `yaml:"-"`
+	InstanceFolderRelativePaths []string  `yaml:"-"`
+	ExportReadTime              time.Time `yaml:"-"`
+	Parallel                    int       `yaml:"-"`
+	Report                      *Report   `yaml:"-"`
+	ReportFilePath              string    `yaml:"-"`
+	JUnitReportFilePath         string    `yaml:"-"`
 	Services                    struct {
-		AppengineAPIService           *appengine.APIService           `yaml:"-"`
-		AssetClient                   *asset.Client                   `yaml:"-"`
-		BigqueryClient                *bigquery.Client                `yaml:"-"`
-		CloudSchedulerClient          *scheduler.CloudSchedulerClient `yaml:"-"`
-		Cloudbillingservice           *cloudbilling.APIService        `yaml:"-"`
-		CloudbuildService             *cloudbuild.Service             `yaml:"-"`
-		CloudfunctionsService         *cloudfunctions.Service         `yaml:"-"`
-		CloudresourcemanagerService   *cloudresourcemanager.Service   `yaml:"-"`
-		CloudresourcemanagerServicev2 *cloudresourcemanagerv2.Service `yaml:"-"`
-		FirestoreClient               *firestore.Client               `yaml:"-"`
-		IAMService                    *iam.Service                    `yaml:"-"`
-		LoggingService                *logging.Service                `yaml:"-"`
-		MonitoringService             *monitoring.Service             `yaml:"-"`
-		PubsubPublisherClient         *pubsub.PublisherClient         `yaml:"-"`
-		ServiceusageService           *serviceusage.Service           `yaml:"-"`
-		SourcerepoService             *sourcerepo.Service             `yaml:"-"`
-		StorageClient                 *storage.Client                 `yaml:"-"`
+		AppengineAPIService           *appengine.APIService                `yaml:"-"`
+		AssetClient                   *asset.Client                        `yaml:"-"`
+		BigqueryClient                *bigquery.Client                     `yaml:"-"`
+		CloudSchedulerClient          *scheduler.CloudSchedulerClient      `yaml:"-"`
+		Cloudbillingservice           *cloudbilling.APIService             `yaml:"-"`
+		CloudbuildService             *cloudbuild.Service                  `yaml:"-"`
+		CloudfunctionsService         *cloudfunctions.Service              `yaml:"-"`
+		CloudresourcemanagerService   *cloudresourcemanager.Service        `yaml:"-"`
+		CloudresourcemanagerServicev2 *cloudresourcemanagerv2.Service      `yaml:"-"`
+		FirestoreAdminClient          *firestoreadmin.FirestoreAdminClient `yaml:"-"`
+		FirestoreClient               *firestore.Client                    `yaml:"-"`
+		IAMService                    *iam.Service                         `yaml:"-"`
+		LoggingService                *logging.Service                     `yaml:"-"`
+		MonitoringService             *monitoring.Service                  `yaml:"-"`
+		MonitoringServicev3           *monitoringv3.Service                `yaml:"-"`
+		PubsubPublisherClient         *pubsub.PublisherClient              `yaml:"-"`
+		ServiceusageService           *serviceusage.Service                `yaml:"-"`
+		SourcerepoService             *sourcerepo.Service                  `yaml:"-"`
+		StorageClient                 *storage.Client                      `yaml:"-"`
 	} `yaml:"-"`
 	Commands struct {
 		// Makeyaml     bool
@@ -80,5 +90,8 @@ type Core struct {
 		Deploy              bool
 		Check               bool
 		Dumpsettings        bool
+		Export              bool
+		PruneIAM            bool
+		AnalyzePrivileges   bool
 	} `yaml:"-"`
 }
@@ -0,0 +1,57 @@
+// Copyright 2020 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the 'License');
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an 'AS IS' BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package deploy
+
+import (
+	"sync"
+	"time"
+)
+
+// Report machine readable report of a ramcli run, safe for concurrent instance deployments
+type Report struct {
+	Command         string            `json:"command"`
+	EnvironmentName string            `json:"environmentName"`
+	RAMVersion      string            `json:"ramVersion"`
+	StartTime       time.Time         `json:"startTime"`
+	EndTime         time.Time         `json:"endTime"`
+	DurationSeconds float64           `json:"durationSeconds"`
+	Error           string            `json:"error,omitempty"`
+	Instances       []*InstanceReport `json:"instances"`
+	mutex           sync.Mutex
+}
+
+// InstanceReport report of the deployment of one microservice instance
+type InstanceReport struct {
+	ServiceName     string            `json:"serviceName"`
+	InstanceName    string            `json:"instanceName"`
+	StartTime       time.Time         `json:"startTime"`
+	DurationSeconds float64           `json:"durationSeconds"`
+	Error           string            `json:"error,omitempty"`
+	Resources       []*ResourceReport `json:"resources"`
+}
+
+// ResourceReport report of the deployment of one resource
+type ResourceReport struct {
+	Kind            string    `json:"kind"`
+	Name            string    `json:"name"`
+	Action          string    `json:"action"`
+	StartTime       time.Time `json:"startTime"`
+	DurationSeconds float64   `json:"durationSeconds"`
+	Error           string    `json:"error,omitempty"`
+	Drift           []string  `json:"drift,omitempty"`
+	report          *Report
+	serviceName     string
+	instanceName    string
+}
@@ -13,4 +13,6 @@
 // limitations under the License.
 
 // Package gae helps with Google Application Engine
+//
+// The application location cannot be updated once created: a drift is reported as a warning, or as an error in check mode.
 package gae
@@ -20,15 +20,39 @@ import (
 	"strings"
 	"time"
 
+	"github.com/BrunoReboul/ram/utilities/deploy"
 	"google.golang.org/api/appengine/v1"
 )
 
-// Deploy AppDeployment check if the repo exist, if not try to create it
+// Deploy AppDeployment check if the app exist, if not try to create it, report location drift
 func (appDeployment *AppDeployment) Deploy() (err error) {
 	log.Printf("%s gae application engine", appDeployment.Core.InstanceName)
 	appsService := appDeployment.Core.Services.AppengineAPIService.Apps
 	appsOperationsService := appengine.NewAppsOperationsService(appDeployment.Core.Services.AppengineAPIService)
+	resourceReport := appDeployment.Core.StartResourceReport("gae application", appDeployment.Core.SolutionSettings.Hosting.ProjectID)
+	defer resourceReport.End(&err)
 	app, err := appsService.Get(appDeployment.Core.SolutionSettings.Hosting.ProjectID).Context(appDeployment.Core.Ctx).Do()
+	if appDeployment.Core.Commands.Check {
+		if err != nil {
+			if strings.Contains(err.Error(), "404") && strings.Contains(err.Error(), "notFound") {
+				return fmt.Errorf("%s gae application NOT found %s", appDeployment.Core.InstanceName, appDeployment.Core.SolutionSettings.Hosting.ProjectID)
+			}
+			if strings.Contains(err.Error(), "403") {
+				log.Printf("%s gae WARNING impossible to GET application %v", appDeployment.Core.InstanceName, err)
+				resourceReport.Action = deploy.ActionSkipped
+				return nil
+			}
+			return fmt.Errorf("gae appsService.Get(name) %v", err)
+		}
+		if app.LocationId != appDeployment.Core.SolutionSettings.Hosting.GAE.Region {
+			resourceReport.Drift = append(resourceReport.Drift, "locationId")
+			return fmt.Errorf("%s gae invalid application configuration:\nlocationId\nwant %s\nhave %s\n",
+				appDeployment.Core.InstanceName,
+				appDeployment.Core.SolutionSettings.Hosting.GAE.Region,
+				app.LocationId)
+		}
+		return nil
+	}
 	if err != nil {
 		if strings.Contains(err.Error(), "404") && strings.Contains(err.Error(), "notFound") {
 			var appToCreate appengine.Application
@@ -38,6 +62,7 @@ func (appDeployment *AppDeployment) Deploy() (err error) {
 			if err != nil {
 				if strings.Contains(err.Error(), "403") {
 					log.Printf("%s gae WARNING impossible to CREATE application %v", appDeployment.Core.InstanceName, err)
+					resourceReport.Action = deploy.ActionSkipped
 					return nil
 				}
 				return fmt.Errorf("gae appsService.Create %v", err)
@@ -56,17 +81,27 @@ func (appDeployment *AppDeployment) Deploy() (err error) {
 					break
 				}
 			}
+			resourceReport.Action = deploy.ActionCreated
 			log.Printf("%s gae application created %s", appDeployment.Core.InstanceName, appToCreate.Id)
 			// ffo.JSONMarshalIndentPrint(operation)
 		} else {
 			if strings.Contains(err.Error(), "403") {
 				log.Printf("%s gae WARNING impossible to GET application %v", appDeployment.Core.InstanceName, err)
+				resourceReport.Action = deploy.ActionSkipped
 				return nil
 			}
 			return fmt.Errorf("gae appsService.Get(name) %v", err)
 		}
 	} else {
 		log.Printf("%s gae application found %s", appDeployment.Core.InstanceName, app.Name)
+		if app.LocationId != appDeployment.Core.SolutionSettings.Hosting.GAE.Region {
+			// The location of an application cannot be changed once created
+			resourceReport.Drift = append(resourceReport.Drift, "locationId")
+			log.Printf("%s gae WARNING application location %s differs from %s and cannot be updated",
+				appDeployment.Core.InstanceName,
+				app.LocationId,
+				appDeployment.Core.SolutionSettings.Hosting.GAE.Region)
+		}
 	}
 	return nil
 }
@@ -30,5 +30,18 @@ func GetAssetsSchema() bigquery.Schema {
 		{Name: "assetType", Required: true, Type: bigquery.StringFieldType},
 		{Name: "deleted", Required: true, Type: bigquery.BooleanFieldType},
 		{Name: "projectID", Required: false, Type: bigquery.StringFieldType},
+		{
+			Name:        "actor",
+			Type:        bigquery.RecordFieldType,
+			Description: "Who made the change, from the correlated admin activity audit log entry",
+			Schema: bigquery.Schema{
+				{Name: "principalEmail", Required: false, Type: bigquery.StringFieldType},
+				{Name: "methodName", Required: false, Type: bigquery.StringFieldType},
+				{Name: "callerIp", Required: false, Type: bigquery.StringFieldType},
+				{Name: "timestamp", Required: false, Type: bigquery.TimestampFieldType},
+				{Name: "logInsertId", Required: false, Type: bigquery.StringFieldType},
+			},
+		},
+		{Name: "origin", Required: false, Type: bigquery.StringFieldType, Description: "Mean to capture the asset change: real-time, batch-export or historical-export"},
 	}
 }
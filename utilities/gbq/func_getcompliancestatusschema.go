@@ -21,7 +21,7 @@ func GetComplianceStatusSchema() bigquery.Schema {
 	return bigquery.Schema{
 		{Name: "assetName", Required: true, Type: bigquery.StringFieldType},
 		{Name: "assetInventoryTimeStamp", Required: true, Type: bigquery.TimestampFieldType, Description: "When the asset change was captured"},
-		{Name: "assetInventoryOrigin", Required: false, Type: bigquery.StringFieldType, Description: "Mean to capture the asset change: real-time or batch-export"},
+		{Name: "assetInventoryOrigin", Required: false, Type: bigquery.StringFieldType, Description: "Mean to capture the asset change: real-time, batch-export or historical-export"},
 		{Name: "ruleName", Required: true, Type: bigquery.StringFieldType},
 		{Name: "ruleDeploymentTimeStamp", Required: true, Type: bigquery.TimestampFieldType, Description: "When the rule was assessed"},
 		{Name: "compliant", Required: true, Type: bigquery.BooleanFieldType},
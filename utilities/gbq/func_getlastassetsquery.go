@@ -30,8 +30,11 @@ FROM
         FROM
             <assets>
         WHERE
-            DATE(_PARTITIONTIME) > DATE_SUB(CURRENT_DATE(), INTERVAL <intervalDays> DAY)
-            OR _PARTITIONTIME IS NULL
+            (
+                DATE(_PARTITIONTIME) > DATE_SUB(CURRENT_DATE(), INTERVAL <intervalDays> DAY)
+                OR _PARTITIONTIME IS NULL
+            )
+            AND IFNULL(origin, "") != "historical-export"
         GROUP BY
             name
         ORDER BY
@@ -49,12 +52,16 @@ FROM
             ancestors,
             assetType,
             deleted,
-            projectID
+            projectID,
+            actor
         FROM
             <assets>
         WHERE
-            DATE(_PARTITIONTIME) > DATE_SUB(CURRENT_DATE(), INTERVAL <intervalDays> DAY)
-            OR _PARTITIONTIME IS NULL
+            (
+                DATE(_PARTITIONTIME) > DATE_SUB(CURRENT_DATE(), INTERVAL <intervalDays> DAY)
+                OR _PARTITIONTIME IS NULL
+            )
+            AND IFNULL(origin, "") != "historical-export"
     ) AS assets ON assets.name = latest_assets.name
     AND assets.timestamp = latest_assets.timestamp
 `
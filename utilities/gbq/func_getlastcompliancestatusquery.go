@@ -26,8 +26,11 @@ WITH complianceStatus0 AS (
     FROM
       <complianceStatus>
     WHERE
-      DATE(_PARTITIONTIME) > DATE_SUB(CURRENT_DATE(), INTERVAL <intervalDays> DAY)
-      OR _PARTITIONTIME IS NULL
+      (
+        DATE(_PARTITIONTIME) > DATE_SUB(CURRENT_DATE(), INTERVAL <intervalDays> DAY)
+        OR _PARTITIONTIME IS NULL
+      )
+      AND IFNULL(assetInventoryOrigin, "") != "historical-export"
   ),
   assets AS (
     SELECT
@@ -99,9 +102,11 @@ WITH complianceStatus0 AS (
       IF(
         SPLIT(status_for_latest_rules.assetName, "/") [SAFE_OFFSET(2)] = "directories",
         CASE
-          SPLIT(status_for_latest_rules.assetName, "/") [SAFE_OFFSET(6)]
-          WHEN "members" THEN "www.googleapis.com/admin/directory/members"
-          WHEN "groupSettings" THEN "groupssettings.googleapis.com/groupSettings"
+          WHEN SPLIT(status_for_latest_rules.assetName, "/") [SAFE_OFFSET(6)] = "members" THEN "www.googleapis.com/admin/directory/members"
+          WHEN SPLIT(status_for_latest_rules.assetName, "/") [SAFE_OFFSET(6)] = "groupSettings" THEN "groupssettings.googleapis.com/groupSettings"
+          WHEN SPLIT(status_for_latest_rules.assetName, "/") [SAFE_OFFSET(4)] = "users" THEN "www.googleapis.com/admin/directory/users"
+          WHEN SPLIT(status_for_latest_rules.assetName, "/") [SAFE_OFFSET(4)] = "domainSettings" THEN "www.googleapis.com/admin/directory/domainSettings"
+          WHEN SPLIT(status_for_latest_rules.assetName, "/") [SAFE_OFFSET(4)] = "securitySettings" THEN "www.googleapis.com/admin/directory/securitySettings"
           ELSE NULL
         END,
         NULL
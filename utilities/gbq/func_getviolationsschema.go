@@ -83,6 +83,13 @@ func GetViolationsSchema() bigquery.Schema {
 						{Name: "assetType", Required: true, Type: bigquery.StringFieldType},
 						{Name: "iamPolicy", Required: false, Type: bigquery.StringFieldType},
 						{Name: "resource", Required: false, Type: bigquery.StringFieldType},
+						{Name: "orgPolicy", Required: false, Type: bigquery.StringFieldType},
+						{Name: "accessPolicy", Required: false, Type: bigquery.StringFieldType},
+						{Name: "accessLevel", Required: false, Type: bigquery.StringFieldType},
+						{Name: "servicePerimeter", Required: false, Type: bigquery.StringFieldType},
+						{Name: "osInventory", Required: false, Type: bigquery.StringFieldType},
+						{Name: "relatedAssets", Required: false, Type: bigquery.StringFieldType},
+						{Name: "relatedAsset", Required: false, Type: bigquery.StringFieldType},
 					},
 				},
 				{
@@ -93,6 +100,18 @@ func GetViolationsSchema() bigquery.Schema {
 					},
 				},
 				{Name: "origin", Required: false, Type: bigquery.StringFieldType},
+				{
+					Name:        "actor",
+					Type:        bigquery.RecordFieldType,
+					Description: "Who made the change, from the correlated admin activity audit log entry",
+					Schema: bigquery.Schema{
+						{Name: "principalEmail", Required: false, Type: bigquery.StringFieldType},
+						{Name: "methodName", Required: false, Type: bigquery.StringFieldType},
+						{Name: "callerIp", Required: false, Type: bigquery.StringFieldType},
+						{Name: "timestamp", Required: false, Type: bigquery.TimestampFieldType},
+						{Name: "logInsertId", Required: false, Type: bigquery.StringFieldType},
+					},
+				},
 			},
 		},
 		{Name: "regoModules", Required: false, Type: bigquery.StringFieldType, Description: "The rego code, including the rule template used to assess the rule as a JSON document"},
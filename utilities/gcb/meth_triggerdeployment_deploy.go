@@ -19,7 +19,9 @@ import (
 	"log"
 	"reflect"
 	"strings"
+	"sync"
 
+	"github.com/BrunoReboul/ram/utilities/deploy"
 	"github.com/BrunoReboul/ram/utilities/erm"
 	"github.com/BrunoReboul/ram/utilities/solution"
 	"google.golang.org/api/cloudbuild/v1"
@@ -30,16 +32,23 @@ const retries = 5
 var globalTriggerDeployment *TriggerDeployment
 var count int
 
+// deployMutex serializes deployments sharing the package level variables used by the pages browsing functions, e.g. ramcli -parallel
+var deployMutex sync.Mutex
+
 // Permission cloudbuild.builds.get is required in complemenet of cloudbuild.builds.list, event if 'get' API is not used
 
 // Deploy delete if exist, then create a cloud build trigger to deploy a microservice instance
 func (triggerDeployment *TriggerDeployment) Deploy() (err error) {
+	deployMutex.Lock()
+	defer deployMutex.Unlock()
 	// log.Printf("%s gcb cloud build trigger", triggerDeployment.Core.InstanceName)
 	if triggerDeployment.Settings.Service.GCB.QueueTTL == "" {
 		triggerDeployment.Settings.Service.GCB.QueueTTL = triggerDeployment.Core.SolutionSettings.Hosting.GCB.QueueTTL
 	}
 	triggerDeployment.Artifacts.ProjectsTriggersService = triggerDeployment.Core.Services.CloudbuildService.Projects.Triggers
 	triggerDeployment.situate()
+	resourceReport := triggerDeployment.Core.StartResourceReport("gcb trigger", triggerDeployment.Artifacts.BuildTrigger.Name)
+	defer resourceReport.End(&err)
 	// ffo.JSONMarshalIndentPrint(&triggerDeployment.Artifacts.BuildTrigger)
 	globalTriggerDeployment = triggerDeployment
 	if triggerDeployment.Core.Commands.Check {
@@ -60,6 +69,7 @@ func (triggerDeployment *TriggerDeployment) Deploy() (err error) {
 				}
 			} else {
 				// ffo.JSONMarshalIndentPrint(buildTrigger)
+				resourceReport.Action = deploy.ActionCreated
 				log.Printf("%s gcb created trigger %s id %s with tag filter %s", globalTriggerDeployment.Core.InstanceName, buildTrigger.Name, buildTrigger.Id, buildTrigger.TriggerTemplate.TagName)
 				break
 			}
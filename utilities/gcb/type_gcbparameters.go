@@ -21,8 +21,8 @@ import (
 
 // Parameters structure
 type Parameters struct {
-	BuildTimeout            string `yaml:"buildTimeout"  valid:"isNotZeroValue"`
-	QueueTTL                string `yaml:"queueTtl"  valid:"isNotZeroValue"`
+	BuildTimeout            string `yaml:"buildTimeout"  valid:"isNotZeroValue,isDuration"`
+	QueueTTL                string `yaml:"queueTtl"  valid:"isNotZeroValue,isDuration"`
 	DeployIAMServiceAccount bool
 	DeployIAMBindings       bool
 	ServiceAccountBindings  struct {
@@ -20,12 +20,13 @@ import (
 	"strings"
 	"time"
 
+	"github.com/BrunoReboul/ram/utilities/deploy"
 	"github.com/BrunoReboul/ram/utilities/ffo"
 	"google.golang.org/api/cloudfunctions/v1"
 )
 
 // createPatchCloudFunction looks for an existing cloud function patch it if found else create it
-func (functionDeployment *FunctionDeployment) createPatchCloudFunction() (err error) {
+func (functionDeployment *FunctionDeployment) createPatchCloudFunction(resourceReport *deploy.ResourceReport) (err error) {
 	var operation *cloudfunctions.Operation
 	location := fmt.Sprintf("projects/%s/locations/%s", functionDeployment.Core.SolutionSettings.Hosting.ProjectID, functionDeployment.Core.SolutionSettings.Hosting.GCF.Region)
 	retreivedCloudFunction, err := functionDeployment.Artifacts.ProjectsLocationsFunctionsService.Get(functionDeployment.Artifacts.CloudFunction.Name).Context(functionDeployment.Core.Ctx).Do()
@@ -36,6 +37,7 @@ func (functionDeployment *FunctionDeployment) createPatchCloudFunction() (err er
 			if err != nil {
 				return fmt.Errorf("ProjectsLocationsFunctionsService.Create %v", err)
 			}
+			resourceReport.Action = deploy.ActionCreated
 		} else {
 			return fmt.Errorf("ProjectsLocationsFunctionsService.Get %v", err)
 		}
@@ -46,6 +48,7 @@ func (functionDeployment *FunctionDeployment) createPatchCloudFunction() (err er
 		if err != nil {
 			return fmt.Errorf("ProjectsLocationsFunctionsService.Patch %v", err)
 		}
+		resourceReport.Action = deploy.ActionUpdated
 	}
 
 	name := operation.Name
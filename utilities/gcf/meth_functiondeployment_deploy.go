@@ -33,6 +33,8 @@ func (functionDeployment *FunctionDeployment) Deploy() (err error) {
 		return err
 	}
 	log.Printf("%s gcf situate settings done", functionDeployment.Core.InstanceName)
+	resourceReport := functionDeployment.Core.StartResourceReport("gcf cloud function", functionDeployment.Artifacts.CloudFunction.Name)
+	defer resourceReport.End(&err)
 	if functionDeployment.Core.Commands.Check {
 		return functionDeployment.checkCloudFunction()
 	}
@@ -51,7 +53,7 @@ func (functionDeployment *FunctionDeployment) Deploy() (err error) {
 		return err
 	}
 	log.Printf("%s gcf upload %s response status code: %v", functionDeployment.Core.InstanceName, functionDeployment.Artifacts.CloudFunctionZipFullPath, response.StatusCode)
-	err = functionDeployment.createPatchCloudFunction()
+	err = functionDeployment.createPatchCloudFunction(resourceReport)
 	if err != nil {
 		return err
 	}
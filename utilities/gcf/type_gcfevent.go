@@ -16,6 +16,6 @@ package gcf
 
 // Event structure
 type Event struct {
-	TriggerTopic string `yaml:"triggerTopic,omitempty"`
+	TriggerTopic string `yaml:"triggerTopic,omitempty" valid:"matches(^[a-zA-Z][a-zA-Z0-9._~+%-]{2,254}$)"`
 	BucketName   string `yaml:"bucketName,omitempty"`
 }
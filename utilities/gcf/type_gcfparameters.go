@@ -25,7 +25,7 @@ type Parameters struct {
 	Description            string
 	FunctionType           string `yaml:"functionType"`
 	RetryTimeOutSeconds    int64  `yaml:"retryTimeOutSeconds"`
-	Timeout                string
+	Timeout                string `valid:"isDuration"`
 	ServiceAccountBindings struct {
 		GRM grm.Bindings
 		IAM iamgt.Bindings
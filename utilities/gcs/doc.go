@@ -13,4 +13,8 @@
 // limitations under the License.
 
 // Package gcs helps with Google Cloud Storage
+//
+// Buckets are reconciled field by field: storage class, versioning, retention policy, uniform bucket level access, name label and delete lifecycle rule.
+// Drifting fields are updated, or reported as an error in check mode. The location cannot be updated and is only reported.
+// A retention policy prevents objects to be overwritten or deleted before the retention period.
 package gcs
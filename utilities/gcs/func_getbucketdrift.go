@@ -0,0 +1,107 @@
+// Copyright 2020 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the 'License');
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an 'AS IS' BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package gcs
+
+import (
+	"fmt"
+	"time"
+
+	"cloud.google.com/go/storage"
+)
+
+// getBucketDrift compare the wanted bucket attributes to the retreived ones field by field
+// location is not compared as it cannot be updated
+// returns the drifting field names, the attributes to update and a want / have description
+func getBucketDrift(wantedAttrs, retreivedAttrs *storage.BucketAttrs) (drift []string, bucketAttrsToUpdate storage.BucketAttrsToUpdate, s string) {
+	if wantedAttrs.StorageClass != retreivedAttrs.StorageClass {
+		drift = append(drift, "storageClass")
+		bucketAttrsToUpdate.StorageClass = wantedAttrs.StorageClass
+		s = fmt.Sprintf("%sstorageClass\nwant %s\nhave %s\n", s,
+			wantedAttrs.StorageClass,
+			retreivedAttrs.StorageClass)
+	}
+	if wantedAttrs.VersioningEnabled != retreivedAttrs.VersioningEnabled {
+		drift = append(drift, "versioningEnabled")
+		bucketAttrsToUpdate.VersioningEnabled = wantedAttrs.VersioningEnabled
+		s = fmt.Sprintf("%sversioningEnabled\nwant %v\nhave %v\n", s,
+			wantedAttrs.VersioningEnabled,
+			retreivedAttrs.VersioningEnabled)
+	}
+	var wantedRetentionPeriod, retreivedRetentionPeriod time.Duration
+	if wantedAttrs.RetentionPolicy != nil {
+		wantedRetentionPeriod = wantedAttrs.RetentionPolicy.RetentionPeriod
+	}
+	if retreivedAttrs.RetentionPolicy != nil {
+		retreivedRetentionPeriod = retreivedAttrs.RetentionPolicy.RetentionPeriod
+	}
+	if wantedRetentionPeriod != retreivedRetentionPeriod {
+		drift = append(drift, "retentionPolicy")
+		// A zero retention period removes the retention policy
+		bucketAttrsToUpdate.RetentionPolicy = &storage.RetentionPolicy{RetentionPeriod: wantedRetentionPeriod}
+		s = fmt.Sprintf("%sretentionPolicy\nwant %v\nhave %v\n", s,
+			wantedRetentionPeriod,
+			retreivedRetentionPeriod)
+	}
+	if !retreivedAttrs.UniformBucketLevelAccess.Enabled {
+		drift = append(drift, "uniformBucketLevelAccess")
+		bucketAttrsToUpdate.UniformBucketLevelAccess = &wantedAttrs.UniformBucketLevelAccess
+		s = fmt.Sprintf("%suniformBucketLevelAccess\nwant %v\nhave %v\n", s,
+			wantedAttrs.UniformBucketLevelAccess.Enabled,
+			retreivedAttrs.UniformBucketLevelAccess.Enabled)
+	}
+	if retreivedAttrs.Labels["name"] != wantedAttrs.Labels["name"] {
+		drift = append(drift, "labels")
+		bucketAttrsToUpdate.SetLabel("name", wantedAttrs.Labels["name"])
+		s = fmt.Sprintf("%slabels name\nwant %s\nhave %s\n", s,
+			wantedAttrs.Labels["name"],
+			retreivedAttrs.Labels["name"])
+	}
+
+	var wantedDeleteRule storage.LifecycleRule
+	for _, rule := range wantedAttrs.Lifecycle.Rules {
+		if rule.Action.Type == "Delete" {
+			wantedDeleteRule = rule
+		}
+	}
+	// Keep the other rules, update the age of the delete rules, may be multiple delete rules
+	rules := make([]storage.LifecycleRule, len(retreivedAttrs.Lifecycle.Rules))
+	copy(rules, retreivedAttrs.Lifecycle.Rules)
+	foundDeleteRule := false
+	ruleToBeUpdated := false
+	for i := range rules {
+		if rules[i].Action.Type == "Delete" {
+			foundDeleteRule = true
+			if rules[i].Condition.AgeInDays != wantedDeleteRule.Condition.AgeInDays {
+				ruleToBeUpdated = true
+				s = fmt.Sprintf("%slifecycle delete rule age\nwant %d\nhave %d\n", s,
+					wantedDeleteRule.Condition.AgeInDays,
+					rules[i].Condition.AgeInDays)
+				rules[i].Condition.AgeInDays = wantedDeleteRule.Condition.AgeInDays
+			}
+		}
+	}
+	if !foundDeleteRule {
+		drift = append(drift, "lifecycle delete rule")
+		rules = append(rules, wantedDeleteRule)
+		bucketAttrsToUpdate.Lifecycle = &storage.Lifecycle{Rules: rules}
+		s = fmt.Sprintf("%slifecycle delete rule\nwant age %d\nhave none\n", s,
+			wantedDeleteRule.Condition.AgeInDays)
+	}
+	if ruleToBeUpdated {
+		drift = append(drift, "lifecycle delete rule age")
+		bucketAttrsToUpdate.Lifecycle = &storage.Lifecycle{Rules: rules}
+	}
+	return drift, bucketAttrsToUpdate, s
+}
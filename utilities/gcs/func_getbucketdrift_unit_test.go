@@ -0,0 +1,91 @@
+// Copyright 2020 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the 'License');
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an 'AS IS' BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package gcs
+
+import (
+	"reflect"
+	"testing"
+	"time"
+
+	"cloud.google.com/go/storage"
+)
+
+func TestUnitGetBucketDrift(t *testing.T) {
+	makeAttrs := func(storageClass string, versioningEnabled bool, retentionPeriod time.Duration, deleteAgeInDays int64) *storage.BucketAttrs {
+		var bucketAttrs storage.BucketAttrs
+		bucketAttrs.StorageClass = storageClass
+		bucketAttrs.VersioningEnabled = versioningEnabled
+		if retentionPeriod > 0 {
+			bucketAttrs.RetentionPolicy = &storage.RetentionPolicy{RetentionPeriod: retentionPeriod}
+		}
+		bucketAttrs.Labels = map[string]string{"name": "qwerty-cai-export"}
+		bucketAttrs.UniformBucketLevelAccess.Enabled = true
+		if deleteAgeInDays > 0 {
+			var lifecycleRule storage.LifecycleRule
+			lifecycleRule.Action.Type = "Delete"
+			lifecycleRule.Condition.AgeInDays = deleteAgeInDays
+			bucketAttrs.Lifecycle.Rules = append(bucketAttrs.Lifecycle.Rules, lifecycleRule)
+		}
+		return &bucketAttrs
+	}
+	var testCases = []struct {
+		name           string
+		wantedAttrs    *storage.BucketAttrs
+		retreivedAttrs *storage.BucketAttrs
+		wantDrift      []string
+	}{
+		{
+			name:           "noDrift",
+			wantedAttrs:    makeAttrs("STANDARD", false, 0, 3),
+			retreivedAttrs: makeAttrs("STANDARD", false, 0, 3),
+		},
+		{
+			name:           "storageClassVersioningRetention",
+			wantedAttrs:    makeAttrs("NEARLINE", true, 24*time.Hour, 3),
+			retreivedAttrs: makeAttrs("STANDARD", false, 0, 3),
+			wantDrift:      []string{"storageClass", "versioningEnabled", "retentionPolicy"},
+		},
+		{
+			name:           "deleteRuleAge",
+			wantedAttrs:    makeAttrs("STANDARD", false, 0, 365),
+			retreivedAttrs: makeAttrs("STANDARD", false, 0, 3),
+			wantDrift:      []string{"lifecycle delete rule age"},
+		},
+		{
+			name:           "missingDeleteRule",
+			wantedAttrs:    makeAttrs("STANDARD", false, 0, 3),
+			retreivedAttrs: makeAttrs("STANDARD", false, 0, 0),
+			wantDrift:      []string{"lifecycle delete rule"},
+		},
+	}
+
+	for _, tc := range testCases {
+		tc := tc // https://github.com/golang/go/wiki/CommonMistakes#using-goroutines-on-loop-iterator-variables
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+			drift, bucketAttrsToUpdate, _ := getBucketDrift(tc.wantedAttrs, tc.retreivedAttrs)
+			if !reflect.DeepEqual(drift, tc.wantDrift) {
+				t.Errorf("Want drift '%v' got '%v'", tc.wantDrift, drift)
+			}
+			for _, d := range drift {
+				if d == "lifecycle delete rule" || d == "lifecycle delete rule age" {
+					if bucketAttrsToUpdate.Lifecycle == nil {
+						t.Errorf("Want lifecycle to update got nil")
+					}
+				}
+			}
+		})
+	}
+}
@@ -18,13 +18,17 @@ import (
 	"fmt"
 	"log"
 	"strings"
+	"time"
 
 	"cloud.google.com/go/storage"
+	"github.com/BrunoReboul/ram/utilities/deploy"
 )
 
-// Deploy bucket
+// Deploy get-create-update bucket
 func (bucketDeployment *BucketDeployment) Deploy() (err error) {
 	log.Printf("%s gcs bucket %s", bucketDeployment.Core.InstanceName, bucketDeployment.Settings.BucketName)
+	resourceReport := bucketDeployment.Core.StartResourceReport("gcs bucket", bucketDeployment.Settings.BucketName)
+	defer resourceReport.End(&err)
 
 	var lifecycle storage.Lifecycle
 	var lifecycleRule storage.LifecycleRule
@@ -39,102 +43,75 @@ func (bucketDeployment *BucketDeployment) Deploy() (err error) {
 	var uniformBucketLevelAccess storage.UniformBucketLevelAccess
 	uniformBucketLevelAccess.Enabled = true
 
+	var wantedAttrs storage.BucketAttrs
+	wantedAttrs.Location = bucketDeployment.Core.SolutionSettings.Hosting.GCF.Region
+	wantedAttrs.StorageClass = bucketDeployment.Settings.StorageClass
+	wantedAttrs.VersioningEnabled = bucketDeployment.Settings.VersioningEnabled
+	if bucketDeployment.Settings.RetentionPeriodInDays > 0 {
+		var retentionPolicy storage.RetentionPolicy
+		retentionPolicy.RetentionPeriod = time.Duration(bucketDeployment.Settings.RetentionPeriodInDays) * 24 * time.Hour
+		wantedAttrs.RetentionPolicy = &retentionPolicy
+	}
+	wantedAttrs.Labels = map[string]string{"name": strings.ToLower(bucketDeployment.Settings.BucketName)}
+	wantedAttrs.Lifecycle = lifecycle
+	wantedAttrs.UniformBucketLevelAccess = uniformBucketLevelAccess
+
+	bucketFound := true
 	bucket := bucketDeployment.Core.Services.StorageClient.Bucket(bucketDeployment.Settings.BucketName)
 	retreivedAttrs, err := bucket.Attrs(bucketDeployment.Core.Ctx)
 	if err != nil {
 		if !strings.Contains(strings.ToLower(err.Error()), "doesn't exist") {
 			return fmt.Errorf("bucket.Attrs %v", err)
 		}
-		// Create
-		var bucketAttrs storage.BucketAttrs
-		bucketAttrs.Location = bucketDeployment.Core.SolutionSettings.Hosting.GCF.Region
-		bucketAttrs.StorageClass = "STANDARD"
-		bucketAttrs.Labels = map[string]string{"name": strings.ToLower(bucketDeployment.Settings.BucketName)}
-		bucketAttrs.Lifecycle = lifecycle
-		bucketAttrs.UniformBucketLevelAccess = uniformBucketLevelAccess
-
-		err = bucket.Create(bucketDeployment.Core.Ctx, bucketDeployment.Core.SolutionSettings.Hosting.ProjectID, &bucketAttrs)
+		bucketFound = false
+	}
+	if bucketDeployment.Core.Commands.Check {
+		if !bucketFound {
+			return fmt.Errorf("%s gcs bucket NOT found %s", bucketDeployment.Core.InstanceName, bucketDeployment.Settings.BucketName)
+		}
+		drift, _, s := getBucketDrift(&wantedAttrs, retreivedAttrs)
+		if !strings.EqualFold(wantedAttrs.Location, retreivedAttrs.Location) {
+			drift = append(drift, "location")
+			s = fmt.Sprintf("%slocation\nwant %s\nhave %s\n", s,
+				wantedAttrs.Location,
+				retreivedAttrs.Location)
+		}
+		if len(drift) > 0 {
+			resourceReport.Drift = drift
+			return fmt.Errorf("%s gcs invalid bucket configuration %s:\n%s", bucketDeployment.Core.InstanceName, bucketDeployment.Settings.BucketName, s)
+		}
+		return nil
+	}
+	if !bucketFound {
+		err = bucket.Create(bucketDeployment.Core.Ctx, bucketDeployment.Core.SolutionSettings.Hosting.ProjectID, &wantedAttrs)
 		if err != nil {
 			return fmt.Errorf("bucket.Create %v", err)
 		}
+		resourceReport.Action = deploy.ActionCreated
 		log.Printf("%s gcs bucket created %s", bucketDeployment.Core.InstanceName, bucketDeployment.Settings.BucketName)
 		return nil
 	}
 	log.Printf("%s gcs bucket found %s", bucketDeployment.Core.InstanceName, retreivedAttrs.Name)
-
-	var bucketAttrsToUpdate storage.BucketAttrsToUpdate
-	toBeUpdated := false
-
-	if retreivedAttrs.Labels != nil {
-		if retreivedAttrs.Labels["name"] != strings.ToLower(bucketDeployment.Settings.BucketName) {
-			toBeUpdated = true
-			bucketAttrsToUpdate.SetLabel("name", strings.ToLower(bucketDeployment.Settings.BucketName))
-			log.Printf("%s gcs bucket %s label to be updated", bucketDeployment.Core.InstanceName, bucketDeployment.Settings.BucketName)
-		}
-	} else {
-		toBeUpdated = true
-		bucketAttrsToUpdate.SetLabel("name", strings.ToLower(bucketDeployment.Settings.BucketName))
-		log.Printf("%s gcs bucket %s label to be updated", bucketDeployment.Core.InstanceName, bucketDeployment.Settings.BucketName)
-	}
-	if !toBeUpdated {
-		log.Printf("%s gcs bucket %s label already uptodate", bucketDeployment.Core.InstanceName, bucketDeployment.Settings.BucketName)
-	}
-
-	if retreivedAttrs.Lifecycle.Rules != nil {
-		rules := retreivedAttrs.Lifecycle.Rules
-		foundDeleteRule := false
-		ruleToBeUpdated := false
-		for i := range rules {
-			log.Printf("%s gcs bucket %s delete lifecycle analyzing rule index %d",
-				bucketDeployment.Core.InstanceName,
-				bucketDeployment.Settings.BucketName,
-				i)
-			if rules[i].Action.Type == "Delete" {
-				foundDeleteRule = true
-				if rules[i].Condition.AgeInDays != bucketDeployment.Settings.DeleteAgeInDays {
-					ruleToBeUpdated = true
-					log.Printf("%s gcs bucket %s delete lifecycle rule found age %d updated to %d",
-						bucketDeployment.Core.InstanceName,
-						bucketDeployment.Settings.BucketName,
-						rules[i].Condition.AgeInDays,
-						bucketDeployment.Settings.DeleteAgeInDays)
-					rules[i].Condition.AgeInDays = bucketDeployment.Settings.DeleteAgeInDays
-				}
-				// Do not break, may be multiple delete rules
-			}
-		}
-		if foundDeleteRule {
-			if ruleToBeUpdated {
-				toBeUpdated = true
-				lifecycle.Rules = rules
-				bucketAttrsToUpdate.Lifecycle = &lifecycle
-				log.Printf("%s gcs bucket %s delete lifecycle rule age to be updated", bucketDeployment.Core.InstanceName, bucketDeployment.Settings.BucketName)
-			} else {
-				log.Printf("%s gcs bucket %s lifecycle already has a delete rule with the desired age set", bucketDeployment.Core.InstanceName, bucketDeployment.Settings.BucketName)
-			}
-		} else {
-			toBeUpdated = true
-			rules = append(rules, lifecycleRule)
-			lifecycle.Rules = rules
-			bucketAttrsToUpdate.Lifecycle = &lifecycle
-			log.Printf("%s gcs bucket %s lifecycle delete on age rule to be added", bucketDeployment.Core.InstanceName, bucketDeployment.Settings.BucketName)
-		}
-	} else {
-		toBeUpdated = true
-		bucketAttrsToUpdate.Lifecycle = &lifecycle
-		log.Printf("%s gcs bucket %s has no lifecycle. to be updated", bucketDeployment.Core.InstanceName, bucketDeployment.Settings.BucketName)
+	if !strings.EqualFold(wantedAttrs.Location, retreivedAttrs.Location) {
+		resourceReport.Drift = append(resourceReport.Drift, "location")
+		log.Printf("%s gcs WARNING bucket %s location %s differs from %s and cannot be updated",
+			bucketDeployment.Core.InstanceName,
+			bucketDeployment.Settings.BucketName,
+			retreivedAttrs.Location,
+			wantedAttrs.Location)
 	}
-	if !retreivedAttrs.UniformBucketLevelAccess.Enabled {
-		toBeUpdated = true
-		bucketAttrsToUpdate.UniformBucketLevelAccess = &uniformBucketLevelAccess
-		log.Printf("%s gcs bucket %s uniform level access to be updated", bucketDeployment.Core.InstanceName, bucketDeployment.Settings.BucketName)
+	drift, bucketAttrsToUpdate, s := getBucketDrift(&wantedAttrs, retreivedAttrs)
+	if len(drift) == 0 {
+		log.Printf("%s gcs bucket %s attributes already uptodate", bucketDeployment.Core.InstanceName, bucketDeployment.Settings.BucketName)
+		return nil
 	}
-	if toBeUpdated {
-		retreivedAttrs, err = bucket.Update(bucketDeployment.Core.Ctx, bucketAttrsToUpdate)
-		if err != nil {
-			return fmt.Errorf("bucket.Update %v", err)
-		}
-		log.Printf("%s gcs bucket %s attributes have been updated", bucketDeployment.Core.InstanceName, bucketDeployment.Settings.BucketName)
+	log.Printf("%s gcs bucket %s attributes to be updated\n%s", bucketDeployment.Core.InstanceName, bucketDeployment.Settings.BucketName, s)
+	_, err = bucket.Update(bucketDeployment.Core.Ctx, bucketAttrsToUpdate)
+	if err != nil {
+		return fmt.Errorf("bucket.Update %v", err)
 	}
+	resourceReport.Action = deploy.ActionUpdated
+	resourceReport.Drift = append(resourceReport.Drift, drift...)
+	log.Printf("%s gcs bucket %s attributes have been updated", bucketDeployment.Core.InstanceName, bucketDeployment.Settings.BucketName)
 	return nil
 }
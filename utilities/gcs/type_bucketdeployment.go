@@ -22,12 +22,17 @@ import (
 type BucketDeployment struct {
 	Core     *deploy.Core
 	Settings struct {
-		BucketName      string `yaml:"bucketName"`
-		DeleteAgeInDays int64  `yaml:"deleteAgeInDays"`
+		BucketName            string `yaml:"bucketName"`
+		DeleteAgeInDays       int64  `yaml:"deleteAgeInDays"`
+		StorageClass          string `yaml:"storageClass"`
+		VersioningEnabled     bool   `yaml:"versioningEnabled"`
+		RetentionPeriodInDays int64  `yaml:"retentionPeriodInDays"`
 	}
 }
 
 // NewBucketDeployment create deployment structure
 func NewBucketDeployment() *BucketDeployment {
-	return &BucketDeployment{}
+	var bucketDeployment BucketDeployment
+	bucketDeployment.Settings.StorageClass = "STANDARD"
+	return &bucketDeployment
 }
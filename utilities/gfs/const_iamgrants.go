@@ -0,0 +1,24 @@
+// Copyright 2020 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the 'License');
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an 'AS IS' BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package gfs
+
+// IAMGrantsCollection firestore collection of the RAM managed IAM grants manifest
+const IAMGrantsCollection = "iamGrants"
+
+// IAMGrantsScopeHosting grants on the RAM hosting organization and project, the default scope
+const IAMGrantsScopeHosting = "hosting"
+
+// IAMGrantsScopeMonitoring grants on the monitored organizations, folders and projects
+const IAMGrantsScopeMonitoring = "monitoring"
@@ -0,0 +1,32 @@
+// Copyright 2020 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the 'License');
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an 'AS IS' BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package gfs
+
+import (
+	"fmt"
+	"strings"
+)
+
+// getDumpDocumentPath returns the firestore document path of a dump, the dump name being prefixed or not by the bucket name
+func getDumpDocumentPath(dumpNameFull string) string {
+	var dumpName string
+	if strings.Contains(dumpNameFull, "/") {
+		parts := strings.Split(dumpNameFull, "/")
+		dumpName = strings.Replace(parts[1], ".dump", "", 1)
+	} else {
+		dumpName = strings.Replace(dumpNameFull, ".dump", "", 1)
+	}
+	return fmt.Sprintf("dumps/%s", dumpName)
+}
@@ -0,0 +1,53 @@
+// Copyright 2020 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the 'License');
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an 'AS IS' BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package gfs
+
+import (
+	"testing"
+)
+
+func TestUnitGetDumpDocumentPath(t *testing.T) {
+	var testCases = []struct {
+		name         string
+		dumpNameFull string
+		want         string
+	}{
+		{
+			name:         "withBucket",
+			dumpNameFull: "mybucket/dumpinventory_org1234_iam_policies.dump",
+			want:         "dumps/dumpinventory_org1234_iam_policies",
+		},
+		{
+			name:         "withoutBucket",
+			dumpNameFull: "dumpinventory_org1234_iam_policies.dump",
+			want:         "dumps/dumpinventory_org1234_iam_policies",
+		},
+		{
+			name:         "childDump",
+			dumpNameFull: "dumpinventory_org1234_iam_policies.1600000000000000.20200913T123456.child2.dump",
+			want:         "dumps/dumpinventory_org1234_iam_policies.1600000000000000.20200913T123456.child2",
+		},
+	}
+	for _, tc := range testCases {
+		tc := tc // https://github.com/golang/go/wiki/CommonMistakes#using-goroutines-on-loop-iterator-variables
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+			got := getDumpDocumentPath(tc.dumpNameFull)
+			if got != tc.want {
+				t.Errorf("Want '%s' got '%s'", tc.want, got)
+			}
+		})
+	}
+}
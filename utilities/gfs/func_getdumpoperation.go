@@ -0,0 +1,44 @@
+// Copyright 2020 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the 'License');
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an 'AS IS' BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package gfs
+
+import (
+	"context"
+	"time"
+
+	"cloud.google.com/go/firestore"
+)
+
+// GetDumpOperation retrieves the CAI export operation recorded in a dump document
+func GetDumpOperation(ctx context.Context,
+	dumpNameFull string,
+	firestoreClient *firestore.Client,
+	retriesNumber time.Duration) (operation DumpOperation, found bool, err error) {
+	documentSnap, found := GetDoc(ctx, firestoreClient, getDumpDocumentPath(dumpNameFull), retriesNumber)
+	if !found {
+		return operation, false, nil
+	}
+	var dump struct {
+		Operation *DumpOperation `firestore:"operation"`
+	}
+	err = documentSnap.DataTo(&dump)
+	if err != nil {
+		return operation, false, err
+	}
+	if dump.Operation == nil {
+		return operation, false, nil
+	}
+	return *dump.Operation, true, nil
+}
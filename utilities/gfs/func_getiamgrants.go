@@ -0,0 +1,61 @@
+// Copyright 2020 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the 'License');
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an 'AS IS' BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package gfs
+
+import (
+	"fmt"
+	"log"
+	"time"
+
+	"github.com/BrunoReboul/ram/utilities/deploy"
+)
+
+// GetIAMGrants returns the grants recorded for the current microservice to a member on a resource
+// protectedRoles are the roles recorded for the same member on the same resource by other microservices or scopes, they are never stale
+func GetIAMGrants(core *deploy.Core, kind, resource, member, scope string, retriesNumber time.Duration) (iamGrants IAMGrants, protectedRoles []string, err error) {
+	if scope == "" {
+		scope = IAMGrantsScopeHosting
+	}
+	iamGrants.ServiceName = core.ServiceName
+	iamGrants.Kind = kind
+	iamGrants.Resource = resource
+	iamGrants.Member = member
+	iamGrants.Scope = scope
+	documentID := getIAMGrantsDocumentPath(core.ServiceName, scope, resource, member)
+	query := core.Services.FirestoreClient.Collection(IAMGrantsCollection).Where("resource", "==", resource).Where("member", "==", member)
+	var i time.Duration
+	for i = 0; i < retriesNumber; i++ {
+		documentSnaps, err := query.Documents(core.Ctx).GetAll()
+		if err != nil {
+			log.Printf("ERROR - iteration %d core.Services.FirestoreClient.Collection(%s).Where().Documents().GetAll %v", i, IAMGrantsCollection, err)
+			time.Sleep(i * 100 * time.Millisecond)
+			continue
+		}
+		for _, documentSnap := range documentSnaps {
+			var recorded IAMGrants
+			if err = documentSnap.DataTo(&recorded); err != nil {
+				return iamGrants, protectedRoles, fmt.Errorf("documentSnap.DataTo %s %v", documentSnap.Ref.Path, err)
+			}
+			if fmt.Sprintf("%s/%s", IAMGrantsCollection, documentSnap.Ref.ID) == documentID {
+				iamGrants.Roles = recorded.Roles
+				iamGrants.UpdateTime = recorded.UpdateTime
+			} else {
+				protectedRoles = append(protectedRoles, recorded.Roles...)
+			}
+		}
+		return iamGrants, protectedRoles, nil
+	}
+	return iamGrants, protectedRoles, fmt.Errorf("GetIAMGrants %s %s failed after %d retries", resource, member, retriesNumber)
+}
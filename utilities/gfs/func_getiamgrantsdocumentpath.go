@@ -0,0 +1,26 @@
+// Copyright 2020 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the 'License');
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an 'AS IS' BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package gfs
+
+import (
+	"fmt"
+
+	"github.com/BrunoReboul/ram/utilities/str"
+)
+
+// getIAMGrantsDocumentPath returns the firestore document path of the grants of a microservice to a member on a resource for a scope
+func getIAMGrantsDocumentPath(serviceName, scope, resource, member string) string {
+	return fmt.Sprintf("%s/%s", IAMGrantsCollection, str.RevertSlash(fmt.Sprintf("%s_%s_%s_%s", serviceName, scope, resource, member)))
+}
@@ -0,0 +1,57 @@
+// Copyright 2020 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the 'License');
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an 'AS IS' BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package gfs
+
+import (
+	"testing"
+)
+
+func TestUnitGetIAMGrantsDocumentPath(t *testing.T) {
+	var testCases = []struct {
+		name        string
+		serviceName string
+		scope       string
+		resource    string
+		member      string
+		want        string
+	}{
+		{
+			name:        "project",
+			serviceName: "monitor",
+			scope:       IAMGrantsScopeHosting,
+			resource:    "projects/ram-hosting",
+			member:      "serviceAccount:monitor@ram-hosting.iam.gserviceaccount.com",
+			want:        "iamGrants/monitor_hosting_projects\\ram-hosting_serviceAccount:monitor@ram-hosting.iam.gserviceaccount.com",
+		},
+		{
+			name:        "serviceAccount",
+			serviceName: "stream2bq",
+			scope:       IAMGrantsScopeHosting,
+			resource:    "projects/ram-hosting/serviceAccounts/stream2bq@ram-hosting.iam.gserviceaccount.com",
+			member:      "serviceAccount:123456@cloudbuild.gserviceaccount.com",
+			want:        "iamGrants/stream2bq_hosting_projects\\ram-hosting\\serviceAccounts\\stream2bq@ram-hosting.iam.gserviceaccount.com_serviceAccount:123456@cloudbuild.gserviceaccount.com",
+		},
+	}
+	for _, tc := range testCases {
+		tc := tc // https://github.com/golang/go/wiki/CommonMistakes#using-goroutines-on-loop-iterator-variables
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+			got := getIAMGrantsDocumentPath(tc.serviceName, tc.scope, tc.resource, tc.member)
+			if got != tc.want {
+				t.Errorf("Want '%s' got '%s'", tc.want, got)
+			}
+		})
+	}
+}
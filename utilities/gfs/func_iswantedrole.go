@@ -0,0 +1,38 @@
+// Copyright 2020 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the 'License');
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an 'AS IS' BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package gfs
+
+import (
+	"strings"
+
+	"github.com/BrunoReboul/ram/utilities/str"
+)
+
+// IsWantedRole returns true when the binding key, the role followed by its condition if any, is in wantedRoles,
+// or is the key of a custom role, e.g. organizations/1234/roles/ramMonitor, which short name key is in wantedCustomRoles
+func IsWantedRole(bindingKey string, wantedRoles, wantedCustomRoles []string) bool {
+	if str.Find(wantedRoles, bindingKey) {
+		return true
+	}
+	if strings.HasPrefix(bindingKey, "roles/") {
+		return false
+	}
+	role, condition := bindingKey, ""
+	if i := strings.Index(bindingKey, " if "); i >= 0 {
+		role, condition = bindingKey[:i], bindingKey[i:]
+	}
+	parts := strings.Split(role, "/")
+	return str.Find(wantedCustomRoles, parts[len(parts)-1]+condition)
+}
@@ -0,0 +1,45 @@
+// Copyright 2020 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the 'License');
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an 'AS IS' BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package gfs
+
+import (
+	"fmt"
+	"log"
+	"time"
+
+	"github.com/BrunoReboul/ram/utilities/deploy"
+)
+
+// ListIAMGrants returns all the grants recorded in the RAM managed IAM grants manifest
+func ListIAMGrants(core *deploy.Core, retriesNumber time.Duration) (iamGrantsList []IAMGrants, err error) {
+	var i time.Duration
+	for i = 0; i < retriesNumber; i++ {
+		documentSnaps, err := core.Services.FirestoreClient.Collection(IAMGrantsCollection).Documents(core.Ctx).GetAll()
+		if err != nil {
+			log.Printf("ERROR - iteration %d core.Services.FirestoreClient.Collection(%s).Documents().GetAll %v", i, IAMGrantsCollection, err)
+			time.Sleep(i * 100 * time.Millisecond)
+			continue
+		}
+		for _, documentSnap := range documentSnaps {
+			var iamGrants IAMGrants
+			if err = documentSnap.DataTo(&iamGrants); err != nil {
+				return iamGrantsList, fmt.Errorf("documentSnap.DataTo %s %v", documentSnap.Ref.Path, err)
+			}
+			iamGrantsList = append(iamGrantsList, iamGrants)
+		}
+		return iamGrantsList, nil
+	}
+	return iamGrantsList, fmt.Errorf("ListIAMGrants failed after %d retries", retriesNumber)
+}
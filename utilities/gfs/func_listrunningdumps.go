@@ -0,0 +1,43 @@
+// Copyright 2020 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the 'License');
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an 'AS IS' BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package gfs
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"cloud.google.com/go/firestore"
+)
+
+// ListRunningDumps lists the dumps of an export whose operation is still running: the scheduled dump, its point-in-time dumps and its kept previous operations
+// The dump names are returned in the bucket/object form of the scheduled dump name
+func ListRunningDumps(ctx context.Context, firestoreClient *firestore.Client, dumpNameFull string) (dumpNames []string, err error) {
+	dumpID := strings.TrimPrefix(getDumpDocumentPath(dumpNameFull), "dumps/")
+	var bucketPrefix string
+	if strings.Contains(dumpNameFull, "/") {
+		bucketPrefix = strings.Split(dumpNameFull, "/")[0] + "/"
+	}
+	documentSnaps, err := firestoreClient.Collection("dumps").Where("operation.status", "==", DumpOperationRunning).Documents(ctx).GetAll()
+	if err != nil {
+		return nil, err
+	}
+	for _, documentSnap := range documentSnaps {
+		if documentSnap.Ref.ID == dumpID || strings.HasPrefix(documentSnap.Ref.ID, dumpID+".") {
+			dumpNames = append(dumpNames, fmt.Sprintf("%s%s.dump", bucketPrefix, documentSnap.Ref.ID))
+		}
+	}
+	return dumpNames, nil
+}
@@ -25,34 +25,41 @@ import (
 	"github.com/BrunoReboul/ram/utilities/glo"
 )
 
-// RecordDump record a dump stepStack in firestore
+// RecordDump record a dump stepStack in firestore, and when not nil the CAI export operation that produces the dump
 func RecordDump(ctx context.Context,
 	dumpNameFull string,
 	firestoreClient *firestore.Client,
 	stepStack glo.Steps,
+	operation *DumpOperation,
 	microserviceName string,
 	instanceName string,
 	environment string,
 	pubSubID string,
 	retriesNumber time.Duration) (err error) {
 	var i time.Duration
-	var dumpName string
-	if strings.Contains(dumpNameFull, "/") {
-		parts := strings.Split(dumpNameFull, "/")
-		dumpName = strings.Replace(parts[1], ".dump", "", 1)
-	} else {
-		dumpName = strings.Replace(dumpNameFull, ".dump", "", 1)
+	documentPath := getDumpDocumentPath(dumpNameFull)
+	fields := map[string]interface{}{
+		"stepStack": stepStack,
+	}
+	updates := []firestore.Update{
+		{
+			Path:  "stepStack",
+			Value: stepStack,
+		},
+	}
+	if operation != nil {
+		fields["operation"] = operation
+		updates = append(updates, firestore.Update{
+			Path:  "operation",
+			Value: operation,
+		})
 	}
-
-	documentPath := fmt.Sprintf("dumps/%s", dumpName)
 
 	for i = 0; i < retriesNumber; i++ {
 		_, err = firestoreClient.Doc(documentPath).Get(ctx)
 		if err != nil {
 			if strings.Contains(strings.ToLower(strings.Replace(err.Error(), " ", "", -1)), "notfound") {
-				_, err = firestoreClient.Doc(documentPath).Set(ctx, map[string]interface{}{
-					"stepStack": stepStack,
-				})
+				_, err = firestoreClient.Doc(documentPath).Set(ctx, fields)
 				if err != nil {
 					log.Println(glo.Entry{
 						MicroserviceName:   microserviceName,
@@ -88,12 +95,7 @@ func RecordDump(ctx context.Context,
 				time.Sleep(i * 100 * time.Millisecond)
 			}
 		} else {
-			_, err = firestoreClient.Doc(documentPath).Update(ctx, []firestore.Update{
-				{
-					Path:  "stepStack",
-					Value: stepStack,
-				},
-			})
+			_, err = firestoreClient.Doc(documentPath).Update(ctx, updates)
 			if err != nil {
 				log.Println(glo.Entry{
 					MicroserviceName:   microserviceName,
@@ -0,0 +1,67 @@
+// Copyright 2020 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the 'License');
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an 'AS IS' BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package gfs
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"time"
+
+	"cloud.google.com/go/firestore"
+	"github.com/BrunoReboul/ram/utilities/glo"
+)
+
+// RecordDumpOperation updates the CAI export operation recorded in a dump document, leaving its stepStack unchanged
+func RecordDumpOperation(ctx context.Context,
+	dumpNameFull string,
+	firestoreClient *firestore.Client,
+	operation DumpOperation,
+	microserviceName string,
+	instanceName string,
+	environment string,
+	pubSubID string,
+	retriesNumber time.Duration) (err error) {
+	var i time.Duration
+	documentPath := getDumpDocumentPath(dumpNameFull)
+	for i = 0; i < retriesNumber; i++ {
+		_, err = firestoreClient.Doc(documentPath).Set(ctx, map[string]interface{}{
+			"operation": operation,
+		}, firestore.MergeAll)
+		if err != nil {
+			log.Println(glo.Entry{
+				MicroserviceName:   microserviceName,
+				InstanceName:       instanceName,
+				Environment:        environment,
+				Severity:           "WARNING",
+				Message:            "recordDumpOperation cannot set firestore doc",
+				Description:        fmt.Sprintf("iteration %d firestoreClient.Doc(documentPath).Set %s %v", i, documentPath, err),
+				TriggeringPubsubID: pubSubID,
+			})
+			time.Sleep(i * 100 * time.Millisecond)
+		} else {
+			log.Println(glo.Entry{
+				MicroserviceName:   microserviceName,
+				InstanceName:       instanceName,
+				Environment:        environment,
+				Severity:           "INFO",
+				Message:            fmt.Sprintf("dump operation %s recorded %s", operation.Status, documentPath),
+				TriggeringPubsubID: pubSubID,
+			})
+			return nil
+		}
+	}
+	return err
+}
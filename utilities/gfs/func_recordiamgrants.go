@@ -0,0 +1,40 @@
+// Copyright 2020 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the 'License');
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an 'AS IS' BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package gfs
+
+import (
+	"log"
+	"time"
+
+	"github.com/BrunoReboul/ram/utilities/deploy"
+)
+
+// RecordIAMGrants records the roles RAM manages for a member on a resource, to detect them as stale once no longer wanted
+func RecordIAMGrants(core *deploy.Core, iamGrants IAMGrants, retriesNumber time.Duration) (err error) {
+	var i time.Duration
+	iamGrants.UpdateTime = time.Now()
+	documentPath := getIAMGrantsDocumentPath(iamGrants.ServiceName, iamGrants.Scope, iamGrants.Resource, iamGrants.Member)
+	for i = 0; i < retriesNumber; i++ {
+		_, err = core.Services.FirestoreClient.Doc(documentPath).Set(core.Ctx, iamGrants)
+		if err != nil {
+			log.Printf("ERROR - iteration %d core.Services.FirestoreClient.Doc(documentPath).Set %v", i, err)
+			time.Sleep(i * 100 * time.Millisecond)
+		} else {
+			log.Printf("%s gfs iam grants recorded %d roles for %s on %s", core.InstanceName, len(iamGrants.Roles), iamGrants.Member, iamGrants.Resource)
+			return nil
+		}
+	}
+	return err
+}
@@ -0,0 +1,31 @@
+// Copyright 2020 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the 'License');
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an 'AS IS' BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package gfs
+
+import (
+	"github.com/BrunoReboul/ram/utilities/str"
+)
+
+// GetStaleRoles returns the recorded binding keys no longer wanted nor protected
+// A role moved from unconditional to conditional, or to another condition, leaves its previous binding key stale
+func (iamGrants IAMGrants) GetStaleRoles(wantedRoles, wantedCustomRoles, protectedRoles []string) (staleRoles []string) {
+	for _, role := range iamGrants.Roles {
+		if IsWantedRole(role, wantedRoles, wantedCustomRoles) || str.Find(protectedRoles, role) {
+			continue
+		}
+		staleRoles = append(staleRoles, role)
+	}
+	return staleRoles
+}
@@ -0,0 +1,91 @@
+// Copyright 2020 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the 'License');
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an 'AS IS' BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package gfs
+
+import (
+	"fmt"
+	"testing"
+)
+
+func TestUnitGetStaleRoles(t *testing.T) {
+	var testCases = []struct {
+		name              string
+		recordedRoles     []string
+		wantedRoles       []string
+		wantedCustomRoles []string
+		protectedRoles    []string
+		want              string
+	}{
+		{
+			name:          "nothingRecorded",
+			recordedRoles: []string{},
+			wantedRoles:   []string{"roles/pubsub.publisher"},
+			want:          "[]",
+		},
+		{
+			name:          "roleRemovedFromSettings",
+			recordedRoles: []string{"roles/pubsub.publisher", "roles/datastore.user"},
+			wantedRoles:   []string{"roles/pubsub.publisher"},
+			want:          "[roles/datastore.user]",
+		},
+		{
+			name:              "customRoleStillWanted",
+			recordedRoles:     []string{"organizations/1234/roles/ramMonitor", "projects/ram/roles/ramOld"},
+			wantedCustomRoles: []string{"ramMonitor"},
+			want:              "[projects/ram/roles/ramOld]",
+		},
+		{
+			name:           "protectedByAnotherService",
+			recordedRoles:  []string{"roles/cloudfunctions.developer"},
+			protectedRoles: []string{"roles/cloudfunctions.developer"},
+			want:           "[]",
+		},
+		{
+			name:          "unconditionalRoleMovedToConditional",
+			recordedRoles: []string{"roles/pubsub.publisher"},
+			wantedRoles:   []string{`roles/pubsub.publisher if resource.name.startsWith("projects/ram/topics/ram-")`},
+			want:          "[roles/pubsub.publisher]",
+		},
+		{
+			name:          "conditionalRoleStillWanted",
+			recordedRoles: []string{`roles/pubsub.publisher if resource.name.startsWith("projects/ram/topics/ram-")`},
+			wantedRoles:   []string{`roles/pubsub.publisher if resource.name.startsWith("projects/ram/topics/ram-")`},
+			want:          "[]",
+		},
+		{
+			name:              "customRoleMovedToConditional",
+			recordedRoles:     []string{"organizations/1234/roles/ramMonitor", `organizations/1234/roles/ramMonitor if request.time < timestamp("2027-01-01T00:00:00Z")`},
+			wantedCustomRoles: []string{`ramMonitor if request.time < timestamp("2027-01-01T00:00:00Z")`},
+			want:              "[organizations/1234/roles/ramMonitor]",
+		},
+		{
+			name:              "predefinedRoleNotMatchedOnShortName",
+			recordedRoles:     []string{"roles/viewer"},
+			wantedCustomRoles: []string{"viewer"},
+			want:              "[roles/viewer]",
+		},
+	}
+	for _, tc := range testCases {
+		tc := tc // https://github.com/golang/go/wiki/CommonMistakes#using-goroutines-on-loop-iterator-variables
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+			iamGrants := IAMGrants{Roles: tc.recordedRoles}
+			got := fmt.Sprintf("%v", iamGrants.GetStaleRoles(tc.wantedRoles, tc.wantedCustomRoles, tc.protectedRoles))
+			if got != tc.want {
+				t.Errorf("Want '%s' got '%s'", tc.want, got)
+			}
+		})
+	}
+}
@@ -0,0 +1,64 @@
+// Copyright 2020 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the 'License');
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an 'AS IS' BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package gfs
+
+import (
+	"fmt"
+	"log"
+
+	"github.com/BrunoReboul/ram/utilities/deploy"
+	adminpb "google.golang.org/genproto/googleapis/firestore/admin/v1"
+	"google.golang.org/protobuf/types/known/fieldmaskpb"
+)
+
+// Deploy get-update the firestore time to live policy of a collection field
+// Documents which field holds a timestamp are deleted once this timestamp is passed
+func (ttlPolicyDeployment *TTLPolicyDeployment) Deploy() (err error) {
+	log.Printf("%s gfs firestore ttl policy", ttlPolicyDeployment.Core.InstanceName)
+	name := fmt.Sprintf("projects/%s/databases/(default)/collectionGroups/%s/fields/%s",
+		ttlPolicyDeployment.Core.SolutionSettings.Hosting.ProjectID,
+		ttlPolicyDeployment.Artifacts.CollectionID,
+		ttlPolicyDeployment.Artifacts.FieldName)
+	resourceReport := ttlPolicyDeployment.Core.StartResourceReport("firestore ttl policy", name)
+	defer resourceReport.End(&err)
+	var getFieldRequest adminpb.GetFieldRequest
+	getFieldRequest.Name = name
+	field, err := ttlPolicyDeployment.Core.Services.FirestoreAdminClient.GetField(ttlPolicyDeployment.Core.Ctx, &getFieldRequest)
+	if err != nil {
+		return fmt.Errorf("FirestoreAdminClient.GetField %v", err)
+	}
+	if field.GetTtlConfig() != nil {
+		log.Printf("%s gfs firestore ttl policy found %s state %s", ttlPolicyDeployment.Core.InstanceName, name, field.GetTtlConfig().GetState())
+		return nil
+	}
+	if ttlPolicyDeployment.Core.Commands.Check {
+		resourceReport.Drift = []string{fmt.Sprintf("+ttlConfig %s", name)}
+		return fmt.Errorf("%s gfs firestore ttl policy NOT found %s", ttlPolicyDeployment.Core.InstanceName, name)
+	}
+	var updateFieldRequest adminpb.UpdateFieldRequest
+	updateFieldRequest.Field = &adminpb.Field{
+		Name:      name,
+		TtlConfig: &adminpb.Field_TtlConfig{},
+	}
+	updateFieldRequest.UpdateMask = &fieldmaskpb.FieldMask{Paths: []string{"ttl_config"}}
+	// Long running operation, the policy becomes active asynchronously, no need to wait
+	_, err = ttlPolicyDeployment.Core.Services.FirestoreAdminClient.UpdateField(ttlPolicyDeployment.Core.Ctx, &updateFieldRequest)
+	if err != nil {
+		return fmt.Errorf("FirestoreAdminClient.UpdateField %v", err)
+	}
+	resourceReport.Action = deploy.ActionCreated
+	log.Printf("%s gfs firestore ttl policy requested %s", ttlPolicyDeployment.Core.InstanceName, name)
+	return nil
+}
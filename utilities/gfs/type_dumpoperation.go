@@ -0,0 +1,32 @@
+// Copyright 2020 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the 'License');
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an 'AS IS' BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package gfs
+
+import "time"
+
+// DumpOperation tracks the CAI export long running operation producing a dump
+type DumpOperation struct {
+	Name            string    `firestore:"name"`
+	Status          string    `firestore:"status"`
+	StartTime       time.Time `firestore:"startTime"`
+	EndTime         time.Time `firestore:"endTime,omitempty"`
+	DurationSeconds float64   `firestore:"durationSeconds,omitempty"`
+	OutputSizeBytes int64     `firestore:"outputSizeBytes,omitempty"`
+	Error           string    `firestore:"error,omitempty"`
+	Origin          string    `firestore:"origin,omitempty"`
+	ReadTime        time.Time `firestore:"readTime,omitempty"`
+	// DumpName the bucket/object the operation exports to, when recorded out of its own dump document
+	DumpName string `firestore:"dumpName,omitempty"`
+}
@@ -0,0 +1,29 @@
+// Copyright 2020 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the 'License');
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an 'AS IS' BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package gfs
+
+import "time"
+
+// IAMGrants records the roles RAM granted to a member on a resource for a microservice, to detect the grants no longer wanted
+// Roles are binding keys, the role followed by its condition expression when conditional, see grm.GetBindingKey
+type IAMGrants struct {
+	ServiceName string    `firestore:"serviceName"`
+	Kind        string    `firestore:"kind"`
+	Resource    string    `firestore:"resource"`
+	Member      string    `firestore:"member"`
+	Scope       string    `firestore:"scope"`
+	Roles       []string  `firestore:"roles"`
+	UpdateTime  time.Time `firestore:"updateTime"`
+}
@@ -13,4 +13,14 @@
 // limitations under the License.
 
 // Package glo helps with google cloud logging
+//
+// A Logger is built once at function initialization with the service context, then set per invocation with the pubsub ID and the step stack.
+// Its helpers (Start, Finish, NoRetry, RedoOnTransient...) keep the JSON field names the log based metrics rely on.
+// Log parameters, set in the service settings and overridden in the instance settings, filter on severity, sample INFO entries per invocation and truncate descriptions.
+// The entries the log based metrics rely on, e.g. start, finish, noretry, are always logged in full.
+//
+// It also exports an OpenTelemetry span per service hop, built from the step stack:
+// the trace ID is derived from the origin step, the span ID from the current step, the parent span ID from the previous one.
+// The trace context is propagated in pubsub message attributes as a W3C traceparent alongside the step stack, and takes precedence when present.
+// The exporter is set in the solution settings hosting tracing section: none (default), otlp or file, by default ram_traces.json in the temporary directory.
 package glo
@@ -0,0 +1,30 @@
+// Copyright 2020 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the 'License');
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an 'AS IS' BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package glo
+
+// GetLogParameters returns the service log parameters overridden by the instance ones that are set
+func GetLogParameters(serviceParameters, instanceParameters LogParameters) (logParameters LogParameters) {
+	logParameters = serviceParameters
+	if instanceParameters.Severity != "" {
+		logParameters.Severity = instanceParameters.Severity
+	}
+	if instanceParameters.SampleRatio != 0 {
+		logParameters.SampleRatio = instanceParameters.SampleRatio
+	}
+	if instanceParameters.MaxDescriptionLength != 0 {
+		logParameters.MaxDescriptionLength = instanceParameters.MaxDescriptionLength
+	}
+	return logParameters
+}
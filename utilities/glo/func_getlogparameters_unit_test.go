@@ -0,0 +1,53 @@
+// Copyright 2020 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the 'License');
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an 'AS IS' BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package glo
+
+import (
+	"testing"
+)
+
+func TestUnitGetLogParameters(t *testing.T) {
+	var testCases = []struct {
+		name               string
+		serviceParameters  LogParameters
+		instanceParameters LogParameters
+		want               LogParameters
+	}{
+		{
+			name: "noParameters",
+		},
+		{
+			name:              "serviceOnly",
+			serviceParameters: LogParameters{Severity: "NOTICE", SampleRatio: 0.1, MaxDescriptionLength: 4096},
+			want:              LogParameters{Severity: "NOTICE", SampleRatio: 0.1, MaxDescriptionLength: 4096},
+		},
+		{
+			name:               "instanceOverride",
+			serviceParameters:  LogParameters{Severity: "NOTICE", SampleRatio: 0.1, MaxDescriptionLength: 4096},
+			instanceParameters: LogParameters{SampleRatio: 1},
+			want:               LogParameters{Severity: "NOTICE", SampleRatio: 1, MaxDescriptionLength: 4096},
+		},
+	}
+	for _, tc := range testCases {
+		tc := tc // https://github.com/golang/go/wiki/CommonMistakes#using-goroutines-on-loop-iterator-variables
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+			got := GetLogParameters(tc.serviceParameters, tc.instanceParameters)
+			if got != tc.want {
+				t.Errorf("Want '%v' got '%v'", tc.want, got)
+			}
+		})
+	}
+}
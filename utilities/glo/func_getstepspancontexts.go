@@ -0,0 +1,63 @@
+// Copyright 2020 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the 'License');
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an 'AS IS' BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package glo
+
+import (
+	"context"
+	"crypto/sha256"
+
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// GetStepSpanContexts returns the span context of the current step, the last one of the stack, and the span context of its parent
+// Trace and span IDs are derived from the step IDs. The trace ID is derived from the origin step, e.g. the CAI feed asset change,
+// so the hops triggered by the same origin event belong to the same trace, even when not chained by pubsub, e.g. GCS triggers
+// When the triggering pubsub message carries a W3C traceparent attribute, it sets the trace ID and the parent span
+func GetStepSpanContexts(attributes map[string]string, stepStack Steps) (parent trace.SpanContext, current trace.SpanContext) {
+	if len(stepStack) == 0 {
+		return parent, current
+	}
+	var traceID trace.TraceID
+	originHash := sha256.Sum256([]byte(stepStack[0].StepID))
+	copy(traceID[:], originHash[:])
+	if len(stepStack) > 1 {
+		parent = trace.NewSpanContext(trace.SpanContextConfig{
+			TraceID:    traceID,
+			SpanID:     getStepSpanID(stepStack[len(stepStack)-2]),
+			TraceFlags: trace.FlagsSampled,
+			Remote:     true,
+		})
+	}
+	if attributes != nil {
+		propagated := trace.SpanContextFromContext(propagation.TraceContext{}.Extract(context.Background(), attributesCarrier(attributes)))
+		if propagated.IsValid() {
+			parent = propagated
+			traceID = propagated.TraceID()
+		}
+	}
+	current = trace.NewSpanContext(trace.SpanContextConfig{
+		TraceID:    traceID,
+		SpanID:     getStepSpanID(stepStack[len(stepStack)-1]),
+		TraceFlags: trace.FlagsSampled,
+	})
+	return parent, current
+}
+
+func getStepSpanID(step Step) (spanID trace.SpanID) {
+	stepHash := sha256.Sum256([]byte(step.StepID))
+	copy(spanID[:], stepHash[:])
+	return spanID
+}
@@ -0,0 +1,105 @@
+// Copyright 2020 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the 'License');
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an 'AS IS' BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package glo
+
+import (
+	"testing"
+	"time"
+)
+
+func TestUnitGetStepSpanContexts(t *testing.T) {
+	caiStep := Step{StepID: "//storage.googleapis.com/bucket1/2020-01-01T00:00:00Z", StepTimestamp: time.Now()}
+	upload2gcsStep := Step{StepID: "upload2gcs-cai-feed/1234", StepTimestamp: time.Now()}
+	monitorStep := Step{StepID: "monitor-cai-feed/5678", StepTimestamp: time.Now()}
+	_, upload2gcsSpanContext := GetStepSpanContexts(nil, Steps{caiStep, upload2gcsStep})
+	_, monitorSpanContext := GetStepSpanContexts(nil, Steps{caiStep, monitorStep})
+	if upload2gcsSpanContext.TraceID() != monitorSpanContext.TraceID() {
+		t.Errorf("Want same trace ID for the same origin step got '%s' and '%s'", upload2gcsSpanContext.TraceID(), monitorSpanContext.TraceID())
+	}
+	if upload2gcsSpanContext.SpanID() == monitorSpanContext.SpanID() {
+		t.Errorf("Want different span IDs for different steps got '%s' twice", upload2gcsSpanContext.SpanID())
+	}
+
+	var testCases = []struct {
+		name              string
+		attributes        map[string]string
+		stepStack         Steps
+		wantValidParent   bool
+		wantParentSpanID  string
+		wantTraceID       string
+		wantCurrentSpanID string
+	}{
+		{
+			name: "emptyStack",
+		},
+		{
+			name:              "rootStep",
+			stepStack:         Steps{caiStep},
+			wantTraceID:       upload2gcsSpanContext.TraceID().String(),
+			wantCurrentSpanID: getStepSpanID(caiStep).String(),
+		},
+		{
+			name:              "parentFromStepStack",
+			stepStack:         Steps{caiStep, monitorStep},
+			wantValidParent:   true,
+			wantParentSpanID:  getStepSpanID(caiStep).String(),
+			wantTraceID:       monitorSpanContext.TraceID().String(),
+			wantCurrentSpanID: monitorSpanContext.SpanID().String(),
+		},
+		{
+			name:              "parentFromTraceparent",
+			attributes:        map[string]string{"traceparent": "00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01"},
+			stepStack:         Steps{caiStep, monitorStep},
+			wantValidParent:   true,
+			wantParentSpanID:  "00f067aa0ba902b7",
+			wantTraceID:       "4bf92f3577b34da6a3ce929d0e0e4736",
+			wantCurrentSpanID: monitorSpanContext.SpanID().String(),
+		},
+		{
+			name:              "invalidTraceparent",
+			attributes:        map[string]string{"traceparent": "blabla"},
+			stepStack:         Steps{caiStep, monitorStep},
+			wantValidParent:   true,
+			wantParentSpanID:  getStepSpanID(caiStep).String(),
+			wantTraceID:       monitorSpanContext.TraceID().String(),
+			wantCurrentSpanID: monitorSpanContext.SpanID().String(),
+		},
+	}
+	for _, tc := range testCases {
+		tc := tc // https://github.com/golang/go/wiki/CommonMistakes#using-goroutines-on-loop-iterator-variables
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+			parent, current := GetStepSpanContexts(tc.attributes, tc.stepStack)
+			if parent.IsValid() != tc.wantValidParent {
+				t.Errorf("Want valid parent '%v' got '%v'", tc.wantValidParent, parent.IsValid())
+			}
+			if tc.wantValidParent && parent.SpanID().String() != tc.wantParentSpanID {
+				t.Errorf("Want parent span ID '%s' got '%s'", tc.wantParentSpanID, parent.SpanID())
+			}
+			if len(tc.stepStack) == 0 {
+				if current.IsValid() {
+					t.Errorf("Want invalid current span context got '%s'", current.SpanID())
+				}
+				return
+			}
+			if current.TraceID().String() != tc.wantTraceID {
+				t.Errorf("Want trace ID '%s' got '%s'", tc.wantTraceID, current.TraceID())
+			}
+			if current.SpanID().String() != tc.wantCurrentSpanID {
+				t.Errorf("Want span ID '%s' got '%s'", tc.wantCurrentSpanID, current.SpanID())
+			}
+		})
+	}
+}
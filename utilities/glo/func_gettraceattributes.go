@@ -0,0 +1,32 @@
+// Copyright 2020 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the 'License');
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an 'AS IS' BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package glo
+
+import (
+	"context"
+
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// GetTraceAttributes returns the pubsub message attributes propagating the trace context of the current step, to be published alongside the step stack
+func GetTraceAttributes(attributes map[string]string, stepStack Steps) map[string]string {
+	carrier := make(attributesCarrier)
+	_, current := GetStepSpanContexts(attributes, stepStack)
+	if current.IsValid() {
+		propagation.TraceContext{}.Inject(trace.ContextWithSpanContext(context.Background(), current), carrier)
+	}
+	return carrier
+}
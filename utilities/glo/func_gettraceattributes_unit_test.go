@@ -0,0 +1,59 @@
+// Copyright 2020 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the 'License');
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an 'AS IS' BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package glo
+
+import (
+	"fmt"
+	"testing"
+	"time"
+)
+
+func TestUnitGetTraceAttributes(t *testing.T) {
+	caiStep := Step{StepID: "//storage.googleapis.com/bucket1/2020-01-01T00:00:00Z", StepTimestamp: time.Now()}
+	monitorStep := Step{StepID: "monitor-cai-feed/5678", StepTimestamp: time.Now()}
+	_, monitorSpanContext := GetStepSpanContexts(nil, Steps{caiStep, monitorStep})
+
+	var testCases = []struct {
+		name            string
+		attributes      map[string]string
+		stepStack       Steps
+		wantTraceparent string
+	}{
+		{
+			name: "emptyStack",
+		},
+		{
+			name:            "fromStepStack",
+			stepStack:       Steps{caiStep, monitorStep},
+			wantTraceparent: fmt.Sprintf("00-%s-%s-01", monitorSpanContext.TraceID(), monitorSpanContext.SpanID()),
+		},
+		{
+			name:            "fromTraceparent",
+			attributes:      map[string]string{"traceparent": "00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01"},
+			stepStack:       Steps{caiStep, monitorStep},
+			wantTraceparent: fmt.Sprintf("00-4bf92f3577b34da6a3ce929d0e0e4736-%s-01", monitorSpanContext.SpanID()),
+		},
+	}
+	for _, tc := range testCases {
+		tc := tc // https://github.com/golang/go/wiki/CommonMistakes#using-goroutines-on-loop-iterator-variables
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+			traceAttributes := GetTraceAttributes(tc.attributes, tc.stepStack)
+			if traceAttributes["traceparent"] != tc.wantTraceparent {
+				t.Errorf("Want traceparent '%s' got '%s'", tc.wantTraceparent, traceAttributes["traceparent"])
+			}
+		})
+	}
+}
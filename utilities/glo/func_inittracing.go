@@ -0,0 +1,82 @@
+// Copyright 2020 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the 'License');
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an 'AS IS' BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package glo
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracegrpc"
+	"go.opentelemetry.io/otel/exporters/stdout/stdouttrace"
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/sdk/resource"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	semconv "go.opentelemetry.io/otel/semconv/v1.10.0"
+)
+
+// InitTracing sets the OpenTelemetry global tracer provider and the W3C trace context propagator
+// exporterName is none (default), otlp to export to an OTLP gRPC endpoint, or file to append spans as JSON to a local file, e.g. for local runs
+// The file defaults to ram_traces.json in the temporary directory, the only writable one in cloud functions
+// A previous tracer provider is shut down, closing its file
+// Spans are exported synchronously as the cloud function CPU is throttled once the function returns
+func InitTracing(ctx context.Context, exporterName, otlpEndpoint, filePath, microserviceName, instanceName, environment string) (err error) {
+	otel.SetTextMapPropagator(propagation.TraceContext{})
+	var exporter sdktrace.SpanExporter
+	switch exporterName {
+	case "", "none":
+		return nil
+	case "otlp":
+		var options []otlptracegrpc.Option
+		if otlpEndpoint != "" {
+			options = append(options, otlptracegrpc.WithEndpoint(otlpEndpoint))
+		}
+		exporter, err = otlptracegrpc.New(ctx, options...)
+		if err != nil {
+			return fmt.Errorf("otlptracegrpc.New %v", err)
+		}
+	case "file":
+		if filePath == "" {
+			filePath = filepath.Join(os.TempDir(), "ram_traces.json")
+		}
+		file, err := os.OpenFile(filePath, os.O_CREATE|os.O_APPEND|os.O_WRONLY, 0644)
+		if err != nil {
+			return fmt.Errorf("os.OpenFile %v", err)
+		}
+		stdoutExporter, err := stdouttrace.New(stdouttrace.WithWriter(file))
+		if err != nil {
+			file.Close()
+			return fmt.Errorf("stdouttrace.New %v", err)
+		}
+		exporter = fileSpanExporter{SpanExporter: stdoutExporter, file: file}
+	default:
+		return fmt.Errorf("unsupported tracing exporter '%s', want none, otlp or file", exporterName)
+	}
+	if previousTracerProvider, ok := otel.GetTracerProvider().(*sdktrace.TracerProvider); ok {
+		if err = previousTracerProvider.Shutdown(ctx); err != nil {
+			return fmt.Errorf("previous tracer provider Shutdown %v", err)
+		}
+	}
+	otel.SetTracerProvider(sdktrace.NewTracerProvider(
+		sdktrace.WithSyncer(exporter),
+		sdktrace.WithIDGenerator(stepIDGenerator{}),
+		sdktrace.WithResource(resource.NewWithAttributes(semconv.SchemaURL,
+			semconv.ServiceNameKey.String(microserviceName),
+			semconv.ServiceInstanceIDKey.String(instanceName),
+			semconv.DeploymentEnvironmentKey.String(environment)))))
+	return nil
+}
@@ -0,0 +1,75 @@
+// Copyright 2020 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the 'License');
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an 'AS IS' BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package glo
+
+import (
+	"context"
+	"io/ioutil"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"go.opentelemetry.io/otel"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+)
+
+func TestUnitInitTracingFile(t *testing.T) {
+	var testCases = []struct {
+		name         string
+		filePath     string
+		wantFileName string
+	}{
+		{
+			name:         "defaultsToTempDir",
+			wantFileName: "ram_traces.json",
+		},
+		{
+			name:         "filePath",
+			filePath:     "traces.json",
+			wantFileName: "traces.json",
+		},
+	}
+	// Not parallel, the tracer provider is global
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctx := context.Background()
+			tempDir := t.TempDir()
+			t.Setenv("TMPDIR", tempDir)
+			filePath := tc.filePath
+			if filePath != "" {
+				filePath = filepath.Join(tempDir, filePath)
+			}
+			if err := InitTracing(ctx, "file", "", filePath, "stream2bq", "stream2bq_test", "test"); err != nil {
+				t.Fatalf("Want no error got %v", err)
+			}
+			_, span := otel.Tracer("test").Start(ctx, "hop")
+			span.End()
+			tracerProvider, ok := otel.GetTracerProvider().(*sdktrace.TracerProvider)
+			if !ok {
+				t.Fatalf("Want an sdk tracer provider got %T", otel.GetTracerProvider())
+			}
+			if err := tracerProvider.Shutdown(ctx); err != nil {
+				t.Errorf("Want the file closed on shutdown got %v", err)
+			}
+			b, err := ioutil.ReadFile(filepath.Join(tempDir, tc.wantFileName))
+			if err != nil {
+				t.Fatalf("Want the traces file got %v", err)
+			}
+			if !strings.Contains(string(b), `"Name":"hop"`) {
+				t.Errorf("Want span hop got '%s'", string(b))
+			}
+		})
+	}
+}
@@ -0,0 +1,48 @@
+// Copyright 2020 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the 'License');
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an 'AS IS' BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package glo
+
+import (
+	"context"
+	"time"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// RecordStepSpan records the span of the current service hop, from the current step timestamp, e.g. the triggering pubsub message publish time, to now
+// No span is recorded when the step stack is empty, e.g. the triggering event has been cancelled before the stack is set
+func RecordStepSpan(ctx context.Context, microserviceName string, attributes map[string]string, stepStack Steps) {
+	if len(stepStack) == 0 {
+		return
+	}
+	parent, current := GetStepSpanContexts(attributes, stepStack)
+	if parent.IsValid() {
+		ctx = trace.ContextWithRemoteSpanContext(ctx, parent)
+	}
+	ctx = context.WithValue(ctx, stepSpanContextKey{}, current)
+	step := stepStack[len(stepStack)-1]
+	now := time.Now()
+	_, span := otel.Tracer("github.com/BrunoReboul/ram/utilities/glo").Start(ctx, microserviceName,
+		trace.WithTimestamp(step.StepTimestamp),
+		trace.WithSpanKind(trace.SpanKindConsumer),
+		trace.WithAttributes(
+			attribute.String("ram.step_id", step.StepID),
+			attribute.String("ram.origin_step_id", stepStack[0].StepID),
+			attribute.Int("ram.step_stack_depth", len(stepStack)),
+			attribute.Float64("ram.latency_e2e_seconds", now.Sub(stepStack[0].StepTimestamp).Seconds())))
+	span.End(trace.WithTimestamp(now))
+}
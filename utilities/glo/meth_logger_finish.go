@@ -0,0 +1,55 @@
+// Copyright 2020 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the 'License');
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an 'AS IS' BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package glo
+
+import "time"
+
+// Finish logs the NOTICE finish entry from which the ram_latency and ram_latency_e2e log based metrics extract latencies
+// message is expected to start with finish, latencies are computed from the current step and the step stack
+func (l *Logger) Finish(message, description, assetInventoryOrigin string) {
+	l.Log(l.finishEntry(message, description, assetInventoryOrigin, time.Now()))
+}
+
+// FinishCompliance logs the NOTICE finish entry with the compliance status of the asset
+func (l *Logger) FinishCompliance(message, description, assetInventoryOrigin string, compliant bool) {
+	entry := l.finishEntry(message, description, assetInventoryOrigin, time.Now())
+	entry.Compliant = compliant
+	l.Log(entry)
+}
+
+// Cancel logs the NOTICE cancel entry when the triggering event is ignored, with latencies once the step stack is set
+func (l *Logger) Cancel(description string) {
+	if len(l.stepStack) == 0 {
+		l.Notice("cancel", description)
+		return
+	}
+	l.Log(l.finishEntry("cancel", description, "", time.Now()))
+}
+
+func (l *Logger) finishEntry(message, description, assetInventoryOrigin string, now time.Time) (entry Entry) {
+	originEventTimestamp := l.step.StepTimestamp
+	entry.Severity = "NOTICE"
+	entry.Message = message
+	entry.Description = description
+	entry.Now = &now
+	entry.OriginEventTimestamp = &originEventTimestamp
+	entry.LatencySeconds = now.Sub(originEventTimestamp).Seconds()
+	if len(l.stepStack) > 0 {
+		entry.LatencyE2ESeconds = now.Sub(l.stepStack[0].StepTimestamp).Seconds()
+	}
+	entry.StepStack = l.stepStack
+	entry.AssetInventoryOrigin = assetInventoryOrigin
+	return entry
+}
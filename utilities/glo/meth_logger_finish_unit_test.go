@@ -0,0 +1,87 @@
+// Copyright 2020 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the 'License');
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an 'AS IS' BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package glo
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+)
+
+func TestUnitLoggerFinishEntry(t *testing.T) {
+	now := time.Now()
+	originStep := Step{StepID: "origin", StepTimestamp: now.Add(-10 * time.Second)}
+	currentStep := Step{StepID: "current", StepTimestamp: now.Add(-2 * time.Second)}
+
+	var testCases = []struct {
+		name                  string
+		stepStack             Steps
+		assetInventoryOrigin  string
+		wantLatencySeconds    float64
+		wantLatencyE2ESeconds float64
+		wantFieldNames        []string
+	}{
+		{
+			name:                  "withStepStack",
+			stepStack:             Steps{originStep, currentStep},
+			assetInventoryOrigin:  "real-time",
+			wantLatencySeconds:    2,
+			wantLatencyE2ESeconds: 10,
+			wantFieldNames: []string{"microservice_name", "instance_name", "environment", "severity", "message",
+				"now", "triggering_pubsub_id", "origin_event_timestamp", "latency_seconds", "latency_e2e_seconds",
+				"step_stack", "assetInventoryOrigin"},
+		},
+		{
+			name:               "noStepStack",
+			wantLatencySeconds: 2,
+			wantFieldNames: []string{"microservice_name", "instance_name", "environment", "severity", "message",
+				"now", "triggering_pubsub_id", "origin_event_timestamp", "latency_seconds"},
+		},
+	}
+	for _, tc := range testCases {
+		tc := tc // https://github.com/golang/go/wiki/CommonMistakes#using-goroutines-on-loop-iterator-variables
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+			logger := NewLogger("monitor", "monitor_instance", "dev", "initID")
+			logger.SetInvocation("pubSubID", currentStep)
+			logger.SetStepStack(tc.stepStack)
+			entry := logger.finishEntry("finish test", "", tc.assetInventoryOrigin, now)
+			if entry.LatencySeconds != tc.wantLatencySeconds {
+				t.Errorf("Want latency_seconds '%v' got '%v'", tc.wantLatencySeconds, entry.LatencySeconds)
+			}
+			if entry.LatencyE2ESeconds != tc.wantLatencyE2ESeconds {
+				t.Errorf("Want latency_e2e_seconds '%v' got '%v'", tc.wantLatencyE2ESeconds, entry.LatencyE2ESeconds)
+			}
+			// Log completes the context, the field names are the ones extracted by the log based metrics
+			entry.MicroserviceName = logger.microserviceName
+			entry.InstanceName = logger.instanceName
+			entry.Environment = logger.environment
+			entry.TriggeringPubsubID = logger.pubSubID
+			var fields map[string]interface{}
+			err := json.Unmarshal([]byte(entry.String()), &fields)
+			if err != nil {
+				t.Fatalf("json.Unmarshal %v", err)
+			}
+			for _, fieldName := range tc.wantFieldNames {
+				if _, ok := fields[fieldName]; !ok {
+					t.Errorf("Want field '%s' got '%v'", fieldName, fields)
+				}
+			}
+			if len(fields) != len(tc.wantFieldNames) {
+				t.Errorf("Want %d fields got %d '%v'", len(tc.wantFieldNames), len(fields), fields)
+			}
+		})
+	}
+}
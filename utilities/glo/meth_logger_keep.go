@@ -0,0 +1,75 @@
+// Copyright 2020 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the 'License');
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an 'AS IS' BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package glo
+
+import (
+	"fmt"
+	"hash/fnv"
+	"strings"
+	"unicode/utf8"
+)
+
+// severityLevels Cloud Logging severity numeric values
+var severityLevels = map[string]int{
+	"DEFAULT":   0,
+	"DEBUG":     100,
+	"INFO":      200,
+	"NOTICE":    300,
+	"WARNING":   400,
+	"ERROR":     500,
+	"CRITICAL":  600,
+	"ALERT":     700,
+	"EMERGENCY": 800,
+}
+
+// isMetricEntry true when the entry message is used by a log based metric, or to troubleshoot one
+func isMetricEntry(message string) bool {
+	switch message {
+	case "coldstart", "start", "noretry", "init_failed", "redo_on_transient", "cancel":
+		return true
+	}
+	return strings.HasPrefix(message, "finish") || strings.HasPrefix(message, "export_")
+}
+
+// keep true when the entry has to be logged according to the log parameters
+// INFO entries are sampled per invocation, so that a sampled invocation logs all its INFO entries
+func (l *Logger) keep(entry Entry) bool {
+	if isMetricEntry(entry.Message) {
+		return true
+	}
+	if severityLevels[entry.Severity] < severityLevels[strings.ToUpper(l.logParameters.Severity)] {
+		return false
+	}
+	if entry.Severity == "INFO" && l.pubSubID != "" &&
+		l.logParameters.SampleRatio > 0 && l.logParameters.SampleRatio < 1 {
+		h := fnv.New32a()
+		h.Write([]byte(l.pubSubID))
+		return float64(h.Sum32()%10000)/10000 < l.logParameters.SampleRatio
+	}
+	return true
+}
+
+// truncate shortens a description longer than the max description length, on a rune boundary
+func (l *Logger) truncate(description string) string {
+	max := int(l.logParameters.MaxDescriptionLength)
+	if max <= 0 || len(description) <= max {
+		return description
+	}
+	cut := max
+	for cut > 0 && !utf8.RuneStart(description[cut]) {
+		cut--
+	}
+	return fmt.Sprintf("%s... truncated %d bytes", description[:cut], len(description)-cut)
+}
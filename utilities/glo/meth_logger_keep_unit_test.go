@@ -0,0 +1,81 @@
+// Copyright 2020 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the 'License');
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an 'AS IS' BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package glo
+
+import (
+	"testing"
+)
+
+func TestUnitLoggerKeep(t *testing.T) {
+	var testCases = []struct {
+		name          string
+		logParameters LogParameters
+		severity      string
+		message       string
+		want          bool
+	}{
+		{name: "noParametersInfo", severity: "INFO", message: "compliant", want: true},
+		{name: "severityNoticeDropInfo", logParameters: LogParameters{Severity: "NOTICE"}, severity: "INFO", message: "compliant", want: false},
+		{name: "severityLowerCase", logParameters: LogParameters{Severity: "notice"}, severity: "INFO", message: "compliant", want: false},
+		{name: "severityWarningDropNotice", logParameters: LogParameters{Severity: "WARNING"}, severity: "NOTICE", message: "deleted", want: false},
+		{name: "severityWarningKeepError", logParameters: LogParameters{Severity: "WARNING"}, severity: "ERROR", message: "failed", want: true},
+		{name: "severityCriticalKeepStart", logParameters: LogParameters{Severity: "CRITICAL"}, severity: "NOTICE", message: "start", want: true},
+		{name: "severityCriticalKeepFinish", logParameters: LogParameters{Severity: "CRITICAL"}, severity: "NOTICE", message: "finish compliant asset", want: true},
+		{name: "severityCriticalKeepExport", logParameters: LogParameters{Severity: "CRITICAL"}, severity: "ERROR", message: "export_failed", want: true},
+		{name: "sampledOut", logParameters: LogParameters{SampleRatio: 0.5}, severity: "INFO", message: "compliant", want: false},
+		{name: "sampledIn", logParameters: LogParameters{SampleRatio: 0.9}, severity: "INFO", message: "compliant", want: true},
+		{name: "sampleOnlyInfo", logParameters: LogParameters{SampleRatio: 0.5}, severity: "WARNING", message: "retry", want: true},
+		{name: "sampleKeepFinish", logParameters: LogParameters{SampleRatio: 0.5}, severity: "NOTICE", message: "finish", want: true},
+	}
+	for _, tc := range testCases {
+		tc := tc // https://github.com/golang/go/wiki/CommonMistakes#using-goroutines-on-loop-iterator-variables
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+			logger := NewLogger("monitor", "monitor_instance", "dev", "initID")
+			logger.SetLogParameters(tc.logParameters)
+			logger.SetInvocation("pubSubID", Step{})
+			got := logger.keep(Entry{Severity: tc.severity, Message: tc.message})
+			if got != tc.want {
+				t.Errorf("Want '%v' got '%v'", tc.want, got)
+			}
+		})
+	}
+}
+
+func TestUnitLoggerTruncate(t *testing.T) {
+	var testCases = []struct {
+		name                 string
+		maxDescriptionLength int64
+		description          string
+		want                 string
+	}{
+		{name: "noTruncation", description: "violationJSON {}", want: "violationJSON {}"},
+		{name: "shortEnough", maxDescriptionLength: 16, description: "violationJSON {}", want: "violationJSON {}"},
+		{name: "truncated", maxDescriptionLength: 13, description: "violationJSON {}", want: "violationJSON... truncated 3 bytes"},
+		{name: "runeBoundary", maxDescriptionLength: 2, description: "aéb", want: "a... truncated 3 bytes"},
+	}
+	for _, tc := range testCases {
+		tc := tc // https://github.com/golang/go/wiki/CommonMistakes#using-goroutines-on-loop-iterator-variables
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+			logger := NewLogger("monitor", "monitor_instance", "dev", "initID")
+			logger.SetLogParameters(LogParameters{MaxDescriptionLength: tc.maxDescriptionLength})
+			got := logger.truncate(tc.description)
+			if got != tc.want {
+				t.Errorf("Want '%s' got '%s'", tc.want, got)
+			}
+		})
+	}
+}
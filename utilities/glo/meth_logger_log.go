@@ -0,0 +1,73 @@
+// Copyright 2020 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the 'License');
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an 'AS IS' BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package glo
+
+import "log"
+
+// Log completes the entry with the service and invocation context, then logs it
+// The init ID is logged until the first invocation sets the triggering pubsub ID
+// Entries are filtered, sampled and truncated according to the log parameters
+func (l *Logger) Log(entry Entry) {
+	if !l.keep(entry) {
+		return
+	}
+	if !isMetricEntry(entry.Message) {
+		entry.Description = l.truncate(entry.Description)
+	}
+	if entry.MicroserviceName == "" {
+		entry.MicroserviceName = l.microserviceName
+	}
+	if entry.InstanceName == "" {
+		entry.InstanceName = l.instanceName
+	}
+	if entry.Environment == "" {
+		entry.Environment = l.environment
+	}
+	if l.pubSubID == "" {
+		if entry.InitID == "" {
+			entry.InitID = l.initID
+		}
+	} else {
+		if entry.TriggeringPubsubID == "" {
+			entry.TriggeringPubsubID = l.pubSubID
+		}
+	}
+	log.Println(entry)
+}
+
+// Info logs an INFO entry
+func (l *Logger) Info(message, description string) {
+	l.Log(Entry{Severity: "INFO", Message: message, Description: description})
+}
+
+// Notice logs a NOTICE entry
+func (l *Logger) Notice(message, description string) {
+	l.Log(Entry{Severity: "NOTICE", Message: message, Description: description})
+}
+
+// Warning logs a WARNING entry
+func (l *Logger) Warning(message, description string) {
+	l.Log(Entry{Severity: "WARNING", Message: message, Description: description})
+}
+
+// Error logs an ERROR entry
+func (l *Logger) Error(message, description string) {
+	l.Log(Entry{Severity: "ERROR", Message: message, Description: description})
+}
+
+// Critical logs a CRITICAL entry
+func (l *Logger) Critical(message, description string) {
+	l.Log(Entry{Severity: "CRITICAL", Message: message, Description: description})
+}
@@ -0,0 +1,30 @@
+// Copyright 2020 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the 'License');
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an 'AS IS' BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package glo
+
+// InitFailed logs the CRITICAL init_failed entry when the cloud function initialization fails
+func (l *Logger) InitFailed(description string) {
+	l.Critical("init_failed", description)
+}
+
+// NoRetry logs the CRITICAL noretry entry, counted by the ram_execution_status log based metric, when the event is dropped
+func (l *Logger) NoRetry(description string) {
+	l.Critical("noretry", description)
+}
+
+// RedoOnTransient logs the CRITICAL redo_on_transient entry when the function returns an error to have the event retried
+func (l *Logger) RedoOnTransient(description string) {
+	l.Critical("redo_on_transient", description)
+}
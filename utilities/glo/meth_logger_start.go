@@ -0,0 +1,44 @@
+// Copyright 2020 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the 'License');
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an 'AS IS' BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package glo
+
+import "time"
+
+// Start logs the NOTICE start entry with the age of the triggering event, and returns this age
+func (l *Logger) Start() (age time.Duration) {
+	now := time.Now()
+	entry := l.ageEntry(now)
+	entry.Severity = "NOTICE"
+	entry.Message = "start"
+	l.Log(entry)
+	return now.Sub(l.step.StepTimestamp)
+}
+
+// NoRetryTooOld logs the CRITICAL noretry entry when the triggering event is older than the retry timeout
+func (l *Logger) NoRetryTooOld() {
+	entry := l.ageEntry(time.Now())
+	entry.Severity = "CRITICAL"
+	entry.Message = "noretry"
+	entry.Description = "Pubsub message too old"
+	l.Log(entry)
+}
+
+func (l *Logger) ageEntry(now time.Time) (entry Entry) {
+	triggeringPubsubTimestamp := l.step.StepTimestamp
+	entry.TriggeringPubsubAgeSeconds = now.Sub(triggeringPubsubTimestamp).Seconds()
+	entry.TriggeringPubsubTimestamp = &triggeringPubsubTimestamp
+	entry.Now = &now
+	return entry
+}
@@ -19,6 +19,7 @@ import (
 	"log"
 	"strings"
 
+	"github.com/BrunoReboul/ram/utilities/deploy"
 	"google.golang.org/api/logging/v2"
 )
 
@@ -33,6 +34,9 @@ func (logMetricDeployment LogMetricDeployment) Deploy() (err error) {
 		logMetricDeployment.Core.SolutionSettings.Hosting.ProjectID,
 		logMetricDeployment.Settings.Instance.GLO.MetricID)
 
+	resourceReport := logMetricDeployment.Core.StartResourceReport("glo log metric", metricName)
+	defer resourceReport.End(&err)
+
 	retrievedLogMetric, err := projectMetricsService.Get(metricName).Context(logMetricDeployment.Core.Ctx).Do()
 
 	if err != nil {
@@ -55,6 +59,7 @@ func (logMetricDeployment LogMetricDeployment) Deploy() (err error) {
 			return fmt.Errorf("projectMetricsService.Create %v", err)
 		}
 		// ffo.YAMLMarshalPrint(&createdLogMetric)
+		resourceReport.Action = deploy.ActionCreated
 		log.Printf("%s glo metric created %s", logMetricDeployment.Core.InstanceName, createdLogMetric.Name)
 	} else {
 		log.Printf("%s glo found log metric %s",
@@ -63,6 +68,7 @@ func (logMetricDeployment LogMetricDeployment) Deploy() (err error) {
 		// ffo.YAMLMarshalPrint(&retrievedLogMetric)
 		err = checkLogMetric(&logMetricDeployment.Artifacts.LogMetric, retrievedLogMetric)
 		if err != nil {
+			resourceReport.Drift = append(resourceReport.Drift, err.Error())
 			if logMetricDeployment.Core.Commands.Check {
 				return err
 			}
@@ -71,6 +77,7 @@ func (logMetricDeployment LogMetricDeployment) Deploy() (err error) {
 			if err != nil {
 				return fmt.Errorf("projectMetricsService.Update %v", err)
 			}
+			resourceReport.Action = deploy.ActionUpdated
 			log.Printf("%s glo metric updated %s", logMetricDeployment.Core.InstanceName, updatedLogMetric.Name)
 		}
 	}
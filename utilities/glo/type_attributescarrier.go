@@ -0,0 +1,37 @@
+// Copyright 2020 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the 'License');
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an 'AS IS' BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package glo
+
+// attributesCarrier adapts pubsub message attributes to the OpenTelemetry text map carrier interface
+type attributesCarrier map[string]string
+
+// Get returns the value associated with the passed key
+func (c attributesCarrier) Get(key string) string {
+	return c[key]
+}
+
+// Set stores the key-value pair
+func (c attributesCarrier) Set(key string, value string) {
+	c[key] = value
+}
+
+// Keys lists the keys stored in this carrier
+func (c attributesCarrier) Keys() []string {
+	keys := make([]string, 0, len(c))
+	for key := range c {
+		keys = append(keys, key)
+	}
+	return keys
+}
@@ -0,0 +1,37 @@
+// Copyright 2020 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the 'License');
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an 'AS IS' BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package glo
+
+import (
+	"context"
+	"os"
+
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+)
+
+// fileSpanExporter closes the file the spans are written to when the tracer provider shuts down
+type fileSpanExporter struct {
+	sdktrace.SpanExporter
+	file *os.File
+}
+
+// Shutdown shuts down the exporter then closes its file
+func (exporter fileSpanExporter) Shutdown(ctx context.Context) (err error) {
+	err = exporter.SpanExporter.Shutdown(ctx)
+	if closeErr := exporter.file.Close(); err == nil {
+		err = closeErr
+	}
+	return err
+}
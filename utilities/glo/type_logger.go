@@ -0,0 +1,55 @@
+// Copyright 2020 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the 'License');
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an 'AS IS' BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package glo
+
+// Logger logs structured entries to Cloud Logging
+// The service context is set once in Initialize, the invocation context, pubsub ID, current step and step stack, at each entry point
+type Logger struct {
+	microserviceName string
+	instanceName     string
+	environment      string
+	initID           string
+	pubSubID         string
+	step             Step
+	stepStack        Steps
+	logParameters    LogParameters
+}
+
+// NewLogger create a logger for a microservice instance, initID identifies the cold start
+func NewLogger(microserviceName, instanceName, environment, initID string) *Logger {
+	return &Logger{
+		microserviceName: microserviceName,
+		instanceName:     instanceName,
+		environment:      environment,
+		initID:           initID,
+	}
+}
+
+// SetInvocation sets the triggering pubsub ID and the current step, and resets the step stack
+func (l *Logger) SetInvocation(pubSubID string, step Step) {
+	l.pubSubID = pubSubID
+	l.step = step
+	l.stepStack = nil
+}
+
+// SetStepStack sets the step stack of the current invocation, used to compute the end to end latency
+func (l *Logger) SetStepStack(stepStack Steps) {
+	l.stepStack = stepStack
+}
+
+// SetLogParameters sets the log verbosity, sampling and truncation settings
+func (l *Logger) SetLogParameters(logParameters LogParameters) {
+	l.logParameters = logParameters
+}
@@ -0,0 +1,24 @@
+// Copyright 2020 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the 'License');
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an 'AS IS' BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package glo
+
+// LogParameters log verbosity and sampling settings
+// Zero values log everything: severity INFO, no sampling, no truncation
+// Entries the log based metrics rely on, e.g. start, finish, noretry, are always logged in full
+type LogParameters struct {
+	Severity             string  `yaml:"severity,omitempty" valid:"isOneOf(INFO|NOTICE|WARNING|ERROR|CRITICAL)"` // minimum severity to log: INFO, NOTICE, WARNING, ERROR, CRITICAL
+	SampleRatio          float64 `yaml:"sampleRatio,omitempty" valid:"inRange(0|1)"`                             // ratio of invocations logging their INFO entries, between 0 and 1, 0 the zero value meaning no sampling as 1
+	MaxDescriptionLength int64   `yaml:"maxDescriptionLength,omitempty"`                                         // descriptions longer than this number of bytes are truncated
+}
@@ -0,0 +1,47 @@
+// Copyright 2020 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the 'License');
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an 'AS IS' BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package glo
+
+import (
+	"context"
+	"crypto/rand"
+
+	"go.opentelemetry.io/otel/trace"
+)
+
+// stepSpanContextKey context key to pass the span context derived from the step stack to the ID generator
+type stepSpanContextKey struct{}
+
+// stepIDGenerator generates the trace and span IDs derived from the step stack when available in the context, random IDs otherwise
+type stepIDGenerator struct{}
+
+// NewIDs returns a new trace and span ID
+func (gen stepIDGenerator) NewIDs(ctx context.Context) (traceID trace.TraceID, spanID trace.SpanID) {
+	if spanContext, ok := ctx.Value(stepSpanContextKey{}).(trace.SpanContext); ok {
+		return spanContext.TraceID(), spanContext.SpanID()
+	}
+	rand.Read(traceID[:])
+	rand.Read(spanID[:])
+	return traceID, spanID
+}
+
+// NewSpanID returns a ID for a new span in the trace with traceID
+func (gen stepIDGenerator) NewSpanID(ctx context.Context, traceID trace.TraceID) (spanID trace.SpanID) {
+	if spanContext, ok := ctx.Value(stepSpanContextKey{}).(trace.SpanContext); ok {
+		return spanContext.SpanID()
+	}
+	rand.Read(spanID[:])
+	return spanID
+}
@@ -17,7 +17,6 @@ package gps
 import (
 	"context"
 	"fmt"
-	"log"
 	"sync"
 	"sync/atomic"
 
@@ -33,48 +32,21 @@ func GetPublishCallResult(ctx context.Context,
 	pubSubErrNumber *uint64,
 	pubSubMsgNumber *uint64,
 	logEventEveryXPubSubMsg uint64,
-	pubSubID string,
-	microserviceName string,
-	instanceName string,
-	environment string) {
+	logger *glo.Logger) {
 	defer waitgroup.Done()
 	id, err := publishResult.Get(ctx)
 	if err != nil {
-		log.Println(glo.Entry{
-			MicroserviceName:   microserviceName,
-			InstanceName:       instanceName,
-			Environment:        environment,
-			Severity:           "WARNING",
-			Message:            "publishResult.Get(ctx)",
-			Description:        fmt.Sprintf("count %d on %s: %v", atomic.AddUint64(pubSubErrNumber, 1), msgInfo, err),
-			TriggeringPubsubID: pubSubID,
-		})
+		logger.Warning("publishResult.Get(ctx)", fmt.Sprintf("count %d on %s: %v", atomic.AddUint64(pubSubErrNumber, 1), msgInfo, err))
 		return
 	}
 	msgNumber := atomic.AddUint64(pubSubMsgNumber, 1)
 
 	// debug log
-	// log.Println(glo.Entry{
-	// 	MicroserviceName:   microserviceName,
-	// 	InstanceName:       instanceName,
-	// 	Environment:        environment,
-	// 	Severity:           "INFO",
-	// 	Message:            fmt.Sprintf("GetPublishCallResult %s pubSubMsgNumber %d", msgInfo, msgNumber),
-	// 	Description:        fmt.Sprintf("id %s", id),
-	// 	TriggeringPubsubID: pubSubID,
-	// })
+	// logger.Info(fmt.Sprintf("GetPublishCallResult %s pubSubMsgNumber %d", msgInfo, msgNumber), fmt.Sprintf("id %s", id))
 	// end debug log
 
 	if msgNumber%logEventEveryXPubSubMsg == 0 {
 		// No retry on pubsub publish as already implemented in the GO client
-		log.Println(glo.Entry{
-			MicroserviceName:   microserviceName,
-			InstanceName:       instanceName,
-			Environment:        environment,
-			Severity:           "INFO",
-			Message:            fmt.Sprintf("progression %d messages published", msgNumber),
-			Description:        fmt.Sprintf("now %s id %s", msgInfo, id),
-			TriggeringPubsubID: pubSubID,
-		})
+		logger.Info(fmt.Sprintf("progression %d messages published", msgNumber), fmt.Sprintf("now %s id %s", msgInfo, id))
 	}
 }
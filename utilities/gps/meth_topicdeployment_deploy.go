@@ -19,6 +19,7 @@ import (
 	"log"
 	"strings"
 
+	"github.com/BrunoReboul/ram/utilities/deploy"
 	pubsubpb "google.golang.org/genproto/googleapis/pubsub/v1"
 	"google.golang.org/genproto/protobuf/field_mask"
 )
@@ -29,6 +30,8 @@ func (topicDeployment *TopicDeployment) Deploy() (err error) {
 	topicName := fmt.Sprintf("projects/%s/topics/%s",
 		topicDeployment.Core.SolutionSettings.Hosting.ProjectID,
 		topicDeployment.Settings.TopicName)
+	resourceReport := topicDeployment.Core.StartResourceReport("gps topic", topicName)
+	defer resourceReport.End(&err)
 	var getTopicRequest pubsubpb.GetTopicRequest
 	getTopicRequest.Topic = topicName
 	topicNotFound := false
@@ -60,6 +63,7 @@ func (topicDeployment *TopicDeployment) Deploy() (err error) {
 			}
 			log.Printf("%s gps try to create topic but already exist %s", topicDeployment.Core.InstanceName, topicDeployment.Settings.TopicName)
 		}
+		resourceReport.Action = deploy.ActionCreated
 		log.Printf("%s gps topic created %s", topicDeployment.Core.InstanceName, topicDeployment.Settings.TopicName)
 	} else {
 		if nameLabelToBeUpdated {
@@ -73,6 +77,8 @@ func (topicDeployment *TopicDeployment) Deploy() (err error) {
 			if err != nil {
 				return fmt.Errorf("topicDeployment.Core.Services.PubsubPublisherClient.UpdateTopic %s", err)
 			}
+			resourceReport.Action = deploy.ActionUpdated
+			resourceReport.Drift = append(resourceReport.Drift, "labels")
 			log.Printf("%s gps topic found, label updated %s", topicDeployment.Core.InstanceName, topicDeployment.Settings.TopicName)
 		} else {
 			log.Printf("%s gps topic found %s", topicDeployment.Core.InstanceName, topicDeployment.Settings.TopicName)
@@ -16,5 +16,6 @@ package gps
 
 // PubSubMessage is the payload of a Pub/Sub event.
 type PubSubMessage struct {
-	Data []byte `json:"data"`
+	Data       []byte            `json:"data"`
+	Attributes map[string]string `json:"attributes,omitempty"`
 }
@@ -13,4 +13,8 @@
 // limitations under the License.
 
 // Package grm helps with Google Resource Manager, aka Organizations, Folders, Projects and their role bindings
+//
+// Roles granted by RAM are recorded in the gfs iamGrants collection. Recorded roles no longer wanted are stale: reported by default, flagged by -check and removed with ramcli -prune-iam. The grants of microservices deleted from the repository are only handled when listed with ramcli -removed-services on a whole repository run. Roles are recorded by binding key, role and condition expression, so moving a role from unconditional to conditional leaves its unconditional binding stale
+//
+// Bindings may be conditional, e.g. time-bound or limited to RAM resource names: policies are read and written in version 3, a binding being identified by its role and condition expression. Updates carry the read policy etag, an etag conflict still detected after Retries read-modify-write cycles is an error
 package grm
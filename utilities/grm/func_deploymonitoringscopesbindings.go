@@ -0,0 +1,64 @@
+// Copyright 2020 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the 'License');
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an 'AS IS' BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package grm
+
+import (
+	"github.com/BrunoReboul/ram/utilities/deploy"
+	"github.com/BrunoReboul/ram/utilities/gfs"
+	"github.com/BrunoReboul/ram/utilities/solution"
+)
+
+// DeployMonitoringScopesBindings grants roles to a member on each monitored organization, folder and project
+func DeployMonitoringScopesBindings(core *deploy.Core, member string, roles []string, customRoles []string, conditions map[string]Condition) (err error) {
+	for _, scope := range core.SolutionSettings.GetMonitoringScopes() {
+		switch scope.Kind {
+		case solution.MonitoringScopeFolder:
+			folderBindingsDeployment := NewFolderBindingsDeployment()
+			folderBindingsDeployment.Core = core
+			folderBindingsDeployment.Settings.Roles = roles
+			folderBindingsDeployment.Settings.CustomRoles = customRoles
+			folderBindingsDeployment.Settings.Conditions = conditions
+			folderBindingsDeployment.Artifacts.FolderID = scope.ID
+			folderBindingsDeployment.Artifacts.OrganizationID = scope.OrganizationID
+			folderBindingsDeployment.Artifacts.Member = member
+			folderBindingsDeployment.Artifacts.Scope = gfs.IAMGrantsScopeMonitoring
+			err = folderBindingsDeployment.Deploy()
+		case solution.MonitoringScopeProject:
+			projectBindingsDeployment := NewProjectBindingsDeployment()
+			projectBindingsDeployment.Core = core
+			projectBindingsDeployment.Settings.Roles = roles
+			projectBindingsDeployment.Settings.CustomRoles = customRoles
+			projectBindingsDeployment.Settings.Conditions = conditions
+			projectBindingsDeployment.Artifacts.ProjectID = scope.ID
+			projectBindingsDeployment.Artifacts.Member = member
+			projectBindingsDeployment.Artifacts.Scope = gfs.IAMGrantsScopeMonitoring
+			err = projectBindingsDeployment.Deploy()
+		default:
+			orgBindingsDeployment := NewOrgBindingsDeployment()
+			orgBindingsDeployment.Core = core
+			orgBindingsDeployment.Settings.Roles = roles
+			orgBindingsDeployment.Settings.CustomRoles = customRoles
+			orgBindingsDeployment.Settings.Conditions = conditions
+			orgBindingsDeployment.Artifacts.OrganizationID = scope.ID
+			orgBindingsDeployment.Artifacts.Member = member
+			orgBindingsDeployment.Artifacts.Scope = gfs.IAMGrantsScopeMonitoring
+			err = orgBindingsDeployment.Deploy()
+		}
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
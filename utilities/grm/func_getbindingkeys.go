@@ -0,0 +1,24 @@
+// Copyright 2020 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the 'License');
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an 'AS IS' BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package grm
+
+// GetBindingKeys returns the binding keys of roles, each with its condition when set
+func GetBindingKeys(roles []string, conditions map[string]Condition) (bindingKeys []string) {
+	bindingKeys = make([]string, 0)
+	for _, role := range roles {
+		bindingKeys = append(bindingKeys, GetBindingKey(role, conditions[role].Expression))
+	}
+	return bindingKeys
+}
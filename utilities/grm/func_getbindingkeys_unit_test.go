@@ -0,0 +1,53 @@
+// Copyright 2020 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the 'License');
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an 'AS IS' BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package grm
+
+import (
+	"fmt"
+	"testing"
+)
+
+func TestUnitGetBindingKeys(t *testing.T) {
+	var testCases = []struct {
+		name       string
+		roles      []string
+		conditions map[string]Condition
+		want       string
+	}{
+		{
+			name:  "unconditional",
+			roles: []string{"roles/pubsub.publisher", "ramMonitor"},
+			want:  "[roles/pubsub.publisher ramMonitor]",
+		},
+		{
+			name:  "movedToConditional",
+			roles: []string{"roles/pubsub.publisher", "ramMonitor"},
+			conditions: map[string]Condition{
+				"roles/pubsub.publisher": {Expression: `resource.name.startsWith("projects/ram/topics/ram-")`},
+			},
+			want: `[roles/pubsub.publisher if resource.name.startsWith("projects/ram/topics/ram-") ramMonitor]`,
+		},
+	}
+	for _, tc := range testCases {
+		tc := tc // https://github.com/golang/go/wiki/CommonMistakes#using-goroutines-on-loop-iterator-variables
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+			got := fmt.Sprintf("%v", GetBindingKeys(tc.roles, tc.conditions))
+			if got != tc.want {
+				t.Errorf("Want '%s' got '%s'", tc.want, got)
+			}
+		})
+	}
+}
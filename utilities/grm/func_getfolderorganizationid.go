@@ -0,0 +1,39 @@
+// Copyright 2020 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the 'License');
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an 'AS IS' BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package grm
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/BrunoReboul/ram/utilities/deploy"
+)
+
+// GetFolderOrganizationID walks up the folder ancestors to find its organization
+func GetFolderOrganizationID(core *deploy.Core, folderID string) (organizationID string, err error) {
+	foldersService := core.Services.CloudresourcemanagerServicev2.Folders
+	name := fmt.Sprintf("folders/%s", folderID)
+	for strings.HasPrefix(name, "folders/") {
+		folder, err := foldersService.Get(name).Context(core.Ctx).Do()
+		if err != nil {
+			return "", fmt.Errorf("grm foldersService.Get(%s) %v", name, err)
+		}
+		name = folder.Parent
+	}
+	if !strings.HasPrefix(name, "organizations/") {
+		return "", fmt.Errorf("grm folder %s unexpected ancestor %s", folderID, name)
+	}
+	return strings.TrimPrefix(name, "organizations/"), nil
+}
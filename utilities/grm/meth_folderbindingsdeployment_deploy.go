@@ -0,0 +1,231 @@
+// Copyright 2020 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the 'License');
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an 'AS IS' BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package grm
+
+import (
+	"fmt"
+	"log"
+	"strings"
+	"time"
+
+	"github.com/BrunoReboul/ram/utilities/deploy"
+	"github.com/BrunoReboul/ram/utilities/gfs"
+	"github.com/BrunoReboul/ram/utilities/str"
+	cloudresourcemanagerv2 "google.golang.org/api/cloudresourcemanager/v2"
+)
+
+// Deploy use retries on a read-modify-write cycle
+// Custom roles are the ones of the folder organization, as folder level custom roles do not exist
+func (folderBindingsDeployment *FolderBindingsDeployment) Deploy() (err error) {
+	if folderBindingsDeployment.Artifacts.FolderID == "" {
+		return nil
+	}
+	if folderBindingsDeployment.Artifacts.OrganizationID == "" && len(folderBindingsDeployment.Settings.CustomRoles) > 0 {
+		return fmt.Errorf("grm unknown organization for folder %s, where custom roles %v are defined", folderBindingsDeployment.Artifacts.FolderID, folderBindingsDeployment.Settings.CustomRoles)
+	}
+	iamGrants, protectedRoles, err := gfs.GetIAMGrants(folderBindingsDeployment.Core, "grm folder bindings", fmt.Sprintf("folders/%s", folderBindingsDeployment.Artifacts.FolderID), folderBindingsDeployment.Artifacts.Member, folderBindingsDeployment.Artifacts.Scope, Retries)
+	if err != nil {
+		return err
+	}
+	if len(folderBindingsDeployment.Settings.Roles)+len(folderBindingsDeployment.Settings.CustomRoles)+len(iamGrants.Roles) > 0 {
+		log.Printf("%s grm folder bindings", folderBindingsDeployment.Core.InstanceName)
+		resourceReport := folderBindingsDeployment.Core.StartResourceReport("grm folder bindings", fmt.Sprintf("folders/%s", folderBindingsDeployment.Artifacts.FolderID))
+		defer resourceReport.End(&err)
+		foldersService := folderBindingsDeployment.Core.Services.CloudresourcemanagerServicev2.Folders
+		var managedRoles []string
+		for i := 0; i < Retries; i++ {
+			if i > 0 {
+				log.Printf("%s grm retrying a full read-modify-write cycle, iteration %d", folderBindingsDeployment.Core.InstanceName, i)
+			}
+			// READ
+			var policy *cloudresourcemanagerv2.Policy
+			var getPolicyOptions cloudresourcemanagerv2.GetPolicyOptions
+			var getRequest cloudresourcemanagerv2.GetIamPolicyRequest
+			getPolicyOptions.RequestedPolicyVersion = PolicyVersion
+			getRequest.Options = &getPolicyOptions
+			policy, err = foldersService.GetIamPolicy(fmt.Sprintf("folders/%s", folderBindingsDeployment.Artifacts.FolderID), &getRequest).Context(folderBindingsDeployment.Core.Ctx).Do()
+			if err != nil {
+				if strings.Contains(err.Error(), "403") {
+					// To not stop on missing folder permission, even if checking is not possible
+					log.Printf("%s grm WARNING impossible to check nor set folder iam policies due to insufficiant permissions on folder %s", folderBindingsDeployment.Core.InstanceName, folderBindingsDeployment.Artifacts.FolderID)
+					log.Printf("%s grm WARNING moving forward and assuming the required roles have been granted by another chanel on folder %s", folderBindingsDeployment.Core.InstanceName, folderBindingsDeployment.Artifacts.FolderID)
+					resourceReport.Action = deploy.ActionSkipped
+					return nil
+				}
+				return fmt.Errorf("grm ram cli foldersService.GetIamPolicy %v", err)
+			}
+			// MODIFY
+			resourceReport.Drift = nil
+			policyIsToBeUpdated := false
+			existingRoles := make([]string, 0)
+			for _, binding := range policy.Bindings {
+				var expression string
+				if binding.Condition != nil {
+					expression = binding.Condition.Expression
+				}
+				existingRoles = append(existingRoles, GetBindingKey(binding.Role, expression))
+				if str.Find(folderBindingsDeployment.Settings.Roles, binding.Role) && folderBindingsDeployment.Settings.Conditions[binding.Role].Expression == expression {
+					isAlreadyMemberOf := false
+					for _, member := range binding.Members {
+						if member == folderBindingsDeployment.Artifacts.Member {
+							isAlreadyMemberOf = true
+						}
+					}
+					if isAlreadyMemberOf {
+						log.Printf("%s grm member %s already have %s on folder %s", folderBindingsDeployment.Core.InstanceName, folderBindingsDeployment.Artifacts.Member, binding.Role, folderBindingsDeployment.Artifacts.FolderID)
+					} else {
+						log.Printf("%s grm add member %s to existing %s on folder %s", folderBindingsDeployment.Core.InstanceName, folderBindingsDeployment.Artifacts.Member, binding.Role, folderBindingsDeployment.Artifacts.FolderID)
+						resourceReport.Drift = append(resourceReport.Drift, fmt.Sprintf("+%s %s", binding.Role, folderBindingsDeployment.Artifacts.Member))
+						binding.Members = append(binding.Members, folderBindingsDeployment.Artifacts.Member)
+						policyIsToBeUpdated = true
+					}
+				}
+				parts := strings.Split(binding.Role, "/")
+				customRole := parts[len(parts)-1]
+				if str.Find(folderBindingsDeployment.Settings.CustomRoles, customRole) && folderBindingsDeployment.Settings.Conditions[customRole].Expression == expression {
+					isAlreadyMemberOf := false
+					for _, member := range binding.Members {
+						if member == folderBindingsDeployment.Artifacts.Member {
+							isAlreadyMemberOf = true
+						}
+					}
+					if isAlreadyMemberOf {
+						log.Printf("%s grm member %s already have %s on folder %s", folderBindingsDeployment.Core.InstanceName, folderBindingsDeployment.Artifacts.Member, customRole, folderBindingsDeployment.Artifacts.FolderID)
+					} else {
+						log.Printf("%s grm add member %s to existing %s on folder %s", folderBindingsDeployment.Core.InstanceName, folderBindingsDeployment.Artifacts.Member, customRole, folderBindingsDeployment.Artifacts.FolderID)
+						resourceReport.Drift = append(resourceReport.Drift, fmt.Sprintf("+%s %s", customRole, folderBindingsDeployment.Artifacts.Member))
+						binding.Members = append(binding.Members, folderBindingsDeployment.Artifacts.Member)
+						policyIsToBeUpdated = true
+					}
+				}
+			}
+			for _, role := range folderBindingsDeployment.Settings.Roles {
+				if !str.Find(existingRoles, GetBindingKey(role, folderBindingsDeployment.Settings.Conditions[role].Expression)) {
+					var binding cloudresourcemanagerv2.Binding
+					binding.Role = role
+					binding.Members = []string{folderBindingsDeployment.Artifacts.Member}
+					if condition, ok := folderBindingsDeployment.Settings.Conditions[role]; ok && condition.Expression != "" {
+						binding.Condition = &cloudresourcemanagerv2.Expr{
+							Title:       condition.Title,
+							Description: condition.Description,
+							Expression:  condition.Expression,
+						}
+					}
+					log.Printf("%s grm add new %s with solo member %s on folder %s", folderBindingsDeployment.Core.InstanceName, binding.Role, folderBindingsDeployment.Artifacts.Member, folderBindingsDeployment.Artifacts.FolderID)
+					resourceReport.Drift = append(resourceReport.Drift, fmt.Sprintf("+%s %s", binding.Role, folderBindingsDeployment.Artifacts.Member))
+					policy.Bindings = append(policy.Bindings, &binding)
+					policyIsToBeUpdated = true
+				}
+			}
+			for _, customRole := range folderBindingsDeployment.Settings.CustomRoles {
+				role := fmt.Sprintf("organizations/%s/roles/%s", folderBindingsDeployment.Artifacts.OrganizationID, customRole)
+				if !str.Find(existingRoles, GetBindingKey(role, folderBindingsDeployment.Settings.Conditions[customRole].Expression)) {
+					var binding cloudresourcemanagerv2.Binding
+					binding.Role = role
+					binding.Members = []string{folderBindingsDeployment.Artifacts.Member}
+					if condition, ok := folderBindingsDeployment.Settings.Conditions[customRole]; ok && condition.Expression != "" {
+						binding.Condition = &cloudresourcemanagerv2.Expr{
+							Title:       condition.Title,
+							Description: condition.Description,
+							Expression:  condition.Expression,
+						}
+					}
+					log.Printf("%s grm add new %s with solo member %s on folder %s", folderBindingsDeployment.Core.InstanceName, binding.Role, folderBindingsDeployment.Artifacts.Member, folderBindingsDeployment.Artifacts.FolderID)
+					resourceReport.Drift = append(resourceReport.Drift, fmt.Sprintf("+%s %s", binding.Role, folderBindingsDeployment.Artifacts.Member))
+					policy.Bindings = append(policy.Bindings, &binding)
+					policyIsToBeUpdated = true
+				}
+			}
+			// STALE roles recorded as granted by RAM, no longer wanted
+			wantedRoles := GetBindingKeys(folderBindingsDeployment.Settings.Roles, folderBindingsDeployment.Settings.Conditions)
+			wantedCustomRoles := GetBindingKeys(folderBindingsDeployment.Settings.CustomRoles, folderBindingsDeployment.Settings.Conditions)
+			staleRoles := iamGrants.GetStaleRoles(wantedRoles, wantedCustomRoles, protectedRoles)
+			managedRoles = make([]string, 0)
+			for _, binding := range policy.Bindings {
+				if !str.Find(binding.Members, folderBindingsDeployment.Artifacts.Member) {
+					continue
+				}
+				var expression string
+				if binding.Condition != nil {
+					expression = binding.Condition.Expression
+				}
+				bindingKey := GetBindingKey(binding.Role, expression)
+				if str.Find(staleRoles, bindingKey) {
+					resourceReport.Drift = append(resourceReport.Drift, fmt.Sprintf("-%s %s", bindingKey, folderBindingsDeployment.Artifacts.Member))
+					if folderBindingsDeployment.Core.Commands.PruneIAM && !folderBindingsDeployment.Core.Commands.Check {
+						log.Printf("%s grm remove member %s from stale %s on folder %s", folderBindingsDeployment.Core.InstanceName, folderBindingsDeployment.Artifacts.Member, bindingKey, folderBindingsDeployment.Artifacts.FolderID)
+						binding.Members = str.Remove(binding.Members, folderBindingsDeployment.Artifacts.Member)
+						policyIsToBeUpdated = true
+						continue
+					}
+					log.Printf("%s grm WARNING member %s still have stale %s on folder %s, use -prune-iam to remove it", folderBindingsDeployment.Core.InstanceName, folderBindingsDeployment.Artifacts.Member, bindingKey, folderBindingsDeployment.Artifacts.FolderID)
+				} else if !gfs.IsWantedRole(bindingKey, wantedRoles, wantedCustomRoles) && !str.Find(iamGrants.Roles, bindingKey) {
+					// Not granted by RAM
+					continue
+				}
+				managedRoles = append(managedRoles, bindingKey)
+			}
+			keptBindings := policy.Bindings[:0]
+			for _, binding := range policy.Bindings {
+				if len(binding.Members) > 0 {
+					keptBindings = append(keptBindings, binding)
+				}
+			}
+			policy.Bindings = keptBindings
+			if folderBindingsDeployment.Core.Commands.Check {
+				if len(resourceReport.Drift) > 0 {
+					return fmt.Errorf("%s grm invalid folder bindings, + missing - stale:\n%s", folderBindingsDeployment.Core.InstanceName, strings.Join(resourceReport.Drift, "\n"))
+				}
+				return nil
+			}
+			// WRITE
+			if policyIsToBeUpdated {
+				policy.Version = PolicyVersion
+				var setRequest cloudresourcemanagerv2.SetIamPolicyRequest
+				setRequest.Policy = policy
+
+				var updatedPolicy *cloudresourcemanagerv2.Policy
+				updatedPolicy, err = foldersService.SetIamPolicy(fmt.Sprintf("folders/%s", folderBindingsDeployment.Artifacts.FolderID), &setRequest).Context(folderBindingsDeployment.Core.Ctx).Do()
+				if err != nil {
+					if !strings.Contains(err.Error(), "There were concurrent policy changes") {
+						return fmt.Errorf("foldersService.SetIamPolicy %v", err)
+					}
+					log.Printf("%s grm there were concurrent policy changes, wait 5 sec and retry a full read-modify-write cycle, iteration %d", folderBindingsDeployment.Core.InstanceName, i)
+					time.Sleep(5 * time.Second)
+				} else {
+					// ffo.JSONMarshalIndentPrint(updatedPolicy)
+					_ = updatedPolicy
+					resourceReport.Action = deploy.ActionUpdated
+					log.Printf("%s grm folder policy updated for %s iteration %d", folderBindingsDeployment.Core.InstanceName, folderBindingsDeployment.Artifacts.FolderID, i)
+					break
+				}
+			} else {
+				log.Printf("%s grm NO need to update folder policy for %s", folderBindingsDeployment.Core.InstanceName, folderBindingsDeployment.Artifacts.FolderID)
+				break
+			}
+		}
+		if err != nil {
+			if strings.Contains(err.Error(), "There were concurrent policy changes") {
+				return fmt.Errorf("grm folder %s iam policy etag conflict, concurrent policy changes still detected after %d read-modify-write cycles %v", folderBindingsDeployment.Artifacts.FolderID, Retries, err)
+			}
+			return err
+		}
+		iamGrants.Roles = managedRoles
+		if err = gfs.RecordIAMGrants(folderBindingsDeployment.Core, iamGrants, Retries); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -18,6 +18,8 @@ import (
 	"fmt"
 	"log"
 	"strings"
+
+	"github.com/BrunoReboul/ram/utilities/deploy"
 )
 
 // Deploy FolderDeployment for now, only check the folder exist and is ACTIVE: It does NOT create the folder.
@@ -25,10 +27,13 @@ func (folderDeployment *FolderDeployment) Deploy() (err error) {
 	log.Printf("%s grm resource manager folder", folderDeployment.Core.InstanceName)
 	foldersService := folderDeployment.Core.Services.CloudresourcemanagerServicev2.Folders
 	folderName := fmt.Sprintf("folders/%s", folderDeployment.Core.SolutionSettings.Hosting.FolderID)
+	resourceReport := folderDeployment.Core.StartResourceReport("grm folder", folderName)
+	defer resourceReport.End(&err)
 	folder, err := foldersService.Get(folderName).Context(folderDeployment.Core.Ctx).Do()
 	if err != nil {
 		if strings.Contains(err.Error(), "403") {
 			log.Printf("%s grm WARNING impossible to GET folder %v", folderDeployment.Core.InstanceName, err)
+			resourceReport.Action = deploy.ActionSkipped
 			return nil
 		}
 		return fmt.Errorf("grm foldersService.Get(folderName) %v", err)
@@ -20,6 +20,8 @@ import (
 	"strings"
 	"time"
 
+	"github.com/BrunoReboul/ram/utilities/deploy"
+	"github.com/BrunoReboul/ram/utilities/gfs"
 	"github.com/BrunoReboul/ram/utilities/str"
 	"google.golang.org/api/cloudresourcemanager/v1"
 )
@@ -27,11 +29,24 @@ import (
 // Retries is the max number of read-modify-write cycles in case of concurrent policy changes detection
 const Retries = 5
 
+// PolicyVersion is the IAM policy version requested and set, version 3 is required to read and write conditional bindings
+const PolicyVersion = 3
+
 // Deploy use retries on a read-modify-write cycle
 func (orgBindingsDeployment *OrgBindingsDeployment) Deploy() (err error) {
-	if len(orgBindingsDeployment.Settings.Roles)+len(orgBindingsDeployment.Settings.CustomRoles) > 0 && orgBindingsDeployment.Artifacts.OrganizationID != "" {
+	if orgBindingsDeployment.Artifacts.OrganizationID == "" {
+		return nil
+	}
+	iamGrants, protectedRoles, err := gfs.GetIAMGrants(orgBindingsDeployment.Core, "grm organization bindings", fmt.Sprintf("organizations/%s", orgBindingsDeployment.Artifacts.OrganizationID), orgBindingsDeployment.Artifacts.Member, orgBindingsDeployment.Artifacts.Scope, Retries)
+	if err != nil {
+		return err
+	}
+	if len(orgBindingsDeployment.Settings.Roles)+len(orgBindingsDeployment.Settings.CustomRoles)+len(iamGrants.Roles) > 0 {
 		log.Printf("%s grm organization bindings", orgBindingsDeployment.Core.InstanceName)
+		resourceReport := orgBindingsDeployment.Core.StartResourceReport("grm organization bindings", fmt.Sprintf("organizations/%s", orgBindingsDeployment.Artifacts.OrganizationID))
+		defer resourceReport.End(&err)
 		organizationsService := orgBindingsDeployment.Core.Services.CloudresourcemanagerService.Organizations
+		var managedRoles []string
 		for i := 0; i < Retries; i++ {
 			if i > 0 {
 				log.Printf("%s grm retrying a full read-modify-write cycle, iteration %d", orgBindingsDeployment.Core.InstanceName, i)
@@ -40,6 +55,7 @@ func (orgBindingsDeployment *OrgBindingsDeployment) Deploy() (err error) {
 			var policy *cloudresourcemanager.Policy
 			var getPolicyOptions cloudresourcemanager.GetPolicyOptions
 			var getRequest cloudresourcemanager.GetIamPolicyRequest
+			getPolicyOptions.RequestedPolicyVersion = PolicyVersion
 			getRequest.Options = &getPolicyOptions
 			policy, err = organizationsService.GetIamPolicy(fmt.Sprintf("organizations/%s", orgBindingsDeployment.Artifacts.OrganizationID), &getRequest).Context(orgBindingsDeployment.Core.Ctx).Do()
 			if err != nil {
@@ -47,16 +63,22 @@ func (orgBindingsDeployment *OrgBindingsDeployment) Deploy() (err error) {
 					// To not stop on missing org permission, even if checking is not possible
 					log.Printf("%s grm WARNING impossible to check nor set organization iam policies due to insufficiant permissions on organization %s", orgBindingsDeployment.Core.InstanceName, orgBindingsDeployment.Artifacts.OrganizationID)
 					log.Printf("%s grm WARNING moving forward and assuming the required roles have been granted by another chanel on organization %s", orgBindingsDeployment.Core.InstanceName, orgBindingsDeployment.Artifacts.OrganizationID)
+					resourceReport.Action = deploy.ActionSkipped
 					return nil
 				}
 				return fmt.Errorf("grm ram cli organizationsService.GetIamPolicy %v", err)
 			}
 			// MODIFY
+			resourceReport.Drift = nil
 			policyIsToBeUpdated := false
 			existingRoles := make([]string, 0)
 			for _, binding := range policy.Bindings {
-				existingRoles = append(existingRoles, binding.Role)
-				if str.Find(orgBindingsDeployment.Settings.Roles, binding.Role) {
+				var expression string
+				if binding.Condition != nil {
+					expression = binding.Condition.Expression
+				}
+				existingRoles = append(existingRoles, GetBindingKey(binding.Role, expression))
+				if str.Find(orgBindingsDeployment.Settings.Roles, binding.Role) && orgBindingsDeployment.Settings.Conditions[binding.Role].Expression == expression {
 					isAlreadyMemberOf := false
 					for _, member := range binding.Members {
 						if member == orgBindingsDeployment.Artifacts.Member {
@@ -67,13 +89,14 @@ func (orgBindingsDeployment *OrgBindingsDeployment) Deploy() (err error) {
 						log.Printf("%s grm member %s already have %s on organization %s", orgBindingsDeployment.Core.InstanceName, orgBindingsDeployment.Artifacts.Member, binding.Role, orgBindingsDeployment.Artifacts.OrganizationID)
 					} else {
 						log.Printf("%s grm add member %s to existing %s on organization %s", orgBindingsDeployment.Core.InstanceName, orgBindingsDeployment.Artifacts.Member, binding.Role, orgBindingsDeployment.Artifacts.OrganizationID)
+						resourceReport.Drift = append(resourceReport.Drift, fmt.Sprintf("+%s %s", binding.Role, orgBindingsDeployment.Artifacts.Member))
 						binding.Members = append(binding.Members, orgBindingsDeployment.Artifacts.Member)
 						policyIsToBeUpdated = true
 					}
 				}
 				parts := strings.Split(binding.Role, "/")
 				customRole := parts[len(parts)-1]
-				if str.Find(orgBindingsDeployment.Settings.CustomRoles, customRole) {
+				if str.Find(orgBindingsDeployment.Settings.CustomRoles, customRole) && orgBindingsDeployment.Settings.Conditions[customRole].Expression == expression {
 					isAlreadyMemberOf := false
 					for _, member := range binding.Members {
 						if member == orgBindingsDeployment.Artifacts.Member {
@@ -84,34 +107,94 @@ func (orgBindingsDeployment *OrgBindingsDeployment) Deploy() (err error) {
 						log.Printf("%s grm member %s already have %s on organization %s", orgBindingsDeployment.Core.InstanceName, orgBindingsDeployment.Artifacts.Member, customRole, orgBindingsDeployment.Artifacts.OrganizationID)
 					} else {
 						log.Printf("%s grm add member %s to existing %s on organization %s", orgBindingsDeployment.Core.InstanceName, orgBindingsDeployment.Artifacts.Member, customRole, orgBindingsDeployment.Artifacts.OrganizationID)
+						resourceReport.Drift = append(resourceReport.Drift, fmt.Sprintf("+%s %s", customRole, orgBindingsDeployment.Artifacts.Member))
 						binding.Members = append(binding.Members, orgBindingsDeployment.Artifacts.Member)
 						policyIsToBeUpdated = true
 					}
 				}
 			}
 			for _, role := range orgBindingsDeployment.Settings.Roles {
-				if !str.Find(existingRoles, role) {
+				if !str.Find(existingRoles, GetBindingKey(role, orgBindingsDeployment.Settings.Conditions[role].Expression)) {
 					var binding cloudresourcemanager.Binding
 					binding.Role = role
 					binding.Members = []string{orgBindingsDeployment.Artifacts.Member}
+					if condition, ok := orgBindingsDeployment.Settings.Conditions[role]; ok && condition.Expression != "" {
+						binding.Condition = &cloudresourcemanager.Expr{
+							Title:       condition.Title,
+							Description: condition.Description,
+							Expression:  condition.Expression,
+						}
+					}
 					log.Printf("%s grm add new %s with solo member %s on organization %s", orgBindingsDeployment.Core.InstanceName, binding.Role, orgBindingsDeployment.Artifacts.Member, orgBindingsDeployment.Artifacts.OrganizationID)
+					resourceReport.Drift = append(resourceReport.Drift, fmt.Sprintf("+%s %s", binding.Role, orgBindingsDeployment.Artifacts.Member))
 					policy.Bindings = append(policy.Bindings, &binding)
 					policyIsToBeUpdated = true
 				}
 			}
 			for _, customRole := range orgBindingsDeployment.Settings.CustomRoles {
 				role := fmt.Sprintf("organizations/%s/roles/%s", orgBindingsDeployment.Artifacts.OrganizationID, customRole)
-				if !str.Find(existingRoles, role) {
+				if !str.Find(existingRoles, GetBindingKey(role, orgBindingsDeployment.Settings.Conditions[customRole].Expression)) {
 					var binding cloudresourcemanager.Binding
 					binding.Role = role
 					binding.Members = []string{orgBindingsDeployment.Artifacts.Member}
+					if condition, ok := orgBindingsDeployment.Settings.Conditions[customRole]; ok && condition.Expression != "" {
+						binding.Condition = &cloudresourcemanager.Expr{
+							Title:       condition.Title,
+							Description: condition.Description,
+							Expression:  condition.Expression,
+						}
+					}
 					log.Printf("%s grm add new %s with solo member %s on organization %s", orgBindingsDeployment.Core.InstanceName, binding.Role, orgBindingsDeployment.Artifacts.Member, orgBindingsDeployment.Artifacts.OrganizationID)
+					resourceReport.Drift = append(resourceReport.Drift, fmt.Sprintf("+%s %s", binding.Role, orgBindingsDeployment.Artifacts.Member))
 					policy.Bindings = append(policy.Bindings, &binding)
 					policyIsToBeUpdated = true
 				}
 			}
+			// STALE roles recorded as granted by RAM, no longer wanted
+			wantedRoles := GetBindingKeys(orgBindingsDeployment.Settings.Roles, orgBindingsDeployment.Settings.Conditions)
+			wantedCustomRoles := GetBindingKeys(orgBindingsDeployment.Settings.CustomRoles, orgBindingsDeployment.Settings.Conditions)
+			staleRoles := iamGrants.GetStaleRoles(wantedRoles, wantedCustomRoles, protectedRoles)
+			managedRoles = make([]string, 0)
+			for _, binding := range policy.Bindings {
+				if !str.Find(binding.Members, orgBindingsDeployment.Artifacts.Member) {
+					continue
+				}
+				var expression string
+				if binding.Condition != nil {
+					expression = binding.Condition.Expression
+				}
+				bindingKey := GetBindingKey(binding.Role, expression)
+				if str.Find(staleRoles, bindingKey) {
+					resourceReport.Drift = append(resourceReport.Drift, fmt.Sprintf("-%s %s", bindingKey, orgBindingsDeployment.Artifacts.Member))
+					if orgBindingsDeployment.Core.Commands.PruneIAM && !orgBindingsDeployment.Core.Commands.Check {
+						log.Printf("%s grm remove member %s from stale %s on organization %s", orgBindingsDeployment.Core.InstanceName, orgBindingsDeployment.Artifacts.Member, bindingKey, orgBindingsDeployment.Artifacts.OrganizationID)
+						binding.Members = str.Remove(binding.Members, orgBindingsDeployment.Artifacts.Member)
+						policyIsToBeUpdated = true
+						continue
+					}
+					log.Printf("%s grm WARNING member %s still have stale %s on organization %s, use -prune-iam to remove it", orgBindingsDeployment.Core.InstanceName, orgBindingsDeployment.Artifacts.Member, bindingKey, orgBindingsDeployment.Artifacts.OrganizationID)
+				} else if !gfs.IsWantedRole(bindingKey, wantedRoles, wantedCustomRoles) && !str.Find(iamGrants.Roles, bindingKey) {
+					// Not granted by RAM
+					continue
+				}
+				managedRoles = append(managedRoles, bindingKey)
+			}
+			keptBindings := policy.Bindings[:0]
+			for _, binding := range policy.Bindings {
+				if len(binding.Members) > 0 {
+					keptBindings = append(keptBindings, binding)
+				}
+			}
+			policy.Bindings = keptBindings
+			if orgBindingsDeployment.Core.Commands.Check {
+				if len(resourceReport.Drift) > 0 {
+					return fmt.Errorf("%s grm invalid organization bindings, + missing - stale:\n%s", orgBindingsDeployment.Core.InstanceName, strings.Join(resourceReport.Drift, "\n"))
+				}
+				return nil
+			}
 			// WRITE
 			if policyIsToBeUpdated {
+				policy.Version = PolicyVersion
 				var setRequest cloudresourcemanager.SetIamPolicyRequest
 				setRequest.Policy = policy
 
@@ -126,6 +209,7 @@ func (orgBindingsDeployment *OrgBindingsDeployment) Deploy() (err error) {
 				} else {
 					// ffo.JSONMarshalIndentPrint(updatedPolicy)
 					_ = updatedPolicy
+					resourceReport.Action = deploy.ActionUpdated
 					log.Printf("%s grm organization policy updated for %s iteration %d", orgBindingsDeployment.Core.InstanceName, orgBindingsDeployment.Artifacts.OrganizationID, i)
 					break
 				}
@@ -135,6 +219,13 @@ func (orgBindingsDeployment *OrgBindingsDeployment) Deploy() (err error) {
 			}
 		}
 		if err != nil {
+			if strings.Contains(err.Error(), "There were concurrent policy changes") {
+				return fmt.Errorf("grm organization %s iam policy etag conflict, concurrent policy changes still detected after %d read-modify-write cycles %v", orgBindingsDeployment.Artifacts.OrganizationID, Retries, err)
+			}
+			return err
+		}
+		iamGrants.Roles = managedRoles
+		if err = gfs.RecordIAMGrants(orgBindingsDeployment.Core, iamGrants, Retries); err != nil {
 			return err
 		}
 	}
@@ -20,15 +20,27 @@ import (
 	"strings"
 	"time"
 
+	"github.com/BrunoReboul/ram/utilities/deploy"
+	"github.com/BrunoReboul/ram/utilities/gfs"
 	"github.com/BrunoReboul/ram/utilities/str"
 	"google.golang.org/api/cloudresourcemanager/v1"
 )
 
 // Deploy use retries on a read-modify-write cycle
 func (projectBindingsDeployment *ProjectBindingsDeployment) Deploy() (err error) {
-	if len(projectBindingsDeployment.Settings.Roles)+len(projectBindingsDeployment.Settings.CustomRoles) > 0 && projectBindingsDeployment.Artifacts.ProjectID != "" {
+	if projectBindingsDeployment.Artifacts.ProjectID == "" {
+		return nil
+	}
+	iamGrants, protectedRoles, err := gfs.GetIAMGrants(projectBindingsDeployment.Core, "grm project bindings", fmt.Sprintf("projects/%s", projectBindingsDeployment.Artifacts.ProjectID), projectBindingsDeployment.Artifacts.Member, projectBindingsDeployment.Artifacts.Scope, Retries)
+	if err != nil {
+		return err
+	}
+	if len(projectBindingsDeployment.Settings.Roles)+len(projectBindingsDeployment.Settings.CustomRoles)+len(iamGrants.Roles) > 0 {
 		log.Printf("%s grm project bindings", projectBindingsDeployment.Core.InstanceName)
+		resourceReport := projectBindingsDeployment.Core.StartResourceReport("grm project bindings", fmt.Sprintf("projects/%s", projectBindingsDeployment.Artifacts.ProjectID))
+		defer resourceReport.End(&err)
 		projectsService := projectBindingsDeployment.Core.Services.CloudresourcemanagerService.Projects
+		var managedRoles []string
 		for i := 0; i < Retries; i++ {
 			if i > 0 {
 				log.Printf("%s grm retrying a full read-modify-write cycle, iteration %d", projectBindingsDeployment.Core.InstanceName, i)
@@ -37,21 +49,28 @@ func (projectBindingsDeployment *ProjectBindingsDeployment) Deploy() (err error)
 			var policy *cloudresourcemanager.Policy
 			var getPolicyOptions cloudresourcemanager.GetPolicyOptions
 			var getRequest cloudresourcemanager.GetIamPolicyRequest
+			getPolicyOptions.RequestedPolicyVersion = PolicyVersion
 			getRequest.Options = &getPolicyOptions
 			policy, err = projectsService.GetIamPolicy(projectBindingsDeployment.Artifacts.ProjectID, &getRequest).Context(projectBindingsDeployment.Core.Ctx).Do()
 			if err != nil {
 				if strings.Contains(err.Error(), "403") {
 					log.Printf("%s grm WARNING impossible to GET project iam policy %v", projectBindingsDeployment.Core.InstanceName, err)
+					resourceReport.Action = deploy.ActionSkipped
 					return nil
 				}
 				return fmt.Errorf("grm projectsService.GetIamPolicy %s", err)
 			}
 			// MODIFY
+			resourceReport.Drift = nil
 			policyIsToBeUpdated := false
 			existingRoles := make([]string, 0)
 			for _, binding := range policy.Bindings {
-				existingRoles = append(existingRoles, binding.Role)
-				if str.Find(projectBindingsDeployment.Settings.Roles, binding.Role) {
+				var expression string
+				if binding.Condition != nil {
+					expression = binding.Condition.Expression
+				}
+				existingRoles = append(existingRoles, GetBindingKey(binding.Role, expression))
+				if str.Find(projectBindingsDeployment.Settings.Roles, binding.Role) && projectBindingsDeployment.Settings.Conditions[binding.Role].Expression == expression {
 					isAlreadyMemberOf := false
 					for _, member := range binding.Members {
 						if member == projectBindingsDeployment.Artifacts.Member {
@@ -62,13 +81,14 @@ func (projectBindingsDeployment *ProjectBindingsDeployment) Deploy() (err error)
 						log.Printf("%s grm member %s already have %s on project %s", projectBindingsDeployment.Core.InstanceName, projectBindingsDeployment.Artifacts.Member, binding.Role, projectBindingsDeployment.Artifacts.ProjectID)
 					} else {
 						log.Printf("%s grm add member %s to existing %s on project %s", projectBindingsDeployment.Core.InstanceName, projectBindingsDeployment.Artifacts.Member, binding.Role, projectBindingsDeployment.Artifacts.ProjectID)
+						resourceReport.Drift = append(resourceReport.Drift, fmt.Sprintf("+%s %s", binding.Role, projectBindingsDeployment.Artifacts.Member))
 						binding.Members = append(binding.Members, projectBindingsDeployment.Artifacts.Member)
 						policyIsToBeUpdated = true
 					}
 				}
 				parts := strings.Split(binding.Role, "/")
 				customRole := parts[len(parts)-1]
-				if str.Find(projectBindingsDeployment.Settings.CustomRoles, customRole) {
+				if str.Find(projectBindingsDeployment.Settings.CustomRoles, customRole) && projectBindingsDeployment.Settings.Conditions[customRole].Expression == expression {
 					isAlreadyMemberOf := false
 					for _, member := range binding.Members {
 						if member == projectBindingsDeployment.Artifacts.Member {
@@ -79,34 +99,94 @@ func (projectBindingsDeployment *ProjectBindingsDeployment) Deploy() (err error)
 						log.Printf("%s grm member %s already have %s on project %s", projectBindingsDeployment.Core.InstanceName, projectBindingsDeployment.Artifacts.Member, customRole, projectBindingsDeployment.Artifacts.ProjectID)
 					} else {
 						log.Printf("%s grm add member %s to existing %s on project %s", projectBindingsDeployment.Core.InstanceName, projectBindingsDeployment.Artifacts.Member, customRole, projectBindingsDeployment.Artifacts.ProjectID)
+						resourceReport.Drift = append(resourceReport.Drift, fmt.Sprintf("+%s %s", customRole, projectBindingsDeployment.Artifacts.Member))
 						binding.Members = append(binding.Members, projectBindingsDeployment.Artifacts.Member)
 						policyIsToBeUpdated = true
 					}
 				}
 			}
 			for _, role := range projectBindingsDeployment.Settings.Roles {
-				if !str.Find(existingRoles, role) {
+				if !str.Find(existingRoles, GetBindingKey(role, projectBindingsDeployment.Settings.Conditions[role].Expression)) {
 					var binding cloudresourcemanager.Binding
 					binding.Role = role
 					binding.Members = []string{projectBindingsDeployment.Artifacts.Member}
+					if condition, ok := projectBindingsDeployment.Settings.Conditions[role]; ok && condition.Expression != "" {
+						binding.Condition = &cloudresourcemanager.Expr{
+							Title:       condition.Title,
+							Description: condition.Description,
+							Expression:  condition.Expression,
+						}
+					}
 					log.Printf("%s grm add new %s with solo member %s on project %s", projectBindingsDeployment.Core.InstanceName, binding.Role, projectBindingsDeployment.Artifacts.Member, projectBindingsDeployment.Artifacts.ProjectID)
+					resourceReport.Drift = append(resourceReport.Drift, fmt.Sprintf("+%s %s", binding.Role, projectBindingsDeployment.Artifacts.Member))
 					policy.Bindings = append(policy.Bindings, &binding)
 					policyIsToBeUpdated = true
 				}
 			}
 			for _, customRole := range projectBindingsDeployment.Settings.CustomRoles {
 				role := fmt.Sprintf("projects/%s/roles/%s", projectBindingsDeployment.Artifacts.ProjectID, customRole)
-				if !str.Find(existingRoles, role) {
+				if !str.Find(existingRoles, GetBindingKey(role, projectBindingsDeployment.Settings.Conditions[customRole].Expression)) {
 					var binding cloudresourcemanager.Binding
 					binding.Role = role
 					binding.Members = []string{projectBindingsDeployment.Artifacts.Member}
+					if condition, ok := projectBindingsDeployment.Settings.Conditions[customRole]; ok && condition.Expression != "" {
+						binding.Condition = &cloudresourcemanager.Expr{
+							Title:       condition.Title,
+							Description: condition.Description,
+							Expression:  condition.Expression,
+						}
+					}
 					log.Printf("%s grm add new %s with solo member %s on project %s", projectBindingsDeployment.Core.InstanceName, binding.Role, projectBindingsDeployment.Artifacts.Member, projectBindingsDeployment.Artifacts.ProjectID)
+					resourceReport.Drift = append(resourceReport.Drift, fmt.Sprintf("+%s %s", binding.Role, projectBindingsDeployment.Artifacts.Member))
 					policy.Bindings = append(policy.Bindings, &binding)
 					policyIsToBeUpdated = true
 				}
 			}
+			// STALE roles recorded as granted by RAM, no longer wanted
+			wantedRoles := GetBindingKeys(projectBindingsDeployment.Settings.Roles, projectBindingsDeployment.Settings.Conditions)
+			wantedCustomRoles := GetBindingKeys(projectBindingsDeployment.Settings.CustomRoles, projectBindingsDeployment.Settings.Conditions)
+			staleRoles := iamGrants.GetStaleRoles(wantedRoles, wantedCustomRoles, protectedRoles)
+			managedRoles = make([]string, 0)
+			for _, binding := range policy.Bindings {
+				if !str.Find(binding.Members, projectBindingsDeployment.Artifacts.Member) {
+					continue
+				}
+				var expression string
+				if binding.Condition != nil {
+					expression = binding.Condition.Expression
+				}
+				bindingKey := GetBindingKey(binding.Role, expression)
+				if str.Find(staleRoles, bindingKey) {
+					resourceReport.Drift = append(resourceReport.Drift, fmt.Sprintf("-%s %s", bindingKey, projectBindingsDeployment.Artifacts.Member))
+					if projectBindingsDeployment.Core.Commands.PruneIAM && !projectBindingsDeployment.Core.Commands.Check {
+						log.Printf("%s grm remove member %s from stale %s on project %s", projectBindingsDeployment.Core.InstanceName, projectBindingsDeployment.Artifacts.Member, bindingKey, projectBindingsDeployment.Artifacts.ProjectID)
+						binding.Members = str.Remove(binding.Members, projectBindingsDeployment.Artifacts.Member)
+						policyIsToBeUpdated = true
+						continue
+					}
+					log.Printf("%s grm WARNING member %s still have stale %s on project %s, use -prune-iam to remove it", projectBindingsDeployment.Core.InstanceName, projectBindingsDeployment.Artifacts.Member, bindingKey, projectBindingsDeployment.Artifacts.ProjectID)
+				} else if !gfs.IsWantedRole(bindingKey, wantedRoles, wantedCustomRoles) && !str.Find(iamGrants.Roles, bindingKey) {
+					// Not granted by RAM
+					continue
+				}
+				managedRoles = append(managedRoles, bindingKey)
+			}
+			keptBindings := policy.Bindings[:0]
+			for _, binding := range policy.Bindings {
+				if len(binding.Members) > 0 {
+					keptBindings = append(keptBindings, binding)
+				}
+			}
+			policy.Bindings = keptBindings
+			if projectBindingsDeployment.Core.Commands.Check {
+				if len(resourceReport.Drift) > 0 {
+					return fmt.Errorf("%s grm invalid project bindings, + missing - stale:\n%s", projectBindingsDeployment.Core.InstanceName, strings.Join(resourceReport.Drift, "\n"))
+				}
+				return nil
+			}
 			// WRITE
 			if policyIsToBeUpdated {
+				policy.Version = PolicyVersion
 				var setRequest cloudresourcemanager.SetIamPolicyRequest
 				setRequest.Policy = policy
 
@@ -116,6 +196,7 @@ func (projectBindingsDeployment *ProjectBindingsDeployment) Deploy() (err error)
 					if !strings.Contains(err.Error(), "There were concurrent policy changes") {
 						if strings.Contains(err.Error(), "403") {
 							log.Printf("%s grm WARNING impossible to SET project iam policy %v", projectBindingsDeployment.Core.InstanceName, err)
+							resourceReport.Action = deploy.ActionSkipped
 							return nil
 						}
 						return fmt.Errorf("grm projectsService.SetIamPolicy %s", err)
@@ -125,6 +206,7 @@ func (projectBindingsDeployment *ProjectBindingsDeployment) Deploy() (err error)
 				} else {
 					// ffo.JSONMarshalIndentPrint(updatedPolicy)
 					_ = updatedPolicy
+					resourceReport.Action = deploy.ActionUpdated
 					log.Printf("%s grm project policy updated for %s iteration %d", projectBindingsDeployment.Core.InstanceName, projectBindingsDeployment.Artifacts.ProjectID, i)
 					break
 				}
@@ -133,6 +215,16 @@ func (projectBindingsDeployment *ProjectBindingsDeployment) Deploy() (err error)
 				break
 			}
 		}
+		if err != nil {
+			if strings.Contains(err.Error(), "There were concurrent policy changes") {
+				return fmt.Errorf("grm project %s iam policy etag conflict, concurrent policy changes still detected after %d read-modify-write cycles %v", projectBindingsDeployment.Artifacts.ProjectID, Retries, err)
+			}
+			return err
+		}
+		iamGrants.Roles = managedRoles
+		if err = gfs.RecordIAMGrants(projectBindingsDeployment.Core, iamGrants, Retries); err != nil {
+			return err
+		}
 	}
-	return err
+	return nil
 }
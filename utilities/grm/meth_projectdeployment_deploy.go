@@ -20,6 +20,7 @@ import (
 	"strings"
 	"time"
 
+	"github.com/BrunoReboul/ram/utilities/deploy"
 	"github.com/BrunoReboul/ram/utilities/ffo"
 	"google.golang.org/api/cloudresourcemanager/v1"
 )
@@ -29,6 +30,8 @@ func (projectDeployment *ProjectDeployment) Deploy() (err error) {
 	log.Printf("%s grm resource manager project", projectDeployment.Core.InstanceName)
 	projectsService := projectDeployment.Core.Services.CloudresourcemanagerService.Projects
 	operationsService := projectDeployment.Core.Services.CloudresourcemanagerService.Operations
+	resourceReport := projectDeployment.Core.StartResourceReport("grm project", fmt.Sprintf("projects/%s", projectDeployment.Core.SolutionSettings.Hosting.ProjectID))
+	defer resourceReport.End(&err)
 	project, err := projectsService.Get(projectDeployment.Core.SolutionSettings.Hosting.ProjectID).Context(projectDeployment.Core.Ctx).Do()
 	if err != nil {
 		// When a project is not found the API returns 403 forbiden instead of 404 not found
@@ -45,6 +48,7 @@ func (projectDeployment *ProjectDeployment) Deploy() (err error) {
 			if err != nil {
 				if strings.Contains(err.Error(), "403") {
 					log.Printf("%s grm WARNING impossible to CREATE project %v", projectDeployment.Core.InstanceName, err)
+					resourceReport.Action = deploy.ActionSkipped
 					return nil
 				}
 				return fmt.Errorf("grm projectsService.Create(&projectToCreate) %v", err)
@@ -73,6 +77,7 @@ func (projectDeployment *ProjectDeployment) Deploy() (err error) {
 				}
 			}
 			ffo.JSONMarshalIndentPrint(operation)
+			resourceReport.Action = deploy.ActionCreated
 			log.Printf("%s grm project %s created", projectDeployment.Core.InstanceName, projectDeployment.Core.SolutionSettings.Hosting.ProjectID)
 		} else {
 			return err
@@ -0,0 +1,23 @@
+// Copyright 2020 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the 'License');
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an 'AS IS' BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package grm
+
+// Condition IAM condition restricting a role binding, e.g. time-bound deploy permissions or resource name based restrictions
+// See https://cloud.google.com/iam/docs/conditions-overview
+type Condition struct {
+	Title       string `yaml:"title"`
+	Description string `yaml:"description"`
+	Expression  string `yaml:"expression"`
+}
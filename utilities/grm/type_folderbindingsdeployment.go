@@ -0,0 +1,42 @@
+// Copyright 2020 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the 'License');
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an 'AS IS' BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package grm
+
+import (
+	"github.com/BrunoReboul/ram/utilities/deploy"
+)
+
+// FolderBindingsDeployment struct
+type FolderBindingsDeployment struct {
+	Artifacts struct {
+		Member         string
+		FolderID       string `yaml:"folderID"`
+		OrganizationID string `yaml:"organizationID"`
+		// Scope distinguishes the grants tracked for the same member on the same resource, default hosting
+		Scope string
+	}
+	Core     *deploy.Core
+	Settings struct {
+		// Conditions by role, or custom role short name, the roles without condition are granted unconditionally
+		Conditions  map[string]Condition
+		CustomRoles []string `yaml:"customRoles"`
+		Roles       []string
+	}
+}
+
+// NewFolderBindingsDeployment create deployment structure
+func NewFolderBindingsDeployment() *FolderBindingsDeployment {
+	return &FolderBindingsDeployment{}
+}
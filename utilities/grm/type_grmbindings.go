@@ -18,22 +18,26 @@ package grm
 type Bindings struct {
 	Hosting struct {
 		Org struct {
-			CustomRoles []string `yaml:"orgCustomRoles"`
-			Roles       []string `yaml:"orgRoles"`
+			CustomRoles []string             `yaml:"orgCustomRoles"`
+			Roles       []string             `yaml:"orgRoles"`
+			Conditions  map[string]Condition `yaml:"conditions"`
 		}
 		Folder struct {
-			CustomRoles []string `yaml:"orgCustomRoles"`
-			Roles       []string `yaml:"orgRoles"`
+			CustomRoles []string             `yaml:"orgCustomRoles"`
+			Roles       []string             `yaml:"orgRoles"`
+			Conditions  map[string]Condition `yaml:"conditions"`
 		}
 		Project struct {
-			CustomRoles []string `yaml:"projectCustomRoles"`
-			Roles       []string `yaml:"projectRoles"`
+			CustomRoles []string             `yaml:"projectCustomRoles"`
+			Roles       []string             `yaml:"projectRoles"`
+			Conditions  map[string]Condition `yaml:"conditions"`
 		}
 	}
 	Monitoring struct {
 		Org struct {
-			CustomRoles []string `yaml:"orgCustomRoles"`
-			Roles       []string `yaml:"orgRoles"`
+			CustomRoles []string             `yaml:"orgCustomRoles"`
+			Roles       []string             `yaml:"orgRoles"`
+			Conditions  map[string]Condition `yaml:"conditions"`
 		}
 	}
 }
@@ -23,9 +23,13 @@ type ProjectBindingsDeployment struct {
 	Artifacts struct {
 		Member    string
 		ProjectID string `yaml:"projectID"`
+		// Scope distinguishes the grants tracked for the same member on the same resource, default hosting
+		Scope string
 	}
 	Core     *deploy.Core
 	Settings struct {
+		// Conditions by role, or custom role short name, the roles without condition are granted unconditionally
+		Conditions  map[string]Condition
 		CustomRoles []string `yaml:"customRoles"`
 		Roles       []string
 	}
@@ -19,6 +19,7 @@ import (
 	"log"
 	"strings"
 
+	"github.com/BrunoReboul/ram/utilities/deploy"
 	"google.golang.org/api/sourcerepo/v1"
 )
 
@@ -28,6 +29,8 @@ func (repoDeployment *RepoDeployment) Deploy() (err error) {
 	projectsService := repoDeployment.Core.Services.SourcerepoService.Projects
 	projectName := fmt.Sprintf("projects/%s", repoDeployment.Core.SolutionSettings.Hosting.ProjectID)
 	repoName := fmt.Sprintf("%s/repos/%s", projectName, repoDeployment.Core.SolutionSettings.Hosting.Repository.Name)
+	resourceReport := repoDeployment.Core.StartResourceReport("gsr repository", repoName)
+	defer resourceReport.End(&err)
 	repo, err := projectsService.Repos.Get(repoName).Context(repoDeployment.Core.Ctx).Do()
 	if err != nil {
 		if strings.Contains(err.Error(), "404") && strings.Contains(err.Error(), "notFound") {
@@ -37,6 +40,7 @@ func (repoDeployment *RepoDeployment) Deploy() (err error) {
 			if err != nil {
 				return fmt.Errorf("gsr projectsService.Repos.Create %v", err)
 			}
+			resourceReport.Action = deploy.ActionCreated
 			log.Printf("%s gsr source repo created %s", repoDeployment.Core.InstanceName, repo.Name)
 		} else {
 			return fmt.Errorf("gsr projectsService.Repos.Get(repoName) %v", err)
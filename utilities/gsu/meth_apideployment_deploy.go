@@ -18,26 +18,36 @@ import (
 	"fmt"
 	"log"
 	"strings"
+	"sync"
 	"time"
 
+	"github.com/BrunoReboul/ram/utilities/deploy"
 	"github.com/BrunoReboul/ram/utilities/str"
 	"google.golang.org/api/serviceusage/v1"
 )
 
 var activeAPIs []string
 
+// deployMutex serializes deployments sharing the package level variable used by the pages browsing function, e.g. ramcli -parallel
+var deployMutex sync.Mutex
+
 // Deploy activates APIs
 func (apiDeployment *APIDeployment) Deploy() (err error) {
+	deployMutex.Lock()
+	defer deployMutex.Unlock()
 	log.Printf("%s gsu APIs", apiDeployment.Core.InstanceName)
 	apiDeployment.Artifacts.ServicesService = apiDeployment.Core.Services.ServiceusageService.Services
 	apiDeployment.Artifacts.OperationsService = apiDeployment.Core.Services.ServiceusageService.Operations
 	activeAPIs = make([]string, 0)
 	parent := fmt.Sprintf("projects/%s", apiDeployment.Core.SolutionSettings.Hosting.ProjectID)
+	resourceReport := apiDeployment.Core.StartResourceReport("gsu APIs", parent)
+	defer resourceReport.End(&err)
 	// log.Println(parent)
 	err = apiDeployment.Artifacts.ServicesService.List(parent).Filter("state:ENABLED").PageSize(200).Pages(apiDeployment.Core.Ctx, browseActiveAPIs)
 	if err != nil {
 		if strings.Contains(err.Error(), "403") {
 			log.Printf("%s gsu WARNING impossible to LIST APIs %v", apiDeployment.Core.InstanceName, err)
+			resourceReport.Action = deploy.ActionSkipped
 			return nil
 		}
 		return fmt.Errorf("gsu ServicesService.List %v", err)
@@ -51,6 +61,8 @@ func (apiDeployment *APIDeployment) Deploy() (err error) {
 			if err != nil {
 				return err
 			}
+			resourceReport.Action = deploy.ActionUpdated
+			resourceReport.Drift = append(resourceReport.Drift, fmt.Sprintf("%s activated", apiName))
 		}
 	}
 	return nil
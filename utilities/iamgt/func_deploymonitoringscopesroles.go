@@ -0,0 +1,60 @@
+// Copyright 2020 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the 'License');
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an 'AS IS' BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package iamgt
+
+import (
+	"fmt"
+
+	"github.com/BrunoReboul/ram/utilities/deploy"
+	"github.com/BrunoReboul/ram/utilities/solution"
+	"github.com/BrunoReboul/ram/utilities/str"
+	"google.golang.org/api/iam/v1"
+)
+
+// DeployMonitoringScopesRoles creates custom roles where monitoring scopes bindings can refer to them:
+// in monitored organizations, in the organization of monitored folders, and in monitored projects
+func DeployMonitoringScopesRoles(core *deploy.Core, roles []iam.Role) (err error) {
+	var organizationIDs []string
+	for _, scope := range core.SolutionSettings.GetMonitoringScopes() {
+		if scope.Kind == solution.MonitoringScopeProject {
+			projectRolesDeployment := NewProjectRolesDeployment()
+			projectRolesDeployment.Core = core
+			projectRolesDeployment.Settings.Roles = roles
+			projectRolesDeployment.Artifacts.ProjectID = scope.ID
+			err = projectRolesDeployment.Deploy()
+			if err != nil {
+				return err
+			}
+		} else {
+			if scope.OrganizationID == "" {
+				return fmt.Errorf("iamgt unknown organization for %s, where its custom roles are defined", scope.Parent())
+			}
+			if !str.Find(organizationIDs, scope.OrganizationID) {
+				organizationIDs = append(organizationIDs, scope.OrganizationID)
+			}
+		}
+	}
+	orgRoleDeployment := NewOrgRolesDeployment()
+	orgRoleDeployment.Core = core
+	orgRoleDeployment.Settings.Roles = roles
+	for _, organizationID := range organizationIDs {
+		orgRoleDeployment.Artifacts.OrganizationID = organizationID
+		err = orgRoleDeployment.Deploy()
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,36 @@
+// Copyright 2020 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the 'License');
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an 'AS IS' BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package iamgt
+
+import (
+	"fmt"
+
+	"github.com/BrunoReboul/ram/utilities/str"
+)
+
+// getPermissionsDrift list the permissions to be added (+) and removed (-) to move from current to wanted
+func getPermissionsDrift(current, wanted []string) (drift []string) {
+	for _, permission := range wanted {
+		if !str.Find(current, permission) {
+			drift = append(drift, fmt.Sprintf("+%s", permission))
+		}
+	}
+	for _, permission := range current {
+		if !str.Find(wanted, permission) {
+			drift = append(drift, fmt.Sprintf("-%s", permission))
+		}
+	}
+	return drift
+}
@@ -0,0 +1,64 @@
+// Copyright 2020 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the 'License');
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an 'AS IS' BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package iamgt
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestUnitGetPermissionsDrift(t *testing.T) {
+	var testCases = []struct {
+		name    string
+		current []string
+		wanted  []string
+		drift   string
+	}{
+		{
+			name:    "noDrift",
+			current: []string{"storage.objects.get", "storage.objects.list"},
+			wanted:  []string{"storage.objects.list", "storage.objects.get"},
+			drift:   "",
+		},
+		{
+			name:    "added",
+			current: []string{"storage.objects.get"},
+			wanted:  []string{"storage.objects.get", "storage.objects.list"},
+			drift:   "+storage.objects.list",
+		},
+		{
+			name:    "removed",
+			current: []string{"storage.objects.get", "storage.objects.list"},
+			wanted:  []string{"storage.objects.get"},
+			drift:   "-storage.objects.list",
+		},
+		{
+			name:    "mixture",
+			current: []string{"storage.objects.get", "storage.objects.list"},
+			wanted:  []string{"storage.objects.get", "storage.buckets.get"},
+			drift:   "+storage.buckets.get,-storage.objects.list",
+		},
+	}
+	for _, tc := range testCases {
+		tc := tc // https://github.com/golang/go/wiki/CommonMistakes#using-goroutines-on-loop-iterator-variables
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+			drift := strings.Join(getPermissionsDrift(tc.current, tc.wanted), ",")
+			if drift != tc.drift {
+				t.Errorf("Want '%s' got '%s'", tc.drift, drift)
+			}
+		})
+	}
+}
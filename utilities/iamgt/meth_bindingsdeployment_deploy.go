@@ -20,6 +20,9 @@ import (
 	"strings"
 	"time"
 
+	"github.com/BrunoReboul/ram/utilities/deploy"
+	"github.com/BrunoReboul/ram/utilities/gfs"
+	"github.com/BrunoReboul/ram/utilities/grm"
 	"github.com/BrunoReboul/ram/utilities/str"
 	"google.golang.org/api/iam/v1"
 )
@@ -27,31 +30,50 @@ import (
 // Retries is the max number of read-modify-write cycles in case of concurrent policy changes detection
 const Retries = 5
 
+// PolicyVersion is the IAM policy version requested and set, version 3 is required to read and write conditional bindings
+const PolicyVersion = 3
+
 // Deploy BindingsDeployment use retries on a read-modify-write cycle
 func (bindingsDeployment *BindingsDeployment) Deploy() (err error) {
-	if len(bindingsDeployment.Settings.Service.IAM.RolesOnServiceAccounts) > 0 {
+	if bindingsDeployment.Artifacts.ServiceAccountName == "" {
+		return nil
+	}
+	iamGrants, protectedRoles, err := gfs.GetIAMGrants(bindingsDeployment.Core, "iam service account bindings", bindingsDeployment.Artifacts.ServiceAccountName, bindingsDeployment.Artifacts.Member, "", Retries)
+	if err != nil {
+		return err
+	}
+	if len(bindingsDeployment.Settings.Service.IAM.RolesOnServiceAccounts)+len(iamGrants.Roles) > 0 {
 		log.Printf("%s iam service accounts bindings", bindingsDeployment.Core.InstanceName)
+		resourceReport := bindingsDeployment.Core.StartResourceReport("iam service account bindings", bindingsDeployment.Artifacts.ServiceAccountName)
+		defer resourceReport.End(&err)
 		projectsServiceAccountsService := bindingsDeployment.Core.Services.IAMService.Projects.ServiceAccounts
+		var managedRoles []string
 		for i := 0; i < Retries; i++ {
 			if i > 0 {
 				log.Printf("%s iam retrying a full read-modify-write cycle, iteration %d", bindingsDeployment.Core.InstanceName, i)
 			}
 			// READ
 			var policy *iam.Policy
-			policy, err = projectsServiceAccountsService.GetIamPolicy(bindingsDeployment.Artifacts.ServiceAccountName).Context(bindingsDeployment.Core.Ctx).Do()
+			policy, err = projectsServiceAccountsService.GetIamPolicy(bindingsDeployment.Artifacts.ServiceAccountName).OptionsRequestedPolicyVersion(PolicyVersion).Context(bindingsDeployment.Core.Ctx).Do()
 			if err != nil {
 				if strings.Contains(err.Error(), "403") {
 					log.Printf("%s iam WARNING impossible to GET service account iam policy %v", bindingsDeployment.Core.InstanceName, err)
+					resourceReport.Action = deploy.ActionSkipped
 					return nil
 				}
 				return fmt.Errorf("iam projectsServiceAccountsService.GetIamPolicy %s", err)
 			}
 			// MODIFY
+			resourceReport.Drift = nil
 			policyIsToBeUpdated := false
 			existingRoles := make([]string, 0)
 			for _, binding := range policy.Bindings {
-				existingRoles = append(existingRoles, binding.Role)
-				if str.Find(bindingsDeployment.Settings.Service.IAM.RolesOnServiceAccounts, binding.Role) {
+				var expression string
+				if binding.Condition != nil {
+					expression = binding.Condition.Expression
+				}
+				existingRoles = append(existingRoles, grm.GetBindingKey(binding.Role, expression))
+				if str.Find(bindingsDeployment.Settings.Service.IAM.RolesOnServiceAccounts, binding.Role) && bindingsDeployment.Settings.Service.IAM.Conditions[binding.Role].Expression == expression {
 					isAlreadyMemberOf := false
 					for _, member := range binding.Members {
 						if member == bindingsDeployment.Artifacts.Member {
@@ -62,23 +84,74 @@ func (bindingsDeployment *BindingsDeployment) Deploy() (err error) {
 						log.Printf("%s iam member %s already have %s on service account %s", bindingsDeployment.Core.InstanceName, bindingsDeployment.Artifacts.Member, binding.Role, bindingsDeployment.Artifacts.ServiceAccountName)
 					} else {
 						log.Printf("%s iam add member %s to existing %s on service account %s", bindingsDeployment.Core.InstanceName, bindingsDeployment.Artifacts.Member, binding.Role, bindingsDeployment.Artifacts.ServiceAccountName)
+						resourceReport.Drift = append(resourceReport.Drift, fmt.Sprintf("+%s %s", binding.Role, bindingsDeployment.Artifacts.Member))
 						binding.Members = append(binding.Members, bindingsDeployment.Artifacts.Member)
 						policyIsToBeUpdated = true
 					}
 				}
 			}
 			for _, role := range bindingsDeployment.Settings.Service.IAM.RolesOnServiceAccounts {
-				if !str.Find(existingRoles, role) {
+				if !str.Find(existingRoles, grm.GetBindingKey(role, bindingsDeployment.Settings.Service.IAM.Conditions[role].Expression)) {
 					var binding iam.Binding
 					binding.Role = role
 					binding.Members = []string{bindingsDeployment.Artifacts.Member}
+					if condition, ok := bindingsDeployment.Settings.Service.IAM.Conditions[role]; ok && condition.Expression != "" {
+						binding.Condition = &iam.Expr{
+							Title:       condition.Title,
+							Description: condition.Description,
+							Expression:  condition.Expression,
+						}
+					}
 					log.Printf("%s iam add new %s with solo member %s on service account %s", bindingsDeployment.Core.InstanceName, binding.Role, bindingsDeployment.Artifacts.Member, bindingsDeployment.Artifacts.ServiceAccountName)
+					resourceReport.Drift = append(resourceReport.Drift, fmt.Sprintf("+%s %s", binding.Role, bindingsDeployment.Artifacts.Member))
 					policy.Bindings = append(policy.Bindings, &binding)
 					policyIsToBeUpdated = true
 				}
 			}
+			// STALE roles recorded as granted by RAM, no longer wanted
+			wantedRoles := grm.GetBindingKeys(bindingsDeployment.Settings.Service.IAM.RolesOnServiceAccounts, bindingsDeployment.Settings.Service.IAM.Conditions)
+			staleRoles := iamGrants.GetStaleRoles(wantedRoles, nil, protectedRoles)
+			managedRoles = make([]string, 0)
+			for _, binding := range policy.Bindings {
+				if !str.Find(binding.Members, bindingsDeployment.Artifacts.Member) {
+					continue
+				}
+				var expression string
+				if binding.Condition != nil {
+					expression = binding.Condition.Expression
+				}
+				bindingKey := grm.GetBindingKey(binding.Role, expression)
+				if str.Find(staleRoles, bindingKey) {
+					resourceReport.Drift = append(resourceReport.Drift, fmt.Sprintf("-%s %s", bindingKey, bindingsDeployment.Artifacts.Member))
+					if bindingsDeployment.Core.Commands.PruneIAM && !bindingsDeployment.Core.Commands.Check {
+						log.Printf("%s iam remove member %s from stale %s on service account %s", bindingsDeployment.Core.InstanceName, bindingsDeployment.Artifacts.Member, bindingKey, bindingsDeployment.Artifacts.ServiceAccountName)
+						binding.Members = str.Remove(binding.Members, bindingsDeployment.Artifacts.Member)
+						policyIsToBeUpdated = true
+						continue
+					}
+					log.Printf("%s iam WARNING member %s still have stale %s on service account %s, use -prune-iam to remove it", bindingsDeployment.Core.InstanceName, bindingsDeployment.Artifacts.Member, bindingKey, bindingsDeployment.Artifacts.ServiceAccountName)
+				} else if !gfs.IsWantedRole(bindingKey, wantedRoles, nil) && !str.Find(iamGrants.Roles, bindingKey) {
+					// Not granted by RAM
+					continue
+				}
+				managedRoles = append(managedRoles, bindingKey)
+			}
+			keptBindings := policy.Bindings[:0]
+			for _, binding := range policy.Bindings {
+				if len(binding.Members) > 0 {
+					keptBindings = append(keptBindings, binding)
+				}
+			}
+			policy.Bindings = keptBindings
+			if bindingsDeployment.Core.Commands.Check {
+				if len(resourceReport.Drift) > 0 {
+					return fmt.Errorf("%s iam invalid service account bindings, + missing - stale:\n%s", bindingsDeployment.Core.InstanceName, strings.Join(resourceReport.Drift, "\n"))
+				}
+				return nil
+			}
 			// WRITE
 			if policyIsToBeUpdated {
+				policy.Version = PolicyVersion
 				var setRequest iam.SetIamPolicyRequest
 				setRequest.Policy = policy
 
@@ -88,6 +161,7 @@ func (bindingsDeployment *BindingsDeployment) Deploy() (err error) {
 					if !strings.Contains(err.Error(), "There were concurrent policy changes") {
 						if strings.Contains(err.Error(), "403") {
 							log.Printf("%s iam WARNING impossible to SET service account iam policy %v", bindingsDeployment.Core.InstanceName, err)
+							resourceReport.Action = deploy.ActionSkipped
 							return nil
 						}
 						return fmt.Errorf("iam projectsServiceAccountsService.SetIamPolicy %s", err)
@@ -97,6 +171,7 @@ func (bindingsDeployment *BindingsDeployment) Deploy() (err error) {
 				} else {
 					// ffo.JSONMarshalIndentPrint(updatedPolicy)
 					_ = updatedPolicy
+					resourceReport.Action = deploy.ActionUpdated
 					log.Printf("%s iam policy updated for service account %s iteration %d", bindingsDeployment.Core.InstanceName, bindingsDeployment.Artifacts.ServiceAccountName, i)
 					break
 				}
@@ -105,6 +180,16 @@ func (bindingsDeployment *BindingsDeployment) Deploy() (err error) {
 				break
 			}
 		}
+		if err != nil {
+			if strings.Contains(err.Error(), "There were concurrent policy changes") {
+				return fmt.Errorf("iam service account %s iam policy etag conflict, concurrent policy changes still detected after %d read-modify-write cycles %v", bindingsDeployment.Artifacts.ServiceAccountName, Retries, err)
+			}
+			return err
+		}
+		iamGrants.Roles = managedRoles
+		if err = gfs.RecordIAMGrants(bindingsDeployment.Core, iamGrants, Retries); err != nil {
+			return err
+		}
 	}
-	return err
+	return nil
 }
@@ -19,6 +19,7 @@ import (
 	"log"
 	"strings"
 
+	"github.com/BrunoReboul/ram/utilities/deploy"
 	"google.golang.org/api/iam/v1"
 )
 
@@ -28,6 +29,8 @@ import (
 func (orgRolesDeployment *OrgRolesDeployment) Deploy() (err error) {
 	log.Printf("%s iam organization roles", orgRolesDeployment.Core.InstanceName)
 	organizationsRolesService := iam.NewOrganizationsRolesService(orgRolesDeployment.Core.Services.IAMService)
+	resourceReport := orgRolesDeployment.Core.StartResourceReport("iam organization roles", fmt.Sprintf("organizations/%s", orgRolesDeployment.Artifacts.OrganizationID))
+	defer resourceReport.End(&err)
 	for _, customRole := range orgRolesDeployment.Settings.Roles {
 		name := fmt.Sprintf("organizations/%s/roles/%s",
 			orgRolesDeployment.Artifacts.OrganizationID, customRole.Title)
@@ -41,18 +44,29 @@ func (orgRolesDeployment *OrgRolesDeployment) Deploy() (err error) {
 				retreivedCustomRole, err = organizationsRolesService.Create(parent, &createRoleRequest).Context(orgRolesDeployment.Core.Ctx).Do()
 				if err != nil {
 					log.Printf("%s iam WARNING impossible to CREATE custom organization roles %v", orgRolesDeployment.Core.InstanceName, err)
+					resourceReport.Action = deploy.ActionSkipped
 					return nil
 				}
+				resourceReport.Action = deploy.ActionCreated
+				resourceReport.Drift = append(resourceReport.Drift, fmt.Sprintf("%s created", customRole.Title))
 				log.Printf("%s iam custom org role created %s", orgRolesDeployment.Core.InstanceName, retreivedCustomRole.Name)
 			} else {
 				log.Printf("%s iam WARNING impossible to GET custom organization roles %v", orgRolesDeployment.Core.InstanceName, err)
+				resourceReport.Action = deploy.ActionSkipped
 				return nil
 			}
 		} else {
 			log.Printf("%s iam found custom org role %s", orgRolesDeployment.Core.InstanceName, retreivedCustomRole.Name)
+			for _, permissionDrift := range getPermissionsDrift(retreivedCustomRole.IncludedPermissions, customRole.IncludedPermissions) {
+				if resourceReport.Action == deploy.ActionUnchanged {
+					resourceReport.Action = deploy.ActionUpdated
+				}
+				resourceReport.Drift = append(resourceReport.Drift, fmt.Sprintf("%s %s", customRole.Title, permissionDrift))
+			}
 			retreivedCustomRole, err = organizationsRolesService.Patch(name, &customRole).Context(orgRolesDeployment.Core.Ctx).Do()
 			if err != nil {
 				log.Printf("%s iam WARNING impossible to PATCH custom organization roles %v", orgRolesDeployment.Core.InstanceName, err)
+				resourceReport.Action = deploy.ActionSkipped
 				return nil
 			}
 			log.Printf("%s iam custom org role patched %s", orgRolesDeployment.Core.InstanceName, retreivedCustomRole.Name)
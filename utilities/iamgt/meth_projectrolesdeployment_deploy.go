@@ -19,6 +19,7 @@ import (
 	"log"
 	"strings"
 
+	"github.com/BrunoReboul/ram/utilities/deploy"
 	"google.golang.org/api/iam/v1"
 )
 
@@ -26,6 +27,8 @@ import (
 func (projectRolesDeployment *ProjectRolesDeployment) Deploy() (err error) {
 	log.Printf("%s iam project roles", projectRolesDeployment.Core.InstanceName)
 	projectsRolesService := iam.NewProjectsRolesService(projectRolesDeployment.Core.Services.IAMService)
+	resourceReport := projectRolesDeployment.Core.StartResourceReport("iam project roles", fmt.Sprintf("projects/%s", projectRolesDeployment.Artifacts.ProjectID))
+	defer resourceReport.End(&err)
 	for _, customRole := range projectRolesDeployment.Settings.Roles {
 		name := fmt.Sprintf("projects/%s/roles/%s",
 			projectRolesDeployment.Artifacts.ProjectID, customRole.Title)
@@ -39,18 +42,29 @@ func (projectRolesDeployment *ProjectRolesDeployment) Deploy() (err error) {
 				retreivedCustomRole, err = projectsRolesService.Create(parent, &createRoleRequest).Context(projectRolesDeployment.Core.Ctx).Do()
 				if err != nil {
 					log.Printf("%s iam WARNING impossible to CREATE custom project roles %v", projectRolesDeployment.Core.InstanceName, err)
+					resourceReport.Action = deploy.ActionSkipped
 					return nil
 				}
+				resourceReport.Action = deploy.ActionCreated
+				resourceReport.Drift = append(resourceReport.Drift, fmt.Sprintf("%s created", customRole.Title))
 				log.Printf("%s iam custom project role created %s", projectRolesDeployment.Core.InstanceName, retreivedCustomRole.Name)
 			} else {
 				log.Printf("%s iam WARNING impossible to GET custom project roles %v", projectRolesDeployment.Core.InstanceName, err)
+				resourceReport.Action = deploy.ActionSkipped
 				return nil
 			}
 		} else {
 			log.Printf("%s iam custom project role found %s", projectRolesDeployment.Core.InstanceName, retreivedCustomRole.Name)
+			for _, permissionDrift := range getPermissionsDrift(retreivedCustomRole.IncludedPermissions, customRole.IncludedPermissions) {
+				if resourceReport.Action == deploy.ActionUnchanged {
+					resourceReport.Action = deploy.ActionUpdated
+				}
+				resourceReport.Drift = append(resourceReport.Drift, fmt.Sprintf("%s %s", customRole.Title, permissionDrift))
+			}
 			retreivedCustomRole, err = projectsRolesService.Patch(name, &customRole).Context(projectRolesDeployment.Core.Ctx).Do()
 			if err != nil {
 				log.Printf("%s iam WARNING impossible to PATCH custom project roles %v", projectRolesDeployment.Core.InstanceName, err)
+				resourceReport.Action = deploy.ActionSkipped
 				return nil
 			}
 			log.Printf("%s iam custom project role patched %s", projectRolesDeployment.Core.InstanceName, retreivedCustomRole.Name)
@@ -19,6 +19,7 @@ import (
 	"log"
 	"strings"
 
+	"github.com/BrunoReboul/ram/utilities/deploy"
 	"google.golang.org/api/iam/v1"
 )
 
@@ -27,6 +28,8 @@ func (serviceaccountDeployment *ServiceaccountDeployment) Deploy() (err error) {
 	log.Printf("%s iam service accounts", serviceaccountDeployment.Core.InstanceName)
 	projectName := fmt.Sprintf("projects/%s", serviceaccountDeployment.Core.SolutionSettings.Hosting.ProjectID)
 	serviceAccountName := fmt.Sprintf("%s/serviceAccounts/%s@%s.iam.gserviceaccount.com", projectName, serviceaccountDeployment.Core.ServiceName, serviceaccountDeployment.Core.SolutionSettings.Hosting.ProjectID)
+	resourceReport := serviceaccountDeployment.Core.StartResourceReport("iam service account", serviceAccountName)
+	defer resourceReport.End(&err)
 	projectServiceAccountService := serviceaccountDeployment.Core.Services.IAMService.Projects.ServiceAccounts
 	retreivedServiceAccount, err := projectServiceAccountService.Get(serviceAccountName).Context(serviceaccountDeployment.Core.Ctx).Do()
 	if err != nil {
@@ -49,15 +52,18 @@ func (serviceaccountDeployment *ServiceaccountDeployment) Deploy() (err error) {
 				} else {
 					if strings.Contains(err.Error(), "403") {
 						log.Printf("%s iam WARNING impossible to CREATE service account %v", serviceaccountDeployment.Core.InstanceName, err)
+						resourceReport.Action = deploy.ActionSkipped
 						return nil
 					}
 					return fmt.Errorf("iam projectServiceAccountService.Create %v", err)
 				}
 			}
+			resourceReport.Action = deploy.ActionCreated
 			log.Printf("%s iam service account created %s", serviceaccountDeployment.Core.InstanceName, retreivedServiceAccount.Email)
 		} else {
 			if strings.Contains(err.Error(), "403") {
 				log.Printf("%s iam WARNING impossible to GET service account %v", serviceaccountDeployment.Core.InstanceName, err)
+				resourceReport.Action = deploy.ActionSkipped
 				return nil
 			}
 			return fmt.Errorf("iam projectServiceAccountService.Get %v", err)
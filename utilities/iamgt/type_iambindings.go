@@ -14,7 +14,11 @@
 
 package iamgt
 
+import "github.com/BrunoReboul/ram/utilities/grm"
+
 // Bindings structure
 type Bindings struct {
 	RolesOnServiceAccounts []string `yaml:"rolesOnServiceAccounts"`
+	// Conditions by role, the roles without condition are granted unconditionally
+	Conditions map[string]grm.Condition `yaml:"conditions"`
 }
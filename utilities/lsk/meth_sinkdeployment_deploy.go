@@ -19,6 +19,7 @@ import (
 	"log"
 	"strings"
 
+	"github.com/BrunoReboul/ram/utilities/deploy"
 	"github.com/BrunoReboul/ram/utilities/gps"
 
 	"cloud.google.com/go/logging/logadmin"
@@ -44,6 +45,9 @@ func (sinkDeployment *SinkDeployment) Deploy() (err error) {
 	sink.Filter = sinkDeployment.Settings.Instance.LSK.Filter
 	sink.IncludeChildren = false
 
+	resourceReport := sinkDeployment.Core.StartResourceReport("lsk log sink", fmt.Sprintf("%s/sinks/%s", sinkDeployment.Settings.Instance.LSK.Parent, sink.ID))
+	defer resourceReport.End(&err)
+
 	var sinkRetreived *logadmin.Sink
 	sinkFound := true
 	// GET
@@ -63,16 +67,19 @@ func (sinkDeployment *SinkDeployment) Deploy() (err error) {
 		}
 		var s string
 		if sink.Destination != sinkRetreived.Destination {
+			resourceReport.Drift = append(resourceReport.Drift, "destination")
 			s = fmt.Sprintf("%sdestination\nwant %s\nhave %s\n", s,
 				sink.Destination,
 				sinkRetreived.Destination)
 		}
 		if sink.Filter != sinkRetreived.Filter {
+			resourceReport.Drift = append(resourceReport.Drift, "filter")
 			s = fmt.Sprintf("%sfilter\nwant %s\nhave %s\n", s,
 				sink.Filter,
 				sinkRetreived.Filter)
 		}
 		if sink.IncludeChildren != sinkRetreived.IncludeChildren {
+			resourceReport.Drift = append(resourceReport.Drift, "includeChildren")
 			s = fmt.Sprintf("%sincludeChildren\nwant %v\nhave %v\n", s,
 				sink.IncludeChildren,
 				sinkRetreived.IncludeChildren)
@@ -83,6 +90,7 @@ func (sinkDeployment *SinkDeployment) Deploy() (err error) {
 			sinkDeployment.Artifacts.TopicFullName,
 			sinkRetreived.WriterIdentity,
 			"roles/pubsub.publisher"); err != nil {
+			resourceReport.Drift = append(resourceReport.Drift, "writerIdentity topic role")
 			s = fmt.Sprintf("%s%s\n", s, err.Error())
 		}
 		if len(s) > 0 {
@@ -96,18 +104,22 @@ func (sinkDeployment *SinkDeployment) Deploy() (err error) {
 		toUpdate := false
 		if sinkRetreived.Destination != sink.Destination {
 			toUpdate = true
+			resourceReport.Drift = append(resourceReport.Drift, "destination")
 		}
 		if sinkRetreived.Filter != sink.Filter {
 			toUpdate = true
+			resourceReport.Drift = append(resourceReport.Drift, "filter")
 		}
 		if sinkRetreived.IncludeChildren != sink.IncludeChildren {
 			toUpdate = true
+			resourceReport.Drift = append(resourceReport.Drift, "includeChildren")
 		}
 		if toUpdate {
 			sinkRetreived, err = logAdminClient.UpdateSink(sinkDeployment.Core.Ctx, &sink)
 			if err != nil {
 				return fmt.Errorf("logAdminClient.UpdateSink %v", err)
 			}
+			resourceReport.Action = deploy.ActionUpdated
 			log.Printf("%s lsk updated sink %s writer identity %s", sinkDeployment.Core.InstanceName, sinkRetreived.ID, sinkRetreived.WriterIdentity)
 		}
 	} else {
@@ -115,6 +127,7 @@ func (sinkDeployment *SinkDeployment) Deploy() (err error) {
 		if err != nil {
 			return fmt.Errorf("logAdminClient.CreateSink %v", err)
 		}
+		resourceReport.Action = deploy.ActionCreated
 		log.Printf("%s lsk created sink %s writer identity %s", sinkDeployment.Core.InstanceName, sinkRetreived.ID, sinkRetreived.WriterIdentity)
 	}
 
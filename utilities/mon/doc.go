@@ -13,4 +13,10 @@
 // limitations under the License.
 
 // Package mon helps cloud monitoring
+//
+// Dashboards, alert policies and notification channels are identified by their display name in the stackdriver project.
+// Alert policy conditions are thresholds on a metric filter, e.g. RAM log based metrics, or on the burn rate of a service level objective.
+//
+// Service level objectives are set on service monitoring custom services, both identified by their ID.
+// The freshness indicator is request based: a distribution cut on the RAM latency metric, per origin, where good events are the ones under the cut off bucket threshold.
 package mon
@@ -0,0 +1,90 @@
+// Copyright 2020 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the 'License');
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an 'AS IS' BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package mon
+
+import (
+	"fmt"
+
+	monitoringv3 "google.golang.org/api/monitoring/v3"
+)
+
+// BuildAlertPolicy builds an alert policy from its parameters
+// A burn rate condition selects the burn rate of a service level objective of the stackdriver project
+// Notification channels names are set at deployment time, once the channels are deployed
+func BuildAlertPolicy(parameters AlertPolicyParameters, projectID string) (alertPolicy monitoringv3.AlertPolicy, err error) {
+	if len(parameters.Conditions) == 0 {
+		return alertPolicy, fmt.Errorf("mon alert policy '%s' has no condition", parameters.DisplayName)
+	}
+	alertPolicy.DisplayName = parameters.DisplayName
+	alertPolicy.Enabled = true
+	alertPolicy.Combiner = parameters.Combiner
+	if alertPolicy.Combiner == "" {
+		alertPolicy.Combiner = "OR"
+	}
+	if parameters.Documentation != "" {
+		var documentation monitoringv3.Documentation
+		documentation.Content = parameters.Documentation
+		documentation.MimeType = "text/markdown"
+		alertPolicy.Documentation = &documentation
+	}
+	if parameters.AutoClose != "" {
+		var alertStrategy monitoringv3.AlertStrategy
+		alertStrategy.AutoClose = parameters.AutoClose
+		alertPolicy.AlertStrategy = &alertStrategy
+	}
+	for _, conditionParameters := range parameters.Conditions {
+		var metricThreshold monitoringv3.MetricThreshold
+		metricThreshold.Comparison = conditionParameters.Comparison
+		if metricThreshold.Comparison == "" {
+			metricThreshold.Comparison = "COMPARISON_GT"
+		}
+		metricThreshold.ThresholdValue = conditionParameters.ThresholdValue
+		metricThreshold.Duration = conditionParameters.Duration
+		if metricThreshold.Duration == "" {
+			metricThreshold.Duration = "0s"
+		}
+		switch true {
+		case conditionParameters.BurnRate.SLOID != "":
+			lookbackPeriod := conditionParameters.BurnRate.LookbackPeriod
+			if lookbackPeriod == "" {
+				lookbackPeriod = "3600s"
+			}
+			metricThreshold.Filter = fmt.Sprintf("select_slo_burn_rate(\"projects/%s/services/%s/serviceLevelObjectives/%s\", \"%s\")",
+				projectID,
+				conditionParameters.BurnRate.ServiceID,
+				conditionParameters.BurnRate.SLOID,
+				lookbackPeriod)
+		case conditionParameters.Filter != "":
+			metricThreshold.Filter = conditionParameters.Filter
+			if conditionParameters.PerSeriesAligner != "" || conditionParameters.CrossSeriesReducer != "" {
+				var aggregation monitoringv3.Aggregation
+				aggregation.AlignmentPeriod = conditionParameters.AlignmentPeriod
+				aggregation.PerSeriesAligner = conditionParameters.PerSeriesAligner
+				aggregation.CrossSeriesReducer = conditionParameters.CrossSeriesReducer
+				aggregation.GroupByFields = conditionParameters.GroupByFields
+				metricThreshold.Aggregations = []*monitoringv3.Aggregation{&aggregation}
+			}
+		default:
+			return alertPolicy, fmt.Errorf("mon alert policy '%s' condition '%s' has neither a filter nor a burn rate service level objective",
+				parameters.DisplayName,
+				conditionParameters.DisplayName)
+		}
+		var condition monitoringv3.Condition
+		condition.DisplayName = conditionParameters.DisplayName
+		condition.ConditionThreshold = &metricThreshold
+		alertPolicy.Conditions = append(alertPolicy.Conditions, &condition)
+	}
+	return alertPolicy, nil
+}
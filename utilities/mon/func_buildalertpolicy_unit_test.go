@@ -0,0 +1,94 @@
+// Copyright 2020 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the 'License');
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an 'AS IS' BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package mon
+
+import (
+	"testing"
+)
+
+func TestUnitBuildAlertPolicy(t *testing.T) {
+	threshold := AlertConditionParameters{
+		DisplayName:        "noretry",
+		Filter:             `metric.type="logging.googleapis.com/user/ram_execution_status" AND metric.label.status="noretry"`,
+		AlignmentPeriod:    "300s",
+		PerSeriesAligner:   "ALIGN_SUM",
+		CrossSeriesReducer: "REDUCE_SUM",
+		GroupByFields:      []string{"metric.label.microservice_name"}}
+	var burnRate AlertConditionParameters
+	burnRate.DisplayName = "fast burn"
+	burnRate.ThresholdValue = 10
+	burnRate.BurnRate.ServiceID = "ram"
+	burnRate.BurnRate.SLOID = "freshness_real_time"
+	var noFilter AlertConditionParameters
+	noFilter.DisplayName = "incomplete"
+
+	tests := []struct {
+		name           string
+		conditions     []AlertConditionParameters
+		wantErr        bool
+		wantFilter     string
+		wantAggregates int
+	}{
+		{
+			name:    "noCondition",
+			wantErr: true,
+		},
+		{
+			name:       "noFilterNoBurnRate",
+			conditions: []AlertConditionParameters{noFilter},
+			wantErr:    true,
+		},
+		{
+			name:           "threshold",
+			conditions:     []AlertConditionParameters{threshold},
+			wantFilter:     threshold.Filter,
+			wantAggregates: 1,
+		},
+		{
+			name:       "burnRate",
+			conditions: []AlertConditionParameters{burnRate},
+			wantFilter: `select_slo_burn_rate("projects/qwerty/services/ram/serviceLevelObjectives/freshness_real_time", "3600s")`,
+		},
+	}
+	for _, tc := range tests {
+		tc := tc // https://github.com/golang/go/wiki/CommonMistakes#using-goroutines-on-loop-iterator-variables
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+			var parameters AlertPolicyParameters
+			parameters.DisplayName = "RAM test"
+			parameters.Conditions = tc.conditions
+			alertPolicy, err := BuildAlertPolicy(parameters, "qwerty")
+			if (err != nil) != tc.wantErr {
+				t.Fatalf("error = %v, wantErr %v", err, tc.wantErr)
+			}
+			if tc.wantErr {
+				return
+			}
+			if alertPolicy.Combiner != "OR" {
+				t.Errorf("Want combiner '%s' got '%s'", "OR", alertPolicy.Combiner)
+			}
+			threshold := alertPolicy.Conditions[0].ConditionThreshold
+			if threshold.Filter != tc.wantFilter {
+				t.Errorf("Want filter '%s' got '%s'", tc.wantFilter, threshold.Filter)
+			}
+			if threshold.Comparison != "COMPARISON_GT" {
+				t.Errorf("Want comparison '%s' got '%s'", "COMPARISON_GT", threshold.Comparison)
+			}
+			if len(threshold.Aggregations) != tc.wantAggregates {
+				t.Errorf("Want %d aggregations got %d", tc.wantAggregates, len(threshold.Aggregations))
+			}
+		})
+	}
+}
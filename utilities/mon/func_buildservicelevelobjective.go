@@ -0,0 +1,61 @@
+// Copyright 2020 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the 'License');
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an 'AS IS' BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package mon
+
+import (
+	"fmt"
+
+	monitoringv3 "google.golang.org/api/monitoring/v3"
+)
+
+// BuildServiceLevelObjective builds a freshness service level objective from its parameters
+// The indicator is request based, a distribution cut on a RAM latency metric, by default ram_latency, the end to end latency ram_latency_e2e being opt-in with MetricID: good events are the ones under the cut off bucket threshold
+func BuildServiceLevelObjective(parameters SLOParameters) (serviceLevelObjective monitoringv3.ServiceLevelObjective, err error) {
+	if parameters.Goal <= 0 || parameters.Goal >= 1 {
+		return serviceLevelObjective, fmt.Errorf("mon slo '%s' goal must be in ]0,1[ got %v", parameters.SLOID, parameters.Goal)
+	}
+	metricID := parameters.MetricID
+	if metricID == "" {
+		metricID = "ram_latency"
+	}
+	microserviceName := parameters.MicroserviceName
+	if microserviceName == "" {
+		microserviceName = "stream2bq"
+	}
+	var latencyRange monitoringv3.GoogleMonitoringV3Range
+	latencyRange.Max = GetFreshnessThresholdSeconds(parameters.CutOffBucketNumber)
+
+	var distributionCut monitoringv3.DistributionCut
+	distributionCut.DistributionFilter = fmt.Sprintf("metric.type=\"logging.googleapis.com/user/%s\" resource.type=\"cloud_function\" metric.label.microservice_name=\"%s\" metric.label.origin=\"%s\"",
+		metricID,
+		microserviceName,
+		parameters.Origin)
+	distributionCut.Range = &latencyRange
+
+	var requestBasedSli monitoringv3.RequestBasedSli
+	requestBasedSli.DistributionCut = &distributionCut
+
+	var serviceLevelIndicator monitoringv3.ServiceLevelIndicator
+	serviceLevelIndicator.RequestBased = &requestBasedSli
+
+	serviceLevelObjective.DisplayName = parameters.DisplayName
+	serviceLevelObjective.Goal = parameters.Goal
+	serviceLevelObjective.RollingPeriod = parameters.RollingPeriod
+	if serviceLevelObjective.RollingPeriod == "" {
+		serviceLevelObjective.RollingPeriod = "2419200s" // 28 days
+	}
+	serviceLevelObjective.ServiceLevelIndicator = &serviceLevelIndicator
+	return serviceLevelObjective, nil
+}
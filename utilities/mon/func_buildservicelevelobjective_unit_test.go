@@ -0,0 +1,100 @@
+// Copyright 2020 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the 'License');
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an 'AS IS' BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package mon
+
+import (
+	"testing"
+)
+
+func TestUnitBuildServiceLevelObjective(t *testing.T) {
+	tests := []struct {
+		name                   string
+		goal                   float64
+		metricID               string
+		origin                 string
+		cutOffBucketNumber     int64
+		wantErr                bool
+		wantDistributionFilter string
+		wantMax                float64
+	}{
+		{
+			name:    "goalZero",
+			goal:    0,
+			wantErr: true,
+		},
+		{
+			name:    "goalOne",
+			goal:    1,
+			wantErr: true,
+		},
+		{
+			name:                   "realTime",
+			goal:                   0.99,
+			origin:                 "real-time",
+			cutOffBucketNumber:     0,
+			wantDistributionFilter: `metric.type="logging.googleapis.com/user/ram_latency" resource.type="cloud_function" metric.label.microservice_name="stream2bq" metric.label.origin="real-time"`,
+			wantMax:                0.01,
+		},
+		{
+			name:                   "batchExport",
+			goal:                   0.95,
+			origin:                 "batch-export",
+			cutOffBucketNumber:     2,
+			wantDistributionFilter: `metric.type="logging.googleapis.com/user/ram_latency" resource.type="cloud_function" metric.label.microservice_name="stream2bq" metric.label.origin="batch-export"`,
+			wantMax:                GetFreshnessThresholdSeconds(2),
+		},
+		{
+			name:                   "metricIDOverride",
+			goal:                   0.99,
+			metricID:               "ram_latency_e2e",
+			origin:                 "real-time",
+			cutOffBucketNumber:     0,
+			wantDistributionFilter: `metric.type="logging.googleapis.com/user/ram_latency_e2e" resource.type="cloud_function" metric.label.microservice_name="stream2bq" metric.label.origin="real-time"`,
+			wantMax:                0.01,
+		},
+	}
+	for _, tc := range tests {
+		tc := tc // https://github.com/golang/go/wiki/CommonMistakes#using-goroutines-on-loop-iterator-variables
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+			var parameters SLOParameters
+			parameters.SLOID = "freshness-test"
+			parameters.Goal = tc.goal
+			parameters.MetricID = tc.metricID
+			parameters.Origin = tc.origin
+			parameters.CutOffBucketNumber = tc.cutOffBucketNumber
+			serviceLevelObjective, err := BuildServiceLevelObjective(parameters)
+			if (err != nil) != tc.wantErr {
+				t.Fatalf("error = %v, wantErr %v", err, tc.wantErr)
+			}
+			if tc.wantErr {
+				return
+			}
+			if serviceLevelObjective.RollingPeriod != "2419200s" {
+				t.Errorf("Want rollingPeriod '%s' got '%s'", "2419200s", serviceLevelObjective.RollingPeriod)
+			}
+			distributionCut := getDistributionCut(&serviceLevelObjective)
+			if distributionCut == nil {
+				t.Fatalf("Want a distribution cut got nil")
+			}
+			if distributionCut.DistributionFilter != tc.wantDistributionFilter {
+				t.Errorf("Want distributionFilter '%s' got '%s'", tc.wantDistributionFilter, distributionCut.DistributionFilter)
+			}
+			if distributionCut.Range.Max != tc.wantMax {
+				t.Errorf("Want range max '%v' got '%v'", tc.wantMax, distributionCut.Range.Max)
+			}
+		})
+	}
+}
@@ -0,0 +1,150 @@
+// Copyright 2020 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the 'License');
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an 'AS IS' BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package mon
+
+import (
+	"fmt"
+	"reflect"
+	"sort"
+
+	monitoringv3 "google.golang.org/api/monitoring/v3"
+)
+
+func checkAlertPolicy(alertPolicy, retrievedAlertPolicy *monitoringv3.AlertPolicy) (err error) {
+	var s string
+	if alertPolicy.Enabled != retrievedAlertPolicy.Enabled {
+		s = fmt.Sprintf("%senabled\nwant %v\nhave %v\n", s,
+			alertPolicy.Enabled,
+			retrievedAlertPolicy.Enabled)
+	}
+	if alertPolicy.Combiner != retrievedAlertPolicy.Combiner {
+		s = fmt.Sprintf("%scombiner\nwant %s\nhave %s\n", s,
+			alertPolicy.Combiner,
+			retrievedAlertPolicy.Combiner)
+	}
+	var documentation, retrievedDocumentation string
+	if alertPolicy.Documentation != nil {
+		documentation = alertPolicy.Documentation.Content
+	}
+	if retrievedAlertPolicy.Documentation != nil {
+		retrievedDocumentation = retrievedAlertPolicy.Documentation.Content
+	}
+	if documentation != retrievedDocumentation {
+		s = fmt.Sprintf("%sdocumentation\nwant %s\nhave %s\n", s,
+			documentation,
+			retrievedDocumentation)
+	}
+	var autoClose, retrievedAutoClose string
+	if alertPolicy.AlertStrategy != nil {
+		autoClose = alertPolicy.AlertStrategy.AutoClose
+	}
+	if retrievedAlertPolicy.AlertStrategy != nil {
+		retrievedAutoClose = retrievedAlertPolicy.AlertStrategy.AutoClose
+	}
+	if autoClose != "" && autoClose != retrievedAutoClose {
+		s = fmt.Sprintf("%salertStrategy.autoClose\nwant %s\nhave %s\n", s,
+			autoClose,
+			retrievedAutoClose)
+	}
+	notificationChannels := append([]string{}, alertPolicy.NotificationChannels...)
+	retrievedNotificationChannels := append([]string{}, retrievedAlertPolicy.NotificationChannels...)
+	sort.Strings(notificationChannels)
+	sort.Strings(retrievedNotificationChannels)
+	if !reflect.DeepEqual(notificationChannels, retrievedNotificationChannels) {
+		s = fmt.Sprintf("%snotificationChannels\nwant %v\nhave %v\n", s,
+			notificationChannels,
+			retrievedNotificationChannels)
+	}
+	if len(alertPolicy.Conditions) != len(retrievedAlertPolicy.Conditions) {
+		s = fmt.Sprintf("%sconditions\nwant %d\nhave %d\n", s,
+			len(alertPolicy.Conditions),
+			len(retrievedAlertPolicy.Conditions))
+	} else {
+		for i := range alertPolicy.Conditions {
+			s = fmt.Sprintf("%s%s", s, checkCondition(alertPolicy.Conditions[i], retrievedAlertPolicy.Conditions[i]))
+		}
+	}
+	if len(s) > 0 {
+		return fmt.Errorf("mon invalid alert policy configuration:\n%s", s)
+	}
+	return nil
+}
+
+func checkCondition(condition, retrievedCondition *monitoringv3.Condition) (s string) {
+	if condition.DisplayName != retrievedCondition.DisplayName {
+		s = fmt.Sprintf("%scondition.displayName\nwant %s\nhave %s\n", s,
+			condition.DisplayName,
+			retrievedCondition.DisplayName)
+	}
+	if condition.ConditionThreshold == nil {
+		return s
+	}
+	if retrievedCondition.ConditionThreshold == nil {
+		return fmt.Sprintf("%snot found retrievedCondition.ConditionThreshold %s\n", s, condition.DisplayName)
+	}
+	threshold := condition.ConditionThreshold
+	retrievedThreshold := retrievedCondition.ConditionThreshold
+	if threshold.Filter != retrievedThreshold.Filter {
+		s = fmt.Sprintf("%scondition %s filter\nwant %s\nhave %s\n", s,
+			condition.DisplayName,
+			threshold.Filter,
+			retrievedThreshold.Filter)
+	}
+	if threshold.Comparison != retrievedThreshold.Comparison {
+		s = fmt.Sprintf("%scondition %s comparison\nwant %s\nhave %s\n", s,
+			condition.DisplayName,
+			threshold.Comparison,
+			retrievedThreshold.Comparison)
+	}
+	if threshold.ThresholdValue != retrievedThreshold.ThresholdValue {
+		s = fmt.Sprintf("%scondition %s thresholdValue\nwant %v\nhave %v\n", s,
+			condition.DisplayName,
+			threshold.ThresholdValue,
+			retrievedThreshold.ThresholdValue)
+	}
+	if threshold.Duration != retrievedThreshold.Duration {
+		s = fmt.Sprintf("%scondition %s duration\nwant %s\nhave %s\n", s,
+			condition.DisplayName,
+			threshold.Duration,
+			retrievedThreshold.Duration)
+	}
+	if len(threshold.Aggregations) != len(retrievedThreshold.Aggregations) {
+		s = fmt.Sprintf("%scondition %s aggregations\nwant %d\nhave %d\n", s,
+			condition.DisplayName,
+			len(threshold.Aggregations),
+			len(retrievedThreshold.Aggregations))
+		return s
+	}
+	for i := range threshold.Aggregations {
+		aggregation := threshold.Aggregations[i]
+		retrievedAggregation := retrievedThreshold.Aggregations[i]
+		if aggregation.AlignmentPeriod != retrievedAggregation.AlignmentPeriod ||
+			aggregation.PerSeriesAligner != retrievedAggregation.PerSeriesAligner ||
+			aggregation.CrossSeriesReducer != retrievedAggregation.CrossSeriesReducer ||
+			!reflect.DeepEqual(aggregation.GroupByFields, retrievedAggregation.GroupByFields) {
+			s = fmt.Sprintf("%scondition %s aggregation\nwant %s %s %s %v\nhave %s %s %s %v\n", s,
+				condition.DisplayName,
+				aggregation.AlignmentPeriod,
+				aggregation.PerSeriesAligner,
+				aggregation.CrossSeriesReducer,
+				aggregation.GroupByFields,
+				retrievedAggregation.AlignmentPeriod,
+				retrievedAggregation.PerSeriesAligner,
+				retrievedAggregation.CrossSeriesReducer,
+				retrievedAggregation.GroupByFields)
+		}
+	}
+	return s
+}
@@ -0,0 +1,93 @@
+// Copyright 2020 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the 'License');
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an 'AS IS' BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package mon
+
+import (
+	"testing"
+
+	monitoringv3 "google.golang.org/api/monitoring/v3"
+)
+
+func TestUnitCheckAlertPolicy(t *testing.T) {
+	makeAlertPolicy := func(thresholdValue float64, perSeriesAligner string, notificationChannels []string) *monitoringv3.AlertPolicy {
+		var alertPolicy monitoringv3.AlertPolicy
+		alertPolicy.DisplayName = "RAM noretry"
+		alertPolicy.Enabled = true
+		alertPolicy.Combiner = "OR"
+		alertPolicy.NotificationChannels = notificationChannels
+		alertPolicy.Conditions = []*monitoringv3.Condition{
+			{
+				DisplayName: "noretry",
+				ConditionThreshold: &monitoringv3.MetricThreshold{
+					Filter:         `metric.type="logging.googleapis.com/user/ram_execution_status"`,
+					Comparison:     "COMPARISON_GT",
+					ThresholdValue: thresholdValue,
+					Duration:       "0s",
+					Aggregations: []*monitoringv3.Aggregation{
+						{
+							AlignmentPeriod:  "300s",
+							PerSeriesAligner: perSeriesAligner,
+						},
+					},
+				},
+			},
+		}
+		return &alertPolicy
+	}
+	tests := []struct {
+		name                 string
+		alertPolicy          *monitoringv3.AlertPolicy
+		retrievedAlertPolicy *monitoringv3.AlertPolicy
+		wantErr              bool
+	}{
+		{
+			name:                 "identical",
+			alertPolicy:          makeAlertPolicy(0, "ALIGN_SUM", []string{"projects/qwerty/notificationChannels/1", "projects/qwerty/notificationChannels/2"}),
+			retrievedAlertPolicy: makeAlertPolicy(0, "ALIGN_SUM", []string{"projects/qwerty/notificationChannels/2", "projects/qwerty/notificationChannels/1"}),
+			wantErr:              false,
+		},
+		{
+			name:                 "thresholdValue",
+			alertPolicy:          makeAlertPolicy(1, "ALIGN_SUM", nil),
+			retrievedAlertPolicy: makeAlertPolicy(0, "ALIGN_SUM", nil),
+			wantErr:              true,
+		},
+		{
+			name:                 "aggregation",
+			alertPolicy:          makeAlertPolicy(0, "ALIGN_SUM", nil),
+			retrievedAlertPolicy: makeAlertPolicy(0, "ALIGN_COUNT", nil),
+			wantErr:              true,
+		},
+		{
+			name:                 "notificationChannels",
+			alertPolicy:          makeAlertPolicy(0, "ALIGN_SUM", []string{"projects/qwerty/notificationChannels/1"}),
+			retrievedAlertPolicy: makeAlertPolicy(0, "ALIGN_SUM", nil),
+			wantErr:              true,
+		},
+	}
+	for _, tc := range tests {
+		tc := tc // https://github.com/golang/go/wiki/CommonMistakes#using-goroutines-on-loop-iterator-variables
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+			err := checkAlertPolicy(tc.alertPolicy, tc.retrievedAlertPolicy)
+			if err != nil {
+				t.Log(err.Error())
+			}
+			if (err != nil) != tc.wantErr {
+				t.Errorf("error = %v, wantErr %v", err, tc.wantErr)
+			}
+		})
+	}
+}
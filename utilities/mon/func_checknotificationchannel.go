@@ -0,0 +1,52 @@
+// Copyright 2020 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the 'License');
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an 'AS IS' BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package mon
+
+import (
+	"fmt"
+	"reflect"
+
+	monitoringv3 "google.golang.org/api/monitoring/v3"
+)
+
+func checkNotificationChannel(notificationChannel, retrievedNotificationChannel *monitoringv3.NotificationChannel) (err error) {
+	var s string
+	if notificationChannel.Type != retrievedNotificationChannel.Type {
+		s = fmt.Sprintf("%stype\nwant %s\nhave %s\n", s,
+			notificationChannel.Type,
+			retrievedNotificationChannel.Type)
+	}
+	if notificationChannel.Description != retrievedNotificationChannel.Description {
+		s = fmt.Sprintf("%sdescription\nwant %s\nhave %s\n", s,
+			notificationChannel.Description,
+			retrievedNotificationChannel.Description)
+	}
+	if notificationChannel.Enabled != retrievedNotificationChannel.Enabled {
+		s = fmt.Sprintf("%senabled\nwant %v\nhave %v\n", s,
+			notificationChannel.Enabled,
+			retrievedNotificationChannel.Enabled)
+	}
+	if len(notificationChannel.Labels) > 0 || len(retrievedNotificationChannel.Labels) > 0 {
+		if !reflect.DeepEqual(notificationChannel.Labels, retrievedNotificationChannel.Labels) {
+			s = fmt.Sprintf("%slabels\nwant %v\nhave %v\n", s,
+				notificationChannel.Labels,
+				retrievedNotificationChannel.Labels)
+		}
+	}
+	if len(s) > 0 {
+		return fmt.Errorf("mon invalid notification channel configuration:\n%s", s)
+	}
+	return nil
+}
@@ -0,0 +1,79 @@
+// Copyright 2020 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the 'License');
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an 'AS IS' BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package mon
+
+import (
+	"fmt"
+
+	monitoringv3 "google.golang.org/api/monitoring/v3"
+)
+
+func checkServiceLevelObjective(serviceLevelObjective, retrievedServiceLevelObjective *monitoringv3.ServiceLevelObjective) (err error) {
+	var s string
+	if serviceLevelObjective.DisplayName != retrievedServiceLevelObjective.DisplayName {
+		s = fmt.Sprintf("%sdisplayName\nwant %s\nhave %s\n", s,
+			serviceLevelObjective.DisplayName,
+			retrievedServiceLevelObjective.DisplayName)
+	}
+	if serviceLevelObjective.Goal != retrievedServiceLevelObjective.Goal {
+		s = fmt.Sprintf("%sgoal\nwant %v\nhave %v\n", s,
+			serviceLevelObjective.Goal,
+			retrievedServiceLevelObjective.Goal)
+	}
+	if serviceLevelObjective.RollingPeriod != retrievedServiceLevelObjective.RollingPeriod {
+		s = fmt.Sprintf("%srollingPeriod\nwant %s\nhave %s\n", s,
+			serviceLevelObjective.RollingPeriod,
+			retrievedServiceLevelObjective.RollingPeriod)
+	}
+	distributionCut := getDistributionCut(serviceLevelObjective)
+	retrievedDistributionCut := getDistributionCut(retrievedServiceLevelObjective)
+	if distributionCut != nil {
+		if retrievedDistributionCut != nil {
+			if distributionCut.DistributionFilter != retrievedDistributionCut.DistributionFilter {
+				s = fmt.Sprintf("%sdistributionCut.distributionFilter\nwant %s\nhave %s\n", s,
+					distributionCut.DistributionFilter,
+					retrievedDistributionCut.DistributionFilter)
+			}
+			var max, retrievedMax float64
+			if distributionCut.Range != nil {
+				max = distributionCut.Range.Max
+			}
+			if retrievedDistributionCut.Range != nil {
+				retrievedMax = retrievedDistributionCut.Range.Max
+			}
+			if max != retrievedMax {
+				s = fmt.Sprintf("%sdistributionCut.range.max\nwant %v\nhave %v\n", s,
+					max,
+					retrievedMax)
+			}
+		} else {
+			s = fmt.Sprintf("%snot found retrievedServiceLevelObjective distributionCut\n", s)
+		}
+	}
+	if len(s) > 0 {
+		return fmt.Errorf("mon invalid service level objective configuration:\n%s", s)
+	}
+	return nil
+}
+
+func getDistributionCut(serviceLevelObjective *monitoringv3.ServiceLevelObjective) *monitoringv3.DistributionCut {
+	if serviceLevelObjective.ServiceLevelIndicator == nil {
+		return nil
+	}
+	if serviceLevelObjective.ServiceLevelIndicator.RequestBased == nil {
+		return nil
+	}
+	return serviceLevelObjective.ServiceLevelIndicator.RequestBased.DistributionCut
+}
@@ -0,0 +1,25 @@
+// Copyright 2020 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the 'License');
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an 'AS IS' BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package mon
+
+import "math"
+
+// GetFreshnessThresholdSeconds returns the upper bound in seconds of a RAM latency bucket
+// RAM latency metrics use exponential buckets with a sqrt(2) growth factor and a 0.01 scale
+func GetFreshnessThresholdSeconds(cutOffBucketNumber int64) float64 {
+	grouwthFactor := math.Sqrt(2)
+	scale := 0.01
+	return scale * math.Pow(grouwthFactor, float64(cutOffBucketNumber))
+}
@@ -0,0 +1,54 @@
+// Copyright 2020 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the 'License');
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an 'AS IS' BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package mon
+
+import (
+	"math"
+	"testing"
+)
+
+func TestUnitGetFreshnessThresholdSeconds(t *testing.T) {
+	tests := []struct {
+		name               string
+		cutOffBucketNumber int64
+		want               float64
+	}{
+		{
+			name:               "bucket0",
+			cutOffBucketNumber: 0,
+			want:               0.01,
+		},
+		{
+			name:               "bucket20",
+			cutOffBucketNumber: 20,
+			want:               10.24,
+		},
+		{
+			name:               "bucket40",
+			cutOffBucketNumber: 40,
+			want:               10485.76,
+		},
+	}
+	for _, tc := range tests {
+		tc := tc // https://github.com/golang/go/wiki/CommonMistakes#using-goroutines-on-loop-iterator-variables
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+			got := GetFreshnessThresholdSeconds(tc.cutOffBucketNumber)
+			if math.Abs(got-tc.want) > 1e-6 {
+				t.Errorf("Want '%v' got '%v'", tc.want, got)
+			}
+		})
+	}
+}
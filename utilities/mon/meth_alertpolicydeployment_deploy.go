@@ -0,0 +1,126 @@
+// Copyright 2020 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the 'License');
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an 'AS IS' BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package mon
+
+import (
+	"fmt"
+	"log"
+
+	"github.com/BrunoReboul/ram/utilities/deploy"
+	monitoringv3 "google.golang.org/api/monitoring/v3"
+)
+
+// Deploy get-create-update notification channels, then get-create-update the alert policy using them
+func (alertPolicyDeployment AlertPolicyDeployment) Deploy() (err error) {
+	log.Printf("%s mon alert policy", alertPolicyDeployment.Core.InstanceName)
+	alertPolicy := alertPolicyDeployment.Artifacts.AlertPolicy
+	alertPolicy.NotificationChannels = []string{}
+	for i := range alertPolicyDeployment.Artifacts.NotificationChannels {
+		notificationChannelName, err := alertPolicyDeployment.deployNotificationChannel(&alertPolicyDeployment.Artifacts.NotificationChannels[i])
+		if err != nil {
+			return err
+		}
+		if notificationChannelName != "" {
+			alertPolicy.NotificationChannels = append(alertPolicy.NotificationChannels, notificationChannelName)
+		}
+	}
+
+	alertPoliciesService := monitoringv3.NewProjectsAlertPoliciesService(alertPolicyDeployment.Core.Services.MonitoringServicev3)
+	parent := fmt.Sprintf("projects/%s", alertPolicyDeployment.Core.SolutionSettings.Hosting.Stackdriver.ProjectID)
+	resourceReport := alertPolicyDeployment.Core.StartResourceReport("mon alert policy", alertPolicy.DisplayName)
+	defer resourceReport.End(&err)
+
+	// GET
+	response, err := alertPoliciesService.List(parent).
+		Filter(fmt.Sprintf("display_name=\"%s\"", alertPolicy.DisplayName)).
+		Context(alertPolicyDeployment.Core.Ctx).Do()
+	if err != nil {
+		return fmt.Errorf("alertPoliciesService.List %v", err)
+	}
+	if len(response.AlertPolicies) == 0 {
+		if alertPolicyDeployment.Core.Commands.Check {
+			return fmt.Errorf("%s mon alert policy NOT found for this instance", alertPolicyDeployment.Core.InstanceName)
+		}
+		createdAlertPolicy, err := alertPoliciesService.Create(parent, &alertPolicy).Context(alertPolicyDeployment.Core.Ctx).Do()
+		if err != nil {
+			return fmt.Errorf("alertPoliciesService.Create %v", err)
+		}
+		resourceReport.Action = deploy.ActionCreated
+		log.Printf("%s mon alert policy created %s", alertPolicyDeployment.Core.InstanceName, createdAlertPolicy.Name)
+		return nil
+	}
+	retrievedAlertPolicy := response.AlertPolicies[0]
+	log.Printf("%s mon found alert policy %s", alertPolicyDeployment.Core.InstanceName, retrievedAlertPolicy.Name)
+	err = checkAlertPolicy(&alertPolicy, retrievedAlertPolicy)
+	if err != nil {
+		resourceReport.Drift = append(resourceReport.Drift, err.Error())
+		if alertPolicyDeployment.Core.Commands.Check {
+			return err
+		}
+		log.Printf("%s mon alert policy need to be updated", alertPolicyDeployment.Core.InstanceName)
+		updatedAlertPolicy, err := alertPoliciesService.Patch(retrievedAlertPolicy.Name, &alertPolicy).Context(alertPolicyDeployment.Core.Ctx).Do()
+		if err != nil {
+			return fmt.Errorf("alertPoliciesService.Patch %v", err)
+		}
+		resourceReport.Action = deploy.ActionUpdated
+		log.Printf("%s mon alert policy updated %s", alertPolicyDeployment.Core.InstanceName, updatedAlertPolicy.Name)
+	}
+	return nil
+}
+
+// deployNotificationChannel get-create-update a notification channel and returns its name
+func (alertPolicyDeployment AlertPolicyDeployment) deployNotificationChannel(notificationChannel *monitoringv3.NotificationChannel) (notificationChannelName string, err error) {
+	notificationChannelsService := monitoringv3.NewProjectsNotificationChannelsService(alertPolicyDeployment.Core.Services.MonitoringServicev3)
+	parent := fmt.Sprintf("projects/%s", alertPolicyDeployment.Core.SolutionSettings.Hosting.Stackdriver.ProjectID)
+	resourceReport := alertPolicyDeployment.Core.StartResourceReport("mon notification channel", notificationChannel.DisplayName)
+	defer resourceReport.End(&err)
+
+	// GET
+	response, err := notificationChannelsService.List(parent).
+		Filter(fmt.Sprintf("display_name=\"%s\"", notificationChannel.DisplayName)).
+		Context(alertPolicyDeployment.Core.Ctx).Do()
+	if err != nil {
+		return "", fmt.Errorf("notificationChannelsService.List %v", err)
+	}
+	if len(response.NotificationChannels) == 0 {
+		if alertPolicyDeployment.Core.Commands.Check {
+			return "", fmt.Errorf("%s mon notification channel NOT found '%s'", alertPolicyDeployment.Core.InstanceName, notificationChannel.DisplayName)
+		}
+		createdNotificationChannel, err := notificationChannelsService.Create(parent, notificationChannel).Context(alertPolicyDeployment.Core.Ctx).Do()
+		if err != nil {
+			return "", fmt.Errorf("notificationChannelsService.Create %v", err)
+		}
+		resourceReport.Action = deploy.ActionCreated
+		log.Printf("%s mon notification channel created %s", alertPolicyDeployment.Core.InstanceName, createdNotificationChannel.Name)
+		return createdNotificationChannel.Name, nil
+	}
+	retrievedNotificationChannel := response.NotificationChannels[0]
+	log.Printf("%s mon found notification channel %s", alertPolicyDeployment.Core.InstanceName, retrievedNotificationChannel.Name)
+	err = checkNotificationChannel(notificationChannel, retrievedNotificationChannel)
+	if err != nil {
+		resourceReport.Drift = append(resourceReport.Drift, err.Error())
+		if alertPolicyDeployment.Core.Commands.Check {
+			return "", err
+		}
+		log.Printf("%s mon notification channel need to be updated", alertPolicyDeployment.Core.InstanceName)
+		updatedNotificationChannel, err := notificationChannelsService.Patch(retrievedNotificationChannel.Name, notificationChannel).Context(alertPolicyDeployment.Core.Ctx).Do()
+		if err != nil {
+			return "", fmt.Errorf("notificationChannelsService.Patch %v", err)
+		}
+		resourceReport.Action = deploy.ActionUpdated
+		log.Printf("%s mon notification channel updated %s", alertPolicyDeployment.Core.InstanceName, updatedNotificationChannel.Name)
+	}
+	return retrievedNotificationChannel.Name, nil
+}
@@ -20,17 +20,26 @@ import (
 
 	"reflect"
 	"strings"
+	"sync"
 
+	"github.com/BrunoReboul/ram/utilities/deploy"
 	"google.golang.org/api/monitoring/v1"
 )
 
 var dashboardID, dashboardDisplayName string
 
+// deployMutex serializes deployments sharing the package level variables used by the pages browsing function, e.g. ramcli -parallel
+var deployMutex sync.Mutex
+
 // Deploy dashboard
 func (dashboardDeployment DashboardDeployment) Deploy() (err error) {
+	deployMutex.Lock()
+	defer deployMutex.Unlock()
 	dashboardService := monitoring.NewProjectsDashboardsService(dashboardDeployment.Core.Services.MonitoringService)
 	parent := fmt.Sprintf("projects/%s", dashboardDeployment.Core.SolutionSettings.Hosting.Stackdriver.ProjectID)
 	dashboardDisplayName = dashboardDeployment.Settings.Instance.MON.DisplayName
+	resourceReport := dashboardDeployment.Core.StartResourceReport("mon dashboard", dashboardDisplayName)
+	defer resourceReport.End(&err)
 	dashboardID = ""
 	err = dashboardService.List(parent).Pages(dashboardDeployment.Core.Ctx, browseDashboards)
 	if err != nil {
@@ -71,10 +80,12 @@ func (dashboardDeployment DashboardDeployment) Deploy() (err error) {
 			if !reflect.DeepEqual(dashboardDeployment.Artifacts.Widgets, retreivedDashboard.GridLayout.Widgets) {
 				// needToUpdateWidgets = true
 				needToUpdate = true
+				resourceReport.Drift = append(resourceReport.Drift, "widgets")
 			}
 			if dashboardDeployment.Settings.Instance.MON.GridLayout.Columns != retreivedDashboard.GridLayout.Columns {
 				// needToUpdateColumns = true
 				needToUpdate = true
+				resourceReport.Drift = append(resourceReport.Drift, "columns")
 			}
 		}
 
@@ -105,10 +116,12 @@ func (dashboardDeployment DashboardDeployment) Deploy() (err error) {
 				if !reflect.DeepEqual(dashboardDeployment.Artifacts.Tiles, retreivedDashboard.MosaicLayout.Tiles) {
 					// needToUpdateTiles = true
 					needToUpdate = true
+					resourceReport.Drift = append(resourceReport.Drift, "tiles")
 				}
 				if dashboardDeployment.Settings.Instance.MON.SLOFreshnessLayout.Columns != retreivedDashboard.MosaicLayout.Columns {
 					// needToUpdateColumns = true
 					needToUpdate = true
+					resourceReport.Drift = append(resourceReport.Drift, "columns")
 				}
 			}
 			if dashboardDeployment.Core.Commands.Check {
@@ -138,6 +151,7 @@ func (dashboardDeployment DashboardDeployment) Deploy() (err error) {
 		if err != nil {
 			return err
 		}
+		resourceReport.Action = deploy.ActionCreated
 		log.Printf("mon dashboard created '%s' %s", retreivedDashboard.DisplayName, retreivedDashboard.Name)
 	} else {
 		// Patch dashboard
@@ -147,6 +161,7 @@ func (dashboardDeployment DashboardDeployment) Deploy() (err error) {
 			if err != nil {
 				return err
 			}
+			resourceReport.Action = deploy.ActionUpdated
 			log.Printf("mon dashboard updated '%s' %s", retreivedDashboard.DisplayName, retreivedDashboard.Name)
 		} else {
 			log.Printf("mon dashboard is up-to-date '%s'", dashboardDeployment.Settings.Instance.MON.DisplayName)
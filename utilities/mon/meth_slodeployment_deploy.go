@@ -0,0 +1,124 @@
+// Copyright 2020 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the 'License');
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an 'AS IS' BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package mon
+
+import (
+	"fmt"
+	"log"
+	"strings"
+
+	"github.com/BrunoReboul/ram/utilities/deploy"
+	monitoringv3 "google.golang.org/api/monitoring/v3"
+)
+
+// Deploy get-create-update the service monitoring custom service, then get-create-update its service level objective
+func (sloDeployment SLODeployment) Deploy() (err error) {
+	log.Printf("%s mon service level objective", sloDeployment.Core.InstanceName)
+	if err = sloDeployment.deployService(); err != nil {
+		return err
+	}
+	servicesServiceLevelObjectivesService := monitoringv3.NewServicesServiceLevelObjectivesService(sloDeployment.Core.Services.MonitoringServicev3)
+	serviceName := fmt.Sprintf("projects/%s/services/%s",
+		sloDeployment.Core.SolutionSettings.Hosting.Stackdriver.ProjectID,
+		sloDeployment.Settings.Instance.MON.ServiceID)
+	sloName := fmt.Sprintf("%s/serviceLevelObjectives/%s", serviceName, sloDeployment.Settings.Instance.MON.SLOID)
+	resourceReport := sloDeployment.Core.StartResourceReport("mon service level objective", sloName)
+	defer resourceReport.End(&err)
+
+	// GET
+	retrievedServiceLevelObjective, err := servicesServiceLevelObjectivesService.Get(sloName).Context(sloDeployment.Core.Ctx).Do()
+	if err != nil {
+		if !strings.Contains(strings.ToLower(err.Error()), "notfound") {
+			return fmt.Errorf("servicesServiceLevelObjectivesService.Get %v", err)
+		}
+		if sloDeployment.Core.Commands.Check {
+			return fmt.Errorf("%s mon service level objective NOT found for this instance", sloDeployment.Core.InstanceName)
+		}
+		createdServiceLevelObjective, err := servicesServiceLevelObjectivesService.Create(serviceName, &sloDeployment.Artifacts.ServiceLevelObjective).
+			ServiceLevelObjectiveId(sloDeployment.Settings.Instance.MON.SLOID).
+			Context(sloDeployment.Core.Ctx).Do()
+		if err != nil {
+			return fmt.Errorf("servicesServiceLevelObjectivesService.Create %v", err)
+		}
+		resourceReport.Action = deploy.ActionCreated
+		log.Printf("%s mon service level objective created %s", sloDeployment.Core.InstanceName, createdServiceLevelObjective.Name)
+		return nil
+	}
+	log.Printf("%s mon found service level objective %s", sloDeployment.Core.InstanceName, retrievedServiceLevelObjective.Name)
+	err = checkServiceLevelObjective(&sloDeployment.Artifacts.ServiceLevelObjective, retrievedServiceLevelObjective)
+	if err != nil {
+		resourceReport.Drift = append(resourceReport.Drift, err.Error())
+		if sloDeployment.Core.Commands.Check {
+			return err
+		}
+		log.Printf("%s mon service level objective need to be updated", sloDeployment.Core.InstanceName)
+		updatedServiceLevelObjective, err := servicesServiceLevelObjectivesService.Patch(sloName, &sloDeployment.Artifacts.ServiceLevelObjective).
+			UpdateMask("displayName,goal,rollingPeriod,serviceLevelIndicator").
+			Context(sloDeployment.Core.Ctx).Do()
+		if err != nil {
+			return fmt.Errorf("servicesServiceLevelObjectivesService.Patch %v", err)
+		}
+		resourceReport.Action = deploy.ActionUpdated
+		log.Printf("%s mon service level objective updated %s", sloDeployment.Core.InstanceName, updatedServiceLevelObjective.Name)
+	}
+	return nil
+}
+
+func (sloDeployment SLODeployment) deployService() (err error) {
+	servicesService := monitoringv3.NewServicesService(sloDeployment.Core.Services.MonitoringServicev3)
+	parent := fmt.Sprintf("projects/%s", sloDeployment.Core.SolutionSettings.Hosting.Stackdriver.ProjectID)
+	serviceName := fmt.Sprintf("%s/services/%s", parent, sloDeployment.Settings.Instance.MON.ServiceID)
+	resourceReport := sloDeployment.Core.StartResourceReport("mon service", serviceName)
+	defer resourceReport.End(&err)
+
+	// GET
+	retrievedService, err := servicesService.Get(serviceName).Context(sloDeployment.Core.Ctx).Do()
+	if err != nil {
+		if !strings.Contains(strings.ToLower(err.Error()), "notfound") {
+			return fmt.Errorf("servicesService.Get %v", err)
+		}
+		if sloDeployment.Core.Commands.Check {
+			return fmt.Errorf("%s mon service NOT found for this instance", sloDeployment.Core.InstanceName)
+		}
+		createdService, err := servicesService.Create(parent, &sloDeployment.Artifacts.Service).
+			ServiceId(sloDeployment.Settings.Instance.MON.ServiceID).
+			Context(sloDeployment.Core.Ctx).Do()
+		if err != nil {
+			return fmt.Errorf("servicesService.Create %v", err)
+		}
+		resourceReport.Action = deploy.ActionCreated
+		log.Printf("%s mon service created %s", sloDeployment.Core.InstanceName, createdService.Name)
+		return nil
+	}
+	log.Printf("%s mon found service %s", sloDeployment.Core.InstanceName, retrievedService.Name)
+	if retrievedService.DisplayName != sloDeployment.Artifacts.Service.DisplayName {
+		resourceReport.Drift = append(resourceReport.Drift, "displayName")
+		if sloDeployment.Core.Commands.Check {
+			return fmt.Errorf("%s mon invalid service configuration:\ndisplayName\nwant %s\nhave %s\n",
+				sloDeployment.Core.InstanceName,
+				sloDeployment.Artifacts.Service.DisplayName,
+				retrievedService.DisplayName)
+		}
+		updatedService, err := servicesService.Patch(serviceName, &sloDeployment.Artifacts.Service).
+			UpdateMask("displayName").
+			Context(sloDeployment.Core.Ctx).Do()
+		if err != nil {
+			return fmt.Errorf("servicesService.Patch %v", err)
+		}
+		resourceReport.Action = deploy.ActionUpdated
+		log.Printf("%s mon service updated %s", sloDeployment.Core.InstanceName, updatedService.Name)
+	}
+	return nil
+}
@@ -0,0 +1,51 @@
+// Copyright 2020 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the 'License');
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an 'AS IS' BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package mon
+
+// AlertPolicyParameters structure
+type AlertPolicyParameters struct {
+	DisplayName          string                          `yaml:"displayName" valid:"isNotZeroValue"`
+	Documentation        string                          `yaml:"documentation,omitempty"`
+	Combiner             string                          `yaml:"combiner,omitempty"`
+	AutoClose            string                          `yaml:"autoClose,omitempty"`
+	Conditions           []AlertConditionParameters      `yaml:"conditions"`
+	NotificationChannels []NotificationChannelParameters `yaml:"notificationChannels,omitempty"`
+}
+
+// AlertConditionParameters structure, a threshold on a metric filter, or a burn rate on a service level objective when burnRate is set
+type AlertConditionParameters struct {
+	DisplayName        string   `yaml:"displayName"`
+	Filter             string   `yaml:"filter,omitempty"`
+	Comparison         string   `yaml:"comparison,omitempty"`
+	ThresholdValue     float64  `yaml:"thresholdValue"`
+	Duration           string   `yaml:"duration,omitempty"`
+	AlignmentPeriod    string   `yaml:"alignmentPeriod,omitempty"`
+	PerSeriesAligner   string   `yaml:"perSeriesAligner,omitempty"`
+	CrossSeriesReducer string   `yaml:"crossSeriesReducer,omitempty"`
+	GroupByFields      []string `yaml:"groupByFields,omitempty"`
+	BurnRate           struct {
+		ServiceID      string `yaml:"serviceID,omitempty"`
+		SLOID          string `yaml:"sloID,omitempty"`
+		LookbackPeriod string `yaml:"lookbackPeriod,omitempty"`
+	} `yaml:"burnRate,omitempty"`
+}
+
+// NotificationChannelParameters structure
+type NotificationChannelParameters struct {
+	DisplayName string            `yaml:"displayName"`
+	Type        string            `yaml:"type"`
+	Description string            `yaml:"description,omitempty"`
+	Labels      map[string]string `yaml:"labels,omitempty"`
+}
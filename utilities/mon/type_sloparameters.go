@@ -0,0 +1,30 @@
+// Copyright 2020 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the 'License');
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an 'AS IS' BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package mon
+
+// SLOParameters structure, a service monitoring custom service and one of its service level objectives
+type SLOParameters struct {
+	ServiceID          string  `yaml:"serviceID" valid:"isNotZeroValue"`
+	ServiceDisplayName string  `yaml:"serviceDisplayName"`
+	SLOID              string  `yaml:"sloID" valid:"isNotZeroValue"`
+	DisplayName        string  `yaml:"displayName"`
+	Origin             string  `yaml:"origin" valid:"isNotZeroValue"`
+	Flow               string  `yaml:"flow"`
+	MetricID           string  `yaml:"metricID,omitempty"`
+	MicroserviceName   string  `yaml:"microserviceName,omitempty"`
+	Goal               float64 `yaml:"goal" valid:"inOpenRange(0|1)"`
+	RollingPeriod      string  `yaml:"rollingPeriod,omitempty" valid:"isDuration"`
+	CutOffBucketNumber int64   `yaml:"cutOffBucketNumber"`
+}
@@ -18,6 +18,8 @@ import (
 	"flag"
 	"fmt"
 	"os"
+	"strings"
+	"time"
 
 	"github.com/BrunoReboul/ram/utilities/ffo"
 	"github.com/BrunoReboul/ram/utilities/solution"
@@ -30,10 +32,23 @@ func (deployment *Deployment) CheckArguments() (err error) {
 	flag.BoolVar(&deployment.Core.Commands.MakeReleasePipeline, "pipe", false, "make release pipeline using cloud build to deploy one instance, one microservice, or all")
 	flag.BoolVar(&deployment.Core.Commands.Deploy, "deploy", false, "deploy one microservice instance")
 	flag.BoolVar(&deployment.Core.Commands.Check, "check", false, "with -pipe it checks if configured instances have a cloud build trigger, with -deploy a running cloud function")
+	flag.BoolVar(&deployment.Core.Commands.PruneIAM, "prune-iam", false, "with -pipe or -deploy removes the roles RAM granted that are no longer wanted, by default they are only reported as stale")
 	flag.BoolVar(&deployment.Core.Commands.Dumpsettings, "dump", false, fmt.Sprintf("dump all settings in %s", solution.SettingsFileName))
+	flag.BoolVar(&deployment.Core.Commands.Export, "export", false, "request dumpinventory instances a point-in-time export, requires -at")
+	var removedServices = flag.String("removed-services", "", "with -pipe or -deploy on the whole repository, comma separated microservices removed from the repository, e.g. listusers,listgroups, their recorded grants are reported as stale, and removed with -prune-iam")
+	var exportAt = flag.String("at", "", "point-in-time of the export in RFC3339 format e.g. 2020-09-13T12:00:00Z, within the last 35 days")
+	flag.IntVar(&deployment.Core.Parallel, "parallel", 1, "with -pipe or -deploy the number of instances deployed concurrently, the first instance of each microservice is deployed before to set shared prerequisites, then producers before consumers. Above 1 the run does not stop on the first error, all errors are reported at the end")
+	flag.StringVar(&deployment.Core.ReportFilePath, "report", "", "Path to the optional JSON report of the run, e.g. ramcli_report.json")
+	flag.StringVar(&deployment.Core.JUnitReportFilePath, "junit", "", "Path to the optional JUnit XML report of the run, e.g. for CI")
 	flag.StringVar(&deployment.Core.RepositoryPath, "repo", ".", "Path to the root of the code repository")
 	flag.StringVar(&deployment.Core.RamcliServiceAccount, "ramclisa", "", "Email of Service Account used when running ramcli")
 	var assetType = flag.String("asset", "", "asset type e.g. k8s.io/Pod")
+	flag.StringVar(&deployment.NewRule.Name, "newrule", "", "scaffold a new monitor rule <serviceName>_<rule> e.g. gce_serial_port, requires -asset")
+	flag.StringVar(&deployment.NewRule.Severity, "severity", "major", fmt.Sprintf("with -newrule the constraint severity %v", ruleSeverities))
+	flag.StringVar(&deployment.ImportPolicyPath, "import-policy", "", "Path to a GCP policy library clone, creates or updates monitor instances from its templates and samples, keeps existing constraints")
+	flag.BoolVar(&deployment.Core.Commands.AnalyzePrivileges, "analyze-privileges", false, "compare the permissions exercised by microservices and Cloud Build service accounts in audit logs with the ones granted by their run and deploy roles, suggests minimal custom roles")
+	flag.StringVar(&deployment.Privileges.AuditLogsFilePath, "auditlogs", "", "with -analyze-privileges path to exported audit logs in JSON, e.g. from gcloud logging read --format json, default queries cloud logging")
+	flag.IntVar(&deployment.Privileges.Days, "days", 30, "with -analyze-privileges the number of days of audit logs to query")
 	var microserviceFolderName = flag.String("service", "", "Microservice folder name")
 	var instanceFolderName = flag.String("instance", "", "Instance folder name")
 	flag.StringVar(&deployment.Core.EnvironmentName, "environment", solution.DevelopmentEnvironmentName, "Environment name")
@@ -50,6 +65,77 @@ func (deployment *Deployment) CheckArguments() (err error) {
 	if deployment.Core.Commands.Deploy && deployment.Core.Commands.MakeReleasePipeline {
 		return fmt.Errorf("-pipe and -deploy are mutually exclusive, starts with -pipe then do -deploy")
 	}
+	if deployment.Core.Commands.PruneIAM {
+		if !deployment.Core.Commands.MakeReleasePipeline && !deployment.Core.Commands.Deploy {
+			return fmt.Errorf("-prune-iam can be used only in conjuction with -pipe or -deploy")
+		}
+		if deployment.Core.Commands.Check {
+			return fmt.Errorf("-prune-iam and -check are mutually exclusive, -check reports stale grants without removing them")
+		}
+	}
+	if *removedServices != "" {
+		if !deployment.Core.Commands.MakeReleasePipeline && !deployment.Core.Commands.Deploy {
+			return fmt.Errorf("-removed-services can be used only in conjuction with -pipe or -deploy")
+		}
+		// A partial run does not tell which microservices are removed
+		if *microserviceFolderName != "" || *instanceFolderName != "" || *assetType != "" {
+			return fmt.Errorf("-removed-services requires a run on the whole repository, without -service, -instance or -asset")
+		}
+		deployment.RemovedServiceNames = strings.Split(*removedServices, ",")
+	}
+	if deployment.Core.Parallel < 1 {
+		return fmt.Errorf("-parallel must be at least 1, got %d", deployment.Core.Parallel)
+	}
+	if deployment.Core.Parallel > 1 {
+		if !deployment.Core.Commands.MakeReleasePipeline && !deployment.Core.Commands.Deploy {
+			return fmt.Errorf("-parallel can be used only in conjuction with -pipe or -deploy")
+		}
+	}
+	if deployment.Core.Commands.Export {
+		if deployment.Core.Commands.Deploy || deployment.Core.Commands.MakeReleasePipeline {
+			return fmt.Errorf("-export cannot be used in conjuction with -pipe or -deploy")
+		}
+		if *exportAt == "" {
+			return fmt.Errorf("-export requires -at")
+		}
+		deployment.Core.ExportReadTime, err = time.Parse(time.RFC3339, *exportAt)
+		if err != nil {
+			return fmt.Errorf("-at %v", err)
+		}
+		if deployment.Core.ExportReadTime.After(time.Now()) {
+			return fmt.Errorf("-at %s is in the future", *exportAt)
+		}
+		if time.Since(deployment.Core.ExportReadTime) > 35*24*time.Hour {
+			return fmt.Errorf("-at %s is older than the 35 days Cloud Asset Inventory keeps", *exportAt)
+		}
+	}
+	if deployment.Core.Commands.AnalyzePrivileges {
+		if deployment.Core.Commands.Deploy || deployment.Core.Commands.MakeReleasePipeline || deployment.Core.Commands.Export {
+			return fmt.Errorf("-analyze-privileges cannot be used in conjuction with -pipe, -deploy or -export")
+		}
+		if deployment.Privileges.Days < 1 {
+			return fmt.Errorf("-days must be at least 1, got %d", deployment.Privileges.Days)
+		}
+	}
+	if deployment.NewRule.Name != "" {
+		if deployment.Core.Commands.Deploy || deployment.Core.Commands.MakeReleasePipeline || deployment.Core.Commands.Export {
+			return fmt.Errorf("-newrule cannot be used in conjuction with -pipe, -deploy or -export")
+		}
+		if *assetType == "" {
+			return fmt.Errorf("-newrule requires -asset")
+		}
+		deployment.NewRule.AssetType = *assetType
+		return nil
+	}
+	if deployment.ImportPolicyPath != "" {
+		if deployment.Core.Commands.Deploy || deployment.Core.Commands.MakeReleasePipeline || deployment.Core.Commands.Export {
+			return fmt.Errorf("-import-policy cannot be used in conjuction with -pipe, -deploy or -export")
+		}
+		if _, err := os.Stat(deployment.ImportPolicyPath); err != nil {
+			return fmt.Errorf("-import-policy %v", err)
+		}
+		return nil
+	}
 	// case one instance
 	if *instanceFolderName != "" {
 		if *microserviceFolderName == "" {
@@ -0,0 +1,49 @@
+// Copyright 2020 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the 'License');
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an 'AS IS' BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ramcli
+
+import "sort"
+
+// getDeploymentStages split the instance list in two stages keeping the initial order within a microservice:
+// 1) the first instance of each microservice, that deploys the prerequisites shared by the microservice instances: APIs, service account, custom roles, bindings, topics, datasets
+// 2) the other instances, in waves of same service rank so producers are deployed before their consumers, the instances of a wave can then be deployed concurrently
+func getDeploymentStages(instanceFolderRelativePaths []string) (prerequisites []string, waves [][]string) {
+	serviceNames := make(map[string]bool)
+	othersByRank := make(map[int][]string)
+	for _, instanceFolderRelativePath := range instanceFolderRelativePaths {
+		serviceName, _ := getServiceAndInstanceNames(instanceFolderRelativePath)
+		if serviceNames[serviceName] {
+			rank := getServiceRank(serviceName)
+			othersByRank[rank] = append(othersByRank[rank], instanceFolderRelativePath)
+		} else {
+			serviceNames[serviceName] = true
+			prerequisites = append(prerequisites, instanceFolderRelativePath)
+		}
+	}
+	sort.SliceStable(prerequisites, func(i, j int) bool {
+		serviceNameI, _ := getServiceAndInstanceNames(prerequisites[i])
+		serviceNameJ, _ := getServiceAndInstanceNames(prerequisites[j])
+		return getServiceRank(serviceNameI) < getServiceRank(serviceNameJ)
+	})
+	var ranks []int
+	for rank := range othersByRank {
+		ranks = append(ranks, rank)
+	}
+	sort.Ints(ranks)
+	for _, rank := range ranks {
+		waves = append(waves, othersByRank[rank])
+	}
+	return prerequisites, waves
+}
@@ -0,0 +1,88 @@
+// Copyright 2020 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the 'License');
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an 'AS IS' BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ramcli
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestUnitGetDeploymentStages(t *testing.T) {
+	var testCases = []struct {
+		name                        string
+		instanceFolderRelativePaths []string
+		prerequisites               string
+		waves                       string
+	}{
+		{
+			name:                        "empty",
+			instanceFolderRelativePaths: []string{},
+			prerequisites:               "",
+			waves:                       "",
+		},
+		{
+			name: "oneInstance",
+			instanceFolderRelativePaths: []string{
+				"services/setfeeds/instances/setfeeds_org1234_k8s_Pod",
+			},
+			prerequisites: "services/setfeeds/instances/setfeeds_org1234_k8s_Pod",
+			waves:         "",
+		},
+		{
+			name: "mixture",
+			instanceFolderRelativePaths: []string{
+				"services/setfeeds/instances/setfeeds_org1234_k8s_Pod",
+				"services/setfeeds/instances/setfeeds_org1234_k8s_Node",
+				"services/monitor/instances/monitor_gcp_bq_dataset_location",
+				"services/setfeeds/instances/setfeeds_folder5678_k8s_Pod",
+				"services/monitor/instances/monitor_gcp_gcs_bucket_location",
+				"services/stream2bq/instances/stream2bq_rces_k8s_Pod",
+			},
+			prerequisites: "services/setfeeds/instances/setfeeds_org1234_k8s_Pod,services/monitor/instances/monitor_gcp_bq_dataset_location,services/stream2bq/instances/stream2bq_rces_k8s_Pod",
+			waves:         "services/setfeeds/instances/setfeeds_org1234_k8s_Node,services/setfeeds/instances/setfeeds_folder5678_k8s_Pod;services/monitor/instances/monitor_gcp_gcs_bucket_location",
+		},
+		{
+			name: "consumersListedFirst",
+			instanceFolderRelativePaths: []string{
+				"services/stream2bq/instances/stream2bq_violations",
+				"services/stream2bq/instances/stream2bq_rces_k8s_Pod",
+				"services/publish2fs/instances/publish2fs_rces_k8s_Pod",
+				"services/monitor/instances/monitor_gcp_bq_dataset_location",
+				"services/monitor/instances/monitor_gcp_gcs_bucket_location",
+				"services/setfeeds/instances/setfeeds_org1234_k8s_Pod",
+				"services/setfeeds/instances/setfeeds_org1234_k8s_Node",
+			},
+			prerequisites: "services/setfeeds/instances/setfeeds_org1234_k8s_Pod,services/monitor/instances/monitor_gcp_bq_dataset_location,services/stream2bq/instances/stream2bq_violations,services/publish2fs/instances/publish2fs_rces_k8s_Pod",
+			waves:         "services/setfeeds/instances/setfeeds_org1234_k8s_Node;services/monitor/instances/monitor_gcp_gcs_bucket_location;services/stream2bq/instances/stream2bq_rces_k8s_Pod",
+		},
+	}
+	for _, tc := range testCases {
+		tc := tc // https://github.com/golang/go/wiki/CommonMistakes#using-goroutines-on-loop-iterator-variables
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+			prerequisites, waves := getDeploymentStages(tc.instanceFolderRelativePaths)
+			if strings.Join(prerequisites, ",") != tc.prerequisites {
+				t.Errorf("Want prerequisites '%s' got '%s'", tc.prerequisites, strings.Join(prerequisites, ","))
+			}
+			var gotWaves []string
+			for _, wave := range waves {
+				gotWaves = append(gotWaves, strings.Join(wave, ","))
+			}
+			if strings.Join(gotWaves, ";") != tc.waves {
+				t.Errorf("Want waves '%s' got '%s'", tc.waves, strings.Join(gotWaves, ";"))
+			}
+		})
+	}
+}
@@ -0,0 +1,72 @@
+// Copyright 2020 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the 'License');
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an 'AS IS' BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ramcli
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"sort"
+
+	"github.com/BrunoReboul/ram/utilities/str"
+)
+
+// getExercisedPermissions returns by principal email the permissions checked in audit logs, a JSON array or one JSON entry per line
+// Denied permissions are kept as they were exercised by the code even if not granted
+func getExercisedPermissions(auditLogs []byte) (exercisedPermissions map[string][]string, err error) {
+	exercisedPermissions = make(map[string][]string)
+	auditLogs = bytes.TrimSpace(auditLogs)
+	if bytes.HasPrefix(auditLogs, []byte("[")) {
+		var entries []auditLogEntry
+		if err = json.Unmarshal(auditLogs, &entries); err != nil {
+			return exercisedPermissions, fmt.Errorf("json.Unmarshal audit logs %v", err)
+		}
+		for _, entry := range entries {
+			addExercisedPermissions(exercisedPermissions, entry.ProtoPayload)
+		}
+		return exercisedPermissions, nil
+	}
+	scanner := bufio.NewScanner(bytes.NewReader(auditLogs))
+	scanner.Buffer(make([]byte, 0, 64*1024), 10*1024*1024)
+	lineNumber := 0
+	for scanner.Scan() {
+		lineNumber++
+		line := bytes.TrimSpace(scanner.Bytes())
+		if len(line) == 0 {
+			continue
+		}
+		var entry auditLogEntry
+		if err = json.Unmarshal(line, &entry); err != nil {
+			return exercisedPermissions, fmt.Errorf("json.Unmarshal audit log line %d %v", lineNumber, err)
+		}
+		addExercisedPermissions(exercisedPermissions, entry.ProtoPayload)
+	}
+	return exercisedPermissions, scanner.Err()
+}
+
+// addExercisedPermissions adds the permissions of one audit log payload to the sorted permissions of its principal
+func addExercisedPermissions(exercisedPermissions map[string][]string, protoPayload auditLogProtoPayload) {
+	principalEmail := protoPayload.AuthenticationInfo.PrincipalEmail
+	if principalEmail == "" {
+		return
+	}
+	for _, authorizationInfo := range protoPayload.AuthorizationInfo {
+		if authorizationInfo.Permission != "" && !str.Find(exercisedPermissions[principalEmail], authorizationInfo.Permission) {
+			exercisedPermissions[principalEmail] = append(exercisedPermissions[principalEmail], authorizationInfo.Permission)
+		}
+	}
+	sort.Strings(exercisedPermissions[principalEmail])
+}
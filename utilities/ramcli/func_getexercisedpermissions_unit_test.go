@@ -0,0 +1,72 @@
+// Copyright 2020 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the 'License');
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an 'AS IS' BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ramcli
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+)
+
+func TestUnitGetExercisedPermissions(t *testing.T) {
+	var testCases = []struct {
+		name           string
+		auditLogs      string
+		principalEmail string
+		want           string
+		wantErrorMsg   string
+	}{
+		{
+			name: "jsonArray",
+			auditLogs: `[
+{"protoPayload":{"authenticationInfo":{"principalEmail":"stream2bq@ram.iam.gserviceaccount.com"},"authorizationInfo":[{"permission":"bigquery.tables.updateData","granted":true}]}},
+{"protoPayload":{"authenticationInfo":{"principalEmail":"stream2bq@ram.iam.gserviceaccount.com"},"authorizationInfo":[{"permission":"bigquery.tables.get","granted":true},{"permission":"bigquery.tables.updateData","granted":true}]}},
+{"protoPayload":{"authenticationInfo":{"principalEmail":"monitor@ram.iam.gserviceaccount.com"},"authorizationInfo":[{"permission":"pubsub.topics.publish","granted":true}]}}
+]`,
+			principalEmail: "stream2bq@ram.iam.gserviceaccount.com",
+			want:           "[bigquery.tables.get bigquery.tables.updateData]",
+		},
+		{
+			name: "jsonLinesWithDenied",
+			auditLogs: `{"protoPayload":{"authenticationInfo":{"principalEmail":"monitor@ram.iam.gserviceaccount.com"},"authorizationInfo":[{"permission":"pubsub.topics.publish","granted":true}]}}
+
+{"protoPayload":{"authenticationInfo":{"principalEmail":"monitor@ram.iam.gserviceaccount.com"},"authorizationInfo":[{"permission":"datastore.entities.get","granted":false}]}}`,
+			principalEmail: "monitor@ram.iam.gserviceaccount.com",
+			want:           "[datastore.entities.get pubsub.topics.publish]",
+		},
+		{
+			name:         "invalidLine",
+			auditLogs:    `{"protoPayload":`,
+			wantErrorMsg: "line 1",
+		},
+	}
+	for _, tc := range testCases {
+		tc := tc // https://github.com/golang/go/wiki/CommonMistakes#using-goroutines-on-loop-iterator-variables
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+			exercisedPermissions, err := getExercisedPermissions([]byte(tc.auditLogs))
+			if err != nil {
+				if tc.wantErrorMsg == "" || !strings.Contains(err.Error(), tc.wantErrorMsg) {
+					t.Errorf("Want error message '%s' got '%s'", tc.wantErrorMsg, err.Error())
+				}
+				return
+			}
+			got := fmt.Sprintf("%v", exercisedPermissions[tc.principalEmail])
+			if got != tc.want {
+				t.Errorf("Want '%s' got '%s'", tc.want, got)
+			}
+		})
+	}
+}
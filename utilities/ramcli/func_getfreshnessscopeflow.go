@@ -0,0 +1,36 @@
+// Copyright 2020 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the 'License');
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an 'AS IS' BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ramcli
+
+import "fmt"
+
+// getFreshnessScopeFlow returns the scope and flow of a freshness SLO origin, e.g. batch-listgroups returns Groups and batch
+func getFreshnessScopeFlow(origin string) (scope string, flow string, err error) {
+	switch origin {
+	case "batch-export":
+		return "GCP", "batch", nil
+	case "real-time":
+		return "GCP", "real-time", nil
+	case "batch-listgroups":
+		return "Groups", "batch", nil
+	case "batch-listusers":
+		return "Users", "batch", nil
+	case "batch-listdirectorysettings":
+		return "Settings", "batch", nil
+	case "real-time-log-export":
+		return "Groups", "real-time", nil
+	}
+	return "", "", fmt.Errorf("unknown freshness SLO origin '%s'", origin)
+}
@@ -0,0 +1,87 @@
+// Copyright 2020 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the 'License');
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an 'AS IS' BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ramcli
+
+import (
+	"testing"
+)
+
+func TestUnitGetFreshnessScopeFlow(t *testing.T) {
+	var testCases = []struct {
+		name      string
+		origin    string
+		wantScope string
+		wantFlow  string
+		wantErr   bool
+	}{
+		{
+			name:      "batchExport",
+			origin:    "batch-export",
+			wantScope: "GCP",
+			wantFlow:  "batch",
+		},
+		{
+			name:      "realTime",
+			origin:    "real-time",
+			wantScope: "GCP",
+			wantFlow:  "real-time",
+		},
+		{
+			name:      "batchListGroups",
+			origin:    "batch-listgroups",
+			wantScope: "Groups",
+			wantFlow:  "batch",
+		},
+		{
+			name:      "batchListUsers",
+			origin:    "batch-listusers",
+			wantScope: "Users",
+			wantFlow:  "batch",
+		},
+		{
+			name:      "batchListDirectorySettings",
+			origin:    "batch-listdirectorysettings",
+			wantScope: "Settings",
+			wantFlow:  "batch",
+		},
+		{
+			name:      "realTimeLogExport",
+			origin:    "real-time-log-export",
+			wantScope: "Groups",
+			wantFlow:  "real-time",
+		},
+		{
+			name:    "unknown",
+			origin:  "blabla",
+			wantErr: true,
+		},
+	}
+	for _, tc := range testCases {
+		tc := tc // https://github.com/golang/go/wiki/CommonMistakes#using-goroutines-on-loop-iterator-variables
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+			scope, flow, err := getFreshnessScopeFlow(tc.origin)
+			if (err != nil) != tc.wantErr {
+				t.Errorf("Want error %v got %v", tc.wantErr, err)
+			}
+			if scope != tc.wantScope {
+				t.Errorf("Want scope '%s' got '%s'", tc.wantScope, scope)
+			}
+			if flow != tc.wantFlow {
+				t.Errorf("Want flow '%s' got '%s'", tc.wantFlow, flow)
+			}
+		})
+	}
+}
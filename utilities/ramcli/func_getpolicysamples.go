@@ -0,0 +1,56 @@
+// Copyright 2020 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the 'License');
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an 'AS IS' BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ramcli
+
+import (
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"gopkg.in/yaml.v2"
+)
+
+// getPolicySamples reads the constraint samples of the GCP policy library, a missing samples folder returns no samples
+func getPolicySamples(samplesFolderPath string) (samples []policySample, err error) {
+	fileInfos, err := ioutil.ReadDir(samplesFolderPath)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return samples, nil
+		}
+		return samples, err
+	}
+	for _, fileInfo := range fileInfos {
+		if fileInfo.IsDir() || !strings.HasSuffix(fileInfo.Name(), ".yaml") {
+			continue
+		}
+		filePath := filepath.Join(samplesFolderPath, fileInfo.Name())
+		b, err := ioutil.ReadFile(filePath)
+		if err != nil {
+			return samples, err
+		}
+		var sample policySample
+		if err = yaml.Unmarshal(b, &sample); err != nil {
+			return samples, fmt.Errorf("yaml.Unmarshal %s %v", filePath, err)
+		}
+		if sample.Kind == "" || sample.Metadata.Name == "" {
+			continue
+		}
+		sample.fileBytes = b
+		samples = append(samples, sample)
+	}
+	return samples, nil
+}
@@ -0,0 +1,48 @@
+// Copyright 2020 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the 'License');
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an 'AS IS' BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ramcli
+
+import "fmt"
+
+// policyTemplateTarget is the policy library target evaluated by config validator, and by RAM
+const policyTemplateTarget = "validation.gcp.forsetisecurity.org"
+
+// getPolicyTemplateRego returns the rego code of the config validator target, for both v1alpha1 (map) and v1beta1 (list) targets
+// The target libs are not returned: the lib functions they define are reported as unsupported when used by the rego code
+func getPolicyTemplateRego(targets interface{}) (rego string, err error) {
+	var target map[interface{}]interface{}
+	switch t := targets.(type) {
+	case map[interface{}]interface{}:
+		if v, ok := t[policyTemplateTarget].(map[interface{}]interface{}); ok {
+			target = v
+		}
+	case []interface{}:
+		for _, item := range t {
+			if v, ok := item.(map[interface{}]interface{}); ok {
+				if v["target"] == policyTemplateTarget {
+					target = v
+				}
+			}
+		}
+	}
+	if target == nil {
+		return "", fmt.Errorf("no %s target found", policyTemplateTarget)
+	}
+	rego, _ = target["rego"].(string)
+	if rego == "" {
+		return "", fmt.Errorf("no rego found in %s target", policyTemplateTarget)
+	}
+	return rego, nil
+}
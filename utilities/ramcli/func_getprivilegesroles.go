@@ -0,0 +1,45 @@
+// Copyright 2020 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the 'License');
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an 'AS IS' BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ramcli
+
+import (
+	"github.com/BrunoReboul/ram/utilities/grm"
+	"github.com/BrunoReboul/ram/utilities/iamgt"
+	"github.com/BrunoReboul/ram/utilities/str"
+	"google.golang.org/api/iam/v1"
+)
+
+// getPrivilegesRoles lists the custom roles and the predefined roles granted to a service account
+// from its custom role definitions, its resource manager bindings and its roles on service accounts
+func getPrivilegesRoles(customRolesSets [][]iam.Role, grmBindings grm.Bindings, iamBindings iamgt.Bindings) (customRoles []iam.Role, predefinedRoles []string) {
+	customRoles = make([]iam.Role, 0)
+	for _, customRolesSet := range customRolesSets {
+		customRoles = append(customRoles, customRolesSet...)
+	}
+	predefinedRoles = make([]string, 0)
+	for _, roles := range [][]string{
+		grmBindings.Hosting.Org.Roles,
+		grmBindings.Hosting.Folder.Roles,
+		grmBindings.Hosting.Project.Roles,
+		grmBindings.Monitoring.Org.Roles,
+		iamBindings.RolesOnServiceAccounts} {
+		for _, role := range roles {
+			if !str.Find(predefinedRoles, role) {
+				predefinedRoles = append(predefinedRoles, role)
+			}
+		}
+	}
+	return customRoles, predefinedRoles
+}
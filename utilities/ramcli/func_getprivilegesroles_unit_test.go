@@ -0,0 +1,88 @@
+// Copyright 2020 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the 'License');
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an 'AS IS' BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ramcli
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/BrunoReboul/ram/utilities/grm"
+	"github.com/BrunoReboul/ram/utilities/iamgt"
+	"google.golang.org/api/iam/v1"
+)
+
+func TestUnitGetPrivilegesRoles(t *testing.T) {
+	var deployGRMBindings grm.Bindings
+	deployGRMBindings.Hosting.Project.Roles = []string{"roles/cloudfunctions.developer", "roles/pubsub.editor"}
+	deployGRMBindings.Hosting.Project.CustomRoles = []string{"ram_stream2bq_project_deploy_core"}
+	deployGRMBindings.Monitoring.Org.Roles = []string{"roles/pubsub.editor"}
+	var deployIAMBindings iamgt.Bindings
+	deployIAMBindings.RolesOnServiceAccounts = []string{"roles/iam.serviceAccountUser"}
+
+	var runGRMBindings grm.Bindings
+	runGRMBindings.Hosting.Org.Roles = []string{"roles/browser"}
+	runGRMBindings.Hosting.Folder.Roles = []string{"roles/browser"}
+
+	var testCases = []struct {
+		name                string
+		customRolesSets     [][]iam.Role
+		grmBindings         grm.Bindings
+		iamBindings         iamgt.Bindings
+		wantCustomRoles     []string
+		wantPredefinedRoles []string
+	}{
+		{
+			name: "deployRolesAndCloudBuildBindings",
+			customRolesSets: [][]iam.Role{
+				{},
+				{{Title: "ram_setfeeds_monitoring_org_deploy_core"}},
+				{{Title: "ram_stream2bq_project_deploy_core"}, {Title: "ram_stream2bq_project_deploy_extended"}}},
+			grmBindings:         deployGRMBindings,
+			iamBindings:         deployIAMBindings,
+			wantCustomRoles:     []string{"ram_setfeeds_monitoring_org_deploy_core", "ram_stream2bq_project_deploy_core", "ram_stream2bq_project_deploy_extended"},
+			wantPredefinedRoles: []string{"roles/cloudfunctions.developer", "roles/pubsub.editor", "roles/iam.serviceAccountUser"},
+		},
+		{
+			name:                "runRolesAndFunctionBindings",
+			customRolesSets:     [][]iam.Role{{{Title: "ram_stream2bq_run"}}, nil, nil},
+			grmBindings:         runGRMBindings,
+			wantCustomRoles:     []string{"ram_stream2bq_run"},
+			wantPredefinedRoles: []string{"roles/browser"},
+		},
+		{
+			name:                "noRole",
+			customRolesSets:     [][]iam.Role{nil, nil, nil},
+			wantCustomRoles:     []string{},
+			wantPredefinedRoles: []string{},
+		},
+	}
+	for _, tc := range testCases {
+		tc := tc // https://github.com/golang/go/wiki/CommonMistakes#using-goroutines-on-loop-iterator-variables
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+			customRoles, predefinedRoles := getPrivilegesRoles(tc.customRolesSets, tc.grmBindings, tc.iamBindings)
+			customRolesTitles := make([]string, 0)
+			for _, role := range customRoles {
+				customRolesTitles = append(customRolesTitles, role.Title)
+			}
+			if !reflect.DeepEqual(customRolesTitles, tc.wantCustomRoles) {
+				t.Errorf("Want custom roles %v got %v", tc.wantCustomRoles, customRolesTitles)
+			}
+			if !reflect.DeepEqual(predefinedRoles, tc.wantPredefinedRoles) {
+				t.Errorf("Want predefined roles %v got %v", tc.wantPredefinedRoles, predefinedRoles)
+			}
+		})
+	}
+}
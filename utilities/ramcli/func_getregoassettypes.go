@@ -0,0 +1,33 @@
+// Copyright 2020 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the 'License');
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an 'AS IS' BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ramcli
+
+import (
+	"regexp"
+
+	"github.com/BrunoReboul/ram/utilities/str"
+)
+
+var regoAssetTypeRegex = regexp.MustCompile(`asset_type\s*==\s*"([^"]+)"`)
+
+// getRegoAssetTypes returns the deduplicated list of asset types a rego rule filters on
+func getRegoAssetTypes(rego string) (assetTypes []string) {
+	for _, match := range regoAssetTypeRegex.FindAllStringSubmatch(rego, -1) {
+		if !str.Find(assetTypes, match[1]) {
+			assetTypes = append(assetTypes, match[1])
+		}
+	}
+	return assetTypes
+}
@@ -0,0 +1,47 @@
+// Copyright 2020 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the 'License');
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an 'AS IS' BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ramcli
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/BrunoReboul/ram/utilities/str"
+)
+
+// supportedLibFunctions are the validator.gcp.lib functions provided by the monitor rego modules: constraints.rego and util.rego
+var supportedLibFunctions = []string{"get_constraint_params", "get_constraint_info", "has_field", "get_default"}
+
+// unsupportedInputs are the inputs config validator provides while the monitor audit rule does not
+var unsupportedInputs = []string{"data.inventory", "input.review", "input.parameters"}
+
+var regoLibFunctionRegex = regexp.MustCompile(`lib\.([a-zA-Z0-9_]+)\(`)
+
+// getRegoUnsupportedInputs lists what a policy library rego depends on that the monitor microservice does not provide
+func getRegoUnsupportedInputs(rego string) (unsupported []string) {
+	for _, input := range unsupportedInputs {
+		if strings.Contains(rego, input) {
+			unsupported = append(unsupported, input)
+		}
+	}
+	for _, match := range regoLibFunctionRegex.FindAllStringSubmatch(rego, -1) {
+		libFunction := fmt.Sprintf("lib.%s", match[1])
+		if !str.Find(supportedLibFunctions, match[1]) && !str.Find(unsupported, libFunction) {
+			unsupported = append(unsupported, libFunction)
+		}
+	}
+	return unsupported
+}
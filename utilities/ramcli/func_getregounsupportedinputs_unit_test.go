@@ -0,0 +1,60 @@
+// Copyright 2020 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the 'License');
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an 'AS IS' BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ramcli
+
+import (
+	"fmt"
+	"testing"
+)
+
+func TestUnitGetRegoUnsupportedInputs(t *testing.T) {
+	var testCases = []struct {
+		name           string
+		rego           string
+		wantInputs     string
+		wantAssetTypes string
+	}{
+		{
+			name:           "supported",
+			rego:           `asset.asset_type == "storage.googleapis.com/Bucket"` + "\n" + `params := lib.get_constraint_params(constraint)`,
+			wantInputs:     "[]",
+			wantAssetTypes: "[storage.googleapis.com/Bucket]",
+		},
+		{
+			name:           "inventory",
+			rego:           `asset.asset_type == "compute.googleapis.com/Firewall"` + "\n" + `subnetworks := data.inventory[asset.ancestry_path]`,
+			wantInputs:     "[data.inventory]",
+			wantAssetTypes: "[compute.googleapis.com/Firewall]",
+		},
+		{
+			name:           "unsupportedLibFunction",
+			rego:           `asset.asset_type == "k8s.io/Pod"` + "\n" + `asset.asset_type == "k8s.io/Pod"` + "\n" + `lib.get_annotations(asset)`,
+			wantInputs:     "[lib.get_annotations]",
+			wantAssetTypes: "[k8s.io/Pod]",
+		},
+	}
+	for _, tc := range testCases {
+		tc := tc // https://github.com/golang/go/wiki/CommonMistakes#using-goroutines-on-loop-iterator-variables
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+			if got := fmt.Sprintf("%v", getRegoUnsupportedInputs(tc.rego)); got != tc.wantInputs {
+				t.Errorf("Want '%s' got '%s'", tc.wantInputs, got)
+			}
+			if got := fmt.Sprintf("%v", getRegoAssetTypes(tc.rego)); got != tc.wantAssetTypes {
+				t.Errorf("Want '%s' got '%s'", tc.wantAssetTypes, got)
+			}
+		})
+	}
+}
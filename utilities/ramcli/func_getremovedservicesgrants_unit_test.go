@@ -0,0 +1,65 @@
+// Copyright 2020 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the 'License');
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an 'AS IS' BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ramcli
+
+import (
+	"testing"
+
+	"github.com/BrunoReboul/ram/utilities/gfs"
+)
+
+func TestUnitGetRemovedServicesGrants(t *testing.T) {
+	var testCases = []struct {
+		name                string
+		iamGrants           gfs.IAMGrants
+		removedServiceNames []string
+		wantRemoved         bool
+	}{
+		{
+			name:                "serviceNotListedAsRemoved",
+			iamGrants:           gfs.IAMGrants{ServiceName: "monitor", Roles: []string{"roles/pubsub.publisher"}},
+			removedServiceNames: []string{"listusers"},
+			wantRemoved:         false,
+		},
+		{
+			name:                "serviceRemoved",
+			iamGrants:           gfs.IAMGrants{ServiceName: "listusers", Roles: []string{"roles/datastore.user"}},
+			removedServiceNames: []string{"listgroups", "listusers"},
+			wantRemoved:         true,
+		},
+		{
+			name:                "alreadyPruned",
+			iamGrants:           gfs.IAMGrants{ServiceName: "listusers", Roles: []string{}},
+			removedServiceNames: []string{"listusers"},
+			wantRemoved:         false,
+		},
+		{
+			name:                "noServiceName",
+			iamGrants:           gfs.IAMGrants{Roles: []string{"roles/datastore.user"}},
+			removedServiceNames: []string{"listusers"},
+			wantRemoved:         false,
+		},
+	}
+	for _, tc := range testCases {
+		tc := tc // https://github.com/golang/go/wiki/CommonMistakes#using-goroutines-on-loop-iterator-variables
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+			removed := len(getRemovedServicesGrants([]gfs.IAMGrants{tc.iamGrants}, tc.removedServiceNames)) == 1
+			if removed != tc.wantRemoved {
+				t.Errorf("Want '%v' got '%v'", tc.wantRemoved, removed)
+			}
+		})
+	}
+}
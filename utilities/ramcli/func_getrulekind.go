@@ -0,0 +1,31 @@
+// Copyright 2020 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the 'License');
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an 'AS IS' BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ramcli
+
+import (
+	"fmt"
+	"strings"
+)
+
+// getRuleKind returns the constraint template kind from the rule name, e.g. gce_serial_port returns GCPGceSerialPortConstraintV1
+func getRuleKind(ruleName string) string {
+	var kind string
+	for _, part := range strings.Split(ruleName, "_") {
+		if part != "" {
+			kind = kind + strings.ToUpper(part[:1]) + part[1:]
+		}
+	}
+	return fmt.Sprintf("GCP%sConstraintV1", kind)
+}
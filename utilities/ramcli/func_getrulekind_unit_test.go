@@ -0,0 +1,53 @@
+// Copyright 2020 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the 'License');
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an 'AS IS' BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ramcli
+
+import (
+	"testing"
+)
+
+func TestUnitGetRuleKind(t *testing.T) {
+	var testCases = []struct {
+		name     string
+		ruleName string
+		wantKind string
+	}{
+		{
+			name:     "standard",
+			ruleName: "gce_serial_port",
+			wantKind: "GCPGceSerialPortConstraintV1",
+		},
+		{
+			name:     "digits",
+			ruleName: "gke_v1beta1_api",
+			wantKind: "GCPGkeV1beta1ApiConstraintV1",
+		},
+		{
+			name:     "doubleUnderscore",
+			ruleName: "bq__location",
+			wantKind: "GCPBqLocationConstraintV1",
+		},
+	}
+	for _, tc := range testCases {
+		tc := tc // https://github.com/golang/go/wiki/CommonMistakes#using-goroutines-on-loop-iterator-variables
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+			kind := getRuleKind(tc.ruleName)
+			if kind != tc.wantKind {
+				t.Errorf("Want '%s' got '%s'", tc.wantKind, kind)
+			}
+		})
+	}
+}
@@ -0,0 +1,34 @@
+// Copyright 2020 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the 'License');
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an 'AS IS' BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ramcli
+
+// getServiceRank returns the deployment rank of a microservice, the ones producing messages, topics or metrics coming before their consumers
+// e.g. monitor creates the violation and compliance status topics consumed by stream2bq and publish2fs
+func getServiceRank(serviceName string) int {
+	switch serviceName {
+	case "setlogsinks", "setlogmetrics", "setfeeds", "dumpinventory", "listgroups", "listusers", "listdirectorysettings":
+		return 0
+	case "convertlog2feed", "splitdump", "listgroupmembers", "getgroupsettings":
+		return 1
+	case "monitor":
+		return 2
+	case "publish2fs", "stream2bq", "upload2gcs":
+		return 3
+	case "consolidategcs", "setslos", "setalerts", "setdashboards":
+		return 4
+	default:
+		return 0
+	}
+}
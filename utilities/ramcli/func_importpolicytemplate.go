@@ -0,0 +1,133 @@
+// Copyright 2020 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the 'License');
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an 'AS IS' BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ramcli
+
+import (
+	"bytes"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+
+	"github.com/BrunoReboul/ram/services/monitor"
+	"github.com/BrunoReboul/ram/utilities/cai"
+	"github.com/BrunoReboul/ram/utilities/deploy"
+	"github.com/BrunoReboul/ram/utilities/ffo"
+	"github.com/BrunoReboul/ram/utilities/solution"
+	"gopkg.in/yaml.v2"
+)
+
+var policyTemplateVersionRegex = regexp.MustCompile(`_v[0-9]+$`)
+
+// getPolicyTemplateInstanceName returns the monitor instance name from the policy library template file name, e.g. gcp_bq_dataset_location_v1.yaml returns monitor_bq_dataset_location
+func getPolicyTemplateInstanceName(templateFilePath string) string {
+	name := strings.TrimSuffix(filepath.Base(templateFilePath), filepath.Ext(templateFilePath))
+	name = strings.ToLower(strings.Replace(name, "-", "_", -1))
+	name = strings.TrimPrefix(name, "gcp_")
+	name = policyTemplateVersionRegex.ReplaceAllString(name, "")
+	return fmt.Sprintf("monitor_%s", name)
+}
+
+// importPolicyTemplate creates or updates the monitor instance of one policy library template
+// The rego rule is always refreshed from upstream, while existing instance settings and constraints are kept to not clobber local parameters
+// The action is skipped with notes when the template depends on inputs the monitor microservice does not provide
+func importPolicyTemplate(repositoryPath, templateFilePath string, samples []policySample, iamPoliciesTopicName string) (instanceName, action string, notes []string, err error) {
+	instanceName = getPolicyTemplateInstanceName(templateFilePath)
+	b, err := ioutil.ReadFile(templateFilePath)
+	if err != nil {
+		return instanceName, deploy.ActionFailed, notes, err
+	}
+	var template policyTemplate
+	if err = yaml.Unmarshal(b, &template); err != nil {
+		return instanceName, deploy.ActionFailed, notes, fmt.Errorf("yaml.Unmarshal %s %v", templateFilePath, err)
+	}
+	kind := template.Spec.CRD.Spec.Names.Kind
+	if template.Kind != "ConstraintTemplate" || kind == "" {
+		return instanceName, deploy.ActionSkipped, append(notes, "not a ConstraintTemplate"), nil
+	}
+	rego, err := getPolicyTemplateRego(template.Spec.Targets)
+	if err != nil {
+		return instanceName, deploy.ActionSkipped, append(notes, err.Error()), nil
+	}
+	for _, unsupported := range getRegoUnsupportedInputs(rego) {
+		notes = append(notes, fmt.Sprintf("unsupported %s", unsupported))
+	}
+	var triggerTopic string
+	assetTypes := getRegoAssetTypes(rego)
+	switch true {
+	case strings.Contains(rego, "iam_policy"):
+		triggerTopic = iamPoliciesTopicName
+	case len(assetTypes) == 1:
+		triggerTopic = fmt.Sprintf("cai-rces-%s", cai.GetAssetShortTypeName(assetTypes[0]))
+	default:
+		notes = append(notes, fmt.Sprintf("unsupported %d asset types %v, one trigger topic per monitor instance", len(assetTypes), assetTypes))
+	}
+	if len(notes) > 0 {
+		return instanceName, deploy.ActionSkipped, notes, nil
+	}
+
+	action = deploy.ActionUnchanged
+	instanceFolderPath := fmt.Sprintf("%s/%s/monitor/%s/%s", repositoryPath, solution.MicroserviceParentFolderName, solution.InstancesFolderName, instanceName)
+	if _, err := os.Stat(instanceFolderPath); os.IsNotExist(err) {
+		if err = os.MkdirAll(instanceFolderPath, 0755); err != nil {
+			return instanceName, deploy.ActionFailed, notes, err
+		}
+		var monitorInstanceDeployment monitor.InstanceDeployment
+		monitorInstance := monitorInstanceDeployment.Settings.Instance
+		monitorInstance.GCF.TriggerTopic = triggerTopic
+		if err = ffo.MarshalYAMLWrite(fmt.Sprintf("%s/%s", instanceFolderPath, solution.InstanceSettingsFileName), monitorInstance); err != nil {
+			return instanceName, deploy.ActionFailed, notes, err
+		}
+		action = deploy.ActionCreated
+	}
+	regoFilePath := fmt.Sprintf("%s/%s.rego", instanceFolderPath, instanceName)
+	existingRego, _ := ioutil.ReadFile(regoFilePath)
+	if !bytes.Equal(existingRego, []byte(rego)) {
+		if err = ioutil.WriteFile(regoFilePath, []byte(rego), 0644); err != nil {
+			return instanceName, deploy.ActionFailed, notes, err
+		}
+		if action == deploy.ActionUnchanged {
+			action = deploy.ActionUpdated
+			notes = append(notes, "rego")
+		}
+	}
+	for _, sample := range samples {
+		if sample.Kind != kind {
+			continue
+		}
+		constraintName := strings.Replace(sample.Metadata.Name, "-", "_", -1)
+		constraintFolderPath := fmt.Sprintf("%s/%s/%s", instanceFolderPath, solution.RegoConstraintsFolderName, constraintName)
+		if _, err := os.Stat(constraintFolderPath); err == nil {
+			// keep local constraint parameters
+			continue
+		}
+		if err = os.MkdirAll(constraintFolderPath, 0755); err != nil {
+			return instanceName, deploy.ActionFailed, notes, err
+		}
+		if err = ioutil.WriteFile(fmt.Sprintf("%s/constraint.yaml", constraintFolderPath), sample.fileBytes, 0644); err != nil {
+			return instanceName, deploy.ActionFailed, notes, err
+		}
+		notes = append(notes, fmt.Sprintf("+constraint %s", constraintName))
+		if _, ok := sample.Spec.Match["ancestries"]; ok || strings.Contains(fmt.Sprintf("%v", sample.Spec.Match), "**") {
+			notes = append(notes, fmt.Sprintf("constraint %s match uses policy library ancestries globs, review it as monitor matches ancestry_path with regex targets", constraintName))
+		}
+		if action == deploy.ActionUnchanged {
+			action = deploy.ActionUpdated
+		}
+	}
+	return instanceName, action, notes, nil
+}
@@ -0,0 +1,127 @@
+// Copyright 2020 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the 'License');
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an 'AS IS' BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ramcli
+
+import (
+	"fmt"
+	"io/ioutil"
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/BrunoReboul/ram/utilities/deploy"
+)
+
+func TestUnitImportPolicyTemplate(t *testing.T) {
+	var testCases = []struct {
+		name               string
+		templateFileName   string
+		wantInstanceName   string
+		wantAction         string
+		wantReimportAction string
+		wantNote           string
+		wantTriggerTopic   string
+	}{
+		{
+			name:               "supportedV1beta1",
+			templateFileName:   "gcp_storage_logging_v1.yaml",
+			wantInstanceName:   "monitor_storage_logging",
+			wantAction:         deploy.ActionCreated,
+			wantReimportAction: deploy.ActionUnchanged,
+			wantNote:           "+constraint require_storage_logging",
+			wantTriggerTopic:   "triggerTopic: cai-rces-storage-Bucket",
+		},
+		{
+			name:               "unsupportedInventoryV1alpha1",
+			templateFileName:   "gcp_network_enable_firewall_logs_v1.yaml",
+			wantInstanceName:   "monitor_network_enable_firewall_logs",
+			wantAction:         deploy.ActionSkipped,
+			wantReimportAction: deploy.ActionSkipped,
+			wantNote:           "unsupported data.inventory",
+		},
+		{
+			name:               "unsupportedMultipleAssetTypes",
+			templateFileName:   "gcp_resource_labels_v1.yaml",
+			wantInstanceName:   "monitor_resource_labels",
+			wantAction:         deploy.ActionSkipped,
+			wantReimportAction: deploy.ActionSkipped,
+			wantNote:           "unsupported 2 asset types",
+		},
+	}
+	samples, err := getPolicySamples("testdata/policy_library/samples")
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, tc := range testCases {
+		tc := tc // https://github.com/golang/go/wiki/CommonMistakes#using-goroutines-on-loop-iterator-variables
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+			repositoryPath, err := ioutil.TempDir("", "importpolicy")
+			if err != nil {
+				t.Fatal(err)
+			}
+			defer os.RemoveAll(repositoryPath)
+			templateFilePath := fmt.Sprintf("testdata/policy_library/policies/templates/%s", tc.templateFileName)
+
+			instanceName, action, notes, err := importPolicyTemplate(repositoryPath, templateFilePath, samples, "cai-iam-policies")
+			if err != nil {
+				t.Fatal(err)
+			}
+			if instanceName != tc.wantInstanceName {
+				t.Errorf("Want '%s' got '%s'", tc.wantInstanceName, instanceName)
+			}
+			if action != tc.wantAction {
+				t.Errorf("Want '%s' got '%s'", tc.wantAction, action)
+			}
+			if !strings.Contains(strings.Join(notes, ", "), tc.wantNote) {
+				t.Errorf("Want '%s' got '%v'", tc.wantNote, notes)
+			}
+			instanceFolderPath := fmt.Sprintf("%s/services/monitor/instances/%s", repositoryPath, tc.wantInstanceName)
+			if tc.wantTriggerTopic == "" {
+				if _, err = os.Stat(instanceFolderPath); err == nil {
+					t.Errorf("Want no instance folder for a skipped template got one")
+				}
+				return
+			}
+			b, err := ioutil.ReadFile(fmt.Sprintf("%s/instance.yaml", instanceFolderPath))
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !strings.Contains(string(b), tc.wantTriggerTopic) {
+				t.Errorf("Want '%s' got '%s'", tc.wantTriggerTopic, string(b))
+			}
+			// re-import keeps local constraint parameters
+			constraintFilePath := fmt.Sprintf("%s/constraints/require_storage_logging/constraint.yaml", instanceFolderPath)
+			localConstraint := []byte("local parameters")
+			if err = ioutil.WriteFile(constraintFilePath, localConstraint, 0644); err != nil {
+				t.Fatal(err)
+			}
+			_, action, _, err = importPolicyTemplate(repositoryPath, templateFilePath, samples, "cai-iam-policies")
+			if err != nil {
+				t.Fatal(err)
+			}
+			if action != tc.wantReimportAction {
+				t.Errorf("Want '%s' got '%s'", tc.wantReimportAction, action)
+			}
+			b, err = ioutil.ReadFile(constraintFilePath)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if string(b) != string(localConstraint) {
+				t.Errorf("Want '%s' got '%s'", string(localConstraint), string(b))
+			}
+		})
+	}
+}
@@ -0,0 +1,181 @@
+// Copyright 2020 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the 'License');
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an 'AS IS' BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ramcli
+
+import (
+	"fmt"
+	"io/ioutil"
+	"os"
+	"regexp"
+	"strings"
+
+	"github.com/BrunoReboul/ram/services/monitor"
+	"github.com/BrunoReboul/ram/utilities/cai"
+	"github.com/BrunoReboul/ram/utilities/ffo"
+	"github.com/BrunoReboul/ram/utilities/solution"
+	"github.com/BrunoReboul/ram/utilities/str"
+)
+
+// ruleSeverities are the severities used in monitor constraints
+var ruleSeverities = []string{"critical", "major", "medium", "low"}
+
+// ruleNameRegex rule name is <serviceName>_<rule>, lower case, as constraints.csv splits the instance name to retreive the serviceName
+var ruleNameRegex = regexp.MustCompile(`^[a-z0-9]+_[a-z0-9_]+$`)
+
+// ruleRegoTemplate parameters: kind, assetType
+const ruleRegoTemplate = `#
+# Copyright 2020 Google LLC
+#
+# Licensed under the Apache License, Version 2.0 (the "License");
+# you may not use this file except in compliance with the License.
+# You may obtain a copy of the License at
+#
+#      http://www.apache.org/licenses/LICENSE-2.0
+#
+# Unless required by applicable law or agreed to in writing, software
+# distributed under the License is distributed on an "AS IS" BASIS,
+# WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+# See the License for the specific language governing permissions and
+# limitations under the License.
+#
+
+package templates.gcp.%[1]s
+
+import data.validator.gcp.lib as lib
+
+deny[{
+	"msg": message,
+	"details": metadata,
+}] {
+	constraint := input.constraint
+	asset := input.asset
+	asset.asset_type == "%[2]s"
+
+	# TODO replace with the condition that makes the asset non compliant
+	asset.resource.data.noncompliant == true
+
+	message := sprintf("%%v: TODO describe the violation.", [asset.name])
+	metadata := {"resource": asset.name}
+}
+`
+
+// ruleConstraintTemplate parameters: kind, ruleName, severity
+const ruleConstraintTemplate = `apiVersion: constraints.gatekeeper.sh/v1alpha1
+kind: %[1]s
+metadata:
+  name: %[2]s
+  annotations:
+    category: TODO
+    description: TODO describe what is compliant.
+spec:
+  severity: %[3]s
+  match:
+    target: ["organization/*"]
+`
+
+// ruleTestTemplate parameters: kind, ruleName, assetType. A sample fixture for opa test, run from the instance folder with the rego modules
+const ruleTestTemplate = `#
+# Copyright 2020 Google LLC
+#
+# Licensed under the Apache License, Version 2.0 (the "License");
+# you may not use this file except in compliance with the License.
+# You may obtain a copy of the License at
+#
+#      http://www.apache.org/licenses/LICENSE-2.0
+#
+# Unless required by applicable law or agreed to in writing, software
+# distributed under the License is distributed on an "AS IS" BASIS,
+# WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+# See the License for the specific language governing permissions and
+# limitations under the License.
+#
+
+package templates.gcp.%[1]s
+
+fixture_constraint := {
+	"kind": "%[1]s",
+	"metadata": {"name": "%[2]s"},
+}
+
+fixture_noncompliant_asset := {
+	"name": "//TODO/noncompliant",
+	"asset_type": "%[3]s",
+	"ancestry_path": "organization/0/project/0",
+	"resource": {"data": {"noncompliant": true}},
+}
+
+fixture_compliant_asset := {
+	"name": "//TODO/compliant",
+	"asset_type": "%[3]s",
+	"ancestry_path": "organization/0/project/0",
+	"resource": {"data": {"noncompliant": false}},
+}
+
+test_violation {
+	violations := deny with input.asset as fixture_noncompliant_asset
+		 with input.constraint as fixture_constraint
+	count(violations) == 1
+}
+
+test_no_violation {
+	violations := deny with input.asset as fixture_compliant_asset
+		 with input.constraint as fixture_constraint
+	count(violations) == 0
+}
+`
+
+// makeNewRuleFiles scaffolds a monitor instance for a new rule: instance.yaml, rego template, constraint and test fixture
+func makeNewRuleFiles(repositoryPath, ruleName, assetType, severity string) (instanceFolderRelativePath string, err error) {
+	if !ruleNameRegex.MatchString(ruleName) {
+		return "", fmt.Errorf("rule name '%s' must be lower case <serviceName>_<rule>, e.g. gce_serial_port", ruleName)
+	}
+	if !strings.Contains(assetType, "/") {
+		return "", fmt.Errorf("asset type '%s' is not valid, e.g. compute.googleapis.com/Instance", assetType)
+	}
+	if !str.Find(ruleSeverities, severity) {
+		return "", fmt.Errorf("severity '%s' must be one of %v", severity, ruleSeverities)
+	}
+	instanceName := fmt.Sprintf("monitor_%s", ruleName)
+	instancesFolderPath := fmt.Sprintf("%s/%s/monitor/%s", repositoryPath, solution.MicroserviceParentFolderName, solution.InstancesFolderName)
+	instanceFolderPath := fmt.Sprintf("%s/%s", instancesFolderPath, instanceName)
+	if _, err := os.Stat(instanceFolderPath); err == nil {
+		return "", fmt.Errorf("rule already exists %s", instanceFolderPath)
+	}
+	constraintFolderPath := fmt.Sprintf("%s/%s/%s", instanceFolderPath, solution.RegoConstraintsFolderName, ruleName)
+	if err = os.MkdirAll(constraintFolderPath, 0755); err != nil {
+		return "", err
+	}
+	kind := getRuleKind(ruleName)
+
+	var monitorInstanceDeployment monitor.InstanceDeployment
+	monitorInstance := monitorInstanceDeployment.Settings.Instance
+	monitorInstance.GCF.TriggerTopic = fmt.Sprintf("cai-rces-%s", cai.GetAssetShortTypeName(assetType))
+	if err = ffo.MarshalYAMLWrite(fmt.Sprintf("%s/%s", instanceFolderPath, solution.InstanceSettingsFileName), monitorInstance); err != nil {
+		return "", err
+	}
+	if err = ioutil.WriteFile(fmt.Sprintf("%s/%s.rego", instanceFolderPath, instanceName),
+		[]byte(fmt.Sprintf(ruleRegoTemplate, kind, assetType)), 0644); err != nil {
+		return "", err
+	}
+	if err = ioutil.WriteFile(fmt.Sprintf("%s/%s_test.rego", instanceFolderPath, instanceName),
+		[]byte(fmt.Sprintf(ruleTestTemplate, kind, ruleName, assetType)), 0644); err != nil {
+		return "", err
+	}
+	if err = ioutil.WriteFile(fmt.Sprintf("%s/constraint.yaml", constraintFolderPath),
+		append([]byte(str.YAMLDisclaimer), []byte(fmt.Sprintf(ruleConstraintTemplate, kind, ruleName, severity))...), 0644); err != nil {
+		return "", err
+	}
+	return fmt.Sprintf("%s/monitor/%s/%s", solution.MicroserviceParentFolderName, solution.InstancesFolderName, instanceName), nil
+}
@@ -0,0 +1,131 @@
+// Copyright 2020 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the 'License');
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an 'AS IS' BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ramcli
+
+import (
+	"fmt"
+	"io/ioutil"
+	"os"
+	"strings"
+	"testing"
+)
+
+func TestUnitMakeNewRuleFiles(t *testing.T) {
+	var testCases = []struct {
+		name                           string
+		ruleName                       string
+		assetType                      string
+		severity                       string
+		wantErrorMsg                   string
+		wantInstanceFolderRelativePath string
+		wantTriggerTopic               string
+		wantKind                       string
+	}{
+		{
+			name:                           "standard",
+			ruleName:                       "gce_serial_port",
+			assetType:                      "compute.googleapis.com/Instance",
+			severity:                       "major",
+			wantInstanceFolderRelativePath: "services/monitor/instances/monitor_gce_serial_port",
+			wantTriggerTopic:               "triggerTopic: cai-rces-compute-Instance",
+			wantKind:                       "kind: GCPGceSerialPortConstraintV1",
+		},
+		{
+			name:                           "k8s",
+			ruleName:                       "k8s_pod_privileged",
+			assetType:                      "k8s.io/Pod",
+			severity:                       "critical",
+			wantInstanceFolderRelativePath: "services/monitor/instances/monitor_k8s_pod_privileged",
+			wantTriggerTopic:               "triggerTopic: cai-rces-k8s-Pod",
+			wantKind:                       "kind: GCPK8sPodPrivilegedConstraintV1",
+		},
+		{
+			name:         "invalidRuleName",
+			ruleName:     "SerialPort",
+			assetType:    "compute.googleapis.com/Instance",
+			severity:     "major",
+			wantErrorMsg: "must be lower case",
+		},
+		{
+			name:         "invalidAssetType",
+			ruleName:     "gce_serial_port",
+			assetType:    "Instance",
+			severity:     "major",
+			wantErrorMsg: "is not valid",
+		},
+		{
+			name:         "invalidSeverity",
+			ruleName:     "gce_serial_port",
+			assetType:    "compute.googleapis.com/Instance",
+			severity:     "blocker",
+			wantErrorMsg: "must be one of",
+		},
+	}
+	for _, tc := range testCases {
+		tc := tc // https://github.com/golang/go/wiki/CommonMistakes#using-goroutines-on-loop-iterator-variables
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+			repositoryPath, err := ioutil.TempDir("", "newrule")
+			if err != nil {
+				t.Fatal(err)
+			}
+			defer os.RemoveAll(repositoryPath)
+
+			instanceFolderRelativePath, err := makeNewRuleFiles(repositoryPath, tc.ruleName, tc.assetType, tc.severity)
+			if err != nil {
+				if tc.wantErrorMsg == "" || !strings.Contains(err.Error(), tc.wantErrorMsg) {
+					t.Errorf("Want error message '%s' got '%s'", tc.wantErrorMsg, err.Error())
+				}
+				return
+			}
+			if tc.wantErrorMsg != "" {
+				t.Fatalf("Want error message '%s' got none", tc.wantErrorMsg)
+			}
+			if instanceFolderRelativePath != tc.wantInstanceFolderRelativePath {
+				t.Errorf("Want '%s' got '%s'", tc.wantInstanceFolderRelativePath, instanceFolderRelativePath)
+			}
+			b, err := ioutil.ReadFile(fmt.Sprintf("%s/%s/instance.yaml", repositoryPath, instanceFolderRelativePath))
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !strings.Contains(string(b), tc.wantTriggerTopic) {
+				t.Errorf("Want '%s' got '%s'", tc.wantTriggerTopic, string(b))
+			}
+			b, err = ioutil.ReadFile(fmt.Sprintf("%s/%s/constraints/%s/constraint.yaml", repositoryPath, instanceFolderRelativePath, tc.ruleName))
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !strings.Contains(string(b), tc.wantKind) {
+				t.Errorf("Want '%s' got '%s'", tc.wantKind, string(b))
+			}
+			for _, suffix := range []string{".rego", "_test.rego"} {
+				if _, err = os.Stat(fmt.Sprintf("%s/%s/monitor_%s%s", repositoryPath, instanceFolderRelativePath, tc.ruleName, suffix)); err != nil {
+					t.Errorf("Want file %s got %v", suffix, err)
+				}
+			}
+			// the generated constraint must be readable by the constraints one files generation
+			constraintFolderRelativePaths, err := GetConstraintFolderRelativePaths(repositoryPath)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if _, err = makeConstraintsYAML(repositoryPath, constraintFolderRelativePaths); err != nil {
+				t.Errorf("makeConstraintsYAML %v", err)
+			}
+			if _, err = makeNewRuleFiles(repositoryPath, tc.ruleName, tc.assetType, tc.severity); err == nil {
+				t.Errorf("Want error message 'rule already exists' got none")
+			}
+		})
+	}
+}
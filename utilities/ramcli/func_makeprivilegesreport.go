@@ -0,0 +1,86 @@
+// Copyright 2020 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the 'License');
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an 'AS IS' BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ramcli
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/BrunoReboul/ram/utilities/str"
+	"google.golang.org/api/iam/v1"
+)
+
+// makePrivilegesReport compares the permissions exercised by a microservice service account with the ones granted by its roles
+// It suggests the minimal included permissions of each custom role, as Go code, with a diff against the current definition:
+// - granted not exercised, + exercised not granted
+func makePrivilegesReport(serviceAccountEmail string, customRoles []iam.Role, predefinedRolesPermissions map[string][]string, exercisedPermissions []string) (report string) {
+	var b strings.Builder
+	grantedPermissions := make([]string, 0)
+	fmt.Fprintf(&b, "# %s exercised %d permissions\n", serviceAccountEmail, len(exercisedPermissions))
+	for _, role := range customRoles {
+		suggestedPermissions := make([]string, 0)
+		unusedPermissions := make([]string, 0)
+		for _, permission := range role.IncludedPermissions {
+			grantedPermissions = append(grantedPermissions, permission)
+			if str.Find(exercisedPermissions, permission) {
+				suggestedPermissions = append(suggestedPermissions, permission)
+			} else {
+				unusedPermissions = append(unusedPermissions, permission)
+			}
+		}
+		fmt.Fprintf(&b, "\n// %s suggested, %d of %d permissions exercised\n", role.Title, len(suggestedPermissions), len(role.IncludedPermissions))
+		if len(suggestedPermissions) > 0 {
+			fmt.Fprintf(&b, "role.IncludedPermissions = []string{\n\t\"%s\"}\n", strings.Join(suggestedPermissions, "\",\n\t\""))
+		} else {
+			fmt.Fprintf(&b, "// no permission exercised, role suggested to be removed\n")
+		}
+		for _, permission := range unusedPermissions {
+			fmt.Fprintf(&b, "-\t%s\n", permission)
+		}
+	}
+	predefinedRoles := make([]string, 0)
+	for role := range predefinedRolesPermissions {
+		predefinedRoles = append(predefinedRoles, role)
+	}
+	sort.Strings(predefinedRoles)
+	for _, role := range predefinedRoles {
+		exercisedCount := 0
+		for _, permission := range predefinedRolesPermissions[role] {
+			grantedPermissions = append(grantedPermissions, permission)
+			if str.Find(exercisedPermissions, permission) {
+				exercisedCount++
+			}
+		}
+		if exercisedCount == 0 {
+			fmt.Fprintf(&b, "\n// %s predefined role suggested to be removed, none of its %d permissions exercised\n", role, len(predefinedRolesPermissions[role]))
+		} else {
+			fmt.Fprintf(&b, "\n// %s predefined role kept, %d of %d permissions exercised\n", role, exercisedCount, len(predefinedRolesPermissions[role]))
+		}
+	}
+	missingPermissions := make([]string, 0)
+	for _, permission := range exercisedPermissions {
+		if !str.Find(grantedPermissions, permission) {
+			missingPermissions = append(missingPermissions, permission)
+		}
+	}
+	if len(missingPermissions) > 0 {
+		fmt.Fprintf(&b, "\n// exercised permissions not granted by the microservice roles\n")
+		for _, permission := range missingPermissions {
+			fmt.Fprintf(&b, "+\t%s\n", permission)
+		}
+	}
+	return b.String()
+}
@@ -0,0 +1,80 @@
+// Copyright 2020 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the 'License');
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an 'AS IS' BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ramcli
+
+import (
+	"strings"
+	"testing"
+
+	"google.golang.org/api/iam/v1"
+)
+
+func TestUnitMakePrivilegesReport(t *testing.T) {
+	var testCases = []struct {
+		name                       string
+		customRoles                []iam.Role
+		predefinedRolesPermissions map[string][]string
+		exercisedPermissions       []string
+		wantLines                  []string
+	}{
+		{
+			name: "unusedPermission",
+			customRoles: []iam.Role{
+				{
+					Title:               "ram_stream2bq_run",
+					IncludedPermissions: []string{"bigquery.datasets.get", "bigquery.tables.get", "bigquery.tables.updateData"},
+				},
+			},
+			exercisedPermissions: []string{"bigquery.tables.get", "bigquery.tables.updateData"},
+			wantLines: []string{
+				"// ram_stream2bq_run suggested, 2 of 3 permissions exercised",
+				"\t\"bigquery.tables.get\",",
+				"\t\"bigquery.tables.updateData\"}",
+				"-\tbigquery.datasets.get",
+			},
+		},
+		{
+			name: "missingPermissionAndPredefinedRoles",
+			customRoles: []iam.Role{
+				{
+					Title:               "ram_monitor_run",
+					IncludedPermissions: []string{"pubsub.topics.publish"},
+				},
+			},
+			predefinedRolesPermissions: map[string][]string{
+				"roles/datastore.user": {"datastore.entities.get", "datastore.entities.update"},
+				"roles/logging.viewer": {"logging.logEntries.list"},
+			},
+			exercisedPermissions: []string{"datastore.entities.get", "pubsub.topics.publish", "storage.objects.get"},
+			wantLines: []string{
+				"// roles/datastore.user predefined role kept, 1 of 2 permissions exercised",
+				"// roles/logging.viewer predefined role suggested to be removed, none of its 1 permissions exercised",
+				"+\tstorage.objects.get",
+			},
+		},
+	}
+	for _, tc := range testCases {
+		tc := tc // https://github.com/golang/go/wiki/CommonMistakes#using-goroutines-on-loop-iterator-variables
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+			report := makePrivilegesReport("sa@ram.iam.gserviceaccount.com", tc.customRoles, tc.predefinedRolesPermissions, tc.exercisedPermissions)
+			for _, wantLine := range tc.wantLines {
+				if !strings.Contains(report, wantLine+"\n") {
+					t.Errorf("Want '%s' got '%s'", wantLine, report)
+				}
+			}
+		})
+	}
+}
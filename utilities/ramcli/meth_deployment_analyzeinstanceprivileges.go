@@ -0,0 +1,77 @@
+// Copyright 2020 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the 'License');
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an 'AS IS' BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ramcli
+
+import (
+	"fmt"
+	"log"
+
+	"google.golang.org/api/iam/v1"
+)
+
+// analyzeInstancePrivileges prints the least privilege reports of the instance microservice service account with its run roles,
+// and of the Cloud Build service account with the microservice deploy roles
+// The Cloud Build service account deploys all microservices, so its not granted permissions may be exercised for another microservice
+func (deployment *Deployment) analyzeInstancePrivileges() (err error) {
+	customRoles, predefinedRoles := getPrivilegesRoles([][]iam.Role{
+		deployment.Settings.Service.IAM.RunRoles.HostingOrg,
+		deployment.Settings.Service.IAM.RunRoles.MonitoringOrg,
+		deployment.Settings.Service.IAM.RunRoles.Project},
+		deployment.Settings.Service.GCF.ServiceAccountBindings.GRM,
+		deployment.Settings.Service.GCF.ServiceAccountBindings.IAM)
+	if len(customRoles) > 0 || len(predefinedRoles) > 0 {
+		err = deployment.analyzeServiceAccountPrivileges(
+			fmt.Sprintf("%s@%s.iam.gserviceaccount.com", deployment.Core.ServiceName, deployment.Core.SolutionSettings.Hosting.ProjectID),
+			customRoles, predefinedRoles)
+		if err != nil {
+			return err
+		}
+	}
+	customRoles, predefinedRoles = getPrivilegesRoles([][]iam.Role{
+		deployment.Settings.Service.IAM.DeployRoles.HostingOrg,
+		deployment.Settings.Service.IAM.DeployRoles.MonitoringOrg,
+		deployment.Settings.Service.IAM.DeployRoles.Project},
+		deployment.Settings.Service.GCB.ServiceAccountBindings.GRM,
+		deployment.Settings.Service.GCB.ServiceAccountBindings.IAM)
+	return deployment.analyzeServiceAccountPrivileges(
+		fmt.Sprintf("%d@cloudbuild.gserviceaccount.com", deployment.Core.ProjectNumber),
+		customRoles, predefinedRoles)
+}
+
+// analyzeServiceAccountPrivileges prints the least privilege report of a service account
+func (deployment *Deployment) analyzeServiceAccountPrivileges(serviceAccountEmail string, customRoles []iam.Role, predefinedRoles []string) (err error) {
+	var exercisedPermissions []string
+	if deployment.Privileges.AuditLogsFilePath != "" {
+		exercisedPermissions = deployment.Privileges.ExercisedPermissions[serviceAccountEmail]
+	} else {
+		exercisedPermissions, err = deployment.getLoggedExercisedPermissions(serviceAccountEmail)
+		if err != nil {
+			return err
+		}
+	}
+	if len(exercisedPermissions) == 0 {
+		log.Printf("%s WARNING no audit log found for %s, are data access audit logs enabled?", deployment.Core.InstanceName, serviceAccountEmail)
+	}
+	predefinedRolesPermissions := make(map[string][]string)
+	for _, predefinedRole := range predefinedRoles {
+		role, err := deployment.Core.Services.IAMService.Roles.Get(predefinedRole).Context(deployment.Core.Ctx).Do()
+		if err != nil {
+			return fmt.Errorf("IAMService.Roles.Get %s %v", predefinedRole, err)
+		}
+		predefinedRolesPermissions[predefinedRole] = role.IncludedPermissions
+	}
+	fmt.Println(makePrivilegesReport(serviceAccountEmail, customRoles, predefinedRolesPermissions, exercisedPermissions))
+	return nil
+}
@@ -0,0 +1,43 @@
+// Copyright 2020 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the 'License');
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an 'AS IS' BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ramcli
+
+import (
+	"io/ioutil"
+	"log"
+)
+
+// analyzePrivileges compares once per microservice the permissions exercised by its service account with the ones granted by its roles
+func (deployment *Deployment) analyzePrivileges() (err error) {
+	if deployment.Privileges.AuditLogsFilePath != "" {
+		b, err := ioutil.ReadFile(deployment.Privileges.AuditLogsFilePath)
+		if err != nil {
+			return err
+		}
+		deployment.Privileges.ExercisedPermissions, err = getExercisedPermissions(b)
+		if err != nil {
+			return err
+		}
+		log.Printf("found exercised permissions for %d principals in %s", len(deployment.Privileges.ExercisedPermissions), deployment.Privileges.AuditLogsFilePath)
+	}
+	// The first instance of each microservice is enough as roles are microservice settings
+	prerequisites, _ := getDeploymentStages(deployment.Core.InstanceFolderRelativePaths)
+	for _, instanceFolderRelativePath := range prerequisites {
+		if err = deployment.deployInstance(instanceFolderRelativePath); err != nil {
+			return err
+		}
+	}
+	return nil
+}
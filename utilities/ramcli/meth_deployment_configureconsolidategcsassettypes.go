@@ -0,0 +1,73 @@
+// Copyright 2020 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the 'License');
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an 'AS IS' BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ramcli
+
+import (
+	"fmt"
+	"log"
+	"os"
+
+	"github.com/BrunoReboul/ram/services/consolidategcs"
+	"github.com/BrunoReboul/ram/utilities/cai"
+	"github.com/BrunoReboul/ram/utilities/ffo"
+	"github.com/BrunoReboul/ram/utilities/solution"
+)
+
+// configureConsolidateGCSAssetTypes for assets types defined in solution.yaml, whatever their content type, writes consolidategcs instance.yaml files and subfolders
+func (deployment *Deployment) configureConsolidateGCSAssetTypes() (err error) {
+	serviceName := "consolidategcs"
+	if len(deployment.Core.SolutionSettings.Monitoring.ConsolidateGCSDefaultSchedulers) == 0 {
+		log.Printf("configure %s skipped, no consolidateGCSDefaultSchedulers in solution settings", serviceName)
+		return nil
+	}
+	log.Printf("configure %s asset types", serviceName)
+	var consolidategcsInstanceDeployment consolidategcs.InstanceDeployment
+	consolidategcsInstance := consolidategcsInstanceDeployment.Settings.Instance
+	serviceFolderPath := fmt.Sprintf("%s/%s/%s", deployment.Core.RepositoryPath, solution.MicroserviceParentFolderName, serviceName)
+	if _, err := os.Stat(serviceFolderPath); os.IsNotExist(err) {
+		os.Mkdir(serviceFolderPath, 0755)
+	}
+	instancesFolderPath := fmt.Sprintf("%s/%s", serviceFolderPath, solution.InstancesFolderName)
+	if _, err := os.Stat(instancesFolderPath); os.IsNotExist(err) {
+		os.Mkdir(instancesFolderPath, 0755)
+	}
+
+	// an instance consolidates all the content types of its asset type, e.g. resources, iam policies, os inventories
+	assetTypes := append([]string{}, deployment.Core.SolutionSettings.Monitoring.AssetTypes.Resources...)
+	assetTypes = append(assetTypes, deployment.Core.SolutionSettings.Monitoring.AssetTypes.IAMPolicies...)
+	for _, item := range deployment.getContentTypesAssetTypes() {
+		assetTypes = append(assetTypes, item.assetTypes...)
+	}
+	configured := make(map[string]bool)
+	for _, assetType := range assetTypes {
+		if configured[assetType] {
+			continue
+		}
+		configured[assetType] = true
+		consolidategcsInstance.CAI.AssetType = assetType
+		consolidategcsInstance.SCH.Schedulers = deployment.Core.SolutionSettings.Monitoring.ConsolidateGCSDefaultSchedulers
+		instanceFolderPath := makeInstanceFolderPath(instancesFolderPath, fmt.Sprintf("%s_%s",
+			serviceName,
+			cai.GetAssetShortTypeName(assetType)))
+		if _, err := os.Stat(instanceFolderPath); os.IsNotExist(err) {
+			os.Mkdir(instanceFolderPath, 0755)
+		}
+		if err = ffo.MarshalYAMLWrite(fmt.Sprintf("%s/%s", instanceFolderPath, solution.InstanceSettingsFileName), consolidategcsInstance); err != nil {
+			return err
+		}
+		log.Printf("done %s", instanceFolderPath)
+	}
+	return nil
+}
@@ -27,10 +27,9 @@ import (
 // configureConvertlog2feedOrganizations
 func (deployment *Deployment) configureConvertlog2feedOrganizations() (err error) {
 	serviceName := "convertlog2feed"
-	// Case activity group
-	sinkNameSuffix := "activity-group"
+	// Case activity group, and GCP admin activity to correlate asset changes with who made them
+	sinkNameSuffixes := []string{"activity-group", "activity-directory", "activity-gcp"}
 
-	log.Printf("configure %s %s", serviceName, sinkNameSuffix)
 	var convertlog2feedInstanceDeployment convertlog2feed.InstanceDeployment
 	convertlog2feedInstance := convertlog2feedInstanceDeployment.Settings.Instance
 	serviceFolderPath := fmt.Sprintf("%s/%s/%s", deployment.Core.RepositoryPath, solution.MicroserviceParentFolderName, serviceName)
@@ -42,29 +41,32 @@ func (deployment *Deployment) configureConvertlog2feedOrganizations() (err error
 		os.Mkdir(instancesFolderPath, 0755)
 	}
 
-	for _, organizationID := range deployment.Core.SolutionSettings.Monitoring.OrganizationIDs {
-		convertlog2feedInstance.GCF.TriggerTopic = fmt.Sprintf("log-org%s-%s", organizationID, sinkNameSuffix)
+	for _, sinkNameSuffix := range sinkNameSuffixes {
+		log.Printf("configure %s %s", serviceName, sinkNameSuffix)
+		for _, organizationID := range deployment.Core.SolutionSettings.Monitoring.OrganizationIDs {
+			convertlog2feedInstance.GCF.TriggerTopic = fmt.Sprintf("log-org%s-%s", organizationID, sinkNameSuffix)
 
-		var directoryCustomerID string
-		organization, err := deployment.Core.Services.CloudresourcemanagerService.Organizations.Get(fmt.Sprintf("organizations/%s", organizationID)).Context(deployment.Core.Ctx).Do()
-		if err != nil {
-			log.Printf("WARNING - cloudresourcemanagerService.Organizations.Get %v", err)
-		} else {
-			directoryCustomerID = organization.Owner.DirectoryCustomerId
-		}
-		convertlog2feedInstance.GCI.SuperAdminEmail = deployment.Core.SolutionSettings.Monitoring.DirectoryCustomerIDs[directoryCustomerID].SuperAdminEmail
+			var directoryCustomerID string
+			organization, err := deployment.Core.Services.CloudresourcemanagerService.Organizations.Get(fmt.Sprintf("organizations/%s", organizationID)).Context(deployment.Core.Ctx).Do()
+			if err != nil {
+				log.Printf("WARNING - cloudresourcemanagerService.Organizations.Get %v", err)
+			} else {
+				directoryCustomerID = organization.Owner.DirectoryCustomerId
+			}
+			convertlog2feedInstance.GCI.SuperAdminEmail = deployment.Core.SolutionSettings.Monitoring.DirectoryCustomerIDs[directoryCustomerID].SuperAdminEmail
 
-		instanceFolderPath := makeInstanceFolderPath(instancesFolderPath, fmt.Sprintf("%s_org%s_%s",
-			serviceName,
-			organizationID,
-			sinkNameSuffix))
-		if _, err := os.Stat(instanceFolderPath); os.IsNotExist(err) {
-			os.Mkdir(instanceFolderPath, 0755)
-		}
-		if err = ffo.MarshalYAMLWrite(fmt.Sprintf("%s/%s", instanceFolderPath, solution.InstanceSettingsFileName), convertlog2feedInstance); err != nil {
-			return err
+			instanceFolderPath := makeInstanceFolderPath(instancesFolderPath, fmt.Sprintf("%s_org%s_%s",
+				serviceName,
+				organizationID,
+				sinkNameSuffix))
+			if _, err := os.Stat(instanceFolderPath); os.IsNotExist(err) {
+				os.Mkdir(instanceFolderPath, 0755)
+			}
+			if err = ffo.MarshalYAMLWrite(fmt.Sprintf("%s/%s", instanceFolderPath, solution.InstanceSettingsFileName), convertlog2feedInstance); err != nil {
+				return err
+			}
+			log.Printf("done %s", instanceFolderPath)
 		}
-		log.Printf("done %s", instanceFolderPath)
 	}
 	return nil
 }
@@ -31,6 +31,7 @@ func (deployment *Deployment) configureDumpInventoryAssetTypes() (err error) {
 	log.Printf("configure %s asset types", serviceName)
 	var dumpinventoryInstanceDeployment dumpinventory.InstanceDeployment
 	dumpinventoryInstance := dumpinventoryInstanceDeployment.Settings.Instance
+	dumpinventoryInstance.ExportSLASeconds = 3600
 	serviceFolderPath := fmt.Sprintf("%s/%s/%s", deployment.Core.RepositoryPath, solution.MicroserviceParentFolderName, serviceName)
 	if _, err := os.Stat(serviceFolderPath); os.IsNotExist(err) {
 		os.Mkdir(serviceFolderPath, 0755)
@@ -40,16 +41,16 @@ func (deployment *Deployment) configureDumpInventoryAssetTypes() (err error) {
 		os.Mkdir(instancesFolderPath, 0755)
 	}
 
-	for _, organizationID := range deployment.Core.SolutionSettings.Monitoring.OrganizationIDs {
-		dumpinventoryInstance.CAI.Parent = fmt.Sprintf("organizations/%s", organizationID)
+	for _, scope := range deployment.Core.SolutionSettings.GetMonitoringScopes() {
+		dumpinventoryInstance.CAI.Parent = scope.Parent()
 		dumpinventoryInstance.SCH.Schedulers = deployment.Core.SolutionSettings.Monitoring.DefaultSchedulers
 
 		// one and only one iam policy feed for all asset types
 		dumpinventoryInstance.CAI.ContentType = "IAM_POLICY"
 		dumpinventoryInstance.CAI.AssetTypes = deployment.Core.SolutionSettings.Monitoring.AssetTypes.IAMPolicies
-		instanceFolderPath := makeInstanceFolderPath(instancesFolderPath, fmt.Sprintf("%s_org%s_iam_policies",
+		instanceFolderPath := makeInstanceFolderPath(instancesFolderPath, fmt.Sprintf("%s_%s_iam_policies",
 			serviceName,
-			organizationID))
+			scope.ShortName()))
 		if _, err := os.Stat(instanceFolderPath); os.IsNotExist(err) {
 			os.Mkdir(instanceFolderPath, 0755)
 		}
@@ -58,13 +59,30 @@ func (deployment *Deployment) configureDumpInventoryAssetTypes() (err error) {
 		}
 		log.Printf("done %s", instanceFolderPath)
 
+		// one export per other content type for all its asset types
+		for _, item := range deployment.getContentTypesAssetTypes() {
+			dumpinventoryInstance.CAI.ContentType = item.contentType
+			dumpinventoryInstance.CAI.AssetTypes = item.assetTypes
+			instanceFolderPath := makeInstanceFolderPath(instancesFolderPath, fmt.Sprintf("%s_%s_%s",
+				serviceName,
+				scope.ShortName(),
+				item.nameSuffix))
+			if _, err := os.Stat(instanceFolderPath); os.IsNotExist(err) {
+				os.Mkdir(instanceFolderPath, 0755)
+			}
+			if err = ffo.MarshalYAMLWrite(fmt.Sprintf("%s/%s", instanceFolderPath, solution.InstanceSettingsFileName), dumpinventoryInstance); err != nil {
+				return err
+			}
+			log.Printf("done %s", instanceFolderPath)
+		}
+
 		// one resource feed per asset type
 		for _, assetType := range deployment.Core.SolutionSettings.Monitoring.AssetTypes.Resources {
 			dumpinventoryInstance.CAI.ContentType = "RESOURCE"
 			dumpinventoryInstance.CAI.AssetTypes = []string{assetType}
-			instanceFolderPath := makeInstanceFolderPath(instancesFolderPath, fmt.Sprintf("%s_org%s_%s",
+			instanceFolderPath := makeInstanceFolderPath(instancesFolderPath, fmt.Sprintf("%s_%s_%s",
 				serviceName,
-				organizationID,
+				scope.ShortName(),
 				cai.GetAssetShortTypeName(assetType)))
 			if _, err := os.Stat(instanceFolderPath); os.IsNotExist(err) {
 				os.Mkdir(instanceFolderPath, 0755)
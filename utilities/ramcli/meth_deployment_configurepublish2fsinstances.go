@@ -72,6 +72,33 @@ func (deployment *Deployment) configurePublish2fsInstances() (err error) {
 	}
 	log.Printf("done %s", instanceFolderPath)
 
+	if deployment.Core.SolutionSettings.Hosting.Pubsub.TopicNames.AssetChanges != "" {
+		publish2fsInstance.GCF.TriggerTopic = deployment.Core.SolutionSettings.Hosting.Pubsub.TopicNames.IAMPolicies
+		instanceFolderPath = makeInstanceFolderPath(instancesFolderPath, fmt.Sprintf("%s_iam_policies",
+			serviceName))
+		if _, err := os.Stat(instanceFolderPath); os.IsNotExist(err) {
+			os.Mkdir(instanceFolderPath, 0755)
+		}
+		if err = ffo.MarshalYAMLWrite(fmt.Sprintf("%s/%s", instanceFolderPath, solution.InstanceSettingsFileName), publish2fsInstance); err != nil {
+			return err
+		}
+		log.Printf("done %s", instanceFolderPath)
+
+		for _, item := range deployment.getContentTypesAssetTypes() {
+			publish2fsInstance.GCF.TriggerTopic = deployment.Core.SolutionSettings.GetContentTypeTopicName(item.contentType)
+			instanceFolderPath = makeInstanceFolderPath(instancesFolderPath, fmt.Sprintf("%s_%s",
+				serviceName,
+				item.nameSuffix))
+			if _, err := os.Stat(instanceFolderPath); os.IsNotExist(err) {
+				os.Mkdir(instanceFolderPath, 0755)
+			}
+			if err = ffo.MarshalYAMLWrite(fmt.Sprintf("%s/%s", instanceFolderPath, solution.InstanceSettingsFileName), publish2fsInstance); err != nil {
+				return err
+			}
+			log.Printf("done %s", instanceFolderPath)
+		}
+	}
+
 	publish2fsInstance.GCF.TriggerTopic = "gci-groupMembers"
 	instanceFolderPath = makeInstanceFolderPath(instancesFolderPath, fmt.Sprintf("%s_gci_groupMembers",
 		serviceName))
@@ -84,17 +111,20 @@ func (deployment *Deployment) configurePublish2fsInstances() (err error) {
 	log.Printf("done %s", instanceFolderPath)
 
 	for directoryCustomerID := range deployment.Core.SolutionSettings.Monitoring.DirectoryCustomerIDs {
-		publish2fsInstance.GCF.TriggerTopic = fmt.Sprintf("gci-groups-%s", directoryCustomerID)
-		instanceFolderPath = makeInstanceFolderPath(instancesFolderPath, fmt.Sprintf("%s_gci_groups_%s",
-			serviceName,
-			directoryCustomerID))
-		if _, err := os.Stat(instanceFolderPath); os.IsNotExist(err) {
-			os.Mkdir(instanceFolderPath, 0755)
+		for _, kind := range []string{"groups", "users", "domainSettings", "securitySettings"} {
+			publish2fsInstance.GCF.TriggerTopic = fmt.Sprintf("gci-%s-%s", kind, directoryCustomerID)
+			instanceFolderPath = makeInstanceFolderPath(instancesFolderPath, fmt.Sprintf("%s_gci_%s_%s",
+				serviceName,
+				kind,
+				directoryCustomerID))
+			if _, err := os.Stat(instanceFolderPath); os.IsNotExist(err) {
+				os.Mkdir(instanceFolderPath, 0755)
+			}
+			if err = ffo.MarshalYAMLWrite(fmt.Sprintf("%s/%s", instanceFolderPath, solution.InstanceSettingsFileName), publish2fsInstance); err != nil {
+				return err
+			}
+			log.Printf("done %s", instanceFolderPath)
 		}
-		if err = ffo.MarshalYAMLWrite(fmt.Sprintf("%s/%s", instanceFolderPath, solution.InstanceSettingsFileName), publish2fsInstance); err != nil {
-			return err
-		}
-		log.Printf("done %s", instanceFolderPath)
 	}
 	return nil
 }
@@ -0,0 +1,146 @@
+// Copyright 2020 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the 'License');
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an 'AS IS' BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ramcli
+
+import (
+	"fmt"
+	"log"
+	"os"
+	"strings"
+
+	"github.com/BrunoReboul/ram/utilities/ffo"
+	"github.com/BrunoReboul/ram/utilities/mon"
+	"github.com/BrunoReboul/ram/utilities/solution"
+	"gopkg.in/yaml.v2"
+)
+
+// configureSetAlerts
+func (deployment *Deployment) configureSetAlerts() (err error) {
+	serviceName := "setalerts"
+	serviceFolderPath := fmt.Sprintf("%s/%s/%s",
+		deployment.Core.RepositoryPath,
+		solution.MicroserviceParentFolderName,
+		serviceName)
+	if _, err := os.Stat(serviceFolderPath); os.IsNotExist(err) {
+		os.Mkdir(serviceFolderPath, 0755)
+	}
+
+	log.Printf("configure %s", serviceName)
+	instancesFolderPath := fmt.Sprintf("%s/%s", serviceFolderPath, solution.InstancesFolderName)
+	if _, err := os.Stat(instancesFolderPath); os.IsNotExist(err) {
+		os.Mkdir(instancesFolderPath, 0755)
+	}
+	alertPolicyListYAML := []byte(`
+- mon:
+    displayName: RAM noretry
+    documentation: A RAM microservice failed to process an event and will not retry. Check the microservice logs with jsonPayload.message="noretry"
+    autoClose: 86400s
+    conditions:
+      - displayName: noretry by microservice
+        filter: metric.type="logging.googleapis.com/user/ram_execution_status" AND resource.type="cloud_function" AND metric.label.status="noretry"
+        comparison: COMPARISON_GT
+        thresholdValue: 0
+        duration: 0s
+        alignmentPeriod: 300s
+        perSeriesAligner: ALIGN_SUM
+        crossSeriesReducer: REDUCE_SUM
+        groupByFields:
+          - metric.label.microservice_name
+- mon:
+    displayName: RAM export failed
+    documentation: A cloud asset inventory export operation failed. Check the dumpinventory logs with jsonPayload.message=~"^export_"
+    autoClose: 86400s
+    conditions:
+      - displayName: export failed by instance
+        filter: metric.type="logging.googleapis.com/user/ram_export_status" AND resource.type="cloud_function" AND metric.label.status="failed"
+        comparison: COMPARISON_GT
+        thresholdValue: 0
+        duration: 0s
+        alignmentPeriod: 3600s
+        perSeriesAligner: ALIGN_SUM
+        crossSeriesReducer: REDUCE_SUM
+        groupByFields:
+          - metric.label.instance_name`)
+
+	var alertPolicyList []struct {
+		MON mon.AlertPolicyParameters
+	}
+	err = yaml.Unmarshal(alertPolicyListYAML, &alertPolicyList)
+	if err != nil {
+		return err
+	}
+
+	for _, freshnessSLOdefiniton := range deployment.Core.SolutionSettings.Hosting.FreshnessSLODefinitions {
+		alertPolicy, err := getFreshnessBurnRateAlertPolicy(freshnessSLOdefiniton.Origin)
+		if err != nil {
+			return err
+		}
+		alertPolicyList = append(alertPolicyList, struct {
+			MON mon.AlertPolicyParameters
+		}{MON: alertPolicy})
+	}
+
+	var notificationChannels []mon.NotificationChannelParameters
+	for _, notificationChannel := range deployment.Core.SolutionSettings.Hosting.NotificationChannels {
+		notificationChannels = append(notificationChannels, mon.NotificationChannelParameters{
+			DisplayName: notificationChannel.DisplayName,
+			Type:        notificationChannel.Type,
+			Description: notificationChannel.Description,
+			Labels:      notificationChannel.Labels,
+		})
+	}
+
+	for _, alertPolicy := range alertPolicyList {
+		alertPolicy.MON.NotificationChannels = notificationChannels
+		instanceFolderPath := makeInstanceFolderPath(instancesFolderPath, fmt.Sprintf("%s_%s",
+			serviceName,
+			strings.ToLower(strings.Replace(alertPolicy.MON.DisplayName, " ", "_", -1))))
+		if _, err := os.Stat(instanceFolderPath); os.IsNotExist(err) {
+			os.Mkdir(instanceFolderPath, 0755)
+		}
+		if err = ffo.MarshalYAMLWrite(fmt.Sprintf("%s/%s",
+			instanceFolderPath,
+			solution.InstanceSettingsFileName),
+			alertPolicy); err != nil {
+			return err
+		}
+		log.Printf("done %s", instanceFolderPath)
+	}
+	return nil
+}
+
+// getFreshnessBurnRateAlertPolicy alerts on the error budget burn rate of a freshness SLO, fast burn on one hour, slow burn on six hours
+func getFreshnessBurnRateAlertPolicy(origin string) (alertPolicy mon.AlertPolicyParameters, err error) {
+	scope, flow, err := getFreshnessScopeFlow(origin)
+	if err != nil {
+		return alertPolicy, err
+	}
+	alertPolicy.DisplayName = fmt.Sprintf("RAM freshness %s %s burn rate", scope, flow)
+	alertPolicy.Documentation = fmt.Sprintf("The error budget of the SLO freshness %s %s is burning too fast. Check the RAM latency of origin %s", scope, flow, origin)
+	alertPolicy.AutoClose = "86400s"
+	var fastBurn, slowBurn mon.AlertConditionParameters
+	fastBurn.DisplayName = "fast burn"
+	fastBurn.ThresholdValue = 10
+	fastBurn.BurnRate.LookbackPeriod = "3600s"
+	slowBurn.DisplayName = "slow burn"
+	slowBurn.ThresholdValue = 2
+	slowBurn.BurnRate.LookbackPeriod = "21600s"
+	for _, condition := range []*mon.AlertConditionParameters{&fastBurn, &slowBurn} {
+		condition.BurnRate.ServiceID = fmt.Sprintf("ram-%s", flow)
+		condition.BurnRate.SLOID = fmt.Sprintf("freshness-%s", origin)
+		alertPolicy.Conditions = append(alertPolicy.Conditions, *condition)
+	}
+	return alertPolicy, nil
+}
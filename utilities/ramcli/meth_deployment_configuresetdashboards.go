@@ -47,19 +47,9 @@ func (deployment *Deployment) configureSetDashboards() (err error) {
 	setSLOFreshnessInstance.MON.SLOFreshnessLayout.Columns = 12
 	for _, freshnessSLOdefiniton := range deployment.Core.SolutionSettings.Hosting.FreshnessSLODefinitions {
 		setSLOFreshnessInstance.MON.SLOFreshnessLayout.Origin = freshnessSLOdefiniton.Origin
-		switch setSLOFreshnessInstance.MON.SLOFreshnessLayout.Origin {
-		case "batch-export":
-			setSLOFreshnessInstance.MON.SLOFreshnessLayout.Scope = "GCP"
-			setSLOFreshnessInstance.MON.SLOFreshnessLayout.Flow = "batch"
-		case "real-time":
-			setSLOFreshnessInstance.MON.SLOFreshnessLayout.Scope = "GCP"
-			setSLOFreshnessInstance.MON.SLOFreshnessLayout.Flow = "real-time"
-		case "batch-listgroups":
-			setSLOFreshnessInstance.MON.SLOFreshnessLayout.Scope = "Groups"
-			setSLOFreshnessInstance.MON.SLOFreshnessLayout.Flow = "batch"
-		case "real-time-log-export":
-			setSLOFreshnessInstance.MON.SLOFreshnessLayout.Scope = "Groups"
-			setSLOFreshnessInstance.MON.SLOFreshnessLayout.Flow = "real-time"
+		setSLOFreshnessInstance.MON.SLOFreshnessLayout.Scope, setSLOFreshnessInstance.MON.SLOFreshnessLayout.Flow, err = getFreshnessScopeFlow(freshnessSLOdefiniton.Origin)
+		if err != nil {
+			return err
 		}
 		setSLOFreshnessInstance.MON.SLOFreshnessLayout.SLO = freshnessSLOdefiniton.SLO
 		setSLOFreshnessInstance.MON.SLOFreshnessLayout.CutOffBucketNumber = freshnessSLOdefiniton.CutOffBucketNumber
@@ -102,12 +92,12 @@ func (deployment *Deployment) configureSetDashboards() (err error) {
 	dashboard.microServiceNameList = []string{"dumpinventory", "splitdump", "monitor", "stream2bq", "publish2fs", "upload2gcs"}
 	dashboards["RAM core microservices"] = dashboard
 
-	dashboard.microServiceNameList = []string{"convertlog2feed", "listgroups", "getgroupsettings", "listgroupmembers"}
+	dashboard.microServiceNameList = []string{"convertlog2feed", "listgroups", "getgroupsettings", "listgroupmembers", "listusers", "listdirectorysettings"}
 	dashboards["RAM groups microservices"] = dashboard
 
 	dashboard.columns = 3
 	dashboard.widgetTypeList = []string{"widgetRAMe2eLatency", "widgetRAMLatency", "widgetRAMTriggerAge", "widgetSubOldestUnackedMsg", "widgetGCFActiveInstances", "widgetGCFExecutionCount", "widgetGCFExecutionTime", "widgetGCFMemoryUsage"}
-	for _, microServiceName := range []string{"stream2bq", "monitor", "upload2gcs", "publish2fs", "splitdump", "dumpinventory", "listgroupmembers", "getgroupsettings", "listgroups", "convertlog2feed"} {
+	for _, microServiceName := range []string{"stream2bq", "monitor", "upload2gcs", "publish2fs", "splitdump", "dumpinventory", "listgroupmembers", "getgroupsettings", "listgroups", "listusers", "listdirectorysettings", "convertlog2feed"} {
 		dashboard.microServiceNameList = []string{microServiceName}
 		dashboards[fmt.Sprintf("RAM %s", microServiceName)] = dashboard
 	}
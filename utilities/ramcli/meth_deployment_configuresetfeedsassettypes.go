@@ -40,15 +40,15 @@ func (deployment *Deployment) configureSetFeedsAssetTypes() (err error) {
 		os.Mkdir(instancesFolderPath, 0755)
 	}
 
-	for _, organizationID := range deployment.Core.SolutionSettings.Monitoring.OrganizationIDs {
-		setfeedsInstance.CAI.Parent = fmt.Sprintf("organizations/%s", organizationID)
+	for _, scope := range deployment.Core.SolutionSettings.GetMonitoringScopes() {
+		setfeedsInstance.CAI.Parent = scope.Parent()
 
 		// one and only one iam policy feed for all asset types
 		setfeedsInstance.CAI.ContentType = "IAM_POLICY"
 		setfeedsInstance.CAI.AssetTypes = deployment.Core.SolutionSettings.Monitoring.AssetTypes.IAMPolicies
-		instanceFolderPath := makeInstanceFolderPath(instancesFolderPath, fmt.Sprintf("%s_org%s_iam_policies",
+		instanceFolderPath := makeInstanceFolderPath(instancesFolderPath, fmt.Sprintf("%s_%s_iam_policies",
 			serviceName,
-			organizationID))
+			scope.ShortName()))
 		if _, err := os.Stat(instanceFolderPath); os.IsNotExist(err) {
 			os.Mkdir(instanceFolderPath, 0755)
 		}
@@ -57,13 +57,30 @@ func (deployment *Deployment) configureSetFeedsAssetTypes() (err error) {
 		}
 		log.Printf("done %s", instanceFolderPath)
 
+		// one feed per other content type for all its asset types
+		for _, item := range deployment.getContentTypesAssetTypes() {
+			setfeedsInstance.CAI.ContentType = item.contentType
+			setfeedsInstance.CAI.AssetTypes = item.assetTypes
+			instanceFolderPath := makeInstanceFolderPath(instancesFolderPath, fmt.Sprintf("%s_%s_%s",
+				serviceName,
+				scope.ShortName(),
+				item.nameSuffix))
+			if _, err := os.Stat(instanceFolderPath); os.IsNotExist(err) {
+				os.Mkdir(instanceFolderPath, 0755)
+			}
+			if err = ffo.MarshalYAMLWrite(fmt.Sprintf("%s/%s", instanceFolderPath, solution.InstanceSettingsFileName), setfeedsInstance); err != nil {
+				return err
+			}
+			log.Printf("done %s", instanceFolderPath)
+		}
+
 		// one resource feed per asset type
 		for _, assetType := range deployment.Core.SolutionSettings.Monitoring.AssetTypes.Resources {
 			setfeedsInstance.CAI.ContentType = "RESOURCE"
 			setfeedsInstance.CAI.AssetTypes = []string{assetType}
-			instanceFolderPath := makeInstanceFolderPath(instancesFolderPath, fmt.Sprintf("%s_org%s_%s",
+			instanceFolderPath := makeInstanceFolderPath(instancesFolderPath, fmt.Sprintf("%s_%s_%s",
 				serviceName,
-				organizationID,
+				scope.ShortName(),
 				cai.GetAssetShortTypeName(assetType)))
 			if _, err := os.Stat(instanceFolderPath); os.IsNotExist(err) {
 				os.Mkdir(instanceFolderPath, 0755)
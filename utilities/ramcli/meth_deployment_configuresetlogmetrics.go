@@ -69,6 +69,27 @@ func (deployment *Deployment) configureSetLogMetrics() (err error) {
       metricKind: DELTA
       unit: '1'
       valueType: INT64
+- glo:
+    metric_id: ram_export_status
+    description: RAM CAI export operations outcome
+    filter: resource.type="cloud_function" jsonPayload.message=~"^export_"
+    labels:
+      - name: environment
+        extractor: EXTRACT(jsonPayload.environment)
+        description: dev, prd...
+        valueType: string
+      - name: instance_name
+        extractor: EXTRACT(jsonPayload.instance_name)
+        description: instance name
+        valueType: string
+      - name: status
+        extractor: REGEXP_EXTRACT(jsonPayload.message, "^export_(\\w+)")
+        description: succeeded, failed, exceeded_sla
+        valueType: string
+    metricDescriptor:
+      metricKind: DELTA
+      unit: '1'
+      valueType: INT64
 - glo:
     metric_id: ram_latency
     description: RAM latency by component
@@ -94,7 +115,7 @@ func (deployment *Deployment) configureSetLogMetrics() (err error) {
         valueType: string
       - name: origin
         extractor: EXTRACT(jsonPayload.assetInventoryOrigin)
-        description: real-time, batch-export, real-time-log-export, batch-listgroups
+        description: real-time, batch-export, historical-export, real-time-log-export, batch-listgroups, batch-listusers, batch-listdirectorysettings
         valueType: string
     metricDescriptor:
       metricKind: DELTA
@@ -125,12 +146,37 @@ func (deployment *Deployment) configureSetLogMetrics() (err error) {
         valueType: string
       - name: origin
         extractor: EXTRACT(jsonPayload.assetInventoryOrigin)
-        description: real-time, batch-export, real-time-log-export, batch-listgroups
+        description: real-time, batch-export, historical-export, real-time-log-export, batch-listgroups, batch-listusers, batch-listdirectorysettings
         valueType: string
     metricDescriptor:
       metricKind: DELTA
       unit: s
       valueType: DISTRIBUTION
+- glo:
+    metric_id: ram_stale_skipped
+    description: RAM writes skipped as older than the cached asset
+    filter: resource.type="cloud_function" severity=NOTICE jsonPayload.message=~"^finish skip stale"
+    labels:
+      - name: environment
+        extractor: EXTRACT(jsonPayload.environment)
+        description: dev, prd...
+        valueType: string
+      - name: instance_name
+        extractor: EXTRACT(jsonPayload.instance_name)
+        description: instance name
+        valueType: string
+      - name: microservice_name
+        extractor: EXTRACT(jsonPayload.microservice_name)
+        description: microservice name
+        valueType: string
+      - name: origin
+        extractor: EXTRACT(jsonPayload.assetInventoryOrigin)
+        description: real-time, batch-export, historical-export, real-time-log-export, batch-listgroups, batch-listusers, batch-listdirectorysettings
+        valueType: string
+    metricDescriptor:
+      metricKind: DELTA
+      unit: '1'
+      valueType: INT64
 - glo:
     metric_id: ram_trigger_age
     description: RAM age of the triggering event
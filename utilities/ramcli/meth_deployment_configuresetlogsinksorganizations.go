@@ -27,11 +27,27 @@ import (
 // configureLogSinksOrganizations
 func (deployment *Deployment) configureLogSinksOrganizations() (err error) {
 	serviceName := "setlogsinks"
-	// Case activity group
-	sinkNameSuffix := "activity-group"
-	filter := `resource.type="audited_resource" AND logName:"logs/cloudaudit.googleapis.com%2Factivity" AND protoPayload.serviceName="admin.googleapis.com" AND protoPayload.methodName:"group"`
+	sinks := []struct {
+		nameSuffix string
+		filter     string
+	}{
+		// Case activity group
+		{
+			nameSuffix: "activity-group",
+			filter:     `resource.type="audited_resource" AND logName:"logs/cloudaudit.googleapis.com%2Factivity" AND protoPayload.serviceName="admin.googleapis.com" AND protoPayload.methodName:"group"`,
+		},
+		// Case activity directory: users, delegated admins, domain and security settings
+		{
+			nameSuffix: "activity-directory",
+			filter:     `resource.type="audited_resource" AND logName:"logs/cloudaudit.googleapis.com%2Factivity" AND protoPayload.serviceName="admin.googleapis.com" AND NOT protoPayload.methodName:"group"`,
+		},
+		// Case GCP admin activity, to correlate asset changes, resource and IAM policy ones, with who made them
+		{
+			nameSuffix: "activity-gcp",
+			filter:     `logName:"logs/cloudaudit.googleapis.com%2Factivity" AND NOT protoPayload.serviceName="admin.googleapis.com"`,
+		},
+	}
 
-	log.Printf("configure %s %s", serviceName, sinkNameSuffix)
 	var setlogsinksInstanceDeployment setlogsinks.InstanceDeployment
 	setlogsinksInstance := setlogsinksInstanceDeployment.Settings.Instance
 	serviceFolderPath := fmt.Sprintf("%s/%s/%s", deployment.Core.RepositoryPath, solution.MicroserviceParentFolderName, serviceName)
@@ -43,23 +59,26 @@ func (deployment *Deployment) configureLogSinksOrganizations() (err error) {
 		os.Mkdir(instancesFolderPath, 0755)
 	}
 
-	for _, organizationID := range deployment.Core.SolutionSettings.Monitoring.OrganizationIDs {
-		setlogsinksInstance.LSK.Parent = fmt.Sprintf("organizations/%s", organizationID)
-		setlogsinksInstance.LSK.SinkNameSuffix = sinkNameSuffix
-		setlogsinksInstance.LSK.Filter = filter
-		setlogsinksInstance.LSK.TopicName = fmt.Sprintf("log-org%s-%s", organizationID, sinkNameSuffix)
+	for _, sink := range sinks {
+		log.Printf("configure %s %s", serviceName, sink.nameSuffix)
+		for _, organizationID := range deployment.Core.SolutionSettings.Monitoring.OrganizationIDs {
+			setlogsinksInstance.LSK.Parent = fmt.Sprintf("organizations/%s", organizationID)
+			setlogsinksInstance.LSK.SinkNameSuffix = sink.nameSuffix
+			setlogsinksInstance.LSK.Filter = sink.filter
+			setlogsinksInstance.LSK.TopicName = fmt.Sprintf("log-org%s-%s", organizationID, sink.nameSuffix)
 
-		instanceFolderPath := makeInstanceFolderPath(instancesFolderPath, fmt.Sprintf("%s_org%s_%s",
-			serviceName,
-			organizationID,
-			sinkNameSuffix))
-		if _, err := os.Stat(instanceFolderPath); os.IsNotExist(err) {
-			os.Mkdir(instanceFolderPath, 0755)
-		}
-		if err = ffo.MarshalYAMLWrite(fmt.Sprintf("%s/%s", instanceFolderPath, solution.InstanceSettingsFileName), setlogsinksInstance); err != nil {
-			return err
+			instanceFolderPath := makeInstanceFolderPath(instancesFolderPath, fmt.Sprintf("%s_org%s_%s",
+				serviceName,
+				organizationID,
+				sink.nameSuffix))
+			if _, err := os.Stat(instanceFolderPath); os.IsNotExist(err) {
+				os.Mkdir(instanceFolderPath, 0755)
+			}
+			if err = ffo.MarshalYAMLWrite(fmt.Sprintf("%s/%s", instanceFolderPath, solution.InstanceSettingsFileName), setlogsinksInstance); err != nil {
+				return err
+			}
+			log.Printf("done %s", instanceFolderPath)
 		}
-		log.Printf("done %s", instanceFolderPath)
 	}
 	return nil
 }
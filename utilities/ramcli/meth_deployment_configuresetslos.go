@@ -0,0 +1,74 @@
+// Copyright 2020 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the 'License');
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an 'AS IS' BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ramcli
+
+import (
+	"fmt"
+	"log"
+	"os"
+
+	"github.com/BrunoReboul/ram/services/setslos"
+	"github.com/BrunoReboul/ram/utilities/ffo"
+	"github.com/BrunoReboul/ram/utilities/solution"
+)
+
+// configureSetSLOs one service level objective per freshness SLO definition, on a custom service per flow
+func (deployment *Deployment) configureSetSLOs() (err error) {
+	serviceName := "setslos"
+	serviceFolderPath := fmt.Sprintf("%s/%s/%s",
+		deployment.Core.RepositoryPath,
+		solution.MicroserviceParentFolderName,
+		serviceName)
+	if _, err := os.Stat(serviceFolderPath); os.IsNotExist(err) {
+		os.Mkdir(serviceFolderPath, 0755)
+	}
+
+	log.Printf("configure %s", serviceName)
+	instancesFolderPath := fmt.Sprintf("%s/%s", serviceFolderPath, solution.InstancesFolderName)
+	if _, err := os.Stat(instancesFolderPath); os.IsNotExist(err) {
+		os.Mkdir(instancesFolderPath, 0755)
+	}
+
+	var setSLOsInstanceDeployment setslos.InstanceDeployment
+	setSLOsInstance := setSLOsInstanceDeployment.Settings.Instance
+	for _, freshnessSLOdefiniton := range deployment.Core.SolutionSettings.Hosting.FreshnessSLODefinitions {
+		scope, flow, err := getFreshnessScopeFlow(freshnessSLOdefiniton.Origin)
+		if err != nil {
+			return err
+		}
+		setSLOsInstance.MON.ServiceID = fmt.Sprintf("ram-%s", flow)
+		setSLOsInstance.MON.ServiceDisplayName = fmt.Sprintf("RAM %s", flow)
+		setSLOsInstance.MON.SLOID = fmt.Sprintf("freshness-%s", freshnessSLOdefiniton.Origin)
+		setSLOsInstance.MON.DisplayName = fmt.Sprintf("SLO freshness %s %s", scope, flow)
+		setSLOsInstance.MON.Origin = freshnessSLOdefiniton.Origin
+		setSLOsInstance.MON.Flow = flow
+		setSLOsInstance.MON.Goal = freshnessSLOdefiniton.SLO
+		setSLOsInstance.MON.CutOffBucketNumber = freshnessSLOdefiniton.CutOffBucketNumber
+		instanceFolderPath := makeInstanceFolderPath(instancesFolderPath, fmt.Sprintf("%s_%s",
+			serviceName,
+			setSLOsInstance.MON.SLOID))
+		if _, err := os.Stat(instanceFolderPath); os.IsNotExist(err) {
+			os.Mkdir(instanceFolderPath, 0755)
+		}
+		if err = ffo.MarshalYAMLWrite(fmt.Sprintf("%s/%s",
+			instanceFolderPath,
+			solution.InstanceSettingsFileName),
+			setSLOsInstance); err != nil {
+			return err
+		}
+		log.Printf("done %s", instanceFolderPath)
+	}
+	return nil
+}
@@ -85,5 +85,20 @@ func (deployment *Deployment) configureStream2bqAssetTypes() (err error) {
 	}
 	log.Printf("done %s", instanceFolderPath)
 
+	// org policies, access policies, os inventories and relationships related assets
+	for _, item := range deployment.getContentTypesAssetTypes() {
+		stream2bqInstance.Bigquery.TableName = "assets"
+		stream2bqInstance.GCF.TriggerTopic = deployment.Core.SolutionSettings.GetContentTypeTopicName(item.contentType)
+		instanceFolderPath := makeInstanceFolderPath(instancesFolderPath, fmt.Sprintf("%s_%s_assets",
+			serviceName,
+			item.nameSuffix))
+		if _, err := os.Stat(instanceFolderPath); os.IsNotExist(err) {
+			os.Mkdir(instanceFolderPath, 0755)
+		}
+		if err = ffo.MarshalYAMLWrite(fmt.Sprintf("%s/%s", instanceFolderPath, solution.InstanceSettingsFileName), stream2bqInstance); err != nil {
+			return err
+		}
+		log.Printf("done %s", instanceFolderPath)
+	}
 	return nil
 }
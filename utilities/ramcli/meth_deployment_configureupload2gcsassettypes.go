@@ -68,12 +68,12 @@ func (deployment *Deployment) configureUpload2gcsMetadataTypes() (err error) {
 	}
 	log.Printf("done %s", instanceFolderPath)
 
-	// groups by directory
-	for directoryCustomerID := range deployment.Core.SolutionSettings.Monitoring.DirectoryCustomerIDs {
-		upload2gcsInstance.GCF.TriggerTopic = fmt.Sprintf("gci-groups-%s", directoryCustomerID)
+	// org policies, access policies, os inventories and relationships
+	for _, item := range deployment.getContentTypesAssetTypes() {
+		upload2gcsInstance.GCF.TriggerTopic = deployment.Core.SolutionSettings.GetContentTypeTopicName(item.contentType)
 		instanceFolderPath := makeInstanceFolderPath(instancesFolderPath, fmt.Sprintf("%s_%s",
 			serviceName,
-			upload2gcsInstance.GCF.TriggerTopic))
+			item.nameSuffix))
 		if _, err := os.Stat(instanceFolderPath); os.IsNotExist(err) {
 			os.Mkdir(instanceFolderPath, 0755)
 		}
@@ -83,6 +83,23 @@ func (deployment *Deployment) configureUpload2gcsMetadataTypes() (err error) {
 		log.Printf("done %s", instanceFolderPath)
 	}
 
+	// groups, users, domain and security settings by directory
+	for directoryCustomerID := range deployment.Core.SolutionSettings.Monitoring.DirectoryCustomerIDs {
+		for _, kind := range []string{"groups", "users", "domainSettings", "securitySettings"} {
+			upload2gcsInstance.GCF.TriggerTopic = fmt.Sprintf("gci-%s-%s", kind, directoryCustomerID)
+			instanceFolderPath := makeInstanceFolderPath(instancesFolderPath, fmt.Sprintf("%s_%s",
+				serviceName,
+				upload2gcsInstance.GCF.TriggerTopic))
+			if _, err := os.Stat(instanceFolderPath); os.IsNotExist(err) {
+				os.Mkdir(instanceFolderPath, 0755)
+			}
+			if err = ffo.MarshalYAMLWrite(fmt.Sprintf("%s/%s", instanceFolderPath, solution.InstanceSettingsFileName), upload2gcsInstance); err != nil {
+				return err
+			}
+			log.Printf("done %s", instanceFolderPath)
+		}
+	}
+
 	// group membership
 
 	for _, topicName := range []string{deployment.Core.SolutionSettings.Hosting.Pubsub.TopicNames.GCIGroupMembers,
@@ -36,6 +36,11 @@ func (deployment *Deployment) deployGetGroupSettings() (err error) {
 		deployment.Settings.Service.GSU = instanceDeployment.Settings.Service.GSU
 		deployment.Core.AssetType = ""
 		err = deployment.deployInstanceReleasePipeline()
+	case deployment.Core.Commands.AnalyzePrivileges:
+		deployment.Settings.Service.IAM = instanceDeployment.Settings.Service.IAM
+		deployment.Settings.Service.GCB = instanceDeployment.Settings.Service.GCB
+		deployment.Settings.Service.GCF = instanceDeployment.Settings.Service.GCF
+		err = deployment.analyzeInstancePrivileges()
 	case deployment.Core.Commands.Deploy:
 		if deployment.Core.Commands.Deploy {
 			err = instanceDeployment.Deploy()
@@ -25,6 +25,7 @@ func (deployment *Deployment) deployGRMHostingOrgBindings() (err error) {
 	orgBindingsDeployment.Core = &deployment.Core
 	orgBindingsDeployment.Settings.Roles = deployment.Settings.Service.GCB.ServiceAccountBindings.GRM.Hosting.Org.Roles
 	orgBindingsDeployment.Settings.CustomRoles = deployment.Settings.Service.GCB.ServiceAccountBindings.GRM.Hosting.Org.CustomRoles
+	orgBindingsDeployment.Settings.Conditions = deployment.Settings.Service.GCB.ServiceAccountBindings.GRM.Hosting.Org.Conditions
 	orgBindingsDeployment.Artifacts.OrganizationID = orgBindingsDeployment.Core.SolutionSettings.Hosting.OrganizationID
 
 	orgBindingsDeployment.Artifacts.Member = fmt.Sprintf("serviceAccount:%d@cloudbuild.gserviceaccount.com", deployment.Core.ProjectNumber)
@@ -21,26 +21,20 @@ import (
 )
 
 func (deployment *Deployment) deployGRMMonitoringOrgBindings() (err error) {
-	orgBindingsDeployment := grm.NewOrgBindingsDeployment()
-	orgBindingsDeployment.Core = &deployment.Core
-	orgBindingsDeployment.Settings.Roles = deployment.Settings.Service.GCB.ServiceAccountBindings.GRM.Monitoring.Org.Roles
-	orgBindingsDeployment.Settings.CustomRoles = deployment.Settings.Service.GCB.ServiceAccountBindings.GRM.Monitoring.Org.CustomRoles
-	for _, organizationID := range orgBindingsDeployment.Core.SolutionSettings.Monitoring.OrganizationIDs {
-		orgBindingsDeployment.Artifacts.OrganizationID = organizationID
-
-		orgBindingsDeployment.Artifacts.Member = fmt.Sprintf("serviceAccount:%d@cloudbuild.gserviceaccount.com", deployment.Core.ProjectNumber)
-		err = orgBindingsDeployment.Deploy()
-		if err != nil {
-			return err
-		}
-
-		if orgBindingsDeployment.Core.RamcliServiceAccount != "" {
-			orgBindingsDeployment.Artifacts.Member = fmt.Sprintf("serviceAccount:%s", orgBindingsDeployment.Core.RamcliServiceAccount)
-			err = orgBindingsDeployment.Deploy()
-			if err != nil {
-				return err
-			}
-		}
+	err = grm.DeployMonitoringScopesBindings(&deployment.Core,
+		fmt.Sprintf("serviceAccount:%d@cloudbuild.gserviceaccount.com", deployment.Core.ProjectNumber),
+		deployment.Settings.Service.GCB.ServiceAccountBindings.GRM.Monitoring.Org.Roles,
+		deployment.Settings.Service.GCB.ServiceAccountBindings.GRM.Monitoring.Org.CustomRoles,
+		deployment.Settings.Service.GCB.ServiceAccountBindings.GRM.Monitoring.Org.Conditions)
+	if err != nil {
+		return err
+	}
+	if deployment.Core.RamcliServiceAccount != "" {
+		return grm.DeployMonitoringScopesBindings(&deployment.Core,
+			fmt.Sprintf("serviceAccount:%s", deployment.Core.RamcliServiceAccount),
+			deployment.Settings.Service.GCB.ServiceAccountBindings.GRM.Monitoring.Org.Roles,
+			deployment.Settings.Service.GCB.ServiceAccountBindings.GRM.Monitoring.Org.CustomRoles,
+			deployment.Settings.Service.GCB.ServiceAccountBindings.GRM.Monitoring.Org.Conditions)
 	}
 	return nil
 }
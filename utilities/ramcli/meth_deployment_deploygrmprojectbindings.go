@@ -25,6 +25,7 @@ func (deployment *Deployment) deployGRMProjectBindings() (err error) {
 	projectBindingsDeployment.Core = &deployment.Core
 	projectBindingsDeployment.Settings.Roles = deployment.Settings.Service.GCB.ServiceAccountBindings.GRM.Hosting.Project.Roles
 	projectBindingsDeployment.Settings.CustomRoles = deployment.Settings.Service.GCB.ServiceAccountBindings.GRM.Hosting.Project.CustomRoles
+	projectBindingsDeployment.Settings.Conditions = deployment.Settings.Service.GCB.ServiceAccountBindings.GRM.Hosting.Project.Conditions
 	projectBindingsDeployment.Artifacts.ProjectID = projectBindingsDeployment.Core.SolutionSettings.Hosting.ProjectID
 
 	projectBindingsDeployment.Artifacts.Member = fmt.Sprintf("serviceAccount:%d@cloudbuild.gserviceaccount.com", deployment.Core.ProjectNumber)
@@ -20,17 +20,7 @@ import (
 
 func (deployment *Deployment) deployIAMMonitoringOrgRole() (err error) {
 	if len(deployment.Settings.Service.IAM.DeployRoles.MonitoringOrg) > 0 {
-		orgRoleDeployment := iamgt.NewOrgRolesDeployment()
-		orgRoleDeployment.Core = &deployment.Core
-		orgRoleDeployment.Settings.Roles = deployment.Settings.Service.IAM.DeployRoles.MonitoringOrg
-		for _, organizationID := range deployment.Core.SolutionSettings.Monitoring.OrganizationIDs {
-			orgRoleDeployment.Artifacts.OrganizationID = organizationID
-			err = orgRoleDeployment.Deploy()
-			if err != nil {
-				break
-			}
-		}
-		return err
+		return iamgt.DeployMonitoringScopesRoles(&deployment.Core, deployment.Settings.Service.IAM.DeployRoles.MonitoringOrg)
 	}
 	return nil
 }
@@ -0,0 +1,73 @@
+// Copyright 2020 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the 'License');
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an 'AS IS' BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ramcli
+
+import (
+	"fmt"
+	"time"
+)
+
+// deployInstance deploys one microservice instance, or its release pipeline, depending on the command
+func (deployment *Deployment) deployInstance(instanceFolderRelativePath string) (err error) {
+	start := time.Now()
+	deployment.Core.ServiceName, deployment.Core.InstanceName = getServiceAndInstanceNames(instanceFolderRelativePath)
+	defer func() {
+		deployment.Core.Report.RecordInstance(deployment.Core.ServiceName, deployment.Core.InstanceName, start, err)
+	}()
+	switch deployment.Core.ServiceName {
+	case "setfeeds":
+		err = deployment.deploySetFeeds()
+	case "dumpinventory":
+		err = deployment.deployDumpInventory()
+	case "splitdump":
+		err = deployment.deploySplitDump()
+	case "publish2fs":
+		err = deployment.deployPublish2fs()
+	case "monitor":
+		err = deployment.deployMonitor()
+	case "stream2bq":
+		err = deployment.deployStream2bq()
+	case "upload2gcs":
+		err = deployment.deployUpload2gcs()
+	case "consolidategcs":
+		err = deployment.deployConsolidateGCS()
+	case "listgroups":
+		err = deployment.deployListGroups()
+	case "listusers":
+		err = deployment.deployListUsers()
+	case "listdirectorysettings":
+		err = deployment.deployListDirectorySettings()
+	case "listgroupmembers":
+		err = deployment.deployListGroupMembers()
+	case "getgroupsettings":
+		err = deployment.deployGetGroupSettings()
+	case "setlogsinks":
+		err = deployment.deploySetLogSinks()
+	case "convertlog2feed":
+		err = deployment.deployConvertLog2Feed()
+	case "setdashboards":
+		err = deployment.deploySetDashboards()
+	case "setlogmetrics":
+		err = deployment.deploySetLogMetrics()
+	case "setalerts":
+		err = deployment.deploySetAlerts()
+	case "setslos":
+		err = deployment.deploySetSLOs()
+	}
+	if err != nil {
+		return fmt.Errorf("%s %v", deployment.Core.InstanceName, err)
+	}
+	return nil
+}
@@ -58,6 +58,20 @@ func (deployment *Deployment) deployInstanceReleasePipeline() (err error) {
 		if err = deployment.deployGSRRepo(); err != nil {
 			return err
 		}
+	} else {
+		// Check grants only: missing and stale roles
+		if err = deployment.deployGRMHostingOrgBindings(); err != nil {
+			return err
+		}
+		if err = deployment.deployGRMMonitoringOrgBindings(); err != nil {
+			return err
+		}
+		if err = deployment.deployGRMProjectBindings(); err != nil {
+			return err
+		}
+		if err = deployment.deployIAMBindings(); err != nil {
+			return err
+		}
 	}
 	if err = deployment.deployGCBTrigger(); err != nil {
 		return err
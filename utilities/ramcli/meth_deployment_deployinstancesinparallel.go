@@ -0,0 +1,72 @@
+// Copyright 2020 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the 'License');
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an 'AS IS' BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ramcli
+
+import (
+	"log"
+	"sync"
+)
+
+// deployInstancesInParallel deploys first, one by one, the first instance of each microservice to set shared prerequisites,
+// then deploys the other instances wave by wave, producers before consumers, using a pool of deployment.Core.Parallel workers per wave.
+// Each instance is deployed from its own copy of the deployment, errors are collected per instance and do not stop the run:
+// the instances already running in other workers cannot be stopped, so a parallel run always reports all errors at the end
+func (deployment *Deployment) deployInstancesInParallel() (errors []error) {
+	prerequisites, waves := getDeploymentStages(deployment.Core.InstanceFolderRelativePaths)
+	log.Printf("stage 1/2 deploy shared prerequisites with %d instance(s), one per microservice", len(prerequisites))
+	for _, instanceFolderRelativePath := range prerequisites {
+		instanceDeployment := *deployment
+		if err := instanceDeployment.deployInstance(instanceFolderRelativePath); err != nil {
+			errors = append(errors, err)
+		}
+	}
+	if len(errors) > 0 && !deployment.Core.Commands.Check {
+		return errors
+	}
+
+	for i, wave := range waves {
+		log.Printf("stage 2/2 wave %d/%d deploy %d other instance(s) with %d workers", i+1, len(waves), len(wave), deployment.Core.Parallel)
+		errors = append(errors, deployment.deployWaveInParallel(wave)...)
+	}
+	return errors
+}
+
+// deployWaveInParallel deploys instances using a pool of deployment.Core.Parallel workers, and returns once they are all deployed
+func (deployment *Deployment) deployWaveInParallel(instanceFolderRelativePaths []string) (errors []error) {
+	instanceErrors := make([]error, len(instanceFolderRelativePaths))
+	jobs := make(chan int)
+	var wg sync.WaitGroup
+	for w := 0; w < deployment.Core.Parallel; w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := range jobs {
+				instanceDeployment := *deployment
+				instanceErrors[i] = instanceDeployment.deployInstance(instanceFolderRelativePaths[i])
+			}
+		}()
+	}
+	for i := range instanceFolderRelativePaths {
+		jobs <- i
+	}
+	close(jobs)
+	wg.Wait()
+	for _, err := range instanceErrors {
+		if err != nil {
+			errors = append(errors, err)
+		}
+	}
+	return errors
+}
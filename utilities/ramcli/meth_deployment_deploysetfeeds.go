@@ -16,6 +16,7 @@ package ramcli
 
 import (
 	"github.com/BrunoReboul/ram/services/setfeeds"
+	"github.com/BrunoReboul/ram/utilities/gcf"
 )
 
 func (deployment *Deployment) deploySetFeeds() (err error) {
@@ -40,6 +41,12 @@ func (deployment *Deployment) deploySetFeeds() (err error) {
 			deployment.Core.AssetType = ""
 		}
 		err = deployment.deployInstanceReleasePipeline()
+	case deployment.Core.Commands.AnalyzePrivileges:
+		deployment.Settings.Service.IAM = instanceDeployment.Settings.Service.IAM
+		deployment.Settings.Service.GCB = instanceDeployment.Settings.Service.GCB
+		// No cloud function, only the Cloud Build service account is analyzed
+		deployment.Settings.Service.GCF = gcf.Parameters{}
+		err = deployment.analyzeInstancePrivileges()
 	case deployment.Core.Commands.Deploy:
 		if deployment.Core.Commands.Deploy {
 			err = instanceDeployment.Deploy()
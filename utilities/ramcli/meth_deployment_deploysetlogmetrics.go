@@ -14,7 +14,10 @@
 
 package ramcli
 
-import "github.com/BrunoReboul/ram/services/setlogmetrics"
+import (
+	"github.com/BrunoReboul/ram/services/setlogmetrics"
+	"github.com/BrunoReboul/ram/utilities/gcf"
+)
 
 func (deployment *Deployment) deploySetLogMetrics() (err error) {
 	instanceDeployment := setlogmetrics.NewInstanceDeployment()
@@ -34,6 +37,12 @@ func (deployment *Deployment) deploySetLogMetrics() (err error) {
 		deployment.Settings.Service.GSU = instanceDeployment.Settings.Service.GSU
 		deployment.Core.AssetType = ""
 		err = deployment.deployInstanceReleasePipeline()
+	case deployment.Core.Commands.AnalyzePrivileges:
+		deployment.Settings.Service.IAM = instanceDeployment.Settings.Service.IAM
+		deployment.Settings.Service.GCB = instanceDeployment.Settings.Service.GCB
+		// No cloud function, only the Cloud Build service account is analyzed
+		deployment.Settings.Service.GCF = gcf.Parameters{}
+		err = deployment.analyzeInstancePrivileges()
 	case deployment.Core.Commands.Deploy:
 		if deployment.Core.Commands.Deploy {
 			err = instanceDeployment.Deploy()
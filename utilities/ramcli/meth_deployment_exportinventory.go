@@ -0,0 +1,66 @@
+// Copyright 2020 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the 'License');
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an 'AS IS' BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ramcli
+
+import (
+	"encoding/json"
+	"fmt"
+	"log"
+	"time"
+
+	"github.com/BrunoReboul/ram/services/dumpinventory"
+	pubsubpb "google.golang.org/genproto/googleapis/pubsub/v1"
+)
+
+// exportInventory requests dumpinventory instances to export the inventory as it was at a point in time
+func (deployment *Deployment) exportInventory() (err error) {
+	messageData, err := json.Marshal(dumpinventory.ExportRequest{ReadTime: deployment.Core.ExportReadTime})
+	if err != nil {
+		return err
+	}
+	requestNumber := 0
+	for _, instanceFolderRelativePath := range deployment.Core.InstanceFolderRelativePaths {
+		deployment.Core.ServiceName, deployment.Core.InstanceName = getServiceAndInstanceNames(instanceFolderRelativePath)
+		if deployment.Core.ServiceName != "dumpinventory" {
+			continue
+		}
+		instanceDeployment := dumpinventory.NewInstanceDeployment()
+		instanceDeployment.Core = &deployment.Core
+		err = instanceDeployment.ReadValidate()
+		if err != nil {
+			return err
+		}
+		err = instanceDeployment.Situate()
+		if err != nil {
+			return err
+		}
+		var publishRequest pubsubpb.PublishRequest
+		publishRequest.Topic = fmt.Sprintf("projects/%s/topics/%s",
+			deployment.Core.SolutionSettings.Hosting.ProjectID,
+			instanceDeployment.Artifacts.TopicName)
+		publishRequest.Messages = []*pubsubpb.PubsubMessage{{Data: messageData}}
+		publishResponse, err := deployment.Core.Services.PubsubPublisherClient.Publish(deployment.Core.Ctx, &publishRequest)
+		if err != nil {
+			return fmt.Errorf("%s PubsubPublisherClient.Publish %v", deployment.Core.InstanceName, err)
+		}
+		log.Printf("%s point-in-time export at %s requested, message id %v", deployment.Core.InstanceName, deployment.Core.ExportReadTime.Format(time.RFC3339), publishResponse.MessageIds)
+		requestNumber++
+	}
+	if requestNumber == 0 {
+		return fmt.Errorf("No dumpinventory instance found")
+	}
+	log.Printf("%d point-in-time export(s) requested, origin historical-export", requestNumber)
+	return nil
+}
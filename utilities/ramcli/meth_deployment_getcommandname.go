@@ -0,0 +1,43 @@
+// Copyright 2020 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the 'License');
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an 'AS IS' BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ramcli
+
+// getCommandName returns the name of the command run, as recorded in the run report
+func (deployment *Deployment) getCommandName() (commandName string) {
+	switch true {
+	case deployment.NewRule.Name != "":
+		commandName = "newrule"
+	case deployment.ImportPolicyPath != "":
+		commandName = "import-policy"
+	case deployment.Core.Commands.Initialize:
+		commandName = "init"
+	case deployment.Core.Commands.ConfigureAssetTypes:
+		commandName = "config"
+	case deployment.Core.Commands.Export:
+		commandName = "export"
+	case deployment.Core.Commands.AnalyzePrivileges:
+		commandName = "analyze-privileges"
+	case deployment.Core.Commands.MakeReleasePipeline:
+		commandName = "pipe"
+	case deployment.Core.Commands.Deploy:
+		commandName = "deploy"
+	default:
+		commandName = "constraints"
+	}
+	if deployment.Core.Commands.Check {
+		commandName = commandName + " check"
+	}
+	return commandName
+}
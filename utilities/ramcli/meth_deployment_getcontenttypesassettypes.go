@@ -0,0 +1,58 @@
+// Copyright 2020 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the 'License');
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an 'AS IS' BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ramcli
+
+import (
+	"github.com/BrunoReboul/ram/utilities/cai"
+)
+
+// contentTypeAssetTypes asset types to monitor for a content type that has one feed and one topic for all asset types
+type contentTypeAssetTypes struct {
+	contentType string
+	nameSuffix  string
+	assetTypes  []string
+}
+
+// getContentTypesAssetTypes returns org policies, access policies, os inventories and relationships asset types defined in solution.yaml
+// Content types without asset types are not monitored and not returned
+func (deployment *Deployment) getContentTypesAssetTypes() (list []contentTypeAssetTypes) {
+	for _, item := range []contentTypeAssetTypes{
+		{
+			contentType: cai.ContentTypeOrgPolicy,
+			nameSuffix:  "org_policies",
+			assetTypes:  deployment.Core.SolutionSettings.Monitoring.AssetTypes.OrgPolicies,
+		},
+		{
+			contentType: cai.ContentTypeAccessPolicy,
+			nameSuffix:  "access_policies",
+			assetTypes:  deployment.Core.SolutionSettings.Monitoring.AssetTypes.AccessPolicies,
+		},
+		{
+			contentType: cai.ContentTypeOSInventory,
+			nameSuffix:  "os_inventories",
+			assetTypes:  deployment.Core.SolutionSettings.Monitoring.AssetTypes.OSInventories,
+		},
+		{
+			contentType: cai.ContentTypeRelationship,
+			nameSuffix:  "relationships",
+			assetTypes:  deployment.Core.SolutionSettings.Monitoring.AssetTypes.Relationships,
+		},
+	} {
+		if len(item.assetTypes) > 0 {
+			list = append(list, item)
+		}
+	}
+	return list
+}
@@ -0,0 +1,55 @@
+// Copyright 2020 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the 'License');
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an 'AS IS' BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ramcli
+
+import (
+	"encoding/json"
+	"fmt"
+	"log"
+	"time"
+
+	"google.golang.org/api/logging/v2"
+)
+
+// getLoggedExercisedPermissions queries the audit logs of the hosting project and monitoring scopes for the permissions exercised by a principal
+func (deployment *Deployment) getLoggedExercisedPermissions(principalEmail string) (exercisedPermissions []string, err error) {
+	var listLogEntriesRequest logging.ListLogEntriesRequest
+	listLogEntriesRequest.ResourceNames = []string{fmt.Sprintf("projects/%s", deployment.Core.SolutionSettings.Hosting.ProjectID)}
+	for _, scope := range deployment.Core.SolutionSettings.GetMonitoringScopes() {
+		listLogEntriesRequest.ResourceNames = append(listLogEntriesRequest.ResourceNames, scope.Parent())
+	}
+	listLogEntriesRequest.Filter = fmt.Sprintf("logName:\"cloudaudit.googleapis.com\" AND protoPayload.authenticationInfo.principalEmail=\"%s\" AND timestamp>=\"%s\"",
+		principalEmail,
+		time.Now().AddDate(0, 0, -deployment.Privileges.Days).Format(time.RFC3339))
+	listLogEntriesRequest.PageSize = 1000
+	exercised := make(map[string][]string)
+	entriesCount := 0
+	err = deployment.Core.Services.LoggingService.Entries.List(&listLogEntriesRequest).Pages(deployment.Core.Ctx, func(response *logging.ListLogEntriesResponse) error {
+		for _, entry := range response.Entries {
+			var protoPayload auditLogProtoPayload
+			if err := json.Unmarshal(entry.ProtoPayload, &protoPayload); err != nil {
+				return fmt.Errorf("json.Unmarshal %s %v", entry.InsertId, err)
+			}
+			addExercisedPermissions(exercised, protoPayload)
+			entriesCount++
+		}
+		return nil
+	})
+	if err != nil {
+		return exercisedPermissions, fmt.Errorf("LoggingService.Entries.List %v", err)
+	}
+	log.Printf("%s found %d audit log entries for %s in the last %d days", deployment.Core.InstanceName, entriesCount, principalEmail, deployment.Privileges.Days)
+	return exercised[principalEmail], nil
+}
@@ -0,0 +1,79 @@
+// Copyright 2020 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the 'License');
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an 'AS IS' BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ramcli
+
+import (
+	"fmt"
+	"io/ioutil"
+	"log"
+	"path/filepath"
+	"strings"
+
+	"github.com/BrunoReboul/ram/utilities/deploy"
+)
+
+// importPolicyLibrary creates or updates monitor instances from the templates of a GCP policy library, aka Forseti config validator policy library
+// Templates depending on inputs the monitor microservice does not provide are skipped and reported
+func (deployment *Deployment) importPolicyLibrary() (err error) {
+	templatesFolderPath := filepath.Join(deployment.ImportPolicyPath, "policies", "templates")
+	fileInfos, err := ioutil.ReadDir(templatesFolderPath)
+	if err != nil {
+		return err
+	}
+	samples, err := getPolicySamples(filepath.Join(deployment.ImportPolicyPath, "samples"))
+	if err != nil {
+		return err
+	}
+	deployment.Core.ServiceName = "monitor"
+	counts := make(map[string]int)
+	for _, fileInfo := range fileInfos {
+		if fileInfo.IsDir() || !strings.HasSuffix(fileInfo.Name(), ".yaml") {
+			continue
+		}
+		deployment.Core.InstanceName = getPolicyTemplateInstanceName(fileInfo.Name())
+		instanceName, action, notes, err := importPolicyTemplate(deployment.Core.RepositoryPath,
+			filepath.Join(templatesFolderPath, fileInfo.Name()),
+			samples,
+			deployment.Core.SolutionSettings.Hosting.Pubsub.TopicNames.IAMPolicies)
+		resourceReport := deployment.Core.StartResourceReport("monitor rule", instanceName)
+		resourceReport.Action = action
+		resourceReport.Drift = notes
+		resourceReport.End(&err)
+		counts[resourceReport.Action]++
+		switch resourceReport.Action {
+		case deploy.ActionFailed:
+			log.Printf("%s ERROR import %s %v", instanceName, fileInfo.Name(), err)
+		case deploy.ActionSkipped:
+			log.Printf("%s WARNING skipped %s %s", instanceName, fileInfo.Name(), strings.Join(notes, ", "))
+		case deploy.ActionUnchanged:
+		default:
+			log.Printf("%s %s %s", instanceName, action, strings.Join(notes, ", "))
+		}
+	}
+	deployment.Core.InstanceName = ""
+	log.Printf("policy library import: %d created, %d updated, %d unchanged, %d skipped, %d failed",
+		counts[deploy.ActionCreated],
+		counts[deploy.ActionUpdated],
+		counts[deploy.ActionUnchanged],
+		counts[deploy.ActionSkipped],
+		counts[deploy.ActionFailed])
+	if err = deployment.makeConstraintsOneFiles(); err != nil {
+		return err
+	}
+	if counts[deploy.ActionFailed] > 0 {
+		return fmt.Errorf("%d policy library templates failed to import", counts[deploy.ActionFailed])
+	}
+	return nil
+}
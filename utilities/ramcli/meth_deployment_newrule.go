@@ -0,0 +1,36 @@
+// Copyright 2020 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the 'License');
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an 'AS IS' BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ramcli
+
+import (
+	"log"
+)
+
+// newRule scaffolds a new monitor rule then refreshes the constraints one files
+func (deployment *Deployment) newRule() (err error) {
+	instanceFolderRelativePath, err := makeNewRuleFiles(deployment.Core.RepositoryPath,
+		deployment.NewRule.Name,
+		deployment.NewRule.AssetType,
+		deployment.NewRule.Severity)
+	if err != nil {
+		return err
+	}
+	log.Printf("new rule scaffolded in %s", instanceFolderRelativePath)
+	if err = deployment.makeConstraintsOneFiles(); err != nil {
+		return err
+	}
+	log.Printf("next steps: write the rego rule, complete the constraint annotations, adapt the test fixture then run opa test")
+	return nil
+}
@@ -0,0 +1,92 @@
+// Copyright 2020 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the 'License');
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an 'AS IS' BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ramcli
+
+import (
+	"fmt"
+	"log"
+	"strings"
+
+	"github.com/BrunoReboul/ram/utilities/ffo"
+	"github.com/BrunoReboul/ram/utilities/gfs"
+	"github.com/BrunoReboul/ram/utilities/grm"
+	"github.com/BrunoReboul/ram/utilities/iamgt"
+	"github.com/BrunoReboul/ram/utilities/solution"
+	"github.com/BrunoReboul/ram/utilities/str"
+)
+
+// pruneRemovedServicesGrants reports, and removes with -prune-iam, the grants recorded for the microservices listed as removed with -removed-services
+// A listed microservice still having instances in the repository stops the pruning, as it may be a mistake
+func (deployment *Deployment) pruneRemovedServicesGrants() (err error) {
+	microserviceRelativeFolderPaths, err := ffo.GetChild(deployment.Core.RepositoryPath, solution.MicroserviceParentFolderName)
+	if err != nil {
+		return err
+	}
+	for _, microserviceRelativeFolderPath := range microserviceRelativeFolderPaths {
+		instanceFolderRelativePaths, err := ffo.GetChild(deployment.Core.RepositoryPath, fmt.Sprintf("%s/%s", microserviceRelativeFolderPath, solution.InstancesFolderName))
+		if err == nil && len(instanceFolderRelativePaths) > 0 {
+			parts := strings.Split(microserviceRelativeFolderPath, "/")
+			if str.Find(deployment.RemovedServiceNames, parts[len(parts)-1]) {
+				return fmt.Errorf("microservice %s listed in -removed-services still has instances in the repository", parts[len(parts)-1])
+			}
+		}
+	}
+	iamGrantsList, err := gfs.ListIAMGrants(&deployment.Core, grm.Retries)
+	if err != nil {
+		return err
+	}
+	for _, iamGrants := range getRemovedServicesGrants(iamGrantsList, deployment.RemovedServiceNames) {
+		log.Printf("%s ramcli WARNING microservice removed, stale grants %v for %s on %s", iamGrants.ServiceName, iamGrants.Roles, iamGrants.Member, iamGrants.Resource)
+		core := deployment.Core
+		core.ServiceName = iamGrants.ServiceName
+		core.InstanceName = iamGrants.ServiceName
+		// No wanted roles: all recorded roles not protected by other microservices are stale
+		switch iamGrants.Kind {
+		case "grm organization bindings":
+			orgBindingsDeployment := grm.NewOrgBindingsDeployment()
+			orgBindingsDeployment.Core = &core
+			orgBindingsDeployment.Artifacts.OrganizationID = strings.TrimPrefix(iamGrants.Resource, "organizations/")
+			orgBindingsDeployment.Artifacts.Member = iamGrants.Member
+			orgBindingsDeployment.Artifacts.Scope = iamGrants.Scope
+			err = orgBindingsDeployment.Deploy()
+		case "grm folder bindings":
+			folderBindingsDeployment := grm.NewFolderBindingsDeployment()
+			folderBindingsDeployment.Core = &core
+			folderBindingsDeployment.Artifacts.FolderID = strings.TrimPrefix(iamGrants.Resource, "folders/")
+			folderBindingsDeployment.Artifacts.Member = iamGrants.Member
+			folderBindingsDeployment.Artifacts.Scope = iamGrants.Scope
+			err = folderBindingsDeployment.Deploy()
+		case "grm project bindings":
+			projectBindingsDeployment := grm.NewProjectBindingsDeployment()
+			projectBindingsDeployment.Core = &core
+			projectBindingsDeployment.Artifacts.ProjectID = strings.TrimPrefix(iamGrants.Resource, "projects/")
+			projectBindingsDeployment.Artifacts.Member = iamGrants.Member
+			projectBindingsDeployment.Artifacts.Scope = iamGrants.Scope
+			err = projectBindingsDeployment.Deploy()
+		case "iam service account bindings":
+			bindingsDeployment := iamgt.NewBindingsDeployment()
+			bindingsDeployment.Core = &core
+			bindingsDeployment.Artifacts.ServiceAccountName = iamGrants.Resource
+			bindingsDeployment.Artifacts.Member = iamGrants.Member
+			err = bindingsDeployment.Deploy()
+		default:
+			err = fmt.Errorf("unknown iam grants kind %s", iamGrants.Kind)
+		}
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
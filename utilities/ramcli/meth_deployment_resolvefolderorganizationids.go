@@ -0,0 +1,34 @@
+// Copyright 2020 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the 'License');
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an 'AS IS' BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ramcli
+
+import (
+	"fmt"
+
+	"github.com/BrunoReboul/ram/utilities/grm"
+)
+
+// resolveFolderOrganizationIDs finds the organization of each monitored folder, where folder bindings custom roles are defined
+func (deployment *Deployment) resolveFolderOrganizationIDs() (err error) {
+	deployment.Core.SolutionSettings.Monitoring.FolderOrganizationIDs = make(map[string]string)
+	for _, folderID := range deployment.Core.SolutionSettings.Monitoring.FolderIDs {
+		organizationID, err := grm.GetFolderOrganizationID(&deployment.Core, folderID)
+		if err != nil {
+			return fmt.Errorf("ERROR - cannot resolve the organization of monitored folder %s, where its custom roles are defined: %v", folderID, err)
+		}
+		deployment.Core.SolutionSettings.Monitoring.FolderOrganizationIDs[folderID] = organizationID
+	}
+	return nil
+}
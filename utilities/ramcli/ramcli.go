@@ -23,10 +23,12 @@ import (
 
 	asset "cloud.google.com/go/asset/apiv1"
 	"cloud.google.com/go/firestore"
+	firestoreadmin "cloud.google.com/go/firestore/apiv1/admin"
 	pubsub "cloud.google.com/go/pubsub/apiv1"
 	scheduler "cloud.google.com/go/scheduler/apiv1"
 
 	"github.com/BrunoReboul/ram/utilities/cai"
+	"github.com/BrunoReboul/ram/utilities/deploy"
 	"github.com/BrunoReboul/ram/utilities/ffo"
 	"github.com/BrunoReboul/ram/utilities/solution"
 
@@ -42,6 +44,7 @@ import (
 	"google.golang.org/api/iam/v1"
 	"google.golang.org/api/logging/v2"
 	"google.golang.org/api/monitoring/v1"
+	monitoringv3 "google.golang.org/api/monitoring/v3"
 	"google.golang.org/api/option"
 	"google.golang.org/api/serviceusage/v1"
 	"google.golang.org/api/sourcerepo/v1"
@@ -95,6 +98,10 @@ func Initialize(ctx context.Context, deployment *Deployment) {
 	if err != nil {
 		log.Fatalln(err)
 	}
+	deployment.Core.Services.MonitoringServicev3, err = monitoringv3.NewService(ctx, option.WithCredentials(creds))
+	if err != nil {
+		log.Fatalln(err)
+	}
 	deployment.Core.Services.ServiceusageService, err = serviceusage.NewService(ctx, option.WithCredentials(creds))
 	if err != nil {
 		log.Fatalln(err)
@@ -124,6 +131,13 @@ func RAMCli(deployment *Deployment) (err error) {
 		return err
 	}
 	log.Printf("goVersion %s, ramVersion %s", deployment.Core.GoVersion, deployment.Core.RAMVersion)
+	deployment.Core.Report = deploy.NewReport(deployment.getCommandName(), deployment.Core.EnvironmentName, deployment.Core.RAMVersion)
+	defer func() {
+		deployment.Core.Report.End(err)
+		if writeErr := deployment.Core.Report.Write(deployment.Core.ReportFilePath, deployment.Core.JUnitReportFilePath); writeErr != nil {
+			log.Printf("WARNING impossible to write the run report %v", writeErr)
+		}
+	}()
 
 	solutionConfigFilePath := fmt.Sprintf("%s/%s", deployment.Core.RepositoryPath, solution.SolutionSettingsFileName)
 	err = ffo.ReadValidate("", "SolutionSettings", solutionConfigFilePath, &deployment.Core.SolutionSettings)
@@ -131,6 +145,9 @@ func RAMCli(deployment *Deployment) (err error) {
 		return err
 	}
 	deployment.Core.SolutionSettings.Situate(deployment.Core.EnvironmentName)
+	if err = deployment.resolveFolderOrganizationIDs(); err != nil {
+		return err
+	}
 	deployment.Core.ProjectNumber, err = getProjectNumber(deployment.Core.Ctx, deployment.Core.Services.CloudresourcemanagerService, deployment.Core.SolutionSettings.Hosting.ProjectID)
 
 	creds, err := google.FindDefaultCredentials(deployment.Core.Ctx, "https://www.googleapis.com/auth/cloud-platform")
@@ -147,20 +164,24 @@ func RAMCli(deployment *Deployment) (err error) {
 	if err != nil {
 		return err
 	}
+	deployment.Core.Services.FirestoreAdminClient, err = firestoreadmin.NewFirestoreAdminClient(deployment.Core.Ctx, option.WithCredentials(creds))
+	if err != nil {
+		return err
+	}
 
 	if deployment.Core.AssetType != "" {
 		// For one (new) assetType build the list of related instances to deploy accross services. aka transversal point of view
 		// Cannot be done in checkarguments like for other deployments as requires orgIDs list that is available only after ReadValidate
 		var instanceFolderRelativePaths []string
-		for _, organizationID := range deployment.Core.SolutionSettings.Monitoring.OrganizationIDs {
+		for _, scope := range deployment.Core.SolutionSettings.GetMonitoringScopes() {
 			serviceName := "setfeeds"
 			instanceRelativePath := strings.Replace(
-				fmt.Sprintf("%s/%s/%s/%s_org%s_%s",
+				fmt.Sprintf("%s/%s/%s/%s_%s_%s",
 					solution.MicroserviceParentFolderName,
 					serviceName,
 					solution.InstancesFolderName,
 					serviceName,
-					organizationID,
+					scope.ShortName(),
 					cai.GetAssetShortTypeName(deployment.Core.AssetType)), "-", "_", -1)
 			instancePath := fmt.Sprintf("%s/%s", deployment.Core.RepositoryPath, instanceRelativePath)
 			if _, err := os.Stat(instancePath); err != nil {
@@ -170,12 +191,12 @@ func RAMCli(deployment *Deployment) (err error) {
 
 			serviceName = "dumpinventory"
 			instanceRelativePath = strings.Replace(
-				fmt.Sprintf("%s/%s/%s/%s_org%s_%s",
+				fmt.Sprintf("%s/%s/%s/%s_%s_%s",
 					solution.MicroserviceParentFolderName,
 					serviceName,
 					solution.InstancesFolderName,
 					serviceName,
-					organizationID,
+					scope.ShortName(),
 					cai.GetAssetShortTypeName(deployment.Core.AssetType)), "-", "_", -1)
 			instancePath = fmt.Sprintf("%s/%s", deployment.Core.RepositoryPath, instanceRelativePath)
 			if _, err := os.Stat(instancePath); err != nil {
@@ -215,6 +236,14 @@ func RAMCli(deployment *Deployment) (err error) {
 	}
 
 	switch true {
+	case deployment.NewRule.Name != "":
+		if err = deployment.newRule(); err != nil {
+			return err
+		}
+	case deployment.ImportPolicyPath != "":
+		if err = deployment.importPolicyLibrary(); err != nil {
+			return err
+		}
 	case deployment.Core.Commands.Initialize:
 		if err = deployment.initialize(); err != nil {
 			return err
@@ -238,9 +267,18 @@ func RAMCli(deployment *Deployment) (err error) {
 		if err = deployment.configureUpload2gcsMetadataTypes(); err != nil {
 			return err
 		}
+		if err = deployment.configureConsolidateGCSAssetTypes(); err != nil {
+			return err
+		}
 		if err = deployment.configureListGroupsDirectories(); err != nil {
 			return err
 		}
+		if err = deployment.configureListUsersDirectories(); err != nil {
+			return err
+		}
+		if err = deployment.configureListDirectorySettingsDirectories(); err != nil {
+			return err
+		}
 		if err = deployment.configureListGroupMembersDirectories(); err != nil {
 			return err
 		}
@@ -259,6 +297,20 @@ func RAMCli(deployment *Deployment) (err error) {
 		if err = deployment.configureSetLogMetrics(); err != nil {
 			return err
 		}
+		if err = deployment.configureSetSLOs(); err != nil {
+			return err
+		}
+		if err = deployment.configureSetAlerts(); err != nil {
+			return err
+		}
+	case deployment.Core.Commands.Export:
+		if err = deployment.exportInventory(); err != nil {
+			return err
+		}
+	case deployment.Core.Commands.AnalyzePrivileges:
+		if err = deployment.analyzePrivileges(); err != nil {
+			return err
+		}
 	case deployment.Core.Commands.Deploy || deployment.Core.Commands.MakeReleasePipeline:
 		log.Printf("found %d instance(s)", len(deployment.Core.InstanceFolderRelativePaths))
 		if err = deployment.makeConstraintsOneFiles(); err != nil {
@@ -275,46 +327,31 @@ func RAMCli(deployment *Deployment) (err error) {
 		if deployment.Core.Commands.Check {
 			breakOnFirstError = false
 		}
-		for _, instanceFolderRelativePath := range deployment.Core.InstanceFolderRelativePaths {
-			deployment.Core.ServiceName, deployment.Core.InstanceName = getServiceAndInstanceNames(instanceFolderRelativePath)
-			switch deployment.Core.ServiceName {
-			case "setfeeds":
-				err = deployment.deploySetFeeds()
-			case "dumpinventory":
-				err = deployment.deployDumpInventory()
-			case "splitdump":
-				err = deployment.deploySplitDump()
-			case "publish2fs":
-				err = deployment.deployPublish2fs()
-			case "monitor":
-				err = deployment.deployMonitor()
-			case "stream2bq":
-				err = deployment.deployStream2bq()
-			case "upload2gcs":
-				err = deployment.deployUpload2gcs()
-			case "listgroups":
-				err = deployment.deployListGroups()
-			case "listgroupmembers":
-				err = deployment.deployListGroupMembers()
-			case "getgroupsettings":
-				err = deployment.deployGetGroupSettings()
-			case "setlogsinks":
-				err = deployment.deploySetLogSinks()
-			case "convertlog2feed":
-				err = deployment.deployConvertLog2Feed()
-			case "setdashboards":
-				err = deployment.deploySetDashboards()
-			case "setlogmetrics":
-				err = deployment.deploySetLogMetrics()
+		if deployment.Core.Parallel > 1 {
+			// Concurrent deployments cannot be stopped on the first error, they are all reported at the end
+			breakOnFirstError = false
+			log.Printf("-parallel %d: errors do not stop the run, they are all reported at the end", deployment.Core.Parallel)
+			errors = deployment.deployInstancesInParallel()
+		} else {
+			for _, instanceFolderRelativePath := range deployment.Core.InstanceFolderRelativePaths {
+				err = deployment.deployInstance(instanceFolderRelativePath)
+				if breakOnFirstError {
+					if err != nil {
+						return err
+					}
+				} else {
+					if err != nil {
+						errors = append(errors, err)
+					}
+				}
 			}
-			if breakOnFirstError {
-				if err != nil {
+		}
+		if len(deployment.RemovedServiceNames) > 0 {
+			if err = deployment.pruneRemovedServicesGrants(); err != nil {
+				if breakOnFirstError {
 					return err
 				}
-			} else {
-				if err != nil {
-					errors = append(errors, err)
-				}
+				errors = append(errors, err)
 			}
 		}
 		if !breakOnFirstError {
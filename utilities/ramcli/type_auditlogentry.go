@@ -0,0 +1,33 @@
+// Copyright 2020 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the 'License');
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an 'AS IS' BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ramcli
+
+// auditLogEntry minimal cloud audit log entry, as exported by gcloud logging read --format json or a log sink
+type auditLogEntry struct {
+	ProtoPayload auditLogProtoPayload `json:"protoPayload"`
+}
+
+// auditLogProtoPayload minimal google.cloud.audit.AuditLog payload
+type auditLogProtoPayload struct {
+	AuthenticationInfo struct {
+		PrincipalEmail string `json:"principalEmail"`
+	} `json:"authenticationInfo"`
+	AuthorizationInfo []struct {
+		Permission string `json:"permission"`
+		Granted    bool   `json:"granted"`
+		Resource   string `json:"resource"`
+	} `json:"authorizationInfo"`
+	MethodName string `json:"methodName"`
+}
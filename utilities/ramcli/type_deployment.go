@@ -17,18 +17,32 @@ package ramcli
 import (
 	"github.com/BrunoReboul/ram/utilities/deploy"
 	"github.com/BrunoReboul/ram/utilities/gcb"
+	"github.com/BrunoReboul/ram/utilities/gcf"
 	"github.com/BrunoReboul/ram/utilities/gsu"
 	"github.com/BrunoReboul/ram/utilities/iamgt"
 )
 
 // Deployment structure
 type Deployment struct {
-	Core     deploy.Core
+	Core    deploy.Core
+	NewRule struct {
+		Name      string
+		AssetType string
+		Severity  string
+	}
+	ImportPolicyPath    string
+	RemovedServiceNames []string
+	Privileges          struct {
+		AuditLogsFilePath    string
+		Days                 int
+		ExercisedPermissions map[string][]string
+	}
 	Settings struct {
 		Service struct {
 			GSU gsu.Parameters
 			IAM iamgt.Parameters
 			GCB gcb.Parameters
+			GCF gcf.Parameters
 		}
 	}
 }
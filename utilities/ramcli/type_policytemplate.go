@@ -0,0 +1,46 @@
+// Copyright 2020 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the 'License');
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an 'AS IS' BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ramcli
+
+// policyTemplate ConstraintTemplate from the GCP policy library, policies/templates/*.yaml
+type policyTemplate struct {
+	Kind     string `yaml:"kind"`
+	Metadata struct {
+		Name string `yaml:"name"`
+	} `yaml:"metadata"`
+	Spec struct {
+		CRD struct {
+			Spec struct {
+				Names struct {
+					Kind string `yaml:"kind"`
+				} `yaml:"names"`
+			} `yaml:"spec"`
+		} `yaml:"crd"`
+		// Targets is a map keyed by target name in v1alpha1 templates, a list of objects with a target field in v1beta1 templates
+		Targets interface{} `yaml:"targets"`
+	} `yaml:"spec"`
+}
+
+// policySample constraint sample from the GCP policy library, samples/*.yaml
+type policySample struct {
+	Kind     string `yaml:"kind"`
+	Metadata struct {
+		Name string `yaml:"name"`
+	} `yaml:"metadata"`
+	Spec struct {
+		Match map[string]interface{} `yaml:"match"`
+	} `yaml:"spec"`
+	fileBytes []byte
+}
@@ -13,4 +13,7 @@
 // limitations under the License.
 
 // Package sch helps with Google Cloud Scheduler
+//
+// Jobs are reconciled field by field: description, schedule, time zone, pubsub target and retry config (retry count, max retry duration).
+// Drifting fields are updated, or reported as an error in check mode.
 package sch
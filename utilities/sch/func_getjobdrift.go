@@ -0,0 +1,73 @@
+// Copyright 2020 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the 'License');
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an 'AS IS' BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sch
+
+import (
+	"bytes"
+	"fmt"
+
+	schedulerpb "google.golang.org/genproto/googleapis/cloud/scheduler/v1"
+)
+
+// getJobDrift compare the wanted job to the retreived one field by field
+// returns the drifting field names, the related update mask paths and a want / have description
+func getJobDrift(wantedJob, retreivedJob *schedulerpb.Job) (drift []string, updatePaths []string, s string) {
+	if wantedJob.Description != retreivedJob.Description {
+		drift = append(drift, "description")
+		updatePaths = append(updatePaths, "description")
+		s = fmt.Sprintf("%sdescription\nwant %s\nhave %s\n", s,
+			wantedJob.Description,
+			retreivedJob.Description)
+	}
+	if wantedJob.Schedule != retreivedJob.Schedule {
+		drift = append(drift, "schedule")
+		updatePaths = append(updatePaths, "schedule")
+		s = fmt.Sprintf("%sschedule\nwant %s\nhave %s\n", s,
+			wantedJob.Schedule,
+			retreivedJob.Schedule)
+	}
+	if wantedJob.TimeZone != retreivedJob.TimeZone {
+		drift = append(drift, "timeZone")
+		updatePaths = append(updatePaths, "time_zone")
+		s = fmt.Sprintf("%stimeZone\nwant %s\nhave %s\n", s,
+			wantedJob.TimeZone,
+			retreivedJob.TimeZone)
+	}
+	wantedTarget := wantedJob.GetPubsubTarget()
+	retreivedTarget := retreivedJob.GetPubsubTarget()
+	if wantedTarget.GetTopicName() != retreivedTarget.GetTopicName() ||
+		!bytes.Equal(wantedTarget.GetData(), retreivedTarget.GetData()) {
+		drift = append(drift, "pubsubTarget")
+		updatePaths = append(updatePaths, "pubsub_target")
+		s = fmt.Sprintf("%spubsubTarget\nwant %s %s\nhave %s %s\n", s,
+			wantedTarget.GetTopicName(),
+			string(wantedTarget.GetData()),
+			retreivedTarget.GetTopicName(),
+			string(retreivedTarget.GetData()))
+	}
+	wantedRetryConfig := wantedJob.GetRetryConfig()
+	retreivedRetryConfig := retreivedJob.GetRetryConfig()
+	if wantedRetryConfig.GetRetryCount() != retreivedRetryConfig.GetRetryCount() ||
+		wantedRetryConfig.GetMaxRetryDuration().AsDuration() != retreivedRetryConfig.GetMaxRetryDuration().AsDuration() {
+		drift = append(drift, "retryConfig")
+		updatePaths = append(updatePaths, "retry_config.retry_count", "retry_config.max_retry_duration")
+		s = fmt.Sprintf("%sretryConfig\nwant retryCount %d maxRetryDuration %v\nhave retryCount %d maxRetryDuration %v\n", s,
+			wantedRetryConfig.GetRetryCount(),
+			wantedRetryConfig.GetMaxRetryDuration().AsDuration(),
+			retreivedRetryConfig.GetRetryCount(),
+			retreivedRetryConfig.GetMaxRetryDuration().AsDuration())
+	}
+	return drift, updatePaths, s
+}
@@ -0,0 +1,85 @@
+// Copyright 2020 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the 'License');
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an 'AS IS' BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sch
+
+import (
+	"reflect"
+	"testing"
+	"time"
+
+	schedulerpb "google.golang.org/genproto/googleapis/cloud/scheduler/v1"
+	"google.golang.org/protobuf/types/known/durationpb"
+)
+
+func TestUnitGetJobDrift(t *testing.T) {
+	makeJob := func(schedule string, timeZone string, data string, retryCount int32, maxRetryDuration time.Duration) *schedulerpb.Job {
+		var job schedulerpb.Job
+		job.Description = "Real-time Asset Monitor"
+		job.Schedule = schedule
+		job.TimeZone = timeZone
+		job.Target = &schedulerpb.Job_PubsubTarget{
+			PubsubTarget: &schedulerpb.PubsubTarget{
+				TopicName: "projects/qwerty/topics/actions-dumpinventory",
+				Data:      []byte(data)}}
+		job.RetryConfig = &schedulerpb.RetryConfig{
+			RetryCount:       retryCount,
+			MaxRetryDuration: durationpb.New(maxRetryDuration)}
+		return &job
+	}
+	var testCases = []struct {
+		name            string
+		wantedJob       *schedulerpb.Job
+		retreivedJob    *schedulerpb.Job
+		wantDrift       []string
+		wantUpdatePaths []string
+	}{
+		{
+			name:         "noDrift",
+			wantedJob:    makeJob("0 * * * *", "Etc/UTC", "cron schedule 0 * * * *", 0, 0),
+			retreivedJob: makeJob("0 * * * *", "Etc/UTC", "cron schedule 0 * * * *", 0, 0),
+		},
+		{
+			name:            "scheduleAndTimeZone",
+			wantedJob:       makeJob("0 2 * * *", "Europe/Paris", "cron schedule 0 2 * * *", 0, 0),
+			retreivedJob:    makeJob("0 * * * *", "Etc/UTC", "cron schedule 0 * * * *", 0, 0),
+			wantDrift:       []string{"schedule", "timeZone", "pubsubTarget"},
+			wantUpdatePaths: []string{"schedule", "time_zone", "pubsub_target"},
+		},
+		{
+			name:            "retryConfig",
+			wantedJob:       makeJob("0 * * * *", "Etc/UTC", "cron schedule 0 * * * *", 3, time.Hour),
+			retreivedJob:    makeJob("0 * * * *", "Etc/UTC", "cron schedule 0 * * * *", 0, 0),
+			wantDrift:       []string{"retryConfig"},
+			wantUpdatePaths: []string{"retry_config.retry_count", "retry_config.max_retry_duration"},
+		},
+	}
+
+	for _, tc := range testCases {
+		tc := tc // https://github.com/golang/go/wiki/CommonMistakes#using-goroutines-on-loop-iterator-variables
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+			drift, updatePaths, s := getJobDrift(tc.wantedJob, tc.retreivedJob)
+			if !reflect.DeepEqual(drift, tc.wantDrift) {
+				t.Errorf("Want drift '%v' got '%v'", tc.wantDrift, drift)
+			}
+			if !reflect.DeepEqual(updatePaths, tc.wantUpdatePaths) {
+				t.Errorf("Want updatePaths '%v' got '%v'", tc.wantUpdatePaths, updatePaths)
+			}
+			if (len(tc.wantDrift) == 0) != (s == "") {
+				t.Errorf("Want description only when drifting got '%s'", s)
+			}
+		})
+	}
+}
@@ -18,52 +18,111 @@ import (
 	"fmt"
 	"log"
 	"strings"
+	"time"
 
+	"github.com/BrunoReboul/ram/utilities/deploy"
 	schedulerpb "google.golang.org/genproto/googleapis/cloud/scheduler/v1"
+	"google.golang.org/protobuf/types/known/durationpb"
+	"google.golang.org/protobuf/types/known/fieldmaskpb"
 )
 
-// Deploy scheduler job
+// Deploy get-create-update scheduler job
 func (jobDeployment *JobDeployment) Deploy() (err error) {
 	log.Printf("%s cloud scheduler job", jobDeployment.Core.InstanceName)
 	name := fmt.Sprintf("projects/%s/locations/%s/jobs/%s",
 		jobDeployment.Core.SolutionSettings.Hosting.ProjectID,
 		jobDeployment.Core.SolutionSettings.Hosting.GCF.Region,
 		jobDeployment.Artifacts.JobName)
+	resourceReport := jobDeployment.Core.StartResourceReport("cloud scheduler job", name)
+	defer resourceReport.End(&err)
+	wantedJob, err := jobDeployment.getWantedJob(name)
+	if err != nil {
+		return err
+	}
+	jobFound := true
 	var getJobRequest schedulerpb.GetJobRequest
 	getJobRequest.Name = name
 	retreivedJob, err := jobDeployment.Core.Services.CloudSchedulerClient.GetJob(jobDeployment.Core.Ctx, &getJobRequest)
 	if err != nil {
 		if strings.Contains(strings.ToLower(err.Error()), "notfound") {
-			var pubsubTarget schedulerpb.PubsubTarget
-			pubsubTarget.TopicName = fmt.Sprintf("projects/%s/topics/%s",
-				jobDeployment.Core.SolutionSettings.Hosting.ProjectID,
-				jobDeployment.Artifacts.TopicName)
-			pubsubTarget.Data = []byte(fmt.Sprintf("cron schedule %s", jobDeployment.Artifacts.Schedule))
-
-			var jobPubsubTarget schedulerpb.Job_PubsubTarget
-			jobPubsubTarget.PubsubTarget = &pubsubTarget
-
-			var job schedulerpb.Job
-			job.Name = name
-			job.Description = "Real-time Asset Monitor"
-			job.Target = &jobPubsubTarget
-			job.Schedule = jobDeployment.Artifacts.Schedule
-
-			var createJobRequest schedulerpb.CreateJobRequest
-			createJobRequest.Parent = fmt.Sprintf("projects/%s/locations/%s",
-				jobDeployment.Core.SolutionSettings.Hosting.ProjectID,
-				jobDeployment.Core.SolutionSettings.Hosting.GCF.Region)
-			createJobRequest.Job = &job
+			jobFound = false
+		} else {
+			return fmt.Errorf("CloudSchedulerClient.GetJob %v", err)
+		}
+	}
+	if jobDeployment.Core.Commands.Check {
+		if !jobFound {
+			return fmt.Errorf("%s sch cloud scheduler job NOT found for this instance", jobDeployment.Core.InstanceName)
+		}
+		drift, _, s := getJobDrift(wantedJob, retreivedJob)
+		if len(drift) > 0 {
+			resourceReport.Drift = drift
+			return fmt.Errorf("%s sch invalid cloud scheduler job configuration:\n%s", jobDeployment.Core.InstanceName, s)
+		}
+		return nil
+	}
+	if !jobFound {
+		var createJobRequest schedulerpb.CreateJobRequest
+		createJobRequest.Parent = fmt.Sprintf("projects/%s/locations/%s",
+			jobDeployment.Core.SolutionSettings.Hosting.ProjectID,
+			jobDeployment.Core.SolutionSettings.Hosting.GCF.Region)
+		createJobRequest.Job = wantedJob
 
-			retreivedJob, err := jobDeployment.Core.Services.CloudSchedulerClient.CreateJob(jobDeployment.Core.Ctx, &createJobRequest)
-			if err != nil {
-				return fmt.Errorf("CloudSchedulerClient.CreateJob %v", err)
-			}
-			log.Printf("%s cloud scheduler job created %s", jobDeployment.Core.InstanceName, retreivedJob.Name)
-			return nil
+		retreivedJob, err := jobDeployment.Core.Services.CloudSchedulerClient.CreateJob(jobDeployment.Core.Ctx, &createJobRequest)
+		if err != nil {
+			return fmt.Errorf("CloudSchedulerClient.CreateJob %v", err)
 		}
-		return fmt.Errorf("CloudSchedulerClient.GetJob %v", err)
+		resourceReport.Action = deploy.ActionCreated
+		log.Printf("%s cloud scheduler job created %s", jobDeployment.Core.InstanceName, retreivedJob.Name)
+		return nil
 	}
 	log.Printf("%s cloud scheduler job found %s", jobDeployment.Core.InstanceName, retreivedJob.Name)
+	drift, updatePaths, s := getJobDrift(wantedJob, retreivedJob)
+	if len(drift) == 0 {
+		log.Printf("%s cloud scheduler job already uptodate %s", jobDeployment.Core.InstanceName, retreivedJob.Name)
+		return nil
+	}
+	log.Printf("%s cloud scheduler job to be updated %s\n%s", jobDeployment.Core.InstanceName, retreivedJob.Name, s)
+	var updateJobRequest schedulerpb.UpdateJobRequest
+	updateJobRequest.Job = wantedJob
+	updateJobRequest.UpdateMask = &fieldmaskpb.FieldMask{Paths: updatePaths}
+	retreivedJob, err = jobDeployment.Core.Services.CloudSchedulerClient.UpdateJob(jobDeployment.Core.Ctx, &updateJobRequest)
+	if err != nil {
+		return fmt.Errorf("CloudSchedulerClient.UpdateJob %v", err)
+	}
+	resourceReport.Action = deploy.ActionUpdated
+	resourceReport.Drift = drift
+	log.Printf("%s cloud scheduler job updated %s", jobDeployment.Core.InstanceName, retreivedJob.Name)
 	return nil
 }
+
+func (jobDeployment *JobDeployment) getWantedJob(name string) (job *schedulerpb.Job, err error) {
+	maxRetryDuration, err := time.ParseDuration(jobDeployment.Settings.MaxRetryDuration)
+	if err != nil {
+		return nil, fmt.Errorf("sch invalid maxRetryDuration %s %v", jobDeployment.Settings.MaxRetryDuration, err)
+	}
+	var pubsubTarget schedulerpb.PubsubTarget
+	pubsubTarget.TopicName = fmt.Sprintf("projects/%s/topics/%s",
+		jobDeployment.Core.SolutionSettings.Hosting.ProjectID,
+		jobDeployment.Artifacts.TopicName)
+	pubsubTarget.Data = []byte(fmt.Sprintf("cron schedule %s", jobDeployment.Artifacts.Schedule))
+	if jobDeployment.Settings.Data != "" {
+		pubsubTarget.Data = []byte(jobDeployment.Settings.Data)
+	}
+
+	var jobPubsubTarget schedulerpb.Job_PubsubTarget
+	jobPubsubTarget.PubsubTarget = &pubsubTarget
+
+	var retryConfig schedulerpb.RetryConfig
+	retryConfig.RetryCount = jobDeployment.Settings.RetryCount
+	retryConfig.MaxRetryDuration = durationpb.New(maxRetryDuration)
+
+	job = &schedulerpb.Job{}
+	job.Name = name
+	job.Description = "Real-time Asset Monitor"
+	job.Target = &jobPubsubTarget
+	job.Schedule = jobDeployment.Artifacts.Schedule
+	job.TimeZone = jobDeployment.Settings.TimeZone
+	job.RetryConfig = &retryConfig
+	return job, nil
+}
@@ -26,9 +26,19 @@ type JobDeployment struct {
 		TopicName string `yaml:"topicName"`
 		Schedule  string
 	}
+	Settings struct {
+		TimeZone         string `yaml:"timeZone"`
+		RetryCount       int32  `yaml:"retryCount"`
+		MaxRetryDuration string `yaml:"maxRetryDuration"`
+		// Data of the published messages, the cron schedule when empty
+		Data string `yaml:"data"`
+	}
 }
 
 // NewJobDeployment create deployment structure
 func NewJobDeployment() *JobDeployment {
-	return &JobDeployment{}
+	var jobDeployment JobDeployment
+	jobDeployment.Settings.TimeZone = "Etc/UTC"
+	jobDeployment.Settings.MaxRetryDuration = "0s"
+	return &jobDeployment
 }
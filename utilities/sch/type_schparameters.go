@@ -17,7 +17,10 @@ package sch
 // Parameters structure
 type Parameters struct {
 	Schedulers map[string]struct {
-		JobName  string `yaml:"jobName"`
-		Schedule string
+		JobName          string `yaml:"jobName"`
+		Schedule         string `valid:"isCron"`
+		TimeZone         string `yaml:"timeZone,omitempty"`
+		RetryCount       int32  `yaml:"retryCount,omitempty" valid:"inRange(0|5)"`
+		MaxRetryDuration string `yaml:"maxRetryDuration,omitempty" valid:"isGoDuration"`
 	}
 }
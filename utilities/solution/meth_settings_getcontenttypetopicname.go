@@ -0,0 +1,34 @@
+// Copyright 2020 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the 'License');
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an 'AS IS' BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package solution
+
+// GetContentTypeTopicName returns the topic receiving all assets of a Cloud Asset Inventory content type
+// RESOURCE content type has one topic per asset type, so no topic name is returned for it
+func (settings *Settings) GetContentTypeTopicName(contentType string) string {
+	switch contentType {
+	case "IAM_POLICY":
+		return settings.Hosting.Pubsub.TopicNames.IAMPolicies
+	case "ORG_POLICY":
+		return settings.Hosting.Pubsub.TopicNames.OrgPolicies
+	case "ACCESS_POLICY":
+		return settings.Hosting.Pubsub.TopicNames.AccessPolicies
+	case "OS_INVENTORY":
+		return settings.Hosting.Pubsub.TopicNames.OSInventories
+	case "RELATIONSHIP":
+		return settings.Hosting.Pubsub.TopicNames.Relationships
+	default:
+		return ""
+	}
+}
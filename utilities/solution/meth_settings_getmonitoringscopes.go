@@ -0,0 +1,41 @@
+// Copyright 2020 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the 'License');
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an 'AS IS' BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package solution
+
+// GetMonitoringScopes returns the organizations, folders and projects to be monitored
+// Folders organization IDs are known once resolved by ramcli, as folder level custom roles do not exist
+func (settings *Settings) GetMonitoringScopes() (scopes []MonitoringScope) {
+	for _, organizationID := range settings.Monitoring.OrganizationIDs {
+		scopes = append(scopes, MonitoringScope{
+			Kind:           MonitoringScopeOrganization,
+			ID:             organizationID,
+			OrganizationID: organizationID,
+		})
+	}
+	for _, folderID := range settings.Monitoring.FolderIDs {
+		scopes = append(scopes, MonitoringScope{
+			Kind:           MonitoringScopeFolder,
+			ID:             folderID,
+			OrganizationID: settings.Monitoring.FolderOrganizationIDs[folderID],
+		})
+	}
+	for _, projectID := range settings.Monitoring.ProjectIDs {
+		scopes = append(scopes, MonitoringScope{
+			Kind: MonitoringScopeProject,
+			ID:   projectID,
+		})
+	}
+	return scopes
+}
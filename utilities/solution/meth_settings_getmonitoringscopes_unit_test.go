@@ -0,0 +1,77 @@
+// Copyright 2020 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the 'License');
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an 'AS IS' BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package solution
+
+import (
+	"testing"
+)
+
+func TestUnitGetMonitoringScopes(t *testing.T) {
+	var settings Settings
+	settings.Monitoring.OrganizationIDs = []string{"111111111111"}
+	settings.Monitoring.FolderIDs = []string{"333333333333"}
+	settings.Monitoring.ProjectIDs = []string{"blabla-dev"}
+	settings.Monitoring.FolderOrganizationIDs = map[string]string{"333333333333": "222222222222"}
+
+	var testCases = []struct {
+		kind               string
+		wantParent         string
+		wantShortName      string
+		wantOrganizationID string
+	}{
+		{
+			kind:               MonitoringScopeOrganization,
+			wantParent:         "organizations/111111111111",
+			wantShortName:      "org111111111111",
+			wantOrganizationID: "111111111111",
+		},
+		{
+			kind:               MonitoringScopeFolder,
+			wantParent:         "folders/333333333333",
+			wantShortName:      "folder333333333333",
+			wantOrganizationID: "222222222222",
+		},
+		{
+			kind:               MonitoringScopeProject,
+			wantParent:         "projects/blabla-dev",
+			wantShortName:      "projectblabla-dev",
+			wantOrganizationID: "",
+		},
+	}
+
+	scopes := settings.GetMonitoringScopes()
+	if len(scopes) != len(testCases) {
+		t.Fatalf("Want %d scopes got %d", len(testCases), len(scopes))
+	}
+	for i, tc := range testCases {
+		tc := tc // https://github.com/golang/go/wiki/CommonMistakes#using-goroutines-on-loop-iterator-variables
+		scope := scopes[i]
+		t.Run(tc.kind, func(t *testing.T) {
+			t.Parallel()
+			if scope.Kind != tc.kind {
+				t.Errorf("Want kind '%s' got '%s'", tc.kind, scope.Kind)
+			}
+			if scope.Parent() != tc.wantParent {
+				t.Errorf("Want parent '%s' got '%s'", tc.wantParent, scope.Parent())
+			}
+			if scope.ShortName() != tc.wantShortName {
+				t.Errorf("Want short name '%s' got '%s'", tc.wantShortName, scope.ShortName())
+			}
+			if scope.OrganizationID != tc.wantOrganizationID {
+				t.Errorf("Want organizationID '%s' got '%s'", tc.wantOrganizationID, scope.OrganizationID)
+			}
+		})
+	}
+}
@@ -17,6 +17,7 @@ package solution
 // Situate set settings from settings based on a given situation
 // Situation is the environment name (string)
 // Set settings are: folderID, projectID, Stackdriver projectID, Buckets names
+// Defaults are set for: GCB queue TTL, buckets delete age, BigQuery views interval, FireStore asset hashes and audit actors collections, content types topics
 func (settings *Settings) Situate(environmentName string) {
 	settings.Hosting.OrganizationID = settings.Hosting.OrganizationIDs[environmentName]
 	settings.Hosting.FolderID = settings.Hosting.FolderIDs[environmentName]
@@ -33,4 +34,25 @@ func (settings *Settings) Situate(environmentName string) {
 	if settings.Hosting.GCS.Buckets.AssetsJSONFile.DeleteAgeInDays == 0 {
 		settings.Hosting.GCS.Buckets.AssetsJSONFile.DeleteAgeInDays = 365
 	}
+	if settings.Hosting.Bigquery.Views.IntervalDays == 0 {
+		settings.Hosting.Bigquery.Views.IntervalDays = 365
+	}
+	if settings.Hosting.FireStore.CollectionIDs.AssetHashes == "" {
+		settings.Hosting.FireStore.CollectionIDs.AssetHashes = "assetHashes"
+	}
+	if settings.Hosting.FireStore.CollectionIDs.AuditActors == "" {
+		settings.Hosting.FireStore.CollectionIDs.AuditActors = "auditActors"
+	}
+	if settings.Hosting.Pubsub.TopicNames.OrgPolicies == "" {
+		settings.Hosting.Pubsub.TopicNames.OrgPolicies = "cai-org-policies"
+	}
+	if settings.Hosting.Pubsub.TopicNames.AccessPolicies == "" {
+		settings.Hosting.Pubsub.TopicNames.AccessPolicies = "cai-access-policies"
+	}
+	if settings.Hosting.Pubsub.TopicNames.OSInventories == "" {
+		settings.Hosting.Pubsub.TopicNames.OSInventories = "cai-os-inventories"
+	}
+	if settings.Hosting.Pubsub.TopicNames.Relationships == "" {
+		settings.Hosting.Pubsub.TopicNames.Relationships = "cai-relationships"
+	}
 }
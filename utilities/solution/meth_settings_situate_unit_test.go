@@ -69,6 +69,13 @@ func TestUnitSituate(t *testing.T) {
     assetsJSONBuccketName: blabla-assets-json-dev
     assetsJSONBuccketDeleteAgeInDays: 365
     GCBQueueTTL: 7200s
+    BQViewsIntervalDays: 365
+    assetHashesCollectionID: assetHashes
+    auditActorsCollectionID: auditActors
+    orgPoliciesTopicName: cai-org-policies
+    accessPoliciesTopicName: cai-access-policies
+    osInventoriesTopicName: cai-os-inventories
+    relationshipsTopicName: cai-relationships
 - name: set2
   settings:
     hosting:
@@ -80,11 +87,31 @@ func TestUnitSituate(t *testing.T) {
             deleteAgeInDays: 9
       gcb:
         queueTtl: 123s
+      bigquery:
+        views:
+          intervalDays: 30
+      firestore:
+        collectionIDs:
+          assetHashes: blablahashes
+          auditActors: blablaactors
+      pubsub:
+        topicNames:
+          orgPolicies: blabla-org-policies
+          accessPolicies: blabla-access-policies
+          osInventories: blabla-os-inventories
+          relationships: blabla-relationships
   environment: dev
   want:
     CAIExportBuccketDeleteAgeInDays: 99
     assetsJSONBuccketDeleteAgeInDays: 9
-    GCBQueueTTL: 123s`)
+    GCBQueueTTL: 123s
+    BQViewsIntervalDays: 30
+    assetHashesCollectionID: blablahashes
+    auditActorsCollectionID: blablaactors
+    orgPoliciesTopicName: blabla-org-policies
+    accessPoliciesTopicName: blabla-access-policies
+    osInventoriesTopicName: blabla-os-inventories
+    relationshipsTopicName: blabla-relationships`)
 
 	err := yaml.Unmarshal(yamlBytes, &testCases)
 	if err != nil {
@@ -146,6 +173,38 @@ func TestUnitSituate(t *testing.T) {
 					if wantedValue != tc.Settings.Hosting.GCB.QueueTTL {
 						t.Errorf("Want %s '%s' got '%s'", key, wantedValue, tc.Settings.Hosting.GCB.QueueTTL)
 					}
+				case "BQViewsIntervalDays":
+					wantedValueInt64, err := strconv.ParseInt(wantedValue, 10, 64)
+					if err != nil {
+						t.Errorf("Wanted value cannot be convected to int64 '%s'", wantedValue)
+					}
+					if wantedValueInt64 != tc.Settings.Hosting.Bigquery.Views.IntervalDays {
+						t.Errorf("Want %s '%d' got '%d'", key, wantedValueInt64, tc.Settings.Hosting.Bigquery.Views.IntervalDays)
+					}
+				case "assetHashesCollectionID":
+					if wantedValue != tc.Settings.Hosting.FireStore.CollectionIDs.AssetHashes {
+						t.Errorf("Want %s '%s' got '%s'", key, wantedValue, tc.Settings.Hosting.FireStore.CollectionIDs.AssetHashes)
+					}
+				case "auditActorsCollectionID":
+					if wantedValue != tc.Settings.Hosting.FireStore.CollectionIDs.AuditActors {
+						t.Errorf("Want %s '%s' got '%s'", key, wantedValue, tc.Settings.Hosting.FireStore.CollectionIDs.AuditActors)
+					}
+				case "orgPoliciesTopicName":
+					if wantedValue != tc.Settings.Hosting.Pubsub.TopicNames.OrgPolicies {
+						t.Errorf("Want %s '%s' got '%s'", key, wantedValue, tc.Settings.Hosting.Pubsub.TopicNames.OrgPolicies)
+					}
+				case "accessPoliciesTopicName":
+					if wantedValue != tc.Settings.Hosting.Pubsub.TopicNames.AccessPolicies {
+						t.Errorf("Want %s '%s' got '%s'", key, wantedValue, tc.Settings.Hosting.Pubsub.TopicNames.AccessPolicies)
+					}
+				case "osInventoriesTopicName":
+					if wantedValue != tc.Settings.Hosting.Pubsub.TopicNames.OSInventories {
+						t.Errorf("Want %s '%s' got '%s'", key, wantedValue, tc.Settings.Hosting.Pubsub.TopicNames.OSInventories)
+					}
+				case "relationshipsTopicName":
+					if wantedValue != tc.Settings.Hosting.Pubsub.TopicNames.Relationships {
+						t.Errorf("Want %s '%s' got '%s'", key, wantedValue, tc.Settings.Hosting.Pubsub.TopicNames.Relationships)
+					}
 				default:
 					t.Errorf("Unmanaged key '%s'", key)
 				}
@@ -0,0 +1,55 @@
+// Copyright 2020 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the 'License');
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an 'AS IS' BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package solution
+
+import "fmt"
+
+// MonitoringScope a resource hierarchy node monitored by RAM: an organization, a folder or a project
+type MonitoringScope struct {
+	Kind           string
+	ID             string
+	OrganizationID string
+}
+
+// Monitoring scope kinds
+const (
+	MonitoringScopeOrganization = "organization"
+	MonitoringScopeFolder       = "folder"
+	MonitoringScopeProject      = "project"
+)
+
+// Parent returns the CAI parent of the scope, e.g. folders/123
+func (scope MonitoringScope) Parent() string {
+	switch scope.Kind {
+	case MonitoringScopeFolder:
+		return fmt.Sprintf("folders/%s", scope.ID)
+	case MonitoringScopeProject:
+		return fmt.Sprintf("projects/%s", scope.ID)
+	default:
+		return fmt.Sprintf("organizations/%s", scope.ID)
+	}
+}
+
+// ShortName returns the scope segment used in instance names, e.g. folder123
+func (scope MonitoringScope) ShortName() string {
+	switch scope.Kind {
+	case MonitoringScopeFolder:
+		return fmt.Sprintf("folder%s", scope.ID)
+	case MonitoringScopeProject:
+		return fmt.Sprintf("project%s", scope.ID)
+	default:
+		return fmt.Sprintf("org%s", scope.ID)
+	}
+}
@@ -36,22 +36,29 @@ type Settings struct {
 			Region string `valid:"isNotZeroValue"`
 		}
 		GCB struct {
-			QueueTTL string `yaml:"queueTtl"`
+			QueueTTL string `yaml:"queueTtl" valid:"isDuration"`
 		}
 		GCF struct {
-			Region string `valid:"isNotZeroValue"`
+			Region string `valid:"isNotZeroValue,isGCPRegion"`
 		}
 		GCS struct {
 			Buckets struct {
 				CAIExport struct {
-					Name            string `yaml:",omitempty"`
-					Names           map[string]string
-					DeleteAgeInDays int64 `yaml:"deleteAgeInDays,omitempty"`
+					Name                  string `yaml:",omitempty"`
+					Names                 map[string]string
+					DeleteAgeInDays       int64  `yaml:"deleteAgeInDays,omitempty"`
+					StorageClass          string `yaml:"storageClass,omitempty" valid:"isOneOf(STANDARD|NEARLINE|COLDLINE|ARCHIVE|MULTI_REGIONAL|REGIONAL|DURABLE_REDUCED_AVAILABILITY)"`
+					VersioningEnabled     bool   `yaml:"versioningEnabled,omitempty"`
+					RetentionPeriodInDays int64  `yaml:"retentionPeriodInDays,omitempty"`
 				} `yaml:"CAIExport"`
 				AssetsJSONFile struct {
-					Name            string `yaml:",omitempty"`
-					Names           map[string]string
-					DeleteAgeInDays int64 `yaml:"deleteAgeInDays,omitempty"`
+					Name                  string `yaml:",omitempty"`
+					Names                 map[string]string
+					DeleteAgeInDays       int64  `yaml:"deleteAgeInDays,omitempty"`
+					KeepHistory           bool   `yaml:"keepHistory,omitempty"`
+					StorageClass          string `yaml:"storageClass,omitempty" valid:"isOneOf(STANDARD|NEARLINE|COLDLINE|ARCHIVE|MULTI_REGIONAL|REGIONAL|DURABLE_REDUCED_AVAILABILITY)"`
+					VersioningEnabled     bool   `yaml:"versioningEnabled,omitempty"`
+					RetentionPeriodInDays int64  `yaml:"retentionPeriodInDays,omitempty"`
 				} `yaml:"assetsJSONFile"`
 			}
 		}
@@ -66,44 +73,96 @@ type Settings struct {
 		}
 		Pubsub struct {
 			TopicNames struct {
-				IAMPolicies         string `yaml:"IAMPolicies" valid:"isNotZeroValue"`
-				RAMViolation        string `yaml:"RAMViolation" valid:"isNotZeroValue"`
-				RAMComplianceStatus string `yaml:"RAMComplianceStatus" valid:"isNotZeroValue"`
-				GCIGroupMembers     string `yaml:"GCIGroupMembers"`
-				GCIGroupSettings    string `yaml:"GCIGroupSettings"`
+				IAMPolicies         string `yaml:"IAMPolicies" valid:"isNotZeroValue,matches(^[a-zA-Z][a-zA-Z0-9._~+%-]{2,254}$)"`
+				RAMViolation        string `yaml:"RAMViolation" valid:"isNotZeroValue,matches(^[a-zA-Z][a-zA-Z0-9._~+%-]{2,254}$)"`
+				RAMComplianceStatus string `yaml:"RAMComplianceStatus" valid:"isNotZeroValue,matches(^[a-zA-Z][a-zA-Z0-9._~+%-]{2,254}$)"`
+				GCIGroupMembers     string `yaml:"GCIGroupMembers" valid:"matches(^[a-zA-Z][a-zA-Z0-9._~+%-]{2,254}$)"`
+				GCIGroupSettings    string `yaml:"GCIGroupSettings" valid:"matches(^[a-zA-Z][a-zA-Z0-9._~+%-]{2,254}$)"`
+				AssetChanges        string `yaml:"assetChanges,omitempty" valid:"matches(^[a-zA-Z][a-zA-Z0-9._~+%-]{2,254}$)"`
+				OrgPolicies         string `yaml:"orgPolicies,omitempty" valid:"matches(^[a-zA-Z][a-zA-Z0-9._~+%-]{2,254}$)"`
+				AccessPolicies      string `yaml:"accessPolicies,omitempty" valid:"matches(^[a-zA-Z][a-zA-Z0-9._~+%-]{2,254}$)"`
+				OSInventories       string `yaml:"osInventories,omitempty" valid:"matches(^[a-zA-Z][a-zA-Z0-9._~+%-]{2,254}$)"`
+				Relationships       string `yaml:"relationships,omitempty" valid:"matches(^[a-zA-Z][a-zA-Z0-9._~+%-]{2,254}$)"`
 			} `yaml:"topicNames"`
 		}
 		FireStore struct {
 			CollectionIDs struct {
-				Assets string `valid:"isNotZeroValue"`
+				Assets      string `valid:"isNotZeroValue"`
+				AssetHashes string `yaml:"assetHashes,omitempty"`
+				AuditActors string `yaml:"auditActors,omitempty"`
 			} `yaml:"collectionIDs"`
 		}
 		FreshnessSLODefinitions []struct {
 			Origin             string
-			SLO                float64
-			CutOffBucketNumber int64 `yaml:"cutOffBucketNumber"`
+			SLO                float64 `valid:"inOpenRange(0|1)"`
+			CutOffBucketNumber int64   `yaml:"cutOffBucketNumber"`
 		} `yaml:"freshnessSLODefinitions"`
+		NotificationChannels []struct {
+			DisplayName string            `yaml:"displayName"`
+			Type        string            `yaml:"type"`
+			Description string            `yaml:"description,omitempty"`
+			Labels      map[string]string `yaml:"labels,omitempty"`
+		} `yaml:"notificationChannels,omitempty"`
+		Tracing struct {
+			Exporter     string `yaml:"exporter,omitempty" valid:"isOneOf(none|otlp|file)"`
+			OTLPEndpoint string `yaml:"otlpEndpoint,omitempty"`
+			FilePath     string `yaml:"filePath,omitempty"`
+		} `yaml:"tracing,omitempty"`
 	}
 	Monitoring struct {
-		OrganizationIDs []string `yaml:"organizationIDs"`
-		LabelKeyNames   struct {
+		OrganizationIDs       []string          `yaml:"organizationIDs"`
+		FolderIDs             []string          `yaml:"folderIDs,omitempty"`
+		ProjectIDs            []string          `yaml:"projectIDs,omitempty"`
+		FolderOrganizationIDs map[string]string `yaml:"-"`
+		LabelKeyNames         struct {
 			Owner             string `valid:"isNotZeroValue"`
 			ViolationResolver string `yaml:"violationResolver" valid:"isNotZeroValue"`
 		} `yaml:"labelKeyNames"`
 		DefaultSchedulers map[string]struct {
-			JobName  string `yaml:"jobName"`
-			Schedule string
+			JobName          string `yaml:"jobName"`
+			Schedule         string `valid:"isCron"`
+			TimeZone         string `yaml:"timeZone,omitempty"`
+			RetryCount       int32  `yaml:"retryCount,omitempty" valid:"inRange(0|5)"`
+			MaxRetryDuration string `yaml:"maxRetryDuration,omitempty" valid:"isGoDuration"`
 		} `yaml:"defaultSchedulers"`
 		DirectoryCustomerIDs map[string]struct {
-			SuperAdminEmail string `yaml:"superAdminEmail"`
+			SuperAdminEmail string `yaml:"superAdminEmail" valid:"isEmail"`
 		} `yaml:"directoryCustomerIDs"`
 		ListGroupsDefaultSchedulers map[string]struct {
-			JobName  string `yaml:"jobName"`
-			Schedule string
+			JobName          string `yaml:"jobName"`
+			Schedule         string `valid:"isCron"`
+			TimeZone         string `yaml:"timeZone,omitempty"`
+			RetryCount       int32  `yaml:"retryCount,omitempty" valid:"inRange(0|5)"`
+			MaxRetryDuration string `yaml:"maxRetryDuration,omitempty" valid:"isGoDuration"`
 		} `yaml:"listGroupsDefaultSchedulers"`
+		ListUsersDefaultSchedulers map[string]struct {
+			JobName          string `yaml:"jobName"`
+			Schedule         string `valid:"isCron"`
+			TimeZone         string `yaml:"timeZone,omitempty"`
+			RetryCount       int32  `yaml:"retryCount,omitempty" valid:"inRange(0|5)"`
+			MaxRetryDuration string `yaml:"maxRetryDuration,omitempty" valid:"isGoDuration"`
+		} `yaml:"listUsersDefaultSchedulers"`
+		ListDirectorySettingsDefaultSchedulers map[string]struct {
+			JobName          string `yaml:"jobName"`
+			Schedule         string `valid:"isCron"`
+			TimeZone         string `yaml:"timeZone,omitempty"`
+			RetryCount       int32  `yaml:"retryCount,omitempty" valid:"inRange(0|5)"`
+			MaxRetryDuration string `yaml:"maxRetryDuration,omitempty" valid:"isGoDuration"`
+		} `yaml:"listDirectorySettingsDefaultSchedulers"`
+		ConsolidateGCSDefaultSchedulers map[string]struct {
+			JobName          string `yaml:"jobName"`
+			Schedule         string `valid:"isCron"`
+			TimeZone         string `yaml:"timeZone,omitempty"`
+			RetryCount       int32  `yaml:"retryCount,omitempty" valid:"inRange(0|5)"`
+			MaxRetryDuration string `yaml:"maxRetryDuration,omitempty" valid:"isGoDuration"`
+		} `yaml:"consolidateGCSDefaultSchedulers"`
 		AssetTypes struct {
-			IAMPolicies []string `yaml:"iamPolicies"`
-			Resources   []string `yaml:"resources"`
+			IAMPolicies    []string `yaml:"iamPolicies"`
+			Resources      []string `yaml:"resources"`
+			OrgPolicies    []string `yaml:"orgPolicies,omitempty"`
+			AccessPolicies []string `yaml:"accessPolicies,omitempty"`
+			OSInventories  []string `yaml:"osInventories,omitempty"`
+			Relationships  []string `yaml:"relationships,omitempty"`
 		} `yaml:"assetTypes"`
 	}
 }
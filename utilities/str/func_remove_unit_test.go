@@ -0,0 +1,59 @@
+// Copyright 2020 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the 'License');
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an 'AS IS' BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package str
+
+import (
+	"fmt"
+	"testing"
+)
+
+func TestUnitRemove(t *testing.T) {
+	var testCases = []struct {
+		name  string
+		slice []string
+		val   string
+		want  string
+	}{
+		{
+			name:  "RemoveStringFromSlice",
+			slice: []string{"Alala", "Btata", "Csa"},
+			val:   "Btata",
+			want:  "[Alala Csa]",
+		},
+		{
+			name:  "RemoveAllOccurences",
+			slice: []string{"Alala", "Btata", "Alala"},
+			val:   "Alala",
+			want:  "[Btata]",
+		},
+		{
+			name:  "NothingToRemove",
+			slice: []string{"Alala", "Btata", "Csa"},
+			val:   "QuiCa",
+			want:  "[Alala Btata Csa]",
+		},
+	}
+
+	for _, tc := range testCases {
+		tc := tc // https://github.com/golang/go/wiki/CommonMistakes#using-goroutines-on-loop-iterator-variables
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+			got := fmt.Sprintf("%v", Remove(tc.slice, tc.val))
+			if got != tc.want {
+				t.Errorf("Want '%s' got '%s'", tc.want, got)
+			}
+		})
+	}
+}
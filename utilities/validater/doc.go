@@ -13,4 +13,12 @@
 // limitations under the License.
 
 // Package validater helps to validate struct fields
+//
+// Rules are set in the valid struct tag, comma separated, e.g. valid:"isNotZeroValue,isDuration":
+// isNotZeroValue, isAvailableMemory, matches(regex), isOneOf(a|b|c), isDuration, isGoDuration, isCron, isEmail, isGCPRegion, inRange(min|max), inOpenRange(min|max).
+// isDuration is a Google API duration in seconds, e.g. 600s, isGoDuration a Go duration, e.g. 10m, both positive.
+// inRange bounds are inclusive, inOpenRange bounds are exclusive.
+// Except isNotZeroValue, rules skip empty strings, and check each string of a slice or of a map values.
+// Structs are explored recursively, including within slices and maps, valid:"-" discards a field.
+// Invalid fields are returned as ValidationErrors located by their YAML path.
 package validater
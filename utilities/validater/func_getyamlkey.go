@@ -0,0 +1,29 @@
+// Copyright 2020 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the 'License');
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an 'AS IS' BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package validater
+
+import (
+	"reflect"
+	"strings"
+)
+
+// getYAMLKey returns the YAML key of a struct field, the yaml tag name when set, the lowercased field name otherwise as yaml.v2 does
+func getYAMLKey(structField reflect.StructField) string {
+	name := strings.Split(structField.Tag.Get("yaml"), ",")[0]
+	if name == "" || name == "-" {
+		return strings.ToLower(structField.Name)
+	}
+	return name
+}
@@ -0,0 +1,43 @@
+// Copyright 2020 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the 'License');
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an 'AS IS' BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package validater
+
+import "strings"
+
+// splitTag splits a validation tag value on commas, except the ones within the parentheses of a rule argument
+// e.g. "isNotZeroValue,matches(^[a-z]{2,8}$)" returns "isNotZeroValue" and "matches(^[a-z]{2,8}$)"
+func splitTag(tagValue string) (rules []string) {
+	depth := 0
+	start := 0
+	for i, r := range tagValue {
+		switch r {
+		case '(':
+			depth++
+		case ')':
+			if depth > 0 {
+				depth--
+			}
+		case ',':
+			if depth == 0 {
+				rules = append(rules, strings.TrimSpace(tagValue[start:i]))
+				start = i + 1
+			}
+		}
+	}
+	if rule := strings.TrimSpace(tagValue[start:]); rule != "" {
+		rules = append(rules, rule)
+	}
+	return rules
+}
@@ -0,0 +1,50 @@
+// Copyright 2020 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the 'License');
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an 'AS IS' BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package validater
+
+import (
+	"fmt"
+	"reflect"
+)
+
+// validateStrings checks a string, or each string of a slice or of a map values
+// Empty strings are skipped, use isNotZeroValue to make a value mandatory
+func validateStrings(value interface{}, check func(s string) error) (bool, error) {
+	v := reflect.ValueOf(value)
+	switch v.Kind() {
+	case reflect.String:
+		if v.String() == "" {
+			return true, nil
+		}
+		if err := check(v.String()); err != nil {
+			return false, err
+		}
+	case reflect.Slice, reflect.Array:
+		for i := 0; i < v.Len(); i++ {
+			if ok, err := validateStrings(v.Index(i).Interface(), check); !ok {
+				return false, fmt.Errorf("[%d] %v", i, err)
+			}
+		}
+	case reflect.Map:
+		for _, key := range v.MapKeys() {
+			if ok, err := validateStrings(v.MapIndex(key).Interface(), check); !ok {
+				return false, fmt.Errorf("[%v] %v", key.Interface(), err)
+			}
+		}
+	default:
+		return false, fmt.Errorf("Should be a string, a slice or a map of strings, got %s", v.Kind())
+	}
+	return true, nil
+}
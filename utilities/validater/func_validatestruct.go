@@ -16,9 +16,10 @@ package validater
 
 import (
 	"fmt"
-	"log"
 	"reflect"
+	"sort"
 	"strings"
+	"time"
 )
 
 const tagKeyName = "valid"
@@ -28,35 +29,29 @@ type validater interface {
 	validate(interface{}) (bool, error)
 }
 
-// defaultValidater is always valid
-type defaultValidater struct {
-}
-
-// validate interface returns true for a valid field, false and why in the error otherwise
-func (v defaultValidater) validate(val interface{}) (bool, error) {
-	return true, nil
-}
-
 // isNotZeroValueValidater do not accept zero value
 type isNotZeroValueValidater struct {
 }
 
 // validate interface returns true for a valid field, false and why in the error otherwise
 func (v isNotZeroValueValidater) validate(value interface{}) (bool, error) {
-	typ := reflect.TypeOf(value)
-	kind := typ.Kind()
+	rv := reflect.ValueOf(value)
+	kind := rv.Kind()
 	switch kind {
 	case reflect.String:
-		l := len(value.(string))
-		if l == 0 {
+		if rv.Len() == 0 {
+			return false, fmt.Errorf("Should NOT be a zero value %s", kind)
+		}
+	case reflect.Int, reflect.Int32, reflect.Int64:
+		if rv.Int() == 0 {
 			return false, fmt.Errorf("Should NOT be a zero value %s", kind)
 		}
-	case reflect.Int64:
-		if value.(int64) == 0 {
+	case reflect.Float64:
+		if rv.Float() == 0 {
 			return false, fmt.Errorf("Should NOT be a zero value %s", kind)
 		}
-	case reflect.Slice:
-		if reflect.ValueOf(value).Len() == 0 {
+	case reflect.Slice, reflect.Map:
+		if rv.Len() == 0 {
 			return false, fmt.Errorf("Should NOT be a zero value %s", kind)
 		}
 
@@ -85,66 +80,122 @@ func (v isAvailableMemoryMbValidater) validate(value interface{}) (bool, error)
 	return false, fmt.Errorf("Should be one of %v", acceptedValueList)
 }
 
-func getValidater(kind reflect.Kind, tagValue string) validater {
-	tagValueParts := strings.Split(tagValue, ",")
-	tagPrefix := tagValueParts[0]
-	switch tagPrefix {
+// getValidater returns the validater of a tag rule, e.g. isNotZeroValue, isOneOf(RESOURCE|IAM_POLICY)
+func getValidater(rule string) (validater, error) {
+	name := rule
+	argument := ""
+	if i := strings.Index(rule, "("); i >= 0 && strings.HasSuffix(rule, ")") {
+		name = rule[:i]
+		argument = rule[i+1 : len(rule)-1]
+	}
+	switch name {
 	case "isNotZeroValue":
-		return isNotZeroValueValidater{}
+		return isNotZeroValueValidater{}, nil
 	case "isAvailableMemory":
-		return isAvailableMemoryMbValidater{}
+		return isAvailableMemoryMbValidater{}, nil
+	case "matches":
+		return newMatchesValidater(argument)
+	case "isOneOf":
+		return isOneOfValidater{acceptedValueList: strings.Split(argument, "|")}, nil
+	case "isDuration":
+		return isDurationValidater{}, nil
+	case "isGoDuration":
+		return isGoDurationValidater{}, nil
+	case "isCron":
+		return isCronValidater{}, nil
+	case "isEmail":
+		return isEmailValidater{}, nil
+	case "isGCPRegion":
+		return isGCPRegionValidater{}, nil
+	case "inRange":
+		return newInRangeValidater(argument, false)
+	case "inOpenRange":
+		return newInRangeValidater(argument, true)
 	}
-	return defaultValidater{}
+	return nil, fmt.Errorf("Unknown validation tag")
 }
 
-// getValidationErrors recursively loop through a struct to find validation errors
-func getValidationErrors(structure interface{}, pedigree string) []error {
-	errs := []error{}
-	if structure == nil {
-		return errs
+// validateField checks a field value against each rule of its validation tag
+func validateField(value reflect.Value, tagValue, pedigree, path string) (errs ValidationErrors) {
+	for _, rule := range splitTag(tagValue) {
+		validationError := ValidationError{Pedigree: pedigree, Path: path, Tag: rule}
+		validater, err := getValidater(rule)
+		if err != nil {
+			validationError.Message = err.Error()
+			errs = append(errs, validationError)
+			continue
+		}
+		ok, err := validater.validate(value.Interface())
+		if !ok {
+			validationError.Message = err.Error()
+			errs = append(errs, validationError)
+		}
 	}
-	value := reflect.ValueOf(structure)
-	if value.Kind() == reflect.Interface || value.Kind() == reflect.Ptr {
+	return errs
+}
+
+// getValidationErrors recursively loop through structs, and slices and maps of structs, to find validation errors
+func getValidationErrors(value reflect.Value, pedigree, path string) (errs ValidationErrors) {
+	for value.Kind() == reflect.Interface || value.Kind() == reflect.Ptr {
+		if value.IsNil() {
+			return errs
+		}
 		value = value.Elem()
 	}
-	if value.Kind() != reflect.Struct {
-		return []error{fmt.Errorf("type %s is not a struct", value.Kind())}
-	}
-
-	for i := 0; i < value.NumField(); i++ {
-		valueField := value.Field(i)
-		typeField := value.Type().Field(i)
-		if valueField.Kind() == reflect.Interface {
-			valueField = valueField.Elem()
+	switch value.Kind() {
+	case reflect.Struct:
+		// time.Time type is retreived as struct, but contains only filtered or unexported fields
+		if value.Type() == reflect.TypeOf(time.Time{}) {
+			return errs
 		}
-		// time.Time type is retreived as struct, but contains only filtered or unexported fields. Results: crach the validater
-		// variable of type time.Type MUST discard validater. time.Time is retreived as struct with only unexported field, leading to crash recurusivity of validater
-		if typeField.Tag.Get(tagKeyName) != "-" &&
-			(valueField.Kind() == reflect.Struct || (valueField.Kind() == reflect.Ptr && valueField.Elem().Kind() == reflect.Struct)) {
-			// log.Printf("Explore %s %s", typeField.Type.Kind(), typeField.Name)
-			childErrs := getValidationErrors(valueField.Interface(), fmt.Sprintf("%s/%s", pedigree, typeField.Name))
-			errs = append(errs, childErrs...)
-		} else {
-			// log.Printf("%s %s %s %s", pedigree, typeField.Name, typeField.Type.Kind(), typeField.Tag.Get(tagKeyName))
-			validater := getValidater(typeField.Type.Kind(), typeField.Tag.Get(tagKeyName))
-			ok, err := validater.validate(valueField.Interface())
-			if !ok {
-				errs = append(errs, fmt.Errorf("Validater error %s '%s' %v", pedigree, typeField.Name, err))
+		for i := 0; i < value.NumField(); i++ {
+			valueField := value.Field(i)
+			typeField := value.Type().Field(i)
+			tagValue := typeField.Tag.Get(tagKeyName)
+			if typeField.PkgPath != "" || tagValue == "-" {
+				continue
 			}
+			fieldPath := getYAMLKey(typeField)
+			if path != "" {
+				fieldPath = fmt.Sprintf("%s.%s", path, fieldPath)
+			}
+			if tagValue != "" {
+				errs = append(errs, validateField(valueField, tagValue, pedigree, fieldPath)...)
+			}
+			errs = append(errs, getValidationErrors(valueField, pedigree, fieldPath)...)
+		}
+	case reflect.Slice, reflect.Array:
+		for i := 0; i < value.Len(); i++ {
+			errs = append(errs, getValidationErrors(value.Index(i), pedigree, fmt.Sprintf("%s[%d]", path, i))...)
+		}
+	case reflect.Map:
+		keys := value.MapKeys()
+		sort.Slice(keys, func(i, j int) bool {
+			return fmt.Sprintf("%v", keys[i].Interface()) < fmt.Sprintf("%v", keys[j].Interface())
+		})
+		for _, key := range keys {
+			errs = append(errs, getValidationErrors(value.MapIndex(key), pedigree, fmt.Sprintf("%s[%v]", path, key.Interface()))...)
 		}
 	}
 	return errs
 }
 
 // ValidateStruct validates the fields of a struct
+// Returns ValidationErrors, the list of invalid fields with their YAML path, when at least one field is invalid
 func ValidateStruct(structure interface{}, pedigree string) (err error) {
-	errors := getValidationErrors(structure, pedigree)
-	if len(errors) > 0 {
-		for _, err := range errors {
-			log.Println(err)
-		}
-		err = fmt.Errorf("Error, settings validation failed")
-		return err
+	if structure == nil {
+		return nil
+	}
+	value := reflect.ValueOf(structure)
+	for value.Kind() == reflect.Interface || value.Kind() == reflect.Ptr {
+		value = value.Elem()
+	}
+	if value.Kind() != reflect.Struct {
+		return ValidationErrors{{Pedigree: pedigree, Message: fmt.Sprintf("type %s is not a struct", value.Kind())}}
+	}
+	validationErrors := getValidationErrors(value, pedigree, "")
+	if len(validationErrors) > 0 {
+		return validationErrors
 	}
 	return nil
 }
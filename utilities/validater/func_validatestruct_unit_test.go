@@ -15,9 +15,8 @@
 package validater
 
 import (
-	"bytes"
-	"log"
-	"os"
+	"errors"
+	"fmt"
 	"strings"
 	"testing"
 )
@@ -47,6 +46,46 @@ func TestUnitValidater(t *testing.T) {
 		IsNotZeroValueSlice isNotZeroValueSlice
 		LevelB              levelB
 	}
+	type isNotZeroValueMap struct {
+		M map[string]string `yaml:"labels" valid:"isNotZeroValue"`
+	}
+	type matchesString struct {
+		TopicName string `yaml:"topicName" valid:"isNotZeroValue,matches(^[a-zA-Z][a-zA-Z0-9._~+%-]{2,254}$)"`
+	}
+	type isOneOfSlice struct {
+		ContentTypes []string `yaml:"contentTypes" valid:"isOneOf(RESOURCE|IAM_POLICY)"`
+	}
+	type isDurationString struct {
+		Timeout string `yaml:"timeout" valid:"isDuration"`
+	}
+	type isCronString struct {
+		Schedule string `valid:"isCron"`
+	}
+	type isEmailMap struct {
+		SuperAdminEmails map[string]string `yaml:"superAdminEmails" valid:"isEmail"`
+	}
+	type isGCPRegionString struct {
+		Region string `valid:"isGCPRegion"`
+	}
+	type inOpenRangeFloat64 struct {
+		Goal float64 `yaml:"goal" valid:"inOpenRange(0|1)"`
+	}
+	type inRangeInt32 struct {
+		RetryCount int32 `yaml:"retryCount" valid:"inRange(0|5)"`
+	}
+	type unknownTag struct {
+		S string `valid:"isNotZeroValu"`
+	}
+	type scheduler struct {
+		Schedule         string `valid:"isCron"`
+		MaxRetryDuration string `yaml:"maxRetryDuration,omitempty" valid:"isGoDuration"`
+	}
+	type mapOfStructs struct {
+		Schedulers map[string]scheduler
+	}
+	type sliceOfStructs struct {
+		Definitions []inOpenRangeFloat64 `yaml:"definitions"`
+	}
 	var testCases = []struct {
 		name                 string
 		structure            interface{}
@@ -129,7 +168,7 @@ func TestUnitValidater(t *testing.T) {
 			wantValidation:    false,
 			wantErrorMsgCount: 1,
 			wantErrorMsgContains: []string{
-				"my/pe/di/gree/IsNotZeroValueInt64",
+				"my/pe/di/gree isnotzerovalueint64.i",
 			},
 		},
 		{
@@ -145,9 +184,9 @@ func TestUnitValidater(t *testing.T) {
 			wantValidation:    false,
 			wantErrorMsgCount: 3,
 			wantErrorMsgContains: []string{
-				"my/pe/di/gree/IsAvailableMemoryint64",
-				"my/pe/di/gree/LevelC/IsNotZeroValueString",
-				"my/pe/di/gree/LevelC/IsNotZeroValueInt64",
+				"my/pe/di/gree isavailablememoryint64.a",
+				"my/pe/di/gree levelc.isnotzerovaluestring.s",
+				"my/pe/di/gree levelc.isnotzerovalueint64.i",
 			},
 		},
 		{
@@ -166,36 +205,272 @@ func TestUnitValidater(t *testing.T) {
 			wantValidation:    false,
 			wantErrorMsgCount: 2,
 			wantErrorMsgContains: []string{
-				"my/pe/di/gree/IsNotZeroValueSlice",
-				"my/pe/di/gree/LevelB/LevelC/IsNotZeroValueInt64",
+				"my/pe/di/gree isnotzerovalueslice.sl",
+				"my/pe/di/gree levelb.levelc.isnotzerovalueint64.i",
+			},
+		},
+		{
+			name:              "IsNotZeroValueMapEmpty",
+			structure:         isNotZeroValueMap{map[string]string{}},
+			pedigree:          "my/pe/di/gree",
+			wantValidation:    false,
+			wantErrorMsgCount: 1,
+			wantErrorMsgContains: []string{
+				"my/pe/di/gree labels isNotZeroValue",
+			},
+		},
+		{
+			name:           "IsNotZeroValueMapProvided",
+			structure:      isNotZeroValueMap{map[string]string{"a": "b"}},
+			pedigree:       "my/pe/di/gree",
+			wantValidation: true,
+		},
+		{
+			name:           "MatchesValid",
+			structure:      matchesString{"ram-iam-policies"},
+			pedigree:       "my/pe/di/gree",
+			wantValidation: true,
+		},
+		{
+			name:              "MatchesInvalid",
+			structure:         matchesString{"ram iam policies"},
+			pedigree:          "my/pe/di/gree",
+			wantValidation:    false,
+			wantErrorMsgCount: 1,
+			wantErrorMsgContains: []string{
+				"my/pe/di/gree topicName matches",
+			},
+		},
+		{
+			name:              "MatchesEmptyAndNotZeroValue",
+			structure:         matchesString{""},
+			pedigree:          "my/pe/di/gree",
+			wantValidation:    false,
+			wantErrorMsgCount: 1,
+			wantErrorMsgContains: []string{
+				"my/pe/di/gree topicName isNotZeroValue",
+			},
+		},
+		{
+			name:           "IsOneOfValid",
+			structure:      isOneOfSlice{[]string{"RESOURCE", "IAM_POLICY"}},
+			pedigree:       "my/pe/di/gree",
+			wantValidation: true,
+		},
+		{
+			name:              "IsOneOfInvalid",
+			structure:         isOneOfSlice{[]string{"RESOURCE", "IAM_POLICIES"}},
+			pedigree:          "my/pe/di/gree",
+			wantValidation:    false,
+			wantErrorMsgCount: 1,
+			wantErrorMsgContains: []string{
+				"my/pe/di/gree contentTypes isOneOf(RESOURCE|IAM_POLICY): [1] 'IAM_POLICIES'",
+			},
+		},
+		{
+			name:           "IsDurationValid",
+			structure:      isDurationString{"600s"},
+			pedigree:       "my/pe/di/gree",
+			wantValidation: true,
+		},
+		{
+			name:              "IsDurationInvalid",
+			structure:         isDurationString{"10m"},
+			pedigree:          "my/pe/di/gree",
+			wantValidation:    false,
+			wantErrorMsgCount: 1,
+			wantErrorMsgContains: []string{
+				"my/pe/di/gree timeout isDuration",
+			},
+		},
+		{
+			name:              "IsDurationNegative",
+			structure:         isDurationString{"-600s"},
+			pedigree:          "my/pe/di/gree",
+			wantValidation:    false,
+			wantErrorMsgCount: 1,
+			wantErrorMsgContains: []string{
+				"my/pe/di/gree timeout isDuration",
+			},
+		},
+		{
+			name:           "IsGoDurationMinutes",
+			structure:      scheduler{Schedule: "0 2 * * *", MaxRetryDuration: "10m"},
+			pedigree:       "my/pe/di/gree",
+			wantValidation: true,
+		},
+		{
+			name:           "IsGoDurationHour",
+			structure:      scheduler{Schedule: "0 2 * * *", MaxRetryDuration: "1h"},
+			pedigree:       "my/pe/di/gree",
+			wantValidation: true,
+		},
+		{
+			name:              "IsGoDurationNegative",
+			structure:         scheduler{Schedule: "0 2 * * *", MaxRetryDuration: "-10m"},
+			pedigree:          "my/pe/di/gree",
+			wantValidation:    false,
+			wantErrorMsgCount: 1,
+			wantErrorMsgContains: []string{
+				"my/pe/di/gree maxRetryDuration isGoDuration",
+			},
+		},
+		{
+			name:           "IsCronValid",
+			structure:      isCronString{"0 */6 1-15,20 * MON-FRI"},
+			pedigree:       "my/pe/di/gree",
+			wantValidation: true,
+		},
+		{
+			name:              "IsCronFieldCount",
+			structure:         isCronString{"0 */6 * *"},
+			pedigree:          "my/pe/di/gree",
+			wantValidation:    false,
+			wantErrorMsgCount: 1,
+			wantErrorMsgContains: []string{
+				"my/pe/di/gree schedule isCron",
+			},
+		},
+		{
+			name:              "IsCronOutOfRange",
+			structure:         isCronString{"0 24 * * *"},
+			pedigree:          "my/pe/di/gree",
+			wantValidation:    false,
+			wantErrorMsgCount: 1,
+			wantErrorMsgContains: []string{
+				"hour Should be between 0 and 23",
+			},
+		},
+		{
+			name:           "IsEmailValid",
+			structure:      isEmailMap{map[string]string{"C0123": "admin@example.com"}},
+			pedigree:       "my/pe/di/gree",
+			wantValidation: true,
+		},
+		{
+			name:              "IsEmailInvalid",
+			structure:         isEmailMap{map[string]string{"C0123": "admin.example.com"}},
+			pedigree:          "my/pe/di/gree",
+			wantValidation:    false,
+			wantErrorMsgCount: 1,
+			wantErrorMsgContains: []string{
+				"my/pe/di/gree superAdminEmails isEmail: [C0123]",
+			},
+		},
+		{
+			name:           "IsGCPRegionValid",
+			structure:      isGCPRegionString{"northamerica-northeast1"},
+			pedigree:       "my/pe/di/gree",
+			wantValidation: true,
+		},
+		{
+			name:              "IsGCPRegionInvalid",
+			structure:         isGCPRegionString{"europe-west"},
+			pedigree:          "my/pe/di/gree",
+			wantValidation:    false,
+			wantErrorMsgCount: 1,
+			wantErrorMsgContains: []string{
+				"my/pe/di/gree region isGCPRegion",
+			},
+		},
+		{
+			name:           "InOpenRangeFloat64Valid",
+			structure:      inOpenRangeFloat64{0.99},
+			pedigree:       "my/pe/di/gree",
+			wantValidation: true,
+		},
+		{
+			name:              "InOpenRangeFloat64Min",
+			structure:         inOpenRangeFloat64{0},
+			pedigree:          "my/pe/di/gree",
+			wantValidation:    false,
+			wantErrorMsgCount: 1,
+			wantErrorMsgContains: []string{
+				"my/pe/di/gree goal inOpenRange(0|1)",
+			},
+		},
+		{
+			name:              "InOpenRangeFloat64Max",
+			structure:         inOpenRangeFloat64{1},
+			pedigree:          "my/pe/di/gree",
+			wantValidation:    false,
+			wantErrorMsgCount: 1,
+			wantErrorMsgContains: []string{
+				"my/pe/di/gree goal inOpenRange(0|1)",
+			},
+		},
+		{
+			name:           "InRangeInt32Max",
+			structure:      inRangeInt32{5},
+			pedigree:       "my/pe/di/gree",
+			wantValidation: true,
+		},
+		{
+			name:              "InRangeInt32Invalid",
+			structure:         inRangeInt32{6},
+			pedigree:          "my/pe/di/gree",
+			wantValidation:    false,
+			wantErrorMsgCount: 1,
+			wantErrorMsgContains: []string{
+				"my/pe/di/gree retryCount inRange(0|5)",
+			},
+		},
+		{
+			name:              "UnknownTag",
+			structure:         unknownTag{"BlaBla"},
+			pedigree:          "my/pe/di/gree",
+			wantValidation:    false,
+			wantErrorMsgCount: 1,
+			wantErrorMsgContains: []string{
+				"my/pe/di/gree s isNotZeroValu: Unknown validation tag",
+			},
+		},
+		{
+			name: "MapOfStructsTwoInvalid",
+			structure: mapOfStructs{map[string]scheduler{
+				"listgroups":    {Schedule: "0 2 * * *", MaxRetryDuration: "60"},
+				"dumpinventory": {Schedule: "0 2 * *"},
+			}},
+			pedigree:          "my/pe/di/gree",
+			wantValidation:    false,
+			wantErrorMsgCount: 2,
+			wantErrorMsgContains: []string{
+				"my/pe/di/gree schedulers[dumpinventory].schedule isCron",
+				"my/pe/di/gree schedulers[listgroups].maxRetryDuration isGoDuration",
+			},
+		},
+		{
+			name:              "SliceOfStructsOneInvalid",
+			structure:         sliceOfStructs{[]inOpenRangeFloat64{{0.99}, {99.9}}},
+			pedigree:          "my/pe/di/gree",
+			wantValidation:    false,
+			wantErrorMsgCount: 1,
+			wantErrorMsgContains: []string{
+				"my/pe/di/gree definitions[1].goal inOpenRange(0|1)",
 			},
 		},
 	}
 
 	for _, tc := range testCases {
+		tc := tc // https://github.com/golang/go/wiki/CommonMistakes#using-goroutines-on-loop-iterator-variables
 		t.Run(tc.name, func(t *testing.T) {
-			// DO NOT RUN CONCURENTLY (aka no t.Parallel() ), as validater is recursive
-			var buffer bytes.Buffer
-			log.SetOutput(&buffer)
-			defer func() {
-				log.SetOutput(os.Stderr)
-			}()
+			t.Parallel()
 			err := ValidateStruct(tc.structure, tc.pedigree)
-			errorMsgString := buffer.String()
-			// t.Log(countRune(errorMsgString, '\n'))
-			// t.Log("Error message list:" + string('\n') + errorMsgString)
 
-			foundErrorMsgCount := countRune(errorMsgString, '\n')
-			if tc.wantErrorMsgCount != foundErrorMsgCount {
-				t.Errorf("Want %d error messages, got %d", tc.wantErrorMsgCount, foundErrorMsgCount)
-				t.Log("Error message list:" + string('\n') + errorMsgString)
+			var validationErrors ValidationErrors
+			if err != nil && !errors.As(err, &validationErrors) {
+				t.Fatalf("Want ValidationErrors got %T %v", err, err)
+			}
+			if tc.wantErrorMsgCount != len(validationErrors) {
+				t.Errorf("Want %d error messages, got %d", tc.wantErrorMsgCount, len(validationErrors))
+				t.Logf("Error message list:\n%v", err)
 			}
 
 			if len(tc.wantErrorMsgContains) > 0 {
 				for _, expectedString := range tc.wantErrorMsgContains {
-					if !strings.Contains(errorMsgString, expectedString) {
+					if !strings.Contains(fmt.Sprintf("%v", err), expectedString) {
 						t.Errorf("Error message should contains '%s' and is", expectedString)
-						t.Log(string('\n') + errorMsgString)
+						t.Logf("\n%v", err)
 					}
 				}
 			}
@@ -213,12 +488,24 @@ func TestUnitValidater(t *testing.T) {
 	}
 }
 
-func countRune(s string, r rune) int {
-	count := 0
-	for _, c := range s {
-		if c == r {
-			count++
-		}
-	}
-	return count
+func TestUnitSplitTag(t *testing.T) {
+	var testCases = []struct {
+		name     string
+		tagValue string
+		want     []string
+	}{
+		{name: "oneRule", tagValue: "isNotZeroValue", want: []string{"isNotZeroValue"}},
+		{name: "twoRules", tagValue: "isNotZeroValue,isDuration", want: []string{"isNotZeroValue", "isDuration"}},
+		{name: "commaInArgument", tagValue: "isNotZeroValue,matches(^[a-z]{2,8}$)", want: []string{"isNotZeroValue", "matches(^[a-z]{2,8}$)"}},
+	}
+	for _, tc := range testCases {
+		tc := tc // https://github.com/golang/go/wiki/CommonMistakes#using-goroutines-on-loop-iterator-variables
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+			got := splitTag(tc.tagValue)
+			if strings.Join(got, " ") != strings.Join(tc.want, " ") {
+				t.Errorf("Want '%v' got '%v'", tc.want, got)
+			}
+		})
+	}
 }
@@ -0,0 +1,75 @@
+// Copyright 2020 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the 'License');
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an 'AS IS' BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package validater
+
+import (
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+)
+
+// inRangeValidater accepts only numbers within inclusive bounds, e.g. inRange(0|5), or exclusive bounds, e.g. inOpenRange(0|1)
+type inRangeValidater struct {
+	min       float64
+	max       float64
+	exclusive bool
+}
+
+// newInRangeValidater parses the min|max argument of the tag
+func newInRangeValidater(argument string, exclusive bool) (validater, error) {
+	bounds := strings.Split(argument, "|")
+	if len(bounds) != 2 {
+		return nil, fmt.Errorf("invalid range %s, expected min|max", argument)
+	}
+	min, err := strconv.ParseFloat(strings.TrimSpace(bounds[0]), 64)
+	if err != nil {
+		return nil, fmt.Errorf("invalid range min %s %v", bounds[0], err)
+	}
+	max, err := strconv.ParseFloat(strings.TrimSpace(bounds[1]), 64)
+	if err != nil {
+		return nil, fmt.Errorf("invalid range max %s %v", bounds[1], err)
+	}
+	if min > max {
+		return nil, fmt.Errorf("invalid range %s, min is greater than max", argument)
+	}
+	return inRangeValidater{min: min, max: max, exclusive: exclusive}, nil
+}
+
+// validate interface returns true for a valid field, false and why in the error otherwise
+func (v inRangeValidater) validate(value interface{}) (bool, error) {
+	var f float64
+	rv := reflect.ValueOf(value)
+	switch rv.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		f = float64(rv.Int())
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		f = float64(rv.Uint())
+	case reflect.Float32, reflect.Float64:
+		f = rv.Float()
+	default:
+		return false, fmt.Errorf("Should be a number, got %s", rv.Kind())
+	}
+	if v.exclusive {
+		if f <= v.min || f >= v.max {
+			return false, fmt.Errorf("%v Should be strictly between %v and %v", value, v.min, v.max)
+		}
+		return true, nil
+	}
+	if f < v.min || f > v.max {
+		return false, fmt.Errorf("%v Should be between %v and %v", value, v.min, v.max)
+	}
+	return true, nil
+}
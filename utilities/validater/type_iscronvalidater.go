@@ -0,0 +1,105 @@
+// Copyright 2020 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the 'License');
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an 'AS IS' BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package validater
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// cronField bounds and accepted names of a unix-cron field
+type cronField struct {
+	name  string
+	min   int
+	max   int
+	names []string // names[i] stands for min+i
+}
+
+// cronFields minute hour day-of-month month day-of-week, as accepted by Cloud Scheduler
+var cronFields = []cronField{
+	{name: "minute", min: 0, max: 59},
+	{name: "hour", min: 0, max: 23},
+	{name: "day of month", min: 1, max: 31},
+	{name: "month", min: 1, max: 12, names: []string{"JAN", "FEB", "MAR", "APR", "MAY", "JUN", "JUL", "AUG", "SEP", "OCT", "NOV", "DEC"}},
+	{name: "day of week", min: 0, max: 7, names: []string{"SUN", "MON", "TUE", "WED", "THU", "FRI", "SAT"}},
+}
+
+// isCronValidater accepts only unix-cron schedules, e.g. 0 */6 * * MON-FRI
+type isCronValidater struct {
+}
+
+// validate interface returns true for a valid field, false and why in the error otherwise
+func (v isCronValidater) validate(value interface{}) (bool, error) {
+	return validateStrings(value, func(s string) error {
+		parts := strings.Fields(s)
+		if len(parts) != len(cronFields) {
+			return fmt.Errorf("'%s' Should be a unix-cron schedule with %d fields, got %d", s, len(cronFields), len(parts))
+		}
+		for i, part := range parts {
+			if err := cronFields[i].check(part); err != nil {
+				return fmt.Errorf("'%s' %v", s, err)
+			}
+		}
+		return nil
+	})
+}
+
+// check validates a comma separated list of *, values, ranges, each with an optional /step
+func (field cronField) check(part string) error {
+	for _, item := range strings.Split(part, ",") {
+		rangePart := item
+		if i := strings.Index(item, "/"); i >= 0 {
+			rangePart = item[:i]
+			step, err := strconv.Atoi(item[i+1:])
+			if err != nil || step < 1 {
+				return fmt.Errorf("%s step Should be a positive number, got '%s'", field.name, item)
+			}
+		}
+		if rangePart == "*" {
+			continue
+		}
+		bounds := strings.Split(rangePart, "-")
+		if len(bounds) > 2 {
+			return fmt.Errorf("%s Should be a value or a range, got '%s'", field.name, item)
+		}
+		var values []int
+		for _, bound := range bounds {
+			n, err := field.parse(bound)
+			if err != nil {
+				return err
+			}
+			values = append(values, n)
+		}
+		if len(values) == 2 && values[0] > values[1] {
+			return fmt.Errorf("%s range Should be ascending, got '%s'", field.name, item)
+		}
+	}
+	return nil
+}
+
+// parse returns the numeric value of a cron field value, given as a number or as a name
+func (field cronField) parse(s string) (int, error) {
+	for i, name := range field.names {
+		if strings.EqualFold(s, name) {
+			return field.min + i, nil
+		}
+	}
+	n, err := strconv.Atoi(s)
+	if err != nil || n < field.min || n > field.max {
+		return 0, fmt.Errorf("%s Should be between %d and %d, got '%s'", field.name, field.min, field.max, s)
+	}
+	return n, nil
+}
@@ -0,0 +1,37 @@
+// Copyright 2020 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the 'License');
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an 'AS IS' BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package validater
+
+import (
+	"fmt"
+	"regexp"
+)
+
+// protobufDurationRegexp a positive google.protobuf.Duration in its JSON form, seconds with up to nine fractional digits followed by "s"
+var protobufDurationRegexp = regexp.MustCompile(`^[0-9]+(\.[0-9]{1,9})?s$`)
+
+// isDurationValidater accepts only durations as expected by Google APIs, e.g. 600s or 3.5s
+type isDurationValidater struct {
+}
+
+// validate interface returns true for a valid field, false and why in the error otherwise
+func (v isDurationValidater) validate(value interface{}) (bool, error) {
+	return validateStrings(value, func(s string) error {
+		if !protobufDurationRegexp.MatchString(s) {
+			return fmt.Errorf("'%s' Should be a duration in seconds, e.g. 600s", s)
+		}
+		return nil
+	})
+}
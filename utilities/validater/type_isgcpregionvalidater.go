@@ -0,0 +1,37 @@
+// Copyright 2020 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the 'License');
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an 'AS IS' BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package validater
+
+import (
+	"fmt"
+	"regexp"
+)
+
+// gcpRegionRegexp a Google Cloud region, e.g. europe-west1, northamerica-northeast2
+var gcpRegionRegexp = regexp.MustCompile(`^[a-z]+-[a-z]+[1-9][0-9]*$`)
+
+// isGCPRegionValidater accepts only Google Cloud region names
+type isGCPRegionValidater struct {
+}
+
+// validate interface returns true for a valid field, false and why in the error otherwise
+func (v isGCPRegionValidater) validate(value interface{}) (bool, error) {
+	return validateStrings(value, func(s string) error {
+		if !gcpRegionRegexp.MatchString(s) {
+			return fmt.Errorf("'%s' Should be a Google Cloud region, e.g. europe-west1", s)
+		}
+		return nil
+	})
+}
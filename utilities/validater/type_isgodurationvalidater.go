@@ -0,0 +1,38 @@
+// Copyright 2020 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the 'License');
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an 'AS IS' BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package validater
+
+import (
+	"fmt"
+	"time"
+)
+
+// isGoDurationValidater accepts only positive durations as parsed by time.ParseDuration, e.g. 10m, 1h or 90s
+type isGoDurationValidater struct {
+}
+
+// validate interface returns true for a valid field, false and why in the error otherwise
+func (v isGoDurationValidater) validate(value interface{}) (bool, error) {
+	return validateStrings(value, func(s string) error {
+		duration, err := time.ParseDuration(s)
+		if err != nil {
+			return fmt.Errorf("'%s' Should be a duration, e.g. 10m or 1h", s)
+		}
+		if duration < 0 {
+			return fmt.Errorf("'%s' Should NOT be a negative duration", s)
+		}
+		return nil
+	})
+}
@@ -0,0 +1,37 @@
+// Copyright 2020 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the 'License');
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an 'AS IS' BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package validater
+
+import (
+	"fmt"
+	"strings"
+)
+
+// isOneOfValidater accepts only strings from an enumeration, e.g. isOneOf(RESOURCE|IAM_POLICY)
+type isOneOfValidater struct {
+	acceptedValueList []string
+}
+
+// validate interface returns true for a valid field, false and why in the error otherwise
+func (v isOneOfValidater) validate(value interface{}) (bool, error) {
+	return validateStrings(value, func(s string) error {
+		for _, acceptedValue := range v.acceptedValueList {
+			if s == acceptedValue {
+				return nil
+			}
+		}
+		return fmt.Errorf("'%s' Should be one of %s", s, strings.Join(v.acceptedValueList, ", "))
+	})
+}
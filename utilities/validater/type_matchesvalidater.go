@@ -0,0 +1,44 @@
+// Copyright 2020 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the 'License');
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an 'AS IS' BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package validater
+
+import (
+	"fmt"
+	"regexp"
+)
+
+// matchesValidater accepts only strings matching a regular expression, e.g. matches(^[a-z][a-z0-9-]{5,29}$)
+type matchesValidater struct {
+	re *regexp.Regexp
+}
+
+// newMatchesValidater compiles the regular expression argument of the tag
+func newMatchesValidater(argument string) (validater, error) {
+	re, err := regexp.Compile(argument)
+	if err != nil {
+		return nil, fmt.Errorf("invalid regular expression %s %v", argument, err)
+	}
+	return matchesValidater{re: re}, nil
+}
+
+// validate interface returns true for a valid field, false and why in the error otherwise
+func (v matchesValidater) validate(value interface{}) (bool, error) {
+	return validateStrings(value, func(s string) error {
+		if !v.re.MatchString(s) {
+			return fmt.Errorf("'%s' Should match %s", s, v.re.String())
+		}
+		return nil
+	})
+}
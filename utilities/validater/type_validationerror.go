@@ -0,0 +1,45 @@
+// Copyright 2020 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the 'License');
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an 'AS IS' BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package validater
+
+import (
+	"fmt"
+	"strings"
+)
+
+// ValidationError a settings field failing a validation tag, located by its YAML path
+type ValidationError struct {
+	Pedigree string // settings being validated, e.g. monitorInstanceSettings
+	Path     string // YAML path of the field in the settings, e.g. gcf.serviceAccountBindings.grm.roles[0]
+	Tag      string // validation tag that failed, e.g. isCron
+	Message  string
+}
+
+// Error formats the validation error
+func (validationError ValidationError) Error() string {
+	return fmt.Sprintf("%s %s %s: %s", validationError.Pedigree, validationError.Path, validationError.Tag, validationError.Message)
+}
+
+// ValidationErrors list of validation errors returned when a settings struct is invalid
+type ValidationErrors []ValidationError
+
+// Error formats the list of validation errors, one per line
+func (validationErrors ValidationErrors) Error() string {
+	lines := make([]string, 0, len(validationErrors))
+	for _, validationError := range validationErrors {
+		lines = append(lines, validationError.Error())
+	}
+	return fmt.Sprintf("settings validation failed, %d errors\n%s", len(validationErrors), strings.Join(lines, "\n"))
+}